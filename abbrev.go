@@ -0,0 +1,78 @@
+package nomnemonic
+
+import (
+	"crypto/subtle"
+	"unicode/utf8"
+)
+
+// abbreviations maps the first _cardPrefix letters of the longer words to
+// their index, the prefixes shared by several words or equal to another word
+// of a custom list are left out so an abbreviation is never ambiguous
+func abbreviations(words []string, dict map[string]int) map[string]int {
+	counts := make(map[string]int, len(words))
+	for _, w := range words {
+		if utf8.RuneCountInString(w) > _cardPrefix {
+			counts[string([]rune(w)[:_cardPrefix])]++
+		}
+	}
+
+	abbrevs := make(map[string]int, len(counts))
+	for i, w := range words {
+		if utf8.RuneCountInString(w) <= _cardPrefix {
+			continue
+		}
+		prefix := string([]rune(w)[:_cardPrefix])
+		if _, ok := dict[prefix]; ok || counts[prefix] > 1 {
+			continue
+		}
+		abbrevs[prefix] = i
+	}
+	return abbrevs
+}
+
+// ExpandWords returns the words with the abbreviations of their first 4
+// letters, the backup convention of steel plates and recovery cards,
+// replaced by the full words of the list, GenerateSeed hashes the sentence as
+// given and needs the full words
+func (m *mnemonicer) ExpandWords(words []string) ([]string, error) {
+	expanded := m.expand(words)
+	if err := m.validateWordsPrecense(expanded); err != nil {
+		return nil, err
+	}
+	return expanded, nil
+}
+
+// expand replaces the abbreviations by their words and keeps the others, in
+// constant time with FeatureConstantTime
+func (m *mnemonicer) expand(words []string) []string {
+	expanded := make([]string, len(words))
+	for i, w := range words {
+		index, ok := m.lookup(w)
+		abbrev, abbreviated := m.lookupAbbrev(w)
+		switch {
+		case ok:
+			expanded[i] = m.words[index]
+		case abbreviated:
+			expanded[i] = m.words[abbrev]
+		default:
+			expanded[i] = w
+		}
+	}
+	return expanded
+}
+
+// lookupAbbrev returns the index of the word of an abbreviation and whether
+// it is one, scanning the whole table with FeatureConstantTime
+func (m *mnemonicer) lookupAbbrev(abbrev string) (int, bool) {
+	if !m.features[FeatureConstantTime] {
+		index, ok := m.abbrevs[abbrev]
+		return index, ok
+	}
+	index, found := 0, 0
+	for prefix, i := range m.abbrevs {
+		match := subtle.ConstantTimeCompare([]byte(prefix), []byte(abbrev))
+		index = subtle.ConstantTimeSelect(match, i, index)
+		found |= match
+	}
+	return index, found == 1
+}
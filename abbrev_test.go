@@ -0,0 +1,154 @@
+package nomnemonic
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+func TestAbbreviations(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	full := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	expected, err := m.CalculateEntropy(full)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := [][]string{
+		strings.Fields("cinn venu brok old bras vagu padd unaw crit alar cons hobb"),
+		strings.Fields("cinnamon venu broken old bras vague padd unaware crit alarm cons hobby"),
+	}
+	for _, abbreviated := range tests {
+		ok, err := m.IsValid(abbreviated)
+		if err != nil || !ok {
+			t.Errorf("%v: expected valid but actual %t %v", abbreviated, ok, err)
+		}
+		entropy, err := m.CalculateEntropy(abbreviated)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(entropy, expected) {
+			t.Errorf("%v: expected entropy %x but actual %x", abbreviated, expected, entropy)
+		}
+		words, err := m.ExpandWords(abbreviated)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(words, full) {
+			t.Errorf("expected %v but actual %v", full, words)
+		}
+	}
+
+	invalid := [][]string{
+		strings.Fields("cin venu brok old bras vagu padd unaw crit alar cons hobb"),
+		strings.Fields("cinna venu brok old bras vagu padd unaw crit alar cons hobb"),
+		strings.Fields("CINN venu brok old bras vagu padd unaw crit alar cons hobb"),
+	}
+	for _, abbreviated := range invalid {
+		if _, err := m.CalculateEntropy(abbreviated); !errors.Is(err, ErrUnknownWord) {
+			t.Errorf("%v: expected %v but actual %v", abbreviated, ErrUnknownWord, err)
+		}
+		if _, err := m.ExpandWords(abbreviated); !errors.Is(err, ErrUnknownWord) {
+			t.Errorf("%v: expected %v but actual %v", abbreviated, ErrUnknownWord, err)
+		}
+	}
+
+	// the abbreviations of every word of the list expand to it
+	for i, w := range words {
+		prefix := []rune(w)
+		if len(prefix) > _cardPrefix {
+			prefix = prefix[:_cardPrefix]
+		}
+		if actual := m.(*mnemonicer).expand([]string{string(prefix)})[0]; actual != w {
+			t.Errorf("%d: expected %s but actual %s", i, w, actual)
+		}
+	}
+}
+
+func TestAbbreviationsAmbiguous(t *testing.T) {
+	words := []string{"abandon", "abandoned", "able", "about", "abou"}
+	dict := map[string]int{}
+	for i, w := range words {
+		dict[w] = i
+	}
+	expected := map[string]int{}
+	if actual := abbreviations(words, dict); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v but actual %v", expected, actual)
+	}
+
+	words = append(words, "ability")
+	dict["ability"] = len(words) - 1
+	expected = map[string]int{"abil": len(words) - 1}
+	if actual := abbreviations(words, dict); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v but actual %v", expected, actual)
+	}
+}
+
+func TestAbbreviationsDownstream(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	full := strings.Fields("legal winner thank year wave sausage worth useful legal winner thank yellow")
+	abbreviated := strings.Fields("lega winn than year wave saus wort usef lega winn than yell")
+	expected := "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607"
+
+	// the seed functions hash the sentence as given, ExpandWords restores it
+	expanded, err := m.ExpandWords(abbreviated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range [][]string{full, expanded} {
+		seed, err := m.GenerateSeed(strings.Join(w, " "), "TREZOR")
+		if err != nil || hex.EncodeToString(seed) != expected {
+			t.Errorf("%v: expected seed %s but actual %x %v", w, expected, seed, err)
+		}
+		var buf bytes.Buffer
+		if err := m.GenerateSeedTo(&buf, strings.Join(w, " "), "TREZOR"); err != nil || hex.EncodeToString(buf.Bytes()) != expected {
+			t.Errorf("%v: expected seed %s to the writer but actual %x %v", w, expected, buf.Bytes(), err)
+		}
+	}
+	literal := pbkdf2.Key([]byte(strings.Join(abbreviated, " ")), []byte("mnemonicTREZOR"), 2048, 64, sha512.New)
+	if actual, _ := m.GenerateSeed(strings.Join(abbreviated, " "), "TREZOR"); !bytes.Equal(actual, literal) {
+		t.Errorf("expected the seed of the literal abbreviated sentence but actual %x", actual)
+	}
+
+	expectedQR, _ := m.EncodeSeedQR(full)
+	if actual, err := m.EncodeSeedQR(abbreviated); err != nil || actual != expectedQR {
+		t.Errorf("expected seedqr %s but actual %s %v", expectedQR, actual, err)
+	}
+	expectedIndexes, _ := m.IndexSentence(full)
+	if actual, err := m.IndexSentence(abbreviated); err != nil || actual != expectedIndexes {
+		t.Errorf("expected indexes %s but actual %s %v", expectedIndexes, actual, err)
+	}
+	expectedCompact, _ := m.EncodeCompactSeedQR(full)
+	if actual, err := m.EncodeCompactSeedQR(abbreviated); err != nil || !bytes.Equal(actual, expectedCompact) {
+		t.Errorf("expected compact seedqr %x but actual %x %v", expectedCompact, actual, err)
+	}
+	parts, err := m.SplitXOR(abbreviated, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if combined, err := m.CombineXOR(parts); err != nil || !reflect.DeepEqual(combined, full) {
+		t.Errorf("expected the xor parts to combine to %v but actual %v %v", full, combined, err)
+	}
+}
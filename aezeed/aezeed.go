@@ -0,0 +1,234 @@
+// Package aezeed encodes 16 bytes entropy as lnd aezeed cipher seed mnemonics
+// with a wallet birthday and passphrase encryption
+package aezeed
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"time"
+
+	"github.com/Yawning/aez"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// Version is the only supported external and internal seed version
+	Version = 0
+
+	EntropySize = 16
+	WordCount   = 24
+
+	_saltSize       = 5
+	_plainSize      = 19 // version, birthday and entropy
+	_cipherSize     = 33 // version, aez cipher text, salt and checksum
+	_checksumSize   = 4
+	_saltOffset     = _cipherSize - _checksumSize - _saltSize
+	_checksumOffset = _cipherSize - _checksumSize
+	_expansion      = 4
+	_wordBits       = 11
+	_keySize        = 32
+
+	_scryptR = 8
+	_scryptP = 1
+
+	_defaultPassphrase = "aezeed"
+)
+
+var (
+	_scryptN = 32768
+
+	// GenesisDate is the bitcoin genesis block time birthdays count days from
+	GenesisDate = time.Unix(1231006505, 0)
+
+	// ErrInvalidPassphrase is returned if the passphrase can't decrypt the seed
+	ErrInvalidPassphrase = errors.New("invalid aezeed passphrase")
+
+	_crcTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+type (
+	// Seed is a deciphered aezeed
+	Seed struct {
+		Version  uint8
+		Birthday uint16 // days since GenesisDate
+		Entropy  []byte
+	}
+
+	aezeed struct {
+		words  []string
+		dict   map[string]int
+		random io.Reader
+	}
+
+	// Aezeed enciphers and deciphers aezeed mnemonics
+	Aezeed interface {
+		Encode(entropy []byte, birthday time.Time, passphrase string) ([]string, error)
+		Decode(words []string, passphrase string) (*Seed, error)
+		ChangePassphrase(words []string, oldPassphrase, newPassphrase string) ([]string, error)
+	}
+)
+
+// New inits a new aezeed cipher with the english bip39 word list lnd uses
+func New(words []string) (Aezeed, error) {
+	if len(words) != 1<<_wordBits {
+		return nil, errors.New("aezeed is based on 2048 words")
+	}
+	dict := make(map[string]int, len(words))
+	for i, w := range words {
+		dict[w] = i
+	}
+	return &aezeed{
+		words:  words,
+		dict:   dict,
+		random: rand.Reader,
+	}, nil
+}
+
+// BirthdayTime returns the time of the seed birthday
+func (s *Seed) BirthdayTime() time.Time {
+	return GenesisDate.Add(time.Duration(s.Birthday) * 24 * time.Hour)
+}
+
+// Encode enciphers the entropy and the birthday with the passphrase under a
+// random salt, an empty passphrase is replaced by lnd's default
+func (a *aezeed) Encode(entropy []byte, birthday time.Time, passphrase string) ([]string, error) {
+	if len(entropy) != EntropySize {
+		return nil, fmt.Errorf("aezeed entropy must be %d bytes", EntropySize)
+	}
+	if birthday.Before(GenesisDate) {
+		return nil, errors.New("birthday must be after the bitcoin genesis block")
+	}
+
+	salt := make([]byte, _saltSize)
+	if _, err := io.ReadFull(a.random, salt); err != nil {
+		return nil, err
+	}
+
+	seed := &Seed{
+		Version:  Version,
+		Birthday: uint16(birthday.Sub(GenesisDate) / (24 * time.Hour)),
+		Entropy:  entropy,
+	}
+	cipherText, err := encipher(seed, salt, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return a.toWords(cipherText), nil
+}
+
+// Decode verifies the checksum of the words and deciphers them with the
+// passphrase
+func (a *aezeed) Decode(words []string, passphrase string) (*Seed, error) {
+	seed, _, err := a.decode(words, passphrase)
+	return seed, err
+}
+
+// ChangePassphrase re-enciphers the words with the new passphrase keeping the
+// same salt like lnd does
+func (a *aezeed) ChangePassphrase(words []string, oldPassphrase, newPassphrase string) ([]string, error) {
+	seed, salt, err := a.decode(words, oldPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := encipher(seed, salt, newPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	return a.toWords(cipherText), nil
+}
+
+func (a *aezeed) decode(words []string, passphrase string) (*Seed, []byte, error) {
+	if len(words) != WordCount {
+		return nil, nil, fmt.Errorf("aezeed must be %d words", WordCount)
+	}
+
+	cipherText := make([]byte, _cipherSize)
+	var acc uint32
+	bits, pos := 0, 0
+	for i, w := range words {
+		index, ok := a.dict[w]
+		if !ok {
+			return nil, nil, fmt.Errorf("unrecognized word %s at %d", w, i)
+		}
+		acc = acc<<_wordBits | uint32(index)
+		bits += _wordBits
+		for bits >= 8 {
+			bits -= 8
+			cipherText[pos] = byte(acc >> bits)
+			pos++
+		}
+	}
+
+	if cipherText[0] != Version {
+		return nil, nil, fmt.Errorf("unsupported aezeed version %d", cipherText[0])
+	}
+	checksum := crc32.Checksum(cipherText[:_checksumOffset], _crcTable)
+	if checksum != binary.BigEndian.Uint32(cipherText[_checksumOffset:]) {
+		return nil, nil, errors.New("invalid aezeed checksum")
+	}
+
+	salt := cipherText[_saltOffset:_checksumOffset]
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, nil, err
+	}
+	plain, ok := aez.Decrypt(key, nil, [][]byte{ad(salt)}, _expansion, cipherText[1:_saltOffset], nil)
+	if !ok {
+		return nil, nil, ErrInvalidPassphrase
+	}
+
+	return &Seed{
+		Version:  plain[0],
+		Birthday: binary.BigEndian.Uint16(plain[1:3]),
+		Entropy:  plain[3:],
+	}, salt, nil
+}
+
+func (a *aezeed) toWords(cipherText []byte) []string {
+	words := make([]string, 0, WordCount)
+	var acc uint32
+	bits := 0
+	for _, b := range cipherText {
+		acc = acc<<8 | uint32(b)
+		bits += 8
+		if bits >= _wordBits {
+			bits -= _wordBits
+			words = append(words, a.words[(acc>>bits)&(1<<_wordBits-1)])
+		}
+	}
+	return words
+}
+
+func encipher(seed *Seed, salt []byte, passphrase string) ([]byte, error) {
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plain := make([]byte, _plainSize)
+	plain[0] = seed.Version
+	binary.BigEndian.PutUint16(plain[1:3], seed.Birthday)
+	copy(plain[3:], seed.Entropy)
+
+	cipherText := make([]byte, 0, _cipherSize)
+	cipherText = append(cipherText, Version)
+	cipherText = aez.Encrypt(key, nil, [][]byte{ad(salt)}, _expansion, plain, cipherText)
+	cipherText = append(cipherText, salt...)
+	return binary.BigEndian.AppendUint32(cipherText, crc32.Checksum(cipherText, _crcTable)), nil
+}
+
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	if passphrase == "" {
+		passphrase = _defaultPassphrase
+	}
+	return scrypt.Key([]byte(passphrase), salt, _scryptN, _scryptR, _scryptP, _keySize)
+}
+
+// ad is the aez associated data, the version and the salt
+func ad(salt []byte) []byte {
+	return append([]byte{Version}, salt...)
+}
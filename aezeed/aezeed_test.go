@@ -0,0 +1,171 @@
+package aezeed
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+const _testEntropy = "81b637d86359e6960de795e41e0b4cfd"
+
+func init() {
+	// lnd test vectors are enciphered with a weak scrypt cost
+	_scryptN = 16
+}
+
+func TestNew(t *testing.T) {
+	_, err := New([]string{})
+	if err == nil || err.Error() != "aezeed is based on 2048 words" {
+		t.Errorf("expected word list size err but actual %v", err)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	a, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	entropy, _ := hex.DecodeString(_testEntropy)
+
+	// lnd test vectors with the "salt1" salt
+	tests := []struct {
+		birthday   time.Time
+		passphrase string
+		days       uint16
+		sentence   string
+	}{
+		{
+			birthday: GenesisDate,
+			sentence: "ability liquid travel stem barely drastic pact cupboard apple thrive morning oak feature tissue couch old math inform success suggest drink motion know royal",
+		},
+		{
+			birthday:   time.Unix(1521799345, 0),
+			passphrase: "!very_safe_55345_password*",
+			days:       3365,
+			sentence:   "able tree stool crush transfer cloud cross three profit outside hen citizen plate ride require leg siren drum success suggest drink require fiscal upgrade",
+		},
+	}
+
+	for _, test := range tests {
+		a.(*aezeed).random = bytes.NewReader([]byte("salt1"))
+		sentence, err := a.Encode(entropy, test.birthday, test.passphrase)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if actual := strings.Join(sentence, " "); actual != test.sentence {
+			t.Errorf("expected '%s' but actual '%s'", test.sentence, actual)
+		}
+
+		seed, err := a.Decode(sentence, test.passphrase)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if seed.Birthday != test.days {
+			t.Errorf("expected birthday %d but actual %d", test.days, seed.Birthday)
+		}
+		if hex.EncodeToString(seed.Entropy) != _testEntropy {
+			t.Errorf("expected entropy %s but actual %x", _testEntropy, seed.Entropy)
+		}
+	}
+
+	_, err = a.Encode(entropy[:15], GenesisDate, "")
+	if err == nil || err.Error() != "aezeed entropy must be 16 bytes" {
+		t.Errorf("expected entropy size err but actual %v", err)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	a, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	valid := "able tree stool crush transfer cloud cross three profit outside hen citizen plate ride require leg siren drum success suggest drink require fiscal upgrade"
+
+	tests := []struct {
+		sentence   string
+		passphrase string
+		err        string
+	}{
+		{
+			sentence:   valid,
+			passphrase: "wrong",
+			err:        "invalid aezeed passphrase",
+		},
+		{
+			sentence:   strings.Replace(valid, "tree", "trees", 1),
+			passphrase: "!very_safe_55345_password*",
+			err:        "unrecognized word trees at 1",
+		},
+		{
+			sentence:   strings.Replace(valid, "stool", "stove", 1),
+			passphrase: "!very_safe_55345_password*",
+			err:        "invalid aezeed checksum",
+		},
+		{
+			sentence: "ability liquid",
+			err:      "aezeed must be 24 words",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := a.Decode(strings.Fields(test.sentence), test.passphrase)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+}
+
+func TestChangePassphrase(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	a, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	old := strings.Fields("ability liquid travel stem barely drastic pact cupboard apple thrive morning oak feature tissue couch old math inform success suggest drink motion know royal")
+	sentence, err := a.ChangePassphrase(old, "", "new passphrase")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	seed, err := a.Decode(sentence, "new passphrase")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if hex.EncodeToString(seed.Entropy) != _testEntropy {
+		t.Errorf("expected entropy %s but actual %x", _testEntropy, seed.Entropy)
+	}
+	if !seed.BirthdayTime().Equal(GenesisDate) {
+		t.Errorf("expected birthday %s but actual %s", GenesisDate, seed.BirthdayTime())
+	}
+}
+
+func buildWords() ([]string, error) {
+	bytes, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		return nil, err
+	}
+	words := strings.Split(string(bytes), "\n")
+	return words, nil
+}
@@ -0,0 +1,75 @@
+package nomnemonic
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	_armorBegin = "-----BEGIN NOMNEMONIC EXPORT-----"
+	_armorEnd   = "-----END NOMNEMONIC EXPORT-----"
+	_armorWidth = 64
+
+	// the openpgp crc24 of rfc 4880
+	_crc24Init = 0xb704ce
+	_crc24Poly = 0x1864cfb
+	_crc24Mask = 0xffffff
+)
+
+// Armor encodes the export container in base64 lines between begin and end
+// markers followed by the =-prefixed base64 crc24 of the container, so it
+// survives mail and copy paste and a damaged copy is noticed before Import
+func Armor(container []byte) string {
+	encoded := base64.StdEncoding.EncodeToString(container)
+	var b strings.Builder
+	b.WriteString(_armorBegin + "\n")
+	for len(encoded) > _armorWidth {
+		b.WriteString(encoded[:_armorWidth] + "\n")
+		encoded = encoded[_armorWidth:]
+	}
+	b.WriteString(encoded + "\n")
+	b.WriteString("=" + crc24Base64(container) + "\n")
+	b.WriteString(_armorEnd + "\n")
+	return b.String()
+}
+
+// Dearmor decodes the armored export container, the text around the markers
+// is ignored. The checksum line is optional for the armors written before it
+func Dearmor(text string) ([]byte, error) {
+	begin := strings.Index(text, _armorBegin)
+	end := strings.Index(text, _armorEnd)
+	if begin < 0 || end < begin {
+		return nil, errors.New("no armored export found")
+	}
+
+	lines := strings.Fields(text[begin+len(_armorBegin) : end])
+	var checksum string
+	if n := len(lines); n > 0 && strings.HasPrefix(lines[n-1], "=") {
+		checksum, lines = lines[n-1][1:], lines[:n-1]
+	}
+	container, err := base64.StdEncoding.DecodeString(strings.Join(lines, ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid armored export: %w", err)
+	}
+	if checksum != "" && checksum != crc24Base64(container) {
+		return nil, errors.New("armored export checksum mismatch")
+	}
+	return container, nil
+}
+
+func crc24Base64(b []byte) string {
+	crc := uint32(_crc24Init)
+	for _, c := range b {
+		crc ^= uint32(c) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= _crc24Poly
+			}
+		}
+	}
+	crc &= _crc24Mask
+	return base64.StdEncoding.EncodeToString([]byte{byte(crc >> 16), byte(crc >> 8), byte(crc)})
+}
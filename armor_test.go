@@ -0,0 +1,65 @@
+package nomnemonic
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCRC24(t *testing.T) {
+	tests := []struct {
+		b        string
+		expected string
+	}{
+		{b: "", expected: "twTO"},
+		{b: "123456789", expected: "Ic8C"}, // 0x21cf02
+	}
+
+	for _, test := range tests {
+		if actual := crc24Base64([]byte(test.b)); actual != test.expected {
+			t.Errorf("%q: expected %s but actual %s", test.b, test.expected, actual)
+		}
+	}
+}
+
+func TestArmor(t *testing.T) {
+	words := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	container, err := Export(words, "correct horse", _testKDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	armored := Armor(container)
+	lines := strings.Split(strings.TrimSpace(armored), "\n")
+	if lines[0] != _armorBegin || lines[len(lines)-1] != _armorEnd || lines[len(lines)-2] != "="+crc24Base64(container) {
+		t.Fatalf("unexpected armor %s", armored)
+	}
+
+	actual, err := Dearmor("forwarded message\n\n" + strings.ReplaceAll(armored, "\n", "\r\n") + "-- \nsignature")
+	if err != nil || !bytes.Equal(actual, container) {
+		t.Errorf("expected %x but actual %x %v", container, actual, err)
+	}
+
+	// written before the checksum line
+	legacy := strings.Join(append(lines[:len(lines)-2:len(lines)-2], _armorEnd), "\n")
+	if actual, err := Dearmor(legacy); err != nil || !bytes.Equal(actual, container) {
+		t.Errorf("expected %x but actual %x %v", container, actual, err)
+	}
+
+	// a copy paste dropping a base64 block keeps the base64 valid
+	damaged := strings.Replace(armored, lines[1], lines[1][4:], 1)
+	tests := []struct {
+		name string
+		text string
+		err  string
+	}{
+		{name: "damaged", text: damaged, err: "armored export checksum mismatch"},
+		{name: "not armored", text: "hello", err: "no armored export found"},
+		{name: "bad base64", text: _armorBegin + "\n***\n" + _armorEnd, err: "invalid armored export: illegal base64 data at input byte 0"},
+	}
+	for _, test := range tests {
+		_, err := Dearmor(test.text)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%s: expected err '%s' but actual %v", test.name, test.err, err)
+		}
+	}
+}
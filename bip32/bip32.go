@@ -0,0 +1,308 @@
+// Package bip32 implements the secp256k1 hierarchical deterministic key
+// derivation used by bitcoin and most of the account based chains
+package bip32
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/ripemd160"
+
+	"github.com/nomnemonic/nomnemonic/internal/base58"
+)
+
+const (
+	_masterKey = "Bitcoin seed"
+
+	_serializedKeySize = 78
+
+	HardenedOffset uint32 = 0x80000000
+
+	// Mainnet extended key versions (xprv/xpub)
+	VersionPrivate uint32 = 0x0488ade4
+	VersionPublic  uint32 = 0x0488b21e
+)
+
+var (
+	errInvalidKey = errors.New("invalid derived key, try the next index")
+)
+
+// Key is an extended private or public key
+type Key struct {
+	key               []byte // 32 bytes private key or 33 bytes compressed public key
+	chainCode         []byte
+	depth             byte
+	parentFingerprint []byte
+	childNumber       uint32
+	private           bool
+}
+
+// NewMasterKey generates the master extended private key from the seed
+func NewMasterKey(seed []byte) (*Key, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("seed must be between 16 and 64 bytes but given %d", len(seed))
+	}
+
+	mac := hmac.New(sha512.New, []byte(_masterKey))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	var k secp256k1.ModNScalar
+	overflow := k.SetByteSlice(sum[:32])
+	if overflow || k.IsZero() {
+		return nil, errors.New("invalid master key for the seed")
+	}
+
+	return &Key{
+		key:               sum[:32],
+		chainCode:         sum[32:],
+		parentFingerprint: []byte{0, 0, 0, 0},
+		private:           true,
+	}, nil
+}
+
+// ParsePath parses bip32 paths like m/44'/0'/0'/0/0 into child indexes,
+// hardened indexes can be marked with ' or h
+func ParsePath(path string) ([]uint32, error) {
+	segments := strings.Split(strings.TrimSpace(path), "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("path must start with m but given '%s'", path)
+	}
+
+	indexes := make([]uint32, 0, len(segments)-1)
+	for _, s := range segments[1:] {
+		offset := uint32(0)
+		if strings.HasSuffix(s, "'") || strings.HasSuffix(s, "h") {
+			offset = HardenedOffset
+			s = s[:len(s)-1]
+		}
+
+		i, err := strconv.ParseUint(s, 10, 32)
+		if err != nil || uint32(i) >= HardenedOffset {
+			return nil, fmt.Errorf("invalid path segment '%s'", s)
+		}
+		indexes = append(indexes, uint32(i)+offset)
+	}
+	return indexes, nil
+}
+
+// DerivePath derives the descendant key for the bip32 path
+func (k *Key) DerivePath(path string) (*Key, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key := k
+	for _, i := range indexes {
+		key, err = key.Derive(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// Derive derives the child key at the index, indexes starting from
+// HardenedOffset derive hardened keys and requires a private key
+func (k *Key) Derive(index uint32) (*Key, error) {
+	if k.depth == 255 {
+		return nil, errors.New("max depth reached")
+	}
+
+	data := make([]byte, 0, 37)
+	if index >= HardenedOffset {
+		if !k.private {
+			return nil, errors.New("hardened derivation requires private key")
+		}
+		data = append(data, 0)
+		data = append(data, k.key...)
+	} else {
+		data = append(data, k.PublicKey()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	var il secp256k1.ModNScalar
+	if overflow := il.SetByteSlice(sum[:32]); overflow {
+		return nil, errInvalidKey
+	}
+
+	child := &Key{
+		chainCode:         sum[32:],
+		depth:             k.depth + 1,
+		parentFingerprint: k.Fingerprint(),
+		childNumber:       index,
+		private:           k.private,
+	}
+
+	if k.private {
+		var parent secp256k1.ModNScalar
+		parent.SetByteSlice(k.key)
+		il.Add(&parent)
+		if il.IsZero() {
+			return nil, errInvalidKey
+		}
+		key := il.Bytes()
+		child.key = key[:]
+		return child, nil
+	}
+
+	parent, err := secp256k1.ParsePubKey(k.key)
+	if err != nil {
+		return nil, err
+	}
+	var p, q secp256k1.JacobianPoint
+	parent.AsJacobian(&p)
+	secp256k1.ScalarBaseMultNonConst(&il, &q)
+	secp256k1.AddNonConst(&q, &p, &q)
+	if (q.X.IsZero() && q.Y.IsZero()) || q.Z.IsZero() {
+		return nil, errInvalidKey
+	}
+	q.ToAffine()
+	child.key = secp256k1.NewPublicKey(&q.X, &q.Y).SerializeCompressed()
+	return child, nil
+}
+
+// Neuter returns the extended public key of the key
+func (k *Key) Neuter() *Key {
+	if !k.private {
+		return k
+	}
+	return &Key{
+		key:               k.PublicKey(),
+		chainCode:         k.chainCode,
+		depth:             k.depth,
+		parentFingerprint: k.parentFingerprint,
+		childNumber:       k.childNumber,
+	}
+}
+
+// IsPrivate reports whether the key is an extended private key
+func (k *Key) IsPrivate() bool {
+	return k.private
+}
+
+// PrivateKey returns the 32 bytes private key, nil for public keys
+func (k *Key) PrivateKey() []byte {
+	if !k.private {
+		return nil
+	}
+	return append([]byte(nil), k.key...)
+}
+
+// PublicKey returns the 33 bytes compressed public key
+func (k *Key) PublicKey() []byte {
+	if !k.private {
+		return append([]byte(nil), k.key...)
+	}
+	return secp256k1.PrivKeyFromBytes(k.key).PubKey().SerializeCompressed()
+}
+
+// UncompressedPublicKey returns the 65 bytes uncompressed public key
+func (k *Key) UncompressedPublicKey() []byte {
+	if k.private {
+		return secp256k1.PrivKeyFromBytes(k.key).PubKey().SerializeUncompressed()
+	}
+	pub, _ := secp256k1.ParsePubKey(k.key)
+	return pub.SerializeUncompressed()
+}
+
+// Fingerprint returns the first 4 bytes of the public key hash
+func (k *Key) Fingerprint() []byte {
+	return Hash160(k.PublicKey())[:4]
+}
+
+// ChainCode returns the 32 bytes chain code
+func (k *Key) ChainCode() []byte {
+	return append([]byte(nil), k.chainCode...)
+}
+
+// ParentFingerprint returns the fingerprint of the parent key, zeros for the
+// master key
+func (k *Key) ParentFingerprint() []byte {
+	return append([]byte(nil), k.parentFingerprint...)
+}
+
+// Depth returns the depth of the key, 0 for the master key
+func (k *Key) Depth() byte {
+	return k.depth
+}
+
+// ChildNumber returns the index the key is derived with
+func (k *Key) ChildNumber() uint32 {
+	return k.childNumber
+}
+
+// Serialize encodes the extended key with the version bytes in base58check
+func (k *Key) Serialize(version uint32) string {
+	b := make([]byte, 0, _serializedKeySize)
+	b = binary.BigEndian.AppendUint32(b, version)
+	b = append(b, k.depth)
+	b = append(b, k.parentFingerprint...)
+	b = binary.BigEndian.AppendUint32(b, k.childNumber)
+	b = append(b, k.chainCode...)
+	if k.private {
+		b = append(b, 0)
+	}
+	b = append(b, k.key...)
+	return base58.CheckEncode(b, base58.AlphabetBitcoin)
+}
+
+// String encodes the key as mainnet xprv or xpub
+func (k *Key) String() string {
+	if k.private {
+		return k.Serialize(VersionPrivate)
+	}
+	return k.Serialize(VersionPublic)
+}
+
+// ParseKey decodes the base58check serialized extended key and returns it
+// with its version bytes
+func ParseKey(s string) (*Key, uint32, error) {
+	b, err := base58.CheckDecode(s, base58.AlphabetBitcoin)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(b) != _serializedKeySize {
+		return nil, 0, errors.New("invalid extended key length")
+	}
+
+	k := &Key{
+		depth:             b[4],
+		parentFingerprint: b[5:9],
+		childNumber:       binary.BigEndian.Uint32(b[9:13]),
+		chainCode:         b[13:45],
+	}
+	if b[45] == 0 {
+		var s secp256k1.ModNScalar
+		if overflow := s.SetByteSlice(b[46:]); overflow || s.IsZero() {
+			return nil, 0, errors.New("invalid extended private key")
+		}
+		k.key, k.private = b[46:], true
+	} else {
+		if _, err := secp256k1.ParsePubKey(b[45:]); err != nil {
+			return nil, 0, err
+		}
+		k.key = b[45:]
+	}
+	return k, binary.BigEndian.Uint32(b[:4]), nil
+}
+
+// Hash160 returns ripemd160(sha256(b))
+func Hash160(b []byte) []byte {
+	h := sha256.Sum256(b)
+	r := ripemd160.New()
+	r.Write(h[:])
+	return r.Sum(nil)
+}
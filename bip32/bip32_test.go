@@ -0,0 +1,166 @@
+package bip32
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestDerivePath(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	tests := []struct {
+		path string
+		xprv string
+		xpub string
+	}{
+		{
+			path: "m",
+			xprv: "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+			xpub: "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+		},
+		{
+			path: "m/0'",
+			xprv: "xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7",
+			xpub: "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+		},
+		{
+			path: "m/0h/1",
+			xprv: "xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs",
+			xpub: "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ",
+		},
+		{
+			path: "m/0'/1/2'/2/1000000000",
+			xprv: "xprvA41z7zogVVwxVSgdKUHDy1SKmdb533PjDz7J6N6mV6uS3ze1ai8FHa8kmHScGpWmj4WggLyQjgPie1rFSruoUihUZREPSL39UNdE3BBDu76",
+			xpub: "xpub6H1LXWLaKsWFhvm6RVpEL9P4KfRZSW7abD2ttkWP3SSQvnyA8FSVqNTEcYFgJS2UaFcxupHiYkro49S8yGasTvXEYBVPamhGW6cFJodrTHy",
+		},
+	}
+
+	for _, test := range tests {
+		k, err := master.DerivePath(test.path)
+		if err != nil {
+			t.Errorf("unexpected error for path %s: %s", test.path, err.Error())
+			continue
+		}
+		if actual := k.String(); actual != test.xprv {
+			t.Errorf("expected xprv for path %s is '%s' but actual '%s'", test.path, test.xprv, actual)
+		}
+		if actual := k.Neuter().String(); actual != test.xpub {
+			t.Errorf("expected xpub for path %s is '%s' but actual '%s'", test.path, test.xpub, actual)
+		}
+	}
+}
+
+func TestKeyParts(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, _ := NewMasterKey(seed)
+	child, _ := master.DerivePath("m/0'")
+
+	if actual := hex.EncodeToString(master.ChainCode()); actual != "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508" {
+		t.Errorf("expected chain code 873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508 but actual %s", actual)
+	}
+	if actual := hex.EncodeToString(master.ParentFingerprint()); actual != "00000000" {
+		t.Errorf("expected parent fingerprint 00000000 but actual %s", actual)
+	}
+	if actual := hex.EncodeToString(child.ParentFingerprint()); actual != "3442193e" {
+		t.Errorf("expected parent fingerprint 3442193e but actual %s", actual)
+	}
+}
+
+func TestPublicDerivation(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, _ := NewMasterKey(seed)
+	parent, _ := master.DerivePath("m/0'/1/2'")
+
+	child, err := parent.Neuter().Derive(2)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	expected, _ := parent.Derive(2)
+	if child.String() != expected.Neuter().String() {
+		t.Errorf("expected public derivation '%s' but actual '%s'", expected.Neuter().String(), child.String())
+	}
+
+	_, err = parent.Neuter().Derive(HardenedOffset)
+	if err == nil || err.Error() != "hardened derivation requires private key" {
+		t.Errorf("expected hardened derivation err but actual %v", err)
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		version uint32
+		err     string
+	}{
+		{
+			key:     "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+			version: VersionPrivate,
+		},
+		{
+			key:     "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+			version: VersionPublic,
+		},
+		{
+			key: "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet9",
+			err: "invalid base58check checksum",
+		},
+	}
+
+	for _, test := range tests {
+		k, version, err := ParseKey(test.key)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' for %s but actual %v", test.err, test.key, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.key, err.Error())
+			continue
+		}
+		if version != test.version {
+			t.Errorf("expected version %x but actual %x", test.version, version)
+		}
+		if k.String() != test.key {
+			t.Errorf("expected '%s' but actual '%s'", test.key, k.String())
+		}
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		indexes []uint32
+		err     string
+	}{
+		{path: "m", indexes: []uint32{}},
+		{path: "m/44'/60'/0'/0/1", indexes: []uint32{HardenedOffset + 44, HardenedOffset + 60, HardenedOffset, 0, 1}},
+		{path: "m/84h/0h", indexes: []uint32{HardenedOffset + 84, HardenedOffset}},
+		{path: "44'/0'", err: "path must start with m but given '44'/0''"},
+		{path: "m/a/0", err: "invalid path segment 'a'"},
+		{path: "m/2147483648", err: "invalid path segment '2147483648'"},
+	}
+
+	for _, test := range tests {
+		indexes, err := ParsePath(test.path)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' for %s but actual %v", test.err, test.path, err)
+			}
+			continue
+		}
+		if len(indexes) != len(test.indexes) {
+			t.Errorf("expected %d indexes for %s but actual %d", len(test.indexes), test.path, len(indexes))
+			continue
+		}
+		for i := range indexes {
+			if indexes[i] != test.indexes[i] {
+				t.Errorf("expected index %d for %s but actual %d", test.indexes[i], test.path, indexes[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package nomnemonic
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	_saltPrefixBrainwallet = "brainwallet"
+
+	_brainwalletMinLength        = 20
+	_brainwalletMinDistinctChars = 10
+)
+
+// FromPassphrase derives mnemonic words of the size from a classic
+// brainwallet passphrase with the same pbkdf2 and scrypt stretching as
+// Generate instead of a single sha256.
+//
+// The policy rules of the size and of the password apply to the passphrase,
+// and the factors, the pepper and the purpose are mixed in like Generate, the
+// factor challenge is the one of the brainwallet identifier.
+//
+// WARNING: a passphrase a human picks is guessable no matter how slow the KDF
+// is, funds behind a brainwallet have been swept within minutes. Use it only
+// to migrate from an existing brainwallet, to Generate for new wallets
+func (m *mnemonicer) FromPassphrase(passphrase string, size int) ([]string, error) {
+	if len(passphrase) < _brainwalletMinLength {
+		return nil, fmt.Errorf("passphrase must be at least %d chars, short brainwallet passphrases are cracked quickly", _brainwalletMinLength)
+	}
+	if distinctChars(passphrase) < _brainwalletMinDistinctChars {
+		return nil, fmt.Errorf("passphrase must have at least %d distinct chars, repetitive brainwallet passphrases are cracked quickly", _brainwalletMinDistinctChars)
+	}
+
+	strength := _sentenceStrengths[size]
+	err := m.validateStrength(strength)
+	if err != nil {
+		return nil, err
+	}
+	if errs := m.checkInputs(passphrase, size); len(errs) > 0 {
+		return nil, joinErrors(errs...)
+	}
+	pepper, err := m.factorPepper(_saltPrefixBrainwallet)
+	if err != nil {
+		return nil, err
+	}
+
+	input := pepperInput(pepper, m.purposeInput([]byte(fmt.Sprintf("%s=%d", passphrase, size))))
+	salt := []byte(_saltPrefixBrainwallet + passphrase)
+	start := m.logStarted(size)
+	entropy := m.stretch(input, salt, strength/_bitChunkSizeOneByte)
+	m.logFinished(size, start)
+	return m.generatedWords(entropy)
+}
+
+func distinctChars(s string) int {
+	seen := map[rune]struct{}{}
+	for _, r := range strings.ToLower(s) {
+		seen[r] = struct{}{}
+	}
+	return len(seen)
+}
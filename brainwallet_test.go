@@ -0,0 +1,119 @@
+package nomnemonic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFromPassphrase(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	tests := []struct {
+		passphrase string
+		size       int
+		sentence   string
+		err        error
+	}{
+		{
+			passphrase: "correct horse battery staple",
+			size:       12,
+			sentence:   "laundry bring phone nerve crunch glide argue donate dutch nasty polar demise",
+		},
+		{
+			passphrase: "correct horse",
+			size:       12,
+			err:        errors.New("passphrase must be at least 20 chars, short brainwallet passphrases are cracked quickly"),
+		},
+		{
+			passphrase: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaab",
+			size:       12,
+			err:        errors.New("passphrase must have at least 10 distinct chars, repetitive brainwallet passphrases are cracked quickly"),
+		},
+		{
+			passphrase: "correct horse battery staple",
+			size:       13,
+			err:        errors.New("unsupported strength: 0"),
+		},
+	}
+
+	for _, test := range tests {
+		sentence, err := m.FromPassphrase(test.passphrase, test.size)
+		if test.err != nil {
+			if err == nil || test.err.Error() != err.Error() {
+				t.Errorf("expected err '%s' but actual %v", test.err.Error(), err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if actual := strings.Join(sentence, " "); actual != test.sentence {
+			t.Errorf("expected sentence (%s) but actual (%s)", test.sentence, actual)
+		}
+	}
+}
+
+func TestFromPassphraseSettings(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const passphrase = "correct horse battery staple"
+	t.Setenv(InsecureFastKDFEnv, "1")
+
+	strict, err := New(words, WithPolicy(Policy{Sizes: []int{24}, PasswordMinLength: 32}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = strict.FromPassphrase(passphrase, 12)
+	if !errors.Is(err, ErrPolicyViolation) || !strings.Contains(err.Error(), "12 words") || !strings.Contains(err.Error(), "32 chars") {
+		t.Errorf("expected the size and password policy violations but actual %v", err)
+	}
+
+	var challenges [][]byte
+	factor := FactorFunc(func(challenge []byte) ([]byte, error) {
+		challenges = append(challenges, challenge)
+		return []byte("response"), nil
+	})
+	fast, err := New(words, WithInsecureFastKDF())
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseline, err := fast.FromPassphrase(passphrase, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := strings.Join(baseline, " ")
+
+	options := map[string]Option{
+		"purpose": WithPurpose("savings"),
+		"pepper":  WithPepper([]byte("pepper")),
+		"factor":  WithFactor(factor),
+	}
+	for name, option := range options {
+		m, err := New(words, option, WithInsecureFastKDF())
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := m.FromPassphrase(passphrase, 12)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err.Error())
+		}
+		if strings.Join(actual, " ") == expected {
+			t.Errorf("%s: expected the phrase to change", name)
+		}
+	}
+	if len(challenges) != 1 || string(challenges[0]) != string(FactorChallenge(_saltPrefixBrainwallet)) {
+		t.Errorf("expected the brainwallet challenge but actual %q", challenges)
+	}
+}
@@ -0,0 +1,108 @@
+package nomnemonic
+
+import (
+	"crypto/sha512"
+	"math"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// the probe costs are small enough to run in milliseconds, both kdfs
+	// scale linearly with their cost so the algorithm cost is extrapolated
+	_calibrateProbeIterations = 1 << 14
+	_calibrateProbeScryptN    = 1 << 14
+	_calibrateMinScryptN      = 1 << 10
+	_calibrateMinIterations   = 1 << 10
+
+	_secondsPerYear = 365.25 * 24 * 60 * 60
+)
+
+type (
+	// Calibration is the key stretching cost measured on the host, Generate
+	// always uses the algorithm cost so the mnemonics stay deterministic,
+	// the profiles are for callers choosing the cost of their own kdfs
+	Calibration struct {
+		PBKDF2Iterations int
+		PBKDF2Duration   time.Duration
+		ScryptN          int
+		ScryptR          int
+		ScryptDuration   time.Duration
+		Profiles         []Profile
+	}
+
+	// Profile is the recommended kdf cost to spend the target duration on the
+	// host, split evenly between pbkdf2 and scrypt
+	Profile struct {
+		Name             string
+		Target           time.Duration
+		PBKDF2Iterations int
+		ScryptN          int
+		Duration         time.Duration // estimated duration of the profile
+	}
+)
+
+var _profileTargets = []struct {
+	name   string
+	target time.Duration
+}{
+	{"interactive", 250 * time.Millisecond},
+	{"default", time.Second},
+	{"paranoid", 5 * time.Second},
+}
+
+// Calibrate measures the pbkdf2 and scrypt throughput of the host and
+// extrapolates the duration of the algorithm cost and of the profiles
+func Calibrate() Calibration {
+	input, salt := []byte("nomnemonic calibrate"), []byte("salt")
+
+	start := time.Now()
+	pbkdf2.Key(input, salt, _calibrateProbeIterations, 32, sha512.New)
+	perIteration := float64(time.Since(start)) / _calibrateProbeIterations
+
+	start = time.Now()
+	scrypt.Key(input, salt, _calibrateProbeScryptN, _kdfScryptR, _kdfScryptP, 32)
+	perN := float64(time.Since(start)) / _calibrateProbeScryptN
+
+	c := Calibration{
+		PBKDF2Iterations: _kdfPBKDF2Iterations,
+		PBKDF2Duration:   time.Duration(perIteration * _kdfPBKDF2Iterations),
+		ScryptN:          _kdfScryptN,
+		ScryptR:          _kdfScryptR,
+		ScryptDuration:   time.Duration(perN * _kdfScryptN),
+	}
+	for _, t := range _profileTargets {
+		half := float64(t.target) / 2
+		iterations := int(half / perIteration)
+		if iterations < _calibrateMinIterations {
+			iterations = _calibrateMinIterations
+		}
+		n := _calibrateMinScryptN
+		for float64(n*2)*perN <= half {
+			n *= 2
+		}
+		c.Profiles = append(c.Profiles, Profile{
+			Name:             t.name,
+			Target:           t.target,
+			PBKDF2Iterations: iterations,
+			ScryptN:          n,
+			Duration:         time.Duration(perIteration*float64(iterations) + perN*float64(n)),
+		})
+	}
+	return c
+}
+
+// Duration is the estimated duration of a Generate call on the host
+func (c Calibration) Duration() time.Duration {
+	return c.PBKDF2Duration + c.ScryptDuration
+}
+
+// AttackYears estimates the years to find a secret of the given entropy bits
+// on average, with an attacker running the algorithm on cores as fast as the
+// host core
+func (c Calibration) AttackYears(bits float64, cores int) float64 {
+	guesses := math.Pow(2, bits-1)
+	return guesses * c.Duration().Seconds() / float64(cores) / _secondsPerYear
+}
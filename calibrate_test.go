@@ -0,0 +1,48 @@
+package nomnemonic
+
+import (
+	"testing"
+)
+
+func TestCalibrate(t *testing.T) {
+	c := Calibrate()
+	if c.PBKDF2Duration <= 0 || c.ScryptDuration <= 0 {
+		t.Fatalf("expected positive durations but actual %s and %s", c.PBKDF2Duration, c.ScryptDuration)
+	}
+	if c.Duration() != c.PBKDF2Duration+c.ScryptDuration {
+		t.Errorf("expected duration %s but actual %s", c.PBKDF2Duration+c.ScryptDuration, c.Duration())
+	}
+	if len(c.Profiles) != len(_profileTargets) {
+		t.Fatalf("expected %d profiles but actual %d", len(_profileTargets), len(c.Profiles))
+	}
+	for i := 1; i < len(c.Profiles); i++ {
+		prev, p := c.Profiles[i-1], c.Profiles[i]
+		if p.PBKDF2Iterations < prev.PBKDF2Iterations || p.ScryptN < prev.ScryptN {
+			t.Errorf("expected %s costs to be at least %s costs", p.Name, prev.Name)
+		}
+		if p.ScryptN&(p.ScryptN-1) != 0 {
+			t.Errorf("expected %s scrypt n to be a power of 2 but actual %d", p.Name, p.ScryptN)
+		}
+	}
+}
+
+func TestAttackYears(t *testing.T) {
+	c := Calibration{PBKDF2Duration: _secondsPerYear * 1e9 / 2, ScryptDuration: _secondsPerYear * 1e9 / 2}
+
+	tests := []struct {
+		bits     float64
+		cores    int
+		expected float64
+	}{
+		{bits: 1, cores: 1, expected: 1},
+		{bits: 11, cores: 1, expected: 1024},
+		{bits: 11, cores: 1024, expected: 1},
+	}
+
+	for _, test := range tests {
+		actual := c.AttackYears(test.bits, test.cores)
+		if actual != test.expected {
+			t.Errorf("expected %v years for %v bits on %d cores but actual %v", test.expected, test.bits, test.cores, actual)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package nomnemonic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	"unicode/utf8"
+)
+
+// _cardPrefix is the number of letters identifying a bip39 word
+const _cardPrefix = 4
+
+type (
+	// Card lays the words out as a recovery card grid, numbered down the
+	// columns like the cards printed by hardware wallet vendors, with the
+	// first 4 letters of each word which are enough to recover it
+	Card struct {
+		Columns int          `json:"columns"`
+		Rows    [][]CardCell `json:"rows"`
+	}
+
+	// CardCell is a numbered word of the card, Number starts from 1
+	CardCell struct {
+		Number int    `json:"number"`
+		Word   string `json:"word"`
+		Prefix string `json:"prefix"`
+	}
+)
+
+var _cardTemplate = template.Must(template.New("card").Parse(`<table class="nomnemonic-card">
+{{range .Rows}}<tr>{{range .}}<td class="number">{{.Number}}</td><td class="word">{{.Word}}</td><td class="prefix">{{.Prefix}}</td>{{end}}</tr>
+{{end}}</table>
+`))
+
+// NewCard lays the words out in at most the columns, each column is filled
+// first so 12 words in 5 columns take 4 columns of 3 rows
+func NewCard(words []string, columns int) (Card, error) {
+	if len(words) == 0 {
+		return Card{}, errors.New("no words given")
+	}
+	if columns < 1 || columns > len(words) {
+		return Card{}, fmt.Errorf("columns must be between 1 and %d", len(words))
+	}
+
+	rows := (len(words) + columns - 1) / columns
+	card := Card{Columns: (len(words) + rows - 1) / rows, Rows: make([][]CardCell, rows)}
+	for i, w := range words {
+		prefix := w
+		if utf8.RuneCountInString(w) > _cardPrefix {
+			prefix = string([]rune(w)[:_cardPrefix])
+		}
+		row := i % rows
+		card.Rows[row] = append(card.Rows[row], CardCell{Number: i + 1, Word: w, Prefix: prefix})
+	}
+	return card, nil
+}
+
+// Text renders the card as aligned plain text, one row per line
+func (c Card) Text() string {
+	numberWidth, wordWidth := 1, 1
+	for _, row := range c.Rows {
+		for _, cell := range row {
+			if n := len(fmt.Sprint(cell.Number)); n > numberWidth {
+				numberWidth = n
+			}
+			if n := utf8.RuneCountInString(cell.Word); n > wordWidth {
+				wordWidth = n
+			}
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range c.Rows {
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			word := cell.Word + strings.Repeat(" ", wordWidth-utf8.RuneCountInString(cell.Word))
+			prefix := cell.Prefix + strings.Repeat(" ", _cardPrefix-utf8.RuneCountInString(cell.Prefix))
+			cells = append(cells, fmt.Sprintf("%*d %s %s", numberWidth, cell.Number, word, prefix))
+		}
+		b.WriteString(strings.TrimRight(strings.Join(cells, "   "), " "))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// HTML renders the card as a table with number, word and prefix cells, the
+// classes are left to the page to style
+func (c Card) HTML() (string, error) {
+	var b bytes.Buffer
+	if err := _cardTemplate.Execute(&b, c); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
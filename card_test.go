@@ -0,0 +1,62 @@
+package nomnemonic
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCard(t *testing.T) {
+	words := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	card, err := NewCard(words, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ` 1 cinnamon cinn    7 paddle   padd
+ 2 venue    venu    8 unaware  unaw
+ 3 broken   brok    9 critic   crit
+ 4 old      old    10 alarm    alar
+ 5 brass    bras   11 consider cons
+ 6 vague    vagu   12 hobby    hobb
+`
+	if card.Text() != expected {
+		t.Errorf("expected\n%s\nbut actual\n%s", expected, card.Text())
+	}
+
+	html, err := card.HTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html, `<tr><td class="number">1</td><td class="word">cinnamon</td><td class="prefix">cinn</td><td class="number">7</td>`) {
+		t.Errorf("expected the first row in %s", html)
+	}
+
+	b, err := json.Marshal(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(b), `{"columns":2,"rows":[[{"number":1,"word":"cinnamon","prefix":"cinn"},{"number":7,"word":"paddle","prefix":"padd"}],`) {
+		t.Errorf("unexpected json %s", b)
+	}
+}
+
+func TestCardUneven(t *testing.T) {
+	words := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	card, err := NewCard(words, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card.Columns != 4 || len(card.Rows) != 3 || len(card.Rows[0]) != 4 || card.Rows[2][3].Number != 12 {
+		t.Errorf("expected 3 rows of 4 columns but actual %v", card.Rows)
+	}
+
+	for _, columns := range []int{0, 13} {
+		if _, err := NewCard(words, columns); err == nil || err.Error() != "columns must be between 1 and 12" {
+			t.Errorf("%d: expected columns err but actual %v", columns, err)
+		}
+	}
+	if _, err := NewCard(nil, 1); err == nil || err.Error() != "no words given" {
+		t.Errorf("expected err 'no words given' but actual %v", err)
+	}
+}
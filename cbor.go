@@ -0,0 +1,121 @@
+package nomnemonic
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+var (
+	// deterministic encoding so the same value always has the same bytes
+	_cborEnc, _ = cbor.CoreDetEncOptions().EncMode()
+	_cborDec, _ = cbor.DecOptions{DupMapKey: cbor.DupMapKeyEnforcedAPF}.DecMode()
+)
+
+// the cbor maps use small integer keys, the numbers are part of the schema
+// and are never reused
+type (
+	resultCBOR struct {
+		Words            []string `cbor:"1,keyasint,omitempty"`
+		Entropy          []byte   `cbor:"2,keyasint,omitempty"`
+		Seed             []byte   `cbor:"3,keyasint,omitempty"`
+		Fingerprint      []byte   `cbor:"4,keyasint,omitempty"`
+		Language         string   `cbor:"5,keyasint,omitempty"`
+		Version          string   `cbor:"6,keyasint,omitempty"`
+		AlgorithmVersion string   `cbor:"7,keyasint,omitempty"`
+		KDFHash          string   `cbor:"8,keyasint,omitempty"`
+		WordlistChecksum string   `cbor:"9,keyasint,omitempty"`
+		Features         []string `cbor:"10,keyasint,omitempty"`
+		Purpose          string   `cbor:"11,keyasint,omitempty"`
+	}
+
+	kdfParamsCBOR struct {
+		Time    uint32 `cbor:"1,keyasint"`
+		Memory  uint32 `cbor:"2,keyasint"`
+		Threads uint8  `cbor:"3,keyasint"`
+	}
+
+	containerCBOR struct {
+		Version    uint8         `cbor:"1,keyasint"`
+		KDF        kdfParamsCBOR `cbor:"2,keyasint"`
+		Salt       []byte        `cbor:"3,keyasint"`
+		Nonce      []byte        `cbor:"4,keyasint"`
+		Ciphertext []byte        `cbor:"5,keyasint"`
+	}
+)
+
+// MarshalCBOR encodes the result as a deterministic cbor map with the integer
+// keys 1 words, 2 entropy, 3 seed, 4 fingerprint, 5 language, 6 version, 7
+// algorithm version, 8 kdf hash, 9 word list checksum, 10 features and 11
+// purpose, the empty fields are left out
+func (r Result) MarshalCBOR() ([]byte, error) {
+	return _cborEnc.Marshal(resultCBOR(r))
+}
+
+// UnmarshalCBOR decodes the result from its cbor map
+func (r *Result) UnmarshalCBOR(b []byte) error {
+	var v resultCBOR
+	if err := _cborDec.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*r = Result(v)
+	return nil
+}
+
+// MarshalCBOR encodes the params as a cbor map with the integer keys 1 time,
+// 2 memory and 3 threads
+func (p KDFParams) MarshalCBOR() ([]byte, error) {
+	return _cborEnc.Marshal(kdfParamsCBOR(p))
+}
+
+// UnmarshalCBOR decodes the params from their cbor map
+func (p *KDFParams) UnmarshalCBOR(b []byte) error {
+	var v kdfParamsCBOR
+	if err := _cborDec.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*p = KDFParams(v)
+	return nil
+}
+
+// ContainerCBOR converts an export container to a cbor map with the integer
+// keys 1 version, 2 kdf params, 3 salt, 4 nonce and 5 ciphertext, it is not
+// decrypted and ContainerFromCBOR gives back the same container. The key
+// slots containers have no cbor form
+func ContainerCBOR(container []byte) ([]byte, error) {
+	if len(container) < _exportHeaderSize || string(container[:4]) != _exportMagic {
+		return nil, errors.New("not an export container")
+	}
+	if !exportVersionSupported(container[4]) {
+		return nil, fmt.Errorf("unsupported export version %d", container[4])
+	}
+	return _cborEnc.Marshal(containerCBOR{
+		Version:    container[4],
+		KDF:        kdfParamsCBOR(exportParams(container)),
+		Salt:       container[14 : 14+_exportSaltSize],
+		Nonce:      container[14+_exportSaltSize : _exportHeaderSize],
+		Ciphertext: container[_exportHeaderSize:],
+	})
+}
+
+// ContainerFromCBOR converts the cbor map back to the export container for
+// Import, the header is authenticated so a changed field fails there
+func ContainerFromCBOR(b []byte) ([]byte, error) {
+	var v containerCBOR
+	if err := _cborDec.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	if !exportVersionSupported(v.Version) {
+		return nil, fmt.Errorf("unsupported export version %d", v.Version)
+	}
+	if len(v.Salt) != _exportSaltSize || len(v.Nonce) != _exportHeaderSize-14-_exportSaltSize {
+		return nil, errors.New("invalid export salt or nonce size")
+	}
+
+	container := make([]byte, _exportHeaderSize, _exportHeaderSize+len(v.Ciphertext))
+	putExportHeader(container, v.Version, KDFParams(v.KDF))
+	copy(container[14:], v.Salt)
+	copy(container[14+_exportSaltSize:], v.Nonce)
+	return append(container, v.Ciphertext...), nil
+}
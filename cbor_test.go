@@ -0,0 +1,94 @@
+package nomnemonic
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+func TestResultCBOR(t *testing.T) {
+	r := Result{
+		Words:       []string{"abandon", "about"},
+		Fingerprint: []byte{0x73, 0xc5, 0xda, 0x0a},
+		Language:    "english",
+	}
+	b, err := cbor.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// {1: ["abandon", "about"], 4: h'73c5da0a', 5: "english"}
+	expected := "a30182676162616e646f6e6561626f7574044473c5da0a0567656e676c697368"
+	if hex.EncodeToString(b) != expected {
+		t.Errorf("expected %s but actual %x", expected, b)
+	}
+
+	var actual Result
+	if err := cbor.Unmarshal(b, &actual); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actual, r) {
+		t.Errorf("expected %+v but actual %+v", r, actual)
+	}
+
+	// duplicate key 5
+	duplicate, _ := hex.DecodeString("a20567656e676c6973680567656e676c697368")
+	if err := cbor.Unmarshal(duplicate, &actual); err == nil {
+		t.Error("expected duplicate keys to be rejected")
+	}
+}
+
+func TestKDFParamsCBOR(t *testing.T) {
+	b, err := cbor.Marshal(KDFInteractive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// {1: 2, 2: 65536, 3: 4}
+	expected := "a30102021a000100000304"
+	if hex.EncodeToString(b) != expected {
+		t.Errorf("expected %s but actual %x", expected, b)
+	}
+	var actual KDFParams
+	if err := cbor.Unmarshal(b, &actual); err != nil || actual != KDFInteractive {
+		t.Errorf("expected %+v but actual %+v %v", KDFInteractive, actual, err)
+	}
+}
+
+func TestContainerCBOR(t *testing.T) {
+	words := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	container, err := Export(words, "correct horse", _testKDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ContainerCBOR(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := ContainerFromCBOR(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(back, container) {
+		t.Errorf("expected %x but actual %x", container, back)
+	}
+	actual, err := Import(back, "correct horse")
+	if err != nil || strings.Join(actual, " ") != strings.Join(words, " ") {
+		t.Errorf("expected %v but actual %v %v", words, actual, err)
+	}
+
+	if _, err := ContainerCBOR([]byte("NMEX")); err == nil || err.Error() != "not an export container" {
+		t.Errorf("expected err 'not an export container' but actual %v", err)
+	}
+	// {1: 3}
+	if _, err := ContainerFromCBOR([]byte{0xa1, 0x01, 0x03}); err == nil || err.Error() != "unsupported export version 3" {
+		t.Errorf("expected err 'unsupported export version 3' but actual %v", err)
+	}
+	// {1: 1}
+	if _, err := ContainerFromCBOR([]byte{0xa1, 0x01, 0x01}); err == nil || err.Error() != "invalid export salt or nonce size" {
+		t.Errorf("expected err 'invalid export salt or nonce size' but actual %v", err)
+	}
+}
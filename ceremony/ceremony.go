@@ -0,0 +1,271 @@
+// Package ceremony runs a generation as recorded steps for key ceremonies:
+// operator confirmations, dual entry of the password and the passcode and the
+// read back of the checksum word, and signs a report free of secrets for the
+// compliance archives
+package ceremony
+
+import (
+	"crypto/ed25519"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nomnemonic/nomnemonic"
+	"github.com/nomnemonic/nomnemonic/bip32"
+)
+
+const (
+	_outcomeOK     = "ok"
+	_outcomeFailed = "failed"
+)
+
+// _outcomes are the recorded outcomes of the error kinds, the messages of the
+// errors may quote the secrets so they are never recorded
+var _outcomes = []struct {
+	kind    error
+	outcome string
+}{
+	{kind: ErrMismatch, outcome: "mismatch"},
+	{kind: ErrNotConfirmed, outcome: "not confirmed"},
+	{kind: nomnemonic.ErrShortIdentifier, outcome: "short identifier"},
+	{kind: nomnemonic.ErrWeakPassword, outcome: "weak password"},
+	{kind: nomnemonic.ErrInvalidPasscode, outcome: "invalid passcode"},
+	{kind: nomnemonic.ErrUnsupportedSize, outcome: "unsupported size"},
+	{kind: nomnemonic.ErrPolicyViolation, outcome: "policy violation"},
+	{kind: nomnemonic.ErrSelfCheck, outcome: "self check failed"},
+}
+
+var (
+	// ErrMismatch is returned when the two entries of a secret differ or the
+	// checksum word read back is not the one shown
+	ErrMismatch = errors.New("entries do not match")
+	// ErrNotConfirmed is returned when the operator does not confirm a
+	// statement
+	ErrNotConfirmed = errors.New("statement not confirmed by the operator")
+	// ErrSignature is returned by Verify when the report is not signed by the
+	// key
+	ErrSignature = errors.New("invalid ceremony report signature")
+
+	// DefaultConfirmations are the statements confirmed when the config has
+	// none
+	DefaultConfirmations = []string{
+		"the room is clear of cameras and people not taking part",
+		"the device is offline",
+	}
+)
+
+type (
+	// Operator answers the prompts of the ceremony, usually on a terminal
+	Operator interface {
+		// Confirm asks the operator to confirm the statement
+		Confirm(statement string) (bool, error)
+		// Enter reads a value shown while typed
+		Enter(prompt string) (string, error)
+		// Secret reads a value hidden while typed
+		Secret(prompt string) (string, error)
+		// Show displays the words to write down
+		Show(words []string) error
+	}
+
+	// Config is the ceremony to run, Key signs the report
+	Config struct {
+		ID            string
+		Operator      string
+		Confirmations []string
+		Size          int
+		Key           ed25519.PrivateKey
+		// Now returns the time of the steps, time.Now by default
+		Now func() time.Time
+	}
+
+	// Step is a recorded step, Outcome is ok or the kind of error the
+	// ceremony stopped with, never its message
+	Step struct {
+		Name    string    `json:"name"`
+		Detail  string    `json:"detail,omitempty"`
+		Outcome string    `json:"outcome"`
+		At      time.Time `json:"at"`
+	}
+
+	// Report is the signed record of a ceremony. Result is the redacted
+	// envelope with the fingerprint of the phrase without passphrase, neither
+	// the inputs nor the words are recorded
+	Report struct {
+		ID        string            `json:"id"`
+		Operator  string            `json:"operator"`
+		Started   time.Time         `json:"started"`
+		Finished  time.Time         `json:"finished"`
+		Steps     []Step            `json:"steps"`
+		Completed bool              `json:"completed"`
+		Result    nomnemonic.Result `json:"result"`
+		PublicKey string            `json:"public_key"`
+		Signature string            `json:"signature,omitempty"`
+	}
+
+	ceremony struct {
+		m      nomnemonic.Mnemonicer
+		op     Operator
+		now    func() time.Time
+		report Report
+	}
+)
+
+// Run runs the ceremony with the operator and returns the signed report, on
+// failure the report records the steps up to the failed one and is signed as
+// well
+func Run(m nomnemonic.Mnemonicer, op Operator, cfg Config) (Report, error) {
+	if len(cfg.Key) != ed25519.PrivateKeySize {
+		return Report{}, errors.New("invalid ceremony signing key")
+	}
+	now := cfg.Now
+	if now == nil {
+		now = time.Now
+	}
+	c := &ceremony{m: m, op: op, now: func() time.Time { return now().UTC() }}
+	c.report = Report{
+		ID:        cfg.ID,
+		Operator:  cfg.Operator,
+		Started:   c.now(),
+		PublicKey: hex.EncodeToString(cfg.Key.Public().(ed25519.PublicKey)),
+	}
+
+	err := c.run(cfg)
+	c.report.Completed = err == nil
+	c.report.Finished = c.now()
+	if serr := c.report.sign(cfg.Key); serr != nil {
+		return Report{}, serr
+	}
+	return c.report, err
+}
+
+func (c *ceremony) run(cfg Config) error {
+	confirmations := cfg.Confirmations
+	if len(confirmations) == 0 {
+		confirmations = DefaultConfirmations
+	}
+	for _, s := range confirmations {
+		ok, err := c.op.Confirm(s)
+		if err == nil && !ok {
+			err = ErrNotConfirmed
+		}
+		if err = c.record("confirmation", s, err); err != nil {
+			return err
+		}
+	}
+
+	identifier, err := c.op.Enter("identifier")
+	if err = c.record("identifier", "", err); err != nil {
+		return err
+	}
+	password, err := c.dualEntry("password")
+	if err != nil {
+		return err
+	}
+	passcode, err := c.dualEntry("passcode")
+	if err != nil {
+		return err
+	}
+
+	result, err := c.m.GenerateDetailed(identifier, password, passcode, cfg.Size)
+	if err == nil {
+		result.Fingerprint, err = c.fingerprint(result.Words)
+	}
+	if err = c.record("generation", fmt.Sprintf("%d words", cfg.Size), err); err != nil {
+		return err
+	}
+	c.report.Result = result.Redacted()
+
+	if err = c.record("display", "", c.op.Show(result.Words)); err != nil {
+		return err
+	}
+	word, err := c.op.Enter("checksum word")
+	if err == nil && subtle.ConstantTimeCompare([]byte(strings.TrimSpace(word)), []byte(result.Words[len(result.Words)-1])) != 1 {
+		err = ErrMismatch
+	}
+	return c.record("checksum read back", "", err)
+}
+
+// dualEntry reads the secret twice and records whether both entries match
+func (c *ceremony) dualEntry(name string) (string, error) {
+	first, err := c.op.Secret(name)
+	if err != nil {
+		return "", c.record(name, "dual entry", err)
+	}
+	second, err := c.op.Secret(name + " again")
+	if err == nil && subtle.ConstantTimeCompare([]byte(first), []byte(second)) != 1 {
+		err = ErrMismatch
+	}
+	return first, c.record(name, "dual entry", err)
+}
+
+// fingerprint returns the bip32 fingerprint of the phrase without passphrase,
+// it identifies the wallet without giving access to it
+func (c *ceremony) fingerprint(words []string) ([]byte, error) {
+	seed, err := c.m.GenerateSeed(strings.Join(words, " "), "")
+	if err != nil {
+		return nil, err
+	}
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	return master.Fingerprint(), nil
+}
+
+// record appends the step with the outcome of the error and returns it
+func (c *ceremony) record(name, detail string, err error) error {
+	c.report.Steps = append(c.report.Steps, Step{Name: name, Detail: detail, Outcome: outcome(err), At: c.now()})
+	return err
+}
+
+// outcome returns the fixed outcome of the kind of the error, failed for the
+// unknown kinds
+func outcome(err error) string {
+	if err == nil {
+		return _outcomeOK
+	}
+	for _, o := range _outcomes {
+		if errors.Is(err, o.kind) {
+			return o.outcome
+		}
+	}
+	return _outcomeFailed
+}
+
+// payload is the json of the report without the signature
+func (r Report) payload() ([]byte, error) {
+	r.Signature = ""
+	return json.Marshal(r)
+}
+
+func (r *Report) sign(key ed25519.PrivateKey) error {
+	payload, err := r.payload()
+	if err != nil {
+		return err
+	}
+	r.Signature = hex.EncodeToString(ed25519.Sign(key, payload))
+	return nil
+}
+
+// Verify checks the report is signed by the public key
+func Verify(r Report, publicKey ed25519.PublicKey) error {
+	if r.PublicKey != hex.EncodeToString(publicKey) {
+		return ErrSignature
+	}
+	signature, err := hex.DecodeString(r.Signature)
+	if err != nil {
+		return ErrSignature
+	}
+	payload, err := r.payload()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, payload, signature) {
+		return ErrSignature
+	}
+	return nil
+}
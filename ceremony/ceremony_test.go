@@ -0,0 +1,176 @@
+package ceremony
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+type scriptedOperator struct {
+	confirm bool
+	entries []string
+	secrets []string
+	shown   []string
+}
+
+func (o *scriptedOperator) Confirm(string) (bool, error) { return o.confirm, nil }
+
+func (o *scriptedOperator) Enter(string) (string, error) {
+	if len(o.entries) == 0 {
+		return "", errors.New("no entry")
+	}
+	e := o.entries[0]
+	o.entries = o.entries[1:]
+	return e, nil
+}
+
+func (o *scriptedOperator) Secret(string) (string, error) {
+	if len(o.secrets) == 0 {
+		return "", errors.New("no secret")
+	}
+	s := o.secrets[0]
+	o.secrets = o.secrets[1:]
+	return s, nil
+}
+
+func (o *scriptedOperator) Show(words []string) error {
+	o.shown = words
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	m := buildMnemonicer(t)
+	_, key, _ := ed25519.GenerateKey(nil)
+	at := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	cfg := Config{ID: "c-1", Operator: "alice", Size: 12, Key: key, Now: func() time.Time { return at }}
+
+	tests := []struct {
+		name      string
+		op        *scriptedOperator
+		err       error
+		completed bool
+		steps     int
+		outcome   string
+	}{
+		{
+			name: "completed",
+			op: &scriptedOperator{confirm: true,
+				entries: []string{"nomnemonic_test", "hobby"},
+				secrets: []string{"test12345678", "test12345678", "101938", "101938"}},
+			completed: true,
+			steps:     8,
+		},
+		{
+			name:  "not confirmed",
+			op:    &scriptedOperator{},
+			err:   ErrNotConfirmed,
+			steps: 1,
+		},
+		{
+			name: "password mismatch",
+			op: &scriptedOperator{confirm: true,
+				entries: []string{"nomnemonic_test"},
+				secrets: []string{"test12345678", "test12345679"}},
+			err:     ErrMismatch,
+			steps:   4,
+			outcome: "mismatch",
+		},
+		{
+			name: "invalid passcode",
+			op: &scriptedOperator{confirm: true,
+				entries: []string{"nomnemonic_test"},
+				secrets: []string{"test12345678", "test12345678", "10193a", "10193a"}},
+			err:     nomnemonic.ErrInvalidPasscode,
+			steps:   6,
+			outcome: "invalid passcode",
+		},
+		{
+			name: "wrong checksum word",
+			op: &scriptedOperator{confirm: true,
+				entries: []string{"nomnemonic_test", "ocean"},
+				secrets: []string{"test12345678", "test12345678", "101938", "101938"}},
+			err:   ErrMismatch,
+			steps: 8,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report, err := Run(m, test.op, cfg)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v but actual %v", test.err, err)
+			}
+			if report.Completed != test.completed {
+				t.Errorf("expected completed %t but actual %t", test.completed, report.Completed)
+			}
+			if len(report.Steps) != test.steps {
+				t.Errorf("expected %d steps but actual %d", test.steps, len(report.Steps))
+			}
+			if err := Verify(report, key.Public().(ed25519.PublicKey)); err != nil {
+				t.Errorf("unexpected error: %s", err.Error())
+			}
+			if last := report.Steps[len(report.Steps)-1]; test.outcome != "" && last.Outcome != test.outcome {
+				t.Errorf("expected outcome %s but actual %s", test.outcome, last.Outcome)
+			}
+
+			data, _ := json.Marshal(report)
+			for _, secret := range []string{"test12345678", "101938", "10193a", "nomnemonic_test", "cinnamon"} {
+				if strings.Contains(string(data), secret) {
+					t.Errorf("expected no %s in the report but actual %s", secret, data)
+				}
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(nil)
+	op := &scriptedOperator{confirm: true,
+		entries: []string{"nomnemonic_test", "hobby"},
+		secrets: []string{"test12345678", "test12345678", "101938", "101938"}}
+	report, err := Run(buildMnemonicer(t), op, Config{ID: "c-2", Operator: "bob", Size: 12, Key: key})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	if actual := strings.Join(op.shown, " "); actual != expected {
+		t.Errorf("expected %s but actual %s", expected, actual)
+	}
+
+	data, _ := json.Marshal(report)
+	var decoded Report
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	public := key.Public().(ed25519.PublicKey)
+	if err := Verify(decoded, public); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	decoded.Operator = "mallory"
+	if err := Verify(decoded, public); !errors.Is(err, ErrSignature) {
+		t.Errorf("expected %v but actual %v", ErrSignature, err)
+	}
+	other, _, _ := ed25519.GenerateKey(nil)
+	if err := Verify(report, other); !errors.Is(err, ErrSignature) {
+		t.Errorf("expected %v but actual %v", ErrSignature, err)
+	}
+}
+
+func buildMnemonicer(t *testing.T) nomnemonic.Mnemonicer {
+	bytes, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	m, err := nomnemonic.New(strings.Split(string(bytes), "\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return m
+}
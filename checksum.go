@@ -0,0 +1,88 @@
+package nomnemonic
+
+// Checksum returns the bip39 checksum bits of the entropy, the first
+// len(entropy)/4 bits of its sha256, for the tools checking steel backups or
+// dice generated phrases
+func Checksum(entropy []byte) (string, error) {
+	strength := len(entropy) * _bitChunkSizeOneByte
+	if _, ok := _strengths[strength]; !ok {
+		return "", errorf(ErrUnsupportedSize, "unsupported strength: %d", strength)
+	}
+	return checksum(entropy, strength/_bitChunkSizeEntropy), nil
+}
+
+// WordIndexes returns the word list indexes of the phrase of the entropy, the
+// last one ends with the checksum bits
+func WordIndexes(entropy []byte) ([]int, error) {
+	cs, err := Checksum(entropy)
+	if err != nil {
+		return nil, err
+	}
+	bins := bytesToBin(entropy) + cs
+	chunks := chunkSplit(bins, _bitChunkSizeBip39WordIndex)
+	indexes := make([]int, len(chunks))
+	for i, c := range chunks {
+		indexes[i] = binToInt(c)
+	}
+	return indexes, nil
+}
+
+// ChecksumWordIndex returns the index of the last word of the phrase of the
+// entropy, its leading bits are the end of the entropy and the trailing ones
+// the checksum
+func ChecksumWordIndex(entropy []byte) (int, error) {
+	indexes, err := WordIndexes(entropy)
+	if err != nil {
+		return 0, err
+	}
+	return indexes[len(indexes)-1], nil
+}
+
+// LastWordIndexes returns the indexes of every last word completing the n-1
+// word indexes into a phrase with a valid checksum, like LastWords, for 11
+// dice rolled words there are 128 of them
+func LastWordIndexes(indexes []int) ([]int, error) {
+	strength := _sentenceStrengths[len(indexes)+1]
+	if _, ok := _strengths[strength]; !ok {
+		return nil, errorf(ErrUnsupportedSize, "unsupported strength: %d", strength)
+	}
+	bins, err := indexBins(indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	csSize := strength / _bitChunkSizeEntropy
+	prefixSize := _bitChunkSizeBip39WordIndex - csSize
+	candidates := make([]int, 0, 1<<prefixSize)
+	for p := 0; p < 1<<prefixSize; p++ {
+		prefix := intToBin(p, prefixSize)
+		candidates = append(candidates, binToInt(prefix+checksum(binToBytes(bins+prefix), csSize)))
+	}
+	return candidates, nil
+}
+
+// ValidWordIndexes reports whether the checksum of the word indexes of a
+// phrase is valid, the unsupported sizes and indexes are errors
+func ValidWordIndexes(indexes []int) (bool, error) {
+	strength := _sentenceStrengths[len(indexes)]
+	if _, ok := _strengths[strength]; !ok {
+		return false, errorf(ErrUnsupportedSize, "unsupported strength: %d", strength)
+	}
+	bins, err := indexBins(indexes)
+	if err != nil {
+		return false, err
+	}
+	return checksum(binToBytes(bins[:strength]), strength/_bitChunkSizeEntropy) == bins[strength:], nil
+}
+
+// indexBins returns the bits of the word indexes
+func indexBins(indexes []int) (string, error) {
+	bins := ""
+	for i, index := range indexes {
+		if index < 0 || index >= _wordlistSize {
+			return "", errorf(ErrUnknownWord, "word index %d at %d out of range", index, i)
+		}
+		bins += intToBin(index, _bitChunkSizeBip39WordIndex)
+	}
+	return bins, nil
+}
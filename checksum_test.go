@@ -0,0 +1,82 @@
+package nomnemonic
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		entropy  string
+		checksum string
+		last     int
+	}{
+		{entropy: "00000000000000000000000000000000", checksum: "0011", last: 3},
+		{entropy: "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", checksum: "1000", last: 2040},
+		{entropy: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", checksum: "10101111", last: 1967},
+	}
+	for _, test := range tests {
+		entropy, _ := hex.DecodeString(test.entropy)
+		cs, err := Checksum(entropy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cs != test.checksum {
+			t.Errorf("%s: expected checksum %s but actual %s", test.entropy, test.checksum, cs)
+		}
+		last, err := ChecksumWordIndex(entropy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if last != test.last {
+			t.Errorf("%s: expected last word index %d but actual %d", test.entropy, test.last, last)
+		}
+
+		indexes, _ := WordIndexes(entropy)
+		phrase, _ := m.EntropyToWords(entropy)
+		for i, index := range indexes {
+			if words[index] != phrase[i] {
+				t.Errorf("%s: expected word %s at %d but actual %s", test.entropy, phrase[i], i, words[index])
+			}
+		}
+		if ok, err := ValidWordIndexes(indexes); !ok || err != nil {
+			t.Errorf("%s: expected valid indexes but actual %v", test.entropy, err)
+		}
+		indexes[len(indexes)-1] ^= 1
+		if ok, _ := ValidWordIndexes(indexes); ok {
+			t.Errorf("%s: expected a changed checksum to be invalid", test.entropy)
+		}
+
+		candidates, err := LastWordIndexes(indexes[:len(indexes)-1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, c := range candidates {
+			found = found || c == test.last
+		}
+		if !found || len(candidates) != 1<<(11-len(test.checksum)) {
+			t.Errorf("%s: expected %d candidates with %d but actual %v", test.entropy, 1<<(11-len(test.checksum)), test.last, candidates)
+		}
+	}
+
+	if _, err := Checksum(make([]byte, 15)); !errors.Is(err, ErrUnsupportedSize) {
+		t.Errorf("expected ErrUnsupportedSize but actual %v", err)
+	}
+	if _, err := ValidWordIndexes([]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2048}); !errors.Is(err, ErrUnknownWord) {
+		t.Errorf("expected ErrUnknownWord but actual %v", err)
+	}
+	if _, err := LastWordIndexes([]int{0, 0}); !errors.Is(err, ErrUnsupportedSize) {
+		t.Errorf("expected ErrUnsupportedSize but actual %v", err)
+	}
+}
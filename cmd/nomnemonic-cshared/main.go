@@ -0,0 +1,136 @@
+// Command nomnemonic-cshared exports the generator as C functions for
+// Python, Rust, Swift and other languages with a C FFI. Build it with
+//
+//	go build -buildmode=c-shared -o libnomnemonic.so ./cmd/nomnemonic-cshared
+//
+// which also writes libnomnemonic.h. Strings returned by the library are
+// allocated with malloc and must be released with nomnemonic_free, which
+// wipes them first. Buffers the caller owns, like the seed, can be wiped with
+// nomnemonic_wipe. The Go copies of the inputs are garbage collected and can
+// not be wiped, keep the process short lived when it matters
+package main
+
+/*
+#include <stdlib.h>
+#include <string.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"strings"
+	"unsafe"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+const _seedSize = 64
+
+var _mnemonicer nomnemonic.Mnemonicer
+
+func init() {
+	m, err := nomnemonic.New(wordlists.English)
+	if err != nil {
+		panic(err)
+	}
+	_mnemonicer = m
+}
+
+func main() {}
+
+// nomnemonic_generate returns the space separated words, or NULL and the
+// error in err when err is not NULL
+//
+//export nomnemonic_generate
+func nomnemonic_generate(identifier, password, passcode *C.char, size C.int, err **C.char) *C.char {
+	words, e := generate(C.GoString(identifier), C.GoString(password), C.GoString(passcode), int(size))
+	if e != nil {
+		setError(err, e)
+		return nil
+	}
+	defer wipe(words)
+	return (*C.char)(C.CBytes(append(words, 0)))
+}
+
+// nomnemonic_validate returns 1 when the space separated words are a valid
+// mnemonic, 0 otherwise
+//
+//export nomnemonic_validate
+func nomnemonic_validate(words *C.char) C.int {
+	if validate(C.GoString(words)) {
+		return 1
+	}
+	return 0
+}
+
+// nomnemonic_seed writes the 64 bytes bip39 seed of the words to out and
+// returns 0, or -1 and the error in err when err is not NULL
+//
+//export nomnemonic_seed
+func nomnemonic_seed(words, passphrase *C.char, out *C.uchar, err **C.char) C.int {
+	s, e := seed(C.GoString(words), C.GoString(passphrase))
+	if e != nil {
+		setError(err, e)
+		return -1
+	}
+	defer wipe(s)
+	copy(unsafe.Slice((*byte)(unsafe.Pointer(out)), _seedSize), s)
+	return 0
+}
+
+// nomnemonic_free wipes and frees a string returned by the library, NULL is
+// ignored
+//
+//export nomnemonic_free
+func nomnemonic_free(s *C.char) {
+	if s == nil {
+		return
+	}
+	wipe(unsafe.Slice((*byte)(unsafe.Pointer(s)), C.strlen(s)))
+	C.free(unsafe.Pointer(s))
+}
+
+// nomnemonic_wipe zeroes n bytes of p
+//
+//export nomnemonic_wipe
+func nomnemonic_wipe(p unsafe.Pointer, n C.size_t) {
+	if p == nil {
+		return
+	}
+	wipe(unsafe.Slice((*byte)(p), n))
+}
+
+func setError(dst **C.char, err error) {
+	if dst != nil {
+		*dst = C.CString(err.Error())
+	}
+}
+
+func generate(identifier, password, passcode string, size int) ([]byte, error) {
+	words, err := _mnemonicer.Generate(identifier, password, passcode, size)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.Join(words, " ")), nil
+}
+
+func validate(words string) bool {
+	ok, err := _mnemonicer.IsValid(strings.Fields(words))
+	return err == nil && ok
+}
+
+func seed(words, passphrase string) ([]byte, error) {
+	full, err := _mnemonicer.ExpandWords(strings.Fields(words))
+	if err != nil || !validate(words) {
+		return nil, errors.New("invalid mnemonic")
+	}
+	return _mnemonicer.GenerateSeed(strings.Join(full, " "), passphrase)
+}
+
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
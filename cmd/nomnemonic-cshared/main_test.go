@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	words, err := generate("nomnemonic_test", "test12345678", "101938", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	if string(words) != expected {
+		t.Errorf("expected '%s' but actual '%s'", expected, words)
+	}
+	if !validate(string(words)) {
+		t.Errorf("expected '%s' to be valid", words)
+	}
+
+	if _, err := generate("nomnemonic_test", "short", "101938", 12); err == nil || err.Error() != "password must be at least 12 chars" {
+		t.Errorf("expected err 'password must be at least 12 chars' but actual '%v'", err)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	tests := []struct {
+		words    string
+		expected string
+		err      string
+	}{
+		{
+			words:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			expected: "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			words: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+			err:   "invalid mnemonic",
+		},
+	}
+
+	for _, test := range tests {
+		s, err := seed(test.words, "TREZOR")
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' but actual '%v'", test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(s) != test.expected {
+			t.Errorf("expected seed %s but actual %x", test.expected, s)
+		}
+		wipe(s)
+		for _, b := range s {
+			if b != 0 {
+				t.Errorf("expected wiped seed but actual %x", s)
+				break
+			}
+		}
+	}
+}
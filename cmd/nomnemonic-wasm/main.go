@@ -0,0 +1,174 @@
+//go:build js && wasm
+
+// Command nomnemonic-wasm exposes generate, validate and seed to javascript
+// so web wallets get the outputs of the Go implementation. Build it with
+//
+//	GOOS=js GOARCH=wasm go build -o nomnemonic.wasm ./cmd/nomnemonic-wasm
+//
+// and load it with the wasm_exec.js of the Go release, it sets a global
+// nomnemonic object:
+//
+//	nomnemonic.generate(identifier, password, passcode, size, onProgress) // Promise<string[]>
+//	nomnemonic.validate(words)                                            // boolean
+//	nomnemonic.seed(words, passphrase)                                    // Promise<Uint8Array>
+//	nomnemonic.entropy(words)                                             // Promise<Uint8Array>
+//	nomnemonic.fromEntropy(entropy)                                       // Promise<string[]>
+//
+// The kdf of generate runs in chunks and yields to the event loop between
+// them, so the main thread is not blocked for the seconds it takes
+package main
+
+import (
+	"errors"
+	"strings"
+	"syscall/js"
+	"time"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+// _yield is the pause between two kdf chunks, the go runtime returns to the
+// event loop when every goroutine sleeps
+const _yield = time.Millisecond
+
+func main() {
+	m, err := nomnemonic.New(wordlists.English)
+	if err != nil {
+		panic(err)
+	}
+
+	js.Global().Set("nomnemonic", js.ValueOf(map[string]interface{}{
+		"generate":    js.FuncOf(func(this js.Value, args []js.Value) interface{} { return generate(m, args) }),
+		"validate":    js.FuncOf(func(this js.Value, args []js.Value) interface{} { return validate(m, args) }),
+		"seed":        js.FuncOf(func(this js.Value, args []js.Value) interface{} { return seed(m, args) }),
+		"entropy":     js.FuncOf(func(this js.Value, args []js.Value) interface{} { return entropy(m, args) }),
+		"fromEntropy": js.FuncOf(func(this js.Value, args []js.Value) interface{} { return fromEntropy(m, args) }),
+	}))
+	select {}
+}
+
+func generate(m nomnemonic.Mnemonicer, args []js.Value) interface{} {
+	return promise(func() (interface{}, error) {
+		if len(args) < 4 {
+			return nil, errors.New("generate needs identifier, password, passcode and size")
+		}
+		onProgress := js.Undefined()
+		if len(args) > 4 && args[4].Type() == js.TypeFunction {
+			onProgress = args[4]
+		}
+		words, err := m.GenerateProgress(args[0].String(), args[1].String(), args[2].String(), args[3].Int(), func(done float64) error {
+			if !onProgress.IsUndefined() {
+				onProgress.Invoke(done)
+			}
+			time.Sleep(_yield)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return stringsToJS(words), nil
+	})
+}
+
+func validate(m nomnemonic.Mnemonicer, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return false
+	}
+	ok, err := m.IsValid(stringsFromJS(args[0]))
+	return err == nil && ok
+}
+
+func seed(m nomnemonic.Mnemonicer, args []js.Value) interface{} {
+	return promise(func() (interface{}, error) {
+		if len(args) < 1 {
+			return nil, errors.New("seed needs the words")
+		}
+		words, err := m.ExpandWords(stringsFromJS(args[0]))
+		if ok, _ := m.IsValid(words); err != nil || !ok {
+			return nil, errors.New("invalid mnemonic")
+		}
+		passphrase := ""
+		if len(args) > 1 && args[1].Type() == js.TypeString {
+			passphrase = args[1].String()
+		}
+		s, err := m.GenerateSeed(strings.Join(words, " "), passphrase)
+		if err != nil {
+			return nil, err
+		}
+		return bytesToJS(s), nil
+	})
+}
+
+func entropy(m nomnemonic.Mnemonicer, args []js.Value) interface{} {
+	return promise(func() (interface{}, error) {
+		if len(args) < 1 {
+			return nil, errors.New("entropy needs the words")
+		}
+		e, err := m.CalculateEntropy(stringsFromJS(args[0]))
+		if err != nil {
+			return nil, err
+		}
+		return bytesToJS(e), nil
+	})
+}
+
+func fromEntropy(m nomnemonic.Mnemonicer, args []js.Value) interface{} {
+	return promise(func() (interface{}, error) {
+		if len(args) < 1 || !args[0].InstanceOf(js.Global().Get("Uint8Array")) {
+			return nil, errors.New("fromEntropy needs an Uint8Array")
+		}
+		e := make([]byte, args[0].Length())
+		js.CopyBytesToGo(e, args[0])
+		words, err := m.EntropyToWords(e)
+		if err != nil {
+			return nil, err
+		}
+		return stringsToJS(words), nil
+	})
+}
+
+// promise runs fn in a goroutine, blocking calls are not allowed in the js
+// callbacks, and settles the returned promise with its result
+func promise(fn func() (interface{}, error)) js.Value {
+	executor := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		resolve, reject := args[0], args[1]
+		go func() {
+			v, err := fn()
+			if err != nil {
+				reject.Invoke(js.Global().Get("Error").New(err.Error()))
+				return
+			}
+			resolve.Invoke(v)
+		}()
+		return nil
+	})
+	defer executor.Release()
+	return js.Global().Get("Promise").New(executor)
+}
+
+func stringsFromJS(v js.Value) []string {
+	if v.Type() == js.TypeString {
+		return strings.Fields(v.String())
+	}
+	s := make([]string, v.Length())
+	for i := range s {
+		s[i] = v.Index(i).String()
+	}
+	return s
+}
+
+func stringsToJS(s []string) js.Value {
+	a := js.Global().Get("Array").New(len(s))
+	for i, w := range s {
+		a.SetIndex(i, w)
+	}
+	return a
+}
+
+func bytesToJS(b []byte) js.Value {
+	a := js.Global().Get("Uint8Array").New(len(b))
+	js.CopyBytesToJS(a, b)
+	return a
+}
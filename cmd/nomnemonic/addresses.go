@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+)
+
+const _outputCSV = "csv"
+
+// addresses exports the first receive addresses of the chains for record
+// keeping, the private keys are only included with --with-keys
+func (c *cli) addresses(args []string) int {
+	fs, common := c.flags("addresses")
+	chains := fs.String("chain", "btc,eth", "comma separated chains, see derive")
+	count := fs.Int("count", 10, "number of addresses per chain")
+	withKeys := fs.Bool("with-keys", false, "include the private keys, anyone with the export can spend the funds")
+	sf := newSeedFlags(fs)
+	if fs.Parse(args) != nil {
+		return exitUsage
+	}
+	// csv is only supported by this command, the other formats are common
+	output := *common.output
+	if output != _outputCSV {
+		if err := validateOutput(output); err != nil {
+			return c.fail(err, exitUsage)
+		}
+	}
+	m, _, err := mnemonicer(*common.language)
+	if err != nil {
+		return c.fail(err, exitUsage)
+	}
+	if *count < 1 {
+		return c.fail(errors.New("count must be at least 1"), exitUsage)
+	}
+	var selected []*chain
+	for _, name := range strings.Split(*chains, ",") {
+		ch, err := lookupChain(strings.TrimSpace(name))
+		if err != nil {
+			return c.fail(err, exitUsage)
+		}
+		selected = append(selected, ch)
+	}
+
+	seed, code := c.seedOf(m, sf, fs.Args())
+	if seed == nil {
+		return code
+	}
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+
+	r := newResult(*common.language)
+	for _, ch := range selected {
+		indexes, _ := bip32.ParsePath(ch.defaultPath())
+		addresses, err := ch.addresses(master, indexes, *count, *withKeys)
+		if err != nil {
+			return c.fail(err, exitError)
+		}
+		for i := range addresses {
+			addresses[i].Chain = ch.name
+		}
+		r.Addresses = append(r.Addresses, addresses...)
+	}
+	if *withKeys {
+		fmt.Fprintln(c.stderr, "warning: the export contains private keys")
+	}
+
+	if output == _outputCSV {
+		if err := writeAddressesCSV(c, r.Addresses, *withKeys); err != nil {
+			return c.fail(err, exitError)
+		}
+		return exitOK
+	}
+	lines := make([]string, 0, len(r.Addresses))
+	for _, a := range r.Addresses {
+		line := fmt.Sprintf("%-12s %-20s %s", a.Chain, a.Path, a.Address)
+		if *withKeys {
+			line += " " + a.PrivateKey
+		}
+		lines = append(lines, line)
+	}
+	return c.write(r, output, strings.Join(lines, "\n"))
+}
+
+func writeAddressesCSV(c *cli, addresses []derivedAddress, withKeys bool) error {
+	w := csv.NewWriter(c.stdout)
+	header := []string{"chain", "path", "address"}
+	if withKeys {
+		header = append(header, "private_key")
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, a := range addresses {
+		record := []string{a.Chain, a.Path, a.Address}
+		if withKeys {
+			record = append(record, a.PrivateKey)
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestAddresses(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+	}{
+		{
+			name: "csv without keys",
+			args: []string{"addresses", "--count", "2", "--output", "csv", _abandonAbout},
+			stdout: "chain,path,address\n" +
+				"bitcoin,m/84'/0'/0'/0/0,bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu\n" +
+				"bitcoin,m/84'/0'/0'/0/1,bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g\n" +
+				"ethereum,m/44'/60'/0'/0/0,0x9858EfFD232B4033E47d90003D41EC34EcaEda94\n" +
+				"ethereum,m/44'/60'/0'/0/1,0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0\n",
+		},
+		{
+			name: "csv with keys",
+			args: []string{"addresses", "--chain", "btc", "--count", "1", "--output", "csv", "--with-keys", _abandonAbout},
+			stdout: "chain,path,address,private_key\n" +
+				"bitcoin,m/84'/0'/0'/0/0,bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu,KyZpNDKnfs94vbrwhJneDi77V6jF64PWPF8x5cdJb8ifgg2DUc9d\n",
+		},
+		{
+			name:   "json without keys",
+			args:   []string{"addresses", "--chain", "eth", "--count", "1", "--output", "json", _abandonAbout},
+			stdout: `"address": "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"`,
+		},
+		{
+			name: "unknown chain",
+			args: []string{"addresses", "--chain", "btc,xyz", _abandonAbout},
+			code: exitUsage,
+		},
+		{
+			name: "unknown output",
+			args: []string{"addresses", "--output", "xml", _abandonAbout},
+			code: exitUsage,
+		},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		c := &cli{
+			stdin:  bufio.NewReader(strings.NewReader("")),
+			stdout: &stdout,
+			stderr: &stderr,
+		}
+
+		code := c.run(test.args)
+		if code != test.code {
+			t.Errorf("%s: expected exit code %d but actual %d (%s)", test.name, test.code, code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), test.stdout) {
+			t.Errorf("%s: expected stdout containing '%s' but actual '%s'", test.name, test.stdout, stdout.String())
+		}
+		if !strings.Contains(test.name, "with keys") && strings.Contains(stdout.String(), "private_key") {
+			t.Errorf("%s: expected no private keys but actual '%s'", test.name, stdout.String())
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+const (
+	_batchJSONL = "jsonl"
+	_batchCSV   = "csv"
+)
+
+type (
+	// batchEntry is an input row, rows with words are validated and the
+	// others are generated from the credentials
+	batchEntry struct {
+		Identifier string `json:"identifier"`
+		Password   string `json:"password"`
+		Passcode   string `json:"passcode"`
+		Size       int    `json:"size"`
+		Words      string `json:"words"`
+	}
+
+	// batchRow is the output of an input row, rows start from 1
+	batchRow struct {
+		Row        int    `json:"row" yaml:"row"`
+		Identifier string `json:"identifier,omitempty" yaml:"identifier,omitempty"`
+		Error      string `json:"error,omitempty" yaml:"error,omitempty"`
+		result     `yaml:",inline"`
+
+		code int
+	}
+)
+
+func (c *cli) batch(args []string) int {
+	fs, common := c.flags("batch")
+	input := fs.String("input", "", "jsonl or csv file of the rows, - for stdin")
+	format := fs.String("format", "", "input format: jsonl or csv, defaults to the file extension and jsonl for stdin")
+	workers := fs.Int("workers", 2, "rows processed concurrently, each generate uses 256 MiB")
+	m, sep, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+	if *input == "" {
+		fmt.Fprintln(c.stderr, "batch: --input is required")
+		return exitUsage
+	}
+	if *workers < 1 {
+		return c.fail(errors.New("workers must be at least 1"), exitUsage)
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*input), ".")
+		if *input == "-" {
+			*format = _batchJSONL
+		}
+	}
+
+	var r io.Reader = c.stdin
+	if *input != "-" {
+		f, err := os.Open(*input)
+		if err != nil {
+			return c.fail(err, exitError)
+		}
+		defer f.Close()
+		r = f
+	}
+	entries, err := readBatch(r, *format)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+
+	rows := make([]batchRow, len(entries))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < *workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				rows[i] = processBatch(m, entries[i], i+1, *common.language)
+			}
+		}()
+	}
+	for i := range entries {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	code = exitOK
+	for i := range rows {
+		if err := c.writeBatch(&rows[i], *common.output, sep); err != nil {
+			return c.fail(err, exitError)
+		}
+		if rows[i].code == exitError || code == exitOK {
+			code = rows[i].code
+		}
+	}
+	return code
+}
+
+// readBatch reads the rows of the jsonl lines or of the csv with a header
+// naming the columns
+func readBatch(r io.Reader, format string) ([]batchEntry, error) {
+	switch format {
+	case _batchJSONL:
+		var entries []batchEntry
+		dec := json.NewDecoder(r)
+		for {
+			var e batchEntry
+			if err := dec.Decode(&e); err == io.EOF {
+				return entries, nil
+			} else if err != nil {
+				return nil, fmt.Errorf("row %d: %w", len(entries)+1, err)
+			}
+			entries = append(entries, e)
+		}
+	case _batchCSV:
+		records, err := csv.NewReader(r).ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == 0 {
+			return nil, nil
+		}
+		entries := make([]batchEntry, 0, len(records)-1)
+		for i, record := range records[1:] {
+			e := batchEntry{}
+			for j, column := range records[0] {
+				switch strings.TrimSpace(column) {
+				case "identifier":
+					e.Identifier = record[j]
+				case "password":
+					e.Password = record[j]
+				case "passcode":
+					e.Passcode = record[j]
+				case "words":
+					e.Words = record[j]
+				case "size":
+					if record[j] == "" {
+						continue
+					}
+					if e.Size, err = strconv.Atoi(record[j]); err != nil {
+						return nil, fmt.Errorf("row %d: invalid size %s", i+1, record[j])
+					}
+				default:
+					return nil, fmt.Errorf("unknown column %s", column)
+				}
+			}
+			entries = append(entries, e)
+		}
+		return entries, nil
+	}
+	return nil, fmt.Errorf("unsupported input format %s", format)
+}
+
+// processBatch validates the words of the row or generates them from the
+// credentials
+func processBatch(m nomnemonic.Mnemonicer, e batchEntry, row int, language string) batchRow {
+	r := batchRow{Row: row, Identifier: e.Identifier, result: *newResult(language)}
+	if e.Words != "" {
+		r.Words = strings.Fields(e.Words)
+		ok, err := m.IsValid(r.Words)
+		r.Valid = &ok
+		if err != nil || !ok {
+			r.Error = invalid(err).Error()
+			r.code = exitInvalid
+		}
+		return r
+	}
+
+	if e.Size == 0 {
+		e.Size = 24
+	}
+	words, err := m.Generate(e.Identifier, e.Password, e.Passcode, e.Size)
+	if err != nil {
+		r.Error = err.Error()
+		r.code = exitError
+		return r
+	}
+	r.Words = words
+	return r
+}
+
+// writeBatch writes a json line per row, a yaml document per row or a text
+// line per row
+func (c *cli) writeBatch(r *batchRow, output, sep string) error {
+	switch output {
+	case _outputJSON:
+		return json.NewEncoder(c.stdout).Encode(r)
+	case _outputYAML:
+		fmt.Fprintln(c.stdout, "---")
+		enc := yaml.NewEncoder(c.stdout)
+		enc.SetIndent(2)
+		defer enc.Close()
+		return enc.Encode(r)
+	}
+
+	text := strings.Join(r.Words, sep)
+	if r.Valid != nil && r.Error == "" {
+		text = "valid"
+	}
+	if r.Error != "" {
+		text = "error: " + r.Error
+	}
+	_, err := fmt.Fprintf(c.stdout, "%d\t%s\n", r.Row, text)
+	return err
+}
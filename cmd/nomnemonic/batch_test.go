@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "accounts.csv")
+	csvData := "identifier,password,passcode,size,words\n" +
+		"nomnemonic_test,test12345678,101938,24,\n" +
+		",,,,cinnamon venue broken old brass vague paddle unaware critic alarm consider consider\n"
+	if err := os.WriteFile(csvPath, []byte(csvData), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			name: "jsonl from stdin",
+			args: []string{"batch", "--input", "-", "--format", "jsonl"},
+			stdin: `{"words":"cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"}` + "\n" +
+				`{"identifier":"x","password":"test12345678","passcode":"101938"}` + "\n",
+			code:   exitError,
+			stdout: "1\tvalid\n2\terror: identifier must be at least 2 chars\n",
+		},
+		{
+			name: "csv",
+			args: []string{"batch", "--input", csvPath},
+			code: exitInvalid,
+			stdout: "1\tdress mule bonus strong village clip volcano public plug fossil travel lobster nerve love gospel dance shove vicious valve else roof observe warrior magic\n" +
+				"2\terror: invalid checksum\n",
+		},
+		{
+			name:   "json output",
+			args:   []string{"batch", "--input", "-", "--output", "json"},
+			stdin:  `{"words":"cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"}`,
+			stdout: `{"row":1,"words":["cinnamon","venue","broken","old","brass","vague","paddle","unaware","critic","alarm","consider","hobby"],"valid":true,"algorithm":{"version":"0.3.0","algorithm_version":"3.0.0","language":"english"}}` + "\n",
+		},
+		{
+			name:   "unsupported format",
+			args:   []string{"batch", "--input", "-", "--format", "xml"},
+			code:   exitError,
+			stderr: "nomnemonic: unsupported input format xml\n",
+		},
+		{
+			name:   "invalid jsonl",
+			args:   []string{"batch", "--input", "-", "--format", "jsonl"},
+			stdin:  "{}\n{\n",
+			code:   exitError,
+			stderr: "nomnemonic: row 2: unexpected EOF\n",
+		},
+		{
+			name:   "without input",
+			args:   []string{"batch"},
+			code:   exitUsage,
+			stderr: "batch: --input is required\n",
+		},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		c := &cli{
+			stdin:  bufio.NewReader(strings.NewReader(test.stdin)),
+			stdout: &stdout,
+			stderr: &stderr,
+		}
+
+		code := c.run(test.args)
+		if code != test.code {
+			t.Errorf("%s: expected exit code %d but actual %d (%s)", test.name, test.code, code, stderr.String())
+		}
+		if test.stdout != "" && stdout.String() != test.stdout {
+			t.Errorf("%s: expected stdout '%s' but actual '%s'", test.name, test.stdout, stdout.String())
+		}
+		if test.stderr != "" && stderr.String() != test.stderr {
+			t.Errorf("%s: expected stderr '%s' but actual '%s'", test.name, test.stderr, stderr.String())
+		}
+	}
+}
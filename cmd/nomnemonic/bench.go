@@ -0,0 +1,136 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+type (
+	benchmark struct {
+		PBKDF2Iterations int            `json:"pbkdf2_iterations" yaml:"pbkdf2_iterations"`
+		PBKDF2Seconds    float64        `json:"pbkdf2_seconds" yaml:"pbkdf2_seconds"`
+		ScryptN          int            `json:"scrypt_n" yaml:"scrypt_n"`
+		ScryptR          int            `json:"scrypt_r" yaml:"scrypt_r"`
+		ScryptSeconds    float64        `json:"scrypt_seconds" yaml:"scrypt_seconds"`
+		GenerateSeconds  float64        `json:"generate_seconds" yaml:"generate_seconds"`
+		Profiles         []benchProfile `json:"profiles" yaml:"profiles"`
+		Cores            int            `json:"attack_cores" yaml:"attack_cores"`
+		CoreHourPrice    float64        `json:"core_hour_price" yaml:"core_hour_price"`
+		Attacks          []benchAttack  `json:"attacks" yaml:"attacks"`
+	}
+
+	benchProfile struct {
+		Name             string  `json:"name" yaml:"name"`
+		TargetSeconds    float64 `json:"target_seconds" yaml:"target_seconds"`
+		PBKDF2Iterations int     `json:"pbkdf2_iterations" yaml:"pbkdf2_iterations"`
+		ScryptN          int     `json:"scrypt_n" yaml:"scrypt_n"`
+		Seconds          float64 `json:"seconds" yaml:"seconds"`
+	}
+
+	benchAttack struct {
+		Secret string  `json:"secret" yaml:"secret"`
+		Bits   float64 `json:"bits" yaml:"bits"`
+		Years  float64 `json:"years" yaml:"years"`
+		Cost   float64 `json:"cost" yaml:"cost"`
+	}
+)
+
+// _attacks are the secrets the attacker guesses knowing the identifier, the
+// bits assume uniformly random chars
+var _attacks = []struct {
+	secret string
+	bits   float64
+}{
+	{"passcode only", math.Log2(1e6)},
+	{"12 lowercase password + passcode", 12*math.Log2(26) + math.Log2(1e6)},
+	{"12 alphanumeric password + passcode", 12*math.Log2(62) + math.Log2(1e6)},
+	{"16 printable password + passcode", 16*math.Log2(95) + math.Log2(1e6)},
+}
+
+func (c *cli) bench(args []string) int {
+	fs, common := c.flags("bench")
+	cores := fs.Int("cores", 1000, "attacker cores as fast as a host core")
+	price := fs.Float64("price", 0.02, "attacker cost of a core hour in USD")
+	if fs.Parse(args) != nil {
+		return exitUsage
+	}
+	if err := validateOutput(*common.output); err != nil {
+		return c.fail(err, exitUsage)
+	}
+	if *cores < 1 {
+		return c.fail(errors.New("cores must be at least 1"), exitUsage)
+	}
+
+	r := newResult(*common.language)
+	r.Benchmark = newBenchmark(nomnemonic.Calibrate(), *cores, *price)
+	return c.write(r, *common.output, r.Benchmark.text())
+}
+
+func newBenchmark(cal nomnemonic.Calibration, cores int, price float64) *benchmark {
+	b := &benchmark{
+		PBKDF2Iterations: cal.PBKDF2Iterations,
+		PBKDF2Seconds:    cal.PBKDF2Duration.Seconds(),
+		ScryptN:          cal.ScryptN,
+		ScryptR:          cal.ScryptR,
+		ScryptSeconds:    cal.ScryptDuration.Seconds(),
+		GenerateSeconds:  cal.Duration().Seconds(),
+		Cores:            cores,
+		CoreHourPrice:    price,
+	}
+	for _, p := range cal.Profiles {
+		b.Profiles = append(b.Profiles, benchProfile{
+			Name:             p.Name,
+			TargetSeconds:    p.Target.Seconds(),
+			PBKDF2Iterations: p.PBKDF2Iterations,
+			ScryptN:          p.ScryptN,
+			Seconds:          p.Duration.Seconds(),
+		})
+	}
+	for _, a := range _attacks {
+		years := cal.AttackYears(a.bits, cores)
+		b.Attacks = append(b.Attacks, benchAttack{
+			Secret: a.secret,
+			Bits:   math.Round(a.bits*10) / 10,
+			Years:  years,
+			Cost:   years * _hoursPerYear * float64(cores) * price,
+		})
+	}
+	return b
+}
+
+const _hoursPerYear = 365.25 * 24
+
+func (b *benchmark) text() string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "generate    %s (pbkdf2 %d iterations %s, scrypt n=%d r=%d %s)\n",
+		seconds(b.GenerateSeconds), b.PBKDF2Iterations, seconds(b.PBKDF2Seconds), b.ScryptN, b.ScryptR, seconds(b.ScryptSeconds))
+	fmt.Fprintln(&s, "\nprofiles")
+	for _, p := range b.Profiles {
+		fmt.Fprintf(&s, "  %-12s pbkdf2 %-9d scrypt n=%-8d ~%s\n", p.Name, p.PBKDF2Iterations, p.ScryptN, seconds(p.Seconds))
+	}
+	fmt.Fprintf(&s, "\nattack on %d cores at $%.2f per core hour\n", b.Cores, b.CoreHourPrice)
+	for _, a := range b.Attacks {
+		fmt.Fprintf(&s, "  %-36s %5.1f bits  %12s  $%.3g\n", a.Secret, a.Bits, years(a.Years), a.Cost)
+	}
+	return s.String()
+}
+
+func seconds(s float64) string {
+	return time.Duration(s * float64(time.Second)).Round(time.Millisecond).String()
+}
+
+// years formats the attack duration in the largest fitting unit
+func years(y float64) string {
+	switch {
+	case y >= 1:
+		return fmt.Sprintf("%.3g years", y)
+	case y*365.25 >= 1:
+		return fmt.Sprintf("%.3g days", y*365.25)
+	}
+	return fmt.Sprintf("%.3g hours", y*_hoursPerYear)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestNewBenchmark(t *testing.T) {
+	cal := nomnemonic.Calibration{
+		PBKDF2Iterations: 1 << 18,
+		PBKDF2Duration:   time.Second / 2,
+		ScryptN:          1 << 18,
+		ScryptR:          8,
+		ScryptDuration:   time.Second / 2,
+	}
+	b := newBenchmark(cal, 1, 1)
+
+	if b.GenerateSeconds != 1 {
+		t.Errorf("expected 1 generate second but actual %v", b.GenerateSeconds)
+	}
+	if len(b.Attacks) != len(_attacks) {
+		t.Fatalf("expected %d attacks but actual %d", len(_attacks), len(b.Attacks))
+	}
+	// 10^6 passcodes at a second each take 5*10^5 seconds on average
+	passcode := b.Attacks[0]
+	if expected := 5e5 / 3600; passcode.Cost < expected*0.999 || passcode.Cost > expected*1.001 {
+		t.Errorf("expected passcode attack cost %v but actual %v", expected, passcode.Cost)
+	}
+}
+
+func TestYears(t *testing.T) {
+	tests := []struct {
+		years    float64
+		expected string
+	}{
+		{years: 1.5e9, expected: "1.5e+09 years"},
+		{years: 2, expected: "2 years"},
+		{years: 7 / 365.25, expected: "7 days"},
+		{years: 3 / _hoursPerYear, expected: "3 hours"},
+	}
+
+	for _, test := range tests {
+		if actual := years(test.years); actual != test.expected {
+			t.Errorf("expected %s but actual %s", test.expected, actual)
+		}
+	}
+}
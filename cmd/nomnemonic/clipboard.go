@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// clipboard reads and writes the system clipboard
+type clipboard interface {
+	Write(text string) error
+	Read() (string, error)
+}
+
+// systemClipboard runs the clipboard tool of the platform, pbcopy on macOS,
+// clip and powershell on Windows, wl-copy on Wayland and xclip or xsel on X11
+type systemClipboard struct{}
+
+type clipboardTool struct {
+	write []string
+	read  []string
+}
+
+func (systemClipboard) tool() (clipboardTool, error) {
+	var tools []clipboardTool
+	switch runtime.GOOS {
+	case "darwin":
+		tools = []clipboardTool{{write: []string{"pbcopy"}, read: []string{"pbpaste"}}}
+	case "windows":
+		tools = []clipboardTool{{write: []string{"clip"}, read: []string{"powershell", "-NoProfile", "-Command", "Get-Clipboard"}}}
+	default:
+		tools = []clipboardTool{
+			{write: []string{"wl-copy"}, read: []string{"wl-paste", "--no-newline"}},
+			{write: []string{"xclip", "-selection", "clipboard"}, read: []string{"xclip", "-selection", "clipboard", "-o"}},
+			{write: []string{"xsel", "--clipboard", "--input"}, read: []string{"xsel", "--clipboard", "--output"}},
+		}
+	}
+	for _, t := range tools {
+		if _, err := exec.LookPath(t.write[0]); err == nil {
+			return t, nil
+		}
+	}
+	return clipboardTool{}, errors.New("no clipboard tool found, install wl-clipboard, xclip or xsel")
+}
+
+func (s systemClipboard) Write(text string) error {
+	t, err := s.tool()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(t.write[0], t.write[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+func (s systemClipboard) Read() (string, error) {
+	t, err := s.tool()
+	if err != nil {
+		return "", err
+	}
+	out, err := exec.Command(t.read[0], t.read[1:]...).Output()
+	return strings.TrimRight(string(out), "\r\n"), err
+}
+
+type copyFlags struct {
+	enabled *bool
+	timeout *time.Duration
+}
+
+func newCopyFlags(fs *flag.FlagSet) *copyFlags {
+	return &copyFlags{
+		enabled: fs.Bool("copy", false, "copy to the clipboard instead of printing"),
+		timeout: fs.Duration("copy-timeout", 30*time.Second, "clear the clipboard after the timeout"),
+	}
+}
+
+// copy writes the text to the clipboard and clears it after the timeout,
+// unless something else has been copied since
+func (c *cli) copy(text string, timeout time.Duration) int {
+	if err := c.clipboard.Write(text); err != nil {
+		return c.fail(err, exitError)
+	}
+	fmt.Fprintf(c.stderr, "copied to the clipboard, clearing in %s\n", timeout)
+	c.sleep(timeout)
+
+	current, err := c.clipboard.Read()
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	if current != text {
+		return exitOK
+	}
+	if err := c.clipboard.Write(""); err != nil {
+		return c.fail(err, exitError)
+	}
+	fmt.Fprintln(c.stderr, "clipboard cleared")
+	return exitOK
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeClipboard struct {
+	text   string
+	writes []string
+}
+
+func (f *fakeClipboard) Write(text string) error {
+	f.text = text
+	f.writes = append(f.writes, text)
+	return nil
+}
+
+func (f *fakeClipboard) Read() (string, error) {
+	return f.text, nil
+}
+
+func TestCopy(t *testing.T) {
+	const words = "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	tests := []struct {
+		name     string
+		args     []string
+		replaced bool
+		cleared  bool
+		slept    time.Duration
+	}{
+		{
+			name:    "seed",
+			args:    []string{"seed", "--copy", "--copy-timeout", "5s", words},
+			cleared: true,
+			slept:   5 * time.Second,
+		},
+		{
+			name:     "copied over before the timeout",
+			args:     []string{"seed", "--copy", words},
+			replaced: true,
+			slept:    30 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		clip := &fakeClipboard{}
+		var slept time.Duration
+		c := &cli{
+			stdin:     bufio.NewReader(strings.NewReader("")),
+			stdout:    &stdout,
+			stderr:    &stderr,
+			clipboard: clip,
+			sleep: func(d time.Duration) {
+				slept = d
+				if test.replaced {
+					clip.text = "something else"
+				}
+			},
+		}
+
+		if code := c.run(test.args); code != exitOK {
+			t.Fatalf("%s: expected exit code %d but actual %d (%s)", test.name, exitOK, code, stderr.String())
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("%s: expected nothing on stdout but actual '%s'", test.name, stdout.String())
+		}
+		if slept != test.slept {
+			t.Errorf("%s: expected to sleep %s but actual %s", test.name, test.slept, slept)
+		}
+		if len(clip.writes[0]) != 128 {
+			t.Errorf("%s: expected the hex seed copied but actual '%s'", test.name, clip.writes[0])
+		}
+		cleared := len(clip.writes) == 2 && clip.writes[1] == ""
+		if cleared != test.cleared {
+			t.Errorf("%s: expected cleared %v but actual %v", test.name, test.cleared, cleared)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic"
+	"github.com/nomnemonic/nomnemonic/pkcs11"
+	"github.com/nomnemonic/nomnemonic/yubikey"
+)
+
+type commonFlags struct {
+	language *string
+	messages *string
+	features *string
+	purpose  *string
+	policy   *string
+	pepper   *string
+	yubikey  *int
+	hsm      *string
+	hsmKey   *string
+	keychain *bool
+	output   *string
+
+	// loaded is the policy of the policy flag once parsed
+	loaded nomnemonic.Policy
+	// hints are the identifier hints of the settings restored by the
+	// keychain flag
+	hints []string
+}
+
+func (c *cli) flags(name string) (*flag.FlagSet, *commonFlags) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	return fs, &commonFlags{
+		language: fs.String("language", "english", "word list language"),
+		messages: fs.String("messages", "english", "language of the validation messages"),
+		features: fs.String("features", "", "comma separated opt-in features: constant-time, unicode-normalization"),
+		purpose:  fs.String("purpose", "", "purpose mixed into the derivation, like savings, for unrelated phrases of the same credentials"),
+		policy:   fs.String("policy", "", "json organization policy file enforced on the generation"),
+		pepper:   fs.String("pepper-file", "", "file of the organization pepper mixed into the derivation"),
+		yubikey:  fs.Int("yubikey-slot", 0, "yubikey hmac-sha1 challenge-response slot, 1 or 2, mixed into the pepper"),
+		hsm:      fs.String("pkcs11-module", "", "pkcs#11 module of the hsm computing a hmac mixed into the pepper"),
+		hsmKey:   fs.String("pkcs11-key", "", "label of the hmac key of the pkcs#11 module"),
+		keychain: fs.Bool("keychain", false, "restore the settings of config save for the flags not given"),
+		output:   outputFlag(fs),
+	}
+}
+
+// parse parses the args and returns the mnemonicer of the language flag, the
+// extra options follow the ones of the common flags
+func (c *cli) parse(fs *flag.FlagSet, common *commonFlags, args []string, extra ...nomnemonic.Option) (nomnemonic.Mnemonicer, string, int) {
+	if fs.Parse(args) != nil {
+		return nil, "", exitUsage
+	}
+	if *common.keychain {
+		if err := c.restoreSettings(fs, common); err != nil {
+			return nil, "", c.fail(err, exitError)
+		}
+	}
+	if err := validateOutput(*common.output); err != nil {
+		return nil, "", c.fail(err, exitUsage)
+	}
+	if !supportedLocale(*common.messages) {
+		return nil, "", c.fail(fmt.Errorf("unsupported messages language %s", *common.messages), exitUsage)
+	}
+	var features []nomnemonic.Feature
+	for _, f := range splitList(*common.features) {
+		features = append(features, nomnemonic.Feature(f))
+	}
+	options := []nomnemonic.Option{
+		nomnemonic.WithLocale(*common.messages),
+		nomnemonic.WithFeatures(features...),
+		nomnemonic.WithPurpose(*common.purpose),
+	}
+	if *common.policy != "" {
+		f, err := os.Open(*common.policy)
+		if err != nil {
+			return nil, "", c.fail(err, exitError)
+		}
+		defer f.Close()
+		if common.loaded, err = nomnemonic.LoadPolicy(f); err != nil {
+			return nil, "", c.fail(err, exitUsage)
+		}
+		options = append(options, nomnemonic.WithPolicy(common.loaded))
+	}
+	if *common.pepper != "" {
+		pepper, err := os.ReadFile(*common.pepper)
+		if err != nil {
+			return nil, "", c.fail(err, exitError)
+		}
+		options = append(options, nomnemonic.WithPepper(bytes.TrimRight(pepper, "\r\n")))
+	}
+	if *common.yubikey != 0 {
+		factor, err := yubikey.New(*common.yubikey)
+		if err != nil {
+			return nil, "", c.fail(err, exitUsage)
+		}
+		options = append(options, nomnemonic.WithFactor(factor))
+	}
+	if *common.hsm != "" || *common.hsmKey != "" {
+		factor, err := pkcs11.New(*common.hsm, *common.hsmKey)
+		if err != nil {
+			return nil, "", c.fail(err, exitUsage)
+		}
+		options = append(options, nomnemonic.WithFactor(factor))
+	}
+	m, sep, err := mnemonicer(*common.language, append(options, extra...)...)
+	if err != nil {
+		return nil, "", c.fail(err, exitUsage)
+	}
+	return m, sep, exitOK
+}
+
+// expanded returns the valid words with their abbreviations replaced by the
+// full words the seed and the outputs are defined on
+func expanded(m nomnemonic.Mnemonicer, words []string) []string {
+	full, err := m.ExpandWords(words)
+	if err != nil {
+		return words
+	}
+	return full
+}
+
+func supportedLocale(language string) bool {
+	for _, l := range nomnemonic.Locales() {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *cli) generate(args []string) int {
+	fs, common := c.flags("generate")
+	identifier := fs.String("identifier", "", "identifier, at least 2 chars")
+	size := fs.Int("size", 24, "number of words: 12, 15, 18, 21 or 24")
+	passwordFlag := newSecretFlag(fs, "password")
+	passcodeFlag := newPasscodeFlag(fs)
+	qr := seedQRFlag(fs)
+	clip := newCopyFlags(fs)
+	m, sep, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+	if *identifier == "" {
+		fmt.Fprintln(c.stderr, "generate: --identifier is required")
+		if len(common.hints) > 0 {
+			fmt.Fprintf(c.stderr, "identifier hints: %s\n", strings.Join(common.hints, ", "))
+		}
+		return exitUsage
+	}
+
+	password, err := passwordFlag.read(c)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	passcode, err := passcodeFlag.read(c)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+
+	detailed, err := m.GenerateDetailed(*identifier, password, passcode, *size)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	words := detailed.Words
+
+	if *clip.enabled {
+		return c.copy(strings.Join(words, sep), *clip.timeout)
+	}
+
+	r := newResult(*common.language)
+	r.Words = words
+	r.Algorithm.KDFHash, r.Algorithm.WordlistChecksum = detailed.KDFHash, detailed.WordlistChecksum
+	r.Algorithm.Features, r.Algorithm.Purpose = detailed.Features, detailed.Purpose
+	if *qr != "" {
+		if r.SeedQR, err = seedQR(m, words, *qr); err != nil {
+			return c.fail(err, exitUsage)
+		}
+		return c.write(r, *common.output, r.SeedQR)
+	}
+	if *common.output != _outputText {
+		r.Entropy = hex.EncodeToString(detailed.Entropy)
+		seed, _ := m.GenerateSeed(strings.Join(words, " "), "")
+		if err := r.setSeed(seed); err != nil {
+			return c.fail(err, exitError)
+		}
+	}
+	return c.write(r, *common.output, strings.Join(words, sep))
+}
+
+func (c *cli) validate(args []string) int {
+	fs, common := c.flags("validate")
+	m, _, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+	words, err := c.words(fs.Args())
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+
+	ok, err := m.IsValid(words)
+	r := newResult(*common.language)
+	r.Words = words
+	r.Valid = &ok
+	if *common.output != _outputText {
+		if code := c.write(r, *common.output, ""); code != exitOK {
+			return code
+		}
+	}
+	if err != nil || !ok {
+		return c.fail(invalid(err), exitInvalid)
+	}
+	if *common.output != _outputText {
+		return exitOK
+	}
+	return c.write(r, *common.output, "valid")
+}
+
+func (c *cli) entropy(args []string) int {
+	fs, common := c.flags("entropy")
+	qr := seedQRFlag(fs)
+	m, _, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+	words, err := c.words(fs.Args())
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+
+	entropy, err := m.CalculateEntropy(words)
+	if err != nil {
+		return c.fail(err, exitInvalid)
+	}
+	r := newResult(*common.language)
+	r.Words = words
+	r.Entropy = hex.EncodeToString(entropy)
+	if *qr != "" {
+		if r.SeedQR, err = seedQR(m, words, *qr); err != nil {
+			return c.fail(err, exitUsage)
+		}
+		return c.write(r, *common.output, r.SeedQR)
+	}
+	return c.write(r, *common.output, r.Entropy)
+}
+
+func (c *cli) seed(args []string) int {
+	fs, common := c.flags("seed")
+	prompt := fs.Bool("passphrase", false, "prompt for the bip39 passphrase")
+	passphraseFlag := newSecretFlag(fs, "passphrase")
+	clip := newCopyFlags(fs)
+	m, _, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+	words, err := c.words(fs.Args())
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	entropy, err := m.CalculateEntropy(words)
+	if err != nil {
+		return c.fail(err, exitInvalid)
+	}
+	words = expanded(m, words)
+
+	passphrase, err := c.passphrase(*prompt, passphraseFlag)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	seed, err := m.GenerateSeed(strings.Join(words, " "), passphrase)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	if *clip.enabled {
+		return c.copy(hex.EncodeToString(seed), *clip.timeout)
+	}
+
+	r := newResult(*common.language)
+	r.Words = words
+	r.Entropy = hex.EncodeToString(entropy)
+	if err := r.setSeed(seed); err != nil {
+		return c.fail(err, exitError)
+	}
+	return c.write(r, *common.output, r.Seed)
+}
+
+func (c *cli) lastword(args []string) int {
+	fs, common := c.flags("lastword")
+	m, _, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+	words, err := c.words(fs.Args())
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+
+	candidates, err := m.LastWords(words)
+	if err != nil {
+		return c.fail(err, exitInvalid)
+	}
+	r := newResult(*common.language)
+	r.Words = words
+	r.LastWords = candidates
+	return c.write(r, *common.output, strings.Join(candidates, "\n"))
+}
+
+// passphrase reads the optional bip39 passphrase when it is prompted for or
+// given with a file or an environment variable
+func (c *cli) passphrase(prompt bool, s *secretFlag) (string, error) {
+	if !prompt && *s.file == "" && *s.env == "" {
+		return "", nil
+	}
+	return s.read(c)
+}
+
+func seedQRFlag(fs *flag.FlagSet) *string {
+	return fs.String("seedqr", "", "print the standard (digits) or compact (hex) SeedQR payload")
+}
+
+// seedQR returns the SeedQR digits or the CompactSeedQR bytes in hex
+func seedQR(m nomnemonic.Mnemonicer, words []string, kind string) (string, error) {
+	switch kind {
+	case "standard":
+		return m.EncodeSeedQR(words)
+	case "compact":
+		data, err := m.EncodeCompactSeedQR(words)
+		return hex.EncodeToString(data), err
+	}
+	return "", fmt.Errorf("unsupported seedqr %s", kind)
+}
+
+func (c *cli) write(r *result, output, text string) int {
+	if err := r.write(c.stdout, output, text); err != nil {
+		return c.fail(err, exitError)
+	}
+	return exitOK
+}
+
+// words returns the words of the args, or of a stdin line without args so
+// the words stay out of the shell history
+func (c *cli) words(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return strings.Fields(strings.Join(args, " ")), nil
+	}
+	line, err := readLine(c.stdin)
+	if err != nil {
+		return nil, errors.New("no words given")
+	}
+	return strings.Fields(line), nil
+}
+
+func invalid(err error) error {
+	if err != nil {
+		return err
+	}
+	return nomnemonic.ErrInvalidChecksum
+}
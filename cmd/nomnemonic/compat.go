@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func (c *cli) compat(args []string) int {
+	fs, common := c.flags("compat")
+	if fs.Parse(args) != nil {
+		return exitUsage
+	}
+	if err := validateOutput(*common.output); err != nil {
+		return c.fail(err, exitUsage)
+	}
+
+	r := newResult(*common.language)
+	matrix := nomnemonic.Compatibility()
+	r.Compatibility = &matrix
+	return c.write(r, *common.output, compatText(matrix))
+}
+
+func compatText(c nomnemonic.CompatibilityMatrix) string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "version     %s\n", c.Version)
+	fmt.Fprintln(&s, "\nalgorithms")
+	for _, a := range c.Algorithms {
+		sizes := make([]string, len(a.Sizes))
+		for i, size := range a.Sizes {
+			sizes[i] = fmt.Sprint(size)
+		}
+		fmt.Fprintf(&s, "  %-10s libraries %s, %s words, %s\n", a.Version, a.Libraries, strings.Join(sizes, "/"), a.KDF)
+	}
+	fmt.Fprintf(&s, "\nexport versions %s, kdf profiles\n", strings.Trim(fmt.Sprint(c.ExportVersions), "[]"))
+	for _, p := range c.KDFProfiles {
+		fmt.Fprintf(&s, "  %-12s argon2id t=%d m=%dKiB p=%d\n", p.Name, p.Time, p.Memory, p.Threads)
+	}
+	fmt.Fprintln(&s, "\nword lists")
+	for _, w := range c.Wordlists {
+		fmt.Fprintf(&s, "  %-20s %s\n", w.Language, w.Checksum)
+	}
+	return s.String()
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestCompatText(t *testing.T) {
+	text := compatText(nomnemonic.Compatibility())
+	for _, expected := range []string{
+		"version     " + nomnemonic.Version + "\n",
+		"  3.0.0      libraries >=0.3.0, 12/15/18/21/24 words, pbkdf2-sha512 262144 iterations xor scrypt n=262144 r=8 p=1\n",
+		"  sensitive    argon2id t=4 m=1048576KiB p=4\n",
+		"  english              187db04a869dd9bc7be80d21a86497d692c0db6abd3aa8cb6be5d618ff757fae\n",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected '%s' in\n%s", expected, text)
+		}
+	}
+}
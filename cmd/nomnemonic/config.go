@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic"
+	"github.com/nomnemonic/nomnemonic/keychain"
+)
+
+// settingsStore keeps the settings restored with --keychain
+type settingsStore interface {
+	Save(c keychain.Config) error
+	Load() (keychain.Config, error)
+	Delete() error
+}
+
+func (c *cli) config(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(c.stderr, "usage: nomnemonic config {save,show,delete} [flags]")
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet("config "+args[0], flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	language := fs.String("language", "english", "word list language")
+	profile := fs.String("profile", "moderate", "argon2id cost of the exports: interactive, moderate or sensitive")
+	features := fs.String("features", "", "comma separated opt-in features: constant-time, unicode-normalization")
+	purpose := fs.String("purpose", "", "purpose mixed into the derivation")
+	hints := fs.String("hints", "", "comma separated identifier hints, keep them vague")
+	output := outputFlag(fs)
+	if fs.Parse(args[1:]) != nil {
+		return exitUsage
+	}
+	if err := validateOutput(*output); err != nil {
+		return c.fail(err, exitUsage)
+	}
+
+	switch args[0] {
+	case "save":
+		if _, ok := _wordlists[*language]; !ok {
+			return c.fail(fmt.Errorf("unsupported language %s", *language), exitUsage)
+		}
+		if _, ok := _kdfProfiles[*profile]; !ok {
+			return c.fail(fmt.Errorf("unsupported profile %s", *profile), exitUsage)
+		}
+		settings := keychain.Config{
+			Language:         *language,
+			Profile:          *profile,
+			AlgorithmVersion: nomnemonic.VersionAlgorithm,
+			Features:         splitList(*features),
+			Purpose:          *purpose,
+			IdentifierHints:  splitList(*hints),
+		}
+		if err := c.settings.Save(settings); err != nil {
+			return c.fail(err, exitError)
+		}
+		fmt.Fprintln(c.stdout, "saved")
+		return exitOK
+	case "show":
+		settings, err := c.settings.Load()
+		if err != nil {
+			return c.fail(err, exitError)
+		}
+		r := newResult(settings.Language)
+		r.Settings = &settings
+		return c.write(r, *output, settingsText(settings))
+	case "delete":
+		if err := c.settings.Delete(); err != nil {
+			return c.fail(err, exitError)
+		}
+		fmt.Fprintln(c.stdout, "deleted")
+		return exitOK
+	}
+	fmt.Fprintf(c.stderr, "unknown config command %s\n", args[0])
+	return exitUsage
+}
+
+// restoreSettings sets the flags not given on the command line to the stored
+// settings, the phrases differ when the settings need another algorithm
+// version
+func (c *cli) restoreSettings(fs *flag.FlagSet, common *commonFlags) error {
+	settings, err := c.settings.Load()
+	if errors.Is(err, keychain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if settings.AlgorithmVersion != "" {
+		if err := nomnemonic.Compatibility().Check(settings.AlgorithmVersion, ""); err != nil {
+			return fmt.Errorf("stored settings: %w", err)
+		}
+	}
+
+	given := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	restore := map[string]string{
+		"language": settings.Language,
+		"features": strings.Join(settings.Features, ","),
+		"purpose":  settings.Purpose,
+		"profile":  settings.Profile,
+	}
+	for name, value := range restore {
+		if given[name] || value == "" || fs.Lookup(name) == nil {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return err
+		}
+	}
+	common.hints = settings.IdentifierHints
+	return nil
+}
+
+func settingsText(s keychain.Config) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "language           %s\n", s.Language)
+	fmt.Fprintf(&b, "profile            %s\n", s.Profile)
+	fmt.Fprintf(&b, "algorithm version  %s\n", s.AlgorithmVersion)
+	fmt.Fprintf(&b, "features           %s\n", strings.Join(s.Features, ", "))
+	fmt.Fprintf(&b, "purpose            %s\n", s.Purpose)
+	fmt.Fprintf(&b, "identifier hints   %s", strings.Join(s.IdentifierHints, ", "))
+	return b.String()
+}
+
+// splitList splits a comma separated flag, skipping the blank items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
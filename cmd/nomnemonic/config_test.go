@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic/keychain"
+)
+
+const _spanishWords = "familia papel familia papel familia papel familia papel familia papel familia parir"
+
+// memorySettings keeps the settings in memory
+type memorySettings struct {
+	settings *keychain.Config
+}
+
+func (m *memorySettings) Save(c keychain.Config) error {
+	m.settings = &c
+	return nil
+}
+
+func (m *memorySettings) Load() (keychain.Config, error) {
+	if m.settings == nil {
+		return keychain.Config{}, keychain.ErrNotFound
+	}
+	return *m.settings, nil
+}
+
+func (m *memorySettings) Delete() error {
+	m.settings = nil
+	return nil
+}
+
+func TestConfig(t *testing.T) {
+	store := &memorySettings{}
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{name: "show nothing", args: []string{"config", "show"}, code: exitError, stderr: "nomnemonic: keychain: no settings stored\n"},
+		{name: "save unsupported language", args: []string{"config", "save", "--language", "klingon"}, code: exitUsage, stderr: "nomnemonic: unsupported language klingon\n"},
+		{name: "save", args: []string{"config", "save", "--language", "spanish", "--profile", "sensitive", "--hints", "work email, laptop"}, stdout: "saved\n"},
+		{
+			name:   "show",
+			args:   []string{"config", "show"},
+			stdout: "language           spanish\nprofile            sensitive\nalgorithm version  3.0.0\nfeatures           \npurpose            \nidentifier hints   work email, laptop\n",
+		},
+		{name: "hints", args: []string{"generate", "--keychain"}, code: exitUsage, stderr: "generate: --identifier is required\nidentifier hints: work email, laptop\n"},
+		{name: "restored language", args: []string{"entropy", "--keychain", _spanishWords}, stdout: "55555555555555555555555555555555\n"},
+		{name: "explicit language", args: []string{"entropy", "--keychain", "--language", "english", _spanishWords}, code: exitInvalid, stderr: "nomnemonic: unrecognized word familia\n"},
+		{name: "delete", args: []string{"config", "delete"}, stdout: "deleted\n"},
+		{name: "restore nothing", args: []string{"validate", "--keychain", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "about"}, stdout: "valid\n"},
+		{name: "unknown command", args: []string{"config", "reset"}, code: exitUsage, stderr: "unknown config command reset\n"},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		c := &cli{stdin: bufio.NewReader(strings.NewReader("")), stdout: &stdout, stderr: &stderr, settings: store}
+		code := c.run(test.args)
+		if code != test.code || stdout.String() != test.stdout || stderr.String() != test.stderr {
+			t.Errorf("%s: expected %d '%s' '%s' but actual %d '%s' '%s'", test.name, test.code, test.stdout, test.stderr, code, stdout.String(), stderr.String())
+		}
+	}
+
+	store.settings = &keychain.Config{AlgorithmVersion: "1.0.0"}
+	var stderr bytes.Buffer
+	c := &cli{stdin: bufio.NewReader(strings.NewReader("")), stdout: &bytes.Buffer{}, stderr: &stderr, settings: store}
+	if code := c.run([]string{"validate", "--keychain", "abandon"}); code != exitError || !strings.Contains(stderr.String(), "stored settings: algorithm version 1.0.0") {
+		t.Errorf("expected an unsupported algorithm version error but actual %d %s", code, stderr.String())
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nomnemonic/nomnemonic"
+	"github.com/nomnemonic/nomnemonic/httpapi"
+)
+
+const _daemonSocket = "nomnemonic.sock"
+
+type peerKey struct{}
+
+// daemon serves the httpapi handlers and their metrics on a unix socket until
+// it is interrupted, every request is checked against the uid of the peer
+// process
+func (c *cli) daemon(args []string) int {
+	fs, common := c.flags("daemon")
+	socket := fs.String("socket", defaultSocket(), "unix socket path")
+	allow := fs.String("allow-uid", "", "comma separated uids allowed besides the daemon user")
+	metrics := httpapi.NewMetrics()
+	m, _, code := c.parse(fs, common, args, nomnemonic.WithObserver(metrics))
+	if m == nil {
+		return code
+	}
+	allowed, err := parseUIDs(*allow)
+	if err != nil {
+		return c.fail(err, exitUsage)
+	}
+	// the peer credentials check the uids, the other users need write access
+	// to the socket to reach it
+	mode := os.FileMode(0o600)
+	if len(allowed) > 0 {
+		mode = 0o666
+	}
+	allowed[os.Getuid()] = true
+
+	ln, err := listenSocket(*socket, mode)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	defer os.Remove(*socket)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	fmt.Fprintf(c.stderr, "listening on %s\n", *socket)
+	mux := http.NewServeMux()
+	mux.Handle("/", metrics.Instrument(httpapi.HandlerWithLimits(m, httpapi.Limits{Client: peerClient})))
+	mux.Handle("/metrics", metrics)
+	if err := serveDaemon(ctx, ln, mux, allowed); err != nil {
+		return c.fail(err, exitError)
+	}
+	return exitOK
+}
+
+// serveDaemon serves h on ln until ctx is done, requests of peers with a uid
+// missing from allowed are refused
+func serveDaemon(ctx context.Context, ln net.Listener, h http.Handler, allowed map[int]bool) error {
+	srv := &http.Server{
+		Handler:           peerAuth(h, allowed),
+		ReadHeaderTimeout: 10 * time.Second,
+		// the server logs connection errors only, never the bodies
+		ErrorLog: log.New(io.Discard, "", 0),
+		ConnContext: func(ctx context.Context, conn net.Conn) context.Context {
+			uc, ok := conn.(*net.UnixConn)
+			if !ok {
+				return ctx
+			}
+			if uid, err := peerUID(uc); err == nil {
+				return context.WithValue(ctx, peerKey{}, uid)
+			}
+			return ctx
+		},
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- srv.Serve(ln) }()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdown); err != nil {
+		return err
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// peerAuth refuses the requests of connections without peer credentials or
+// with a uid missing from allowed
+func peerAuth(h http.Handler, allowed map[int]bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uid, ok := r.Context().Value(peerKey{}).(int)
+		if !ok || !allowed[uid] {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintln(w, `{"error":"peer is not allowed"}`)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// peerClient limits the requests by peer uid, the remote address of a unix
+// socket is empty
+func peerClient(r *http.Request) string {
+	uid, _ := r.Context().Value(peerKey{}).(int)
+	return strconv.Itoa(uid)
+}
+
+// listenSocket listens on the unix socket path, replacing a stale socket. The
+// socket is bound in a private directory next to the path, given the mode and
+// only then renamed to the path, so it is never reachable with the mode of
+// the umask
+func listenSocket(path string, mode os.FileMode) (net.Listener, error) {
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%s is already served", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".nomnemonic-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	private := filepath.Join(dir, "s")
+	ln, err := net.Listen("unix", private)
+	if err != nil {
+		return nil, err
+	}
+	// the socket is removed by the daemon at its path
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := os.Chmod(private, mode); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	if err := os.Rename(private, path); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+// defaultSocket returns the socket path in the user runtime dir, the temp dir
+// when there is none
+func defaultSocket() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, _daemonSocket)
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("nomnemonic-%d.sock", os.Getuid()))
+}
+
+func parseUIDs(s string) (map[int]bool, error) {
+	uids := map[int]bool{}
+	if s == "" {
+		return uids, nil
+	}
+	for _, field := range strings.Split(s, ",") {
+		uid, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || uid < 0 {
+			return nil, fmt.Errorf("invalid uid %s", field)
+		}
+		uids[uid] = true
+	}
+	return uids, nil
+}
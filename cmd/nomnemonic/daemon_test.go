@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic/httpapi"
+)
+
+func TestDaemon(t *testing.T) {
+	m, _, err := mnemonicer("english")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		allowed map[int]bool
+		status  int
+		body    string
+	}{
+		{name: "own uid", allowed: map[int]bool{os.Getuid(): true}, status: http.StatusOK, body: `{"valid":true}`},
+		{name: "other uid", allowed: map[int]bool{os.Getuid() + 1: true}, status: http.StatusForbidden, body: `{"error":"peer is not allowed"}`},
+	}
+
+	for _, test := range tests {
+		socket := filepath.Join(t.TempDir(), "nomnemonic.sock")
+		ln, err := listenSocket(socket, 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- serveDaemon(ctx, ln, httpapi.Handler(m), test.allowed) }()
+
+		client := &http.Client{Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		}}
+		words := `"` + strings.Join(strings.Fields(_abandonAbout), `","`) + `"`
+		resp, err := client.Post("http://nomnemonic/validate", "application/json", strings.NewReader(`{"words":[`+words+`]}`))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", test.name, err.Error())
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		client.CloseIdleConnections()
+
+		if resp.StatusCode != test.status {
+			t.Errorf("%s: expected status %d but actual %d", test.name, test.status, resp.StatusCode)
+		}
+		if strings.TrimSpace(string(body)) != test.body {
+			t.Errorf("%s: expected body '%s' but actual '%s'", test.name, test.body, body)
+		}
+
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+		}
+	}
+}
+
+func TestListenSocket(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := listenSocket(file, 0o600); err == nil || err.Error() != file+" exists and is not a socket" {
+		t.Errorf("expected not a socket error but actual %v", err)
+	}
+
+	for _, mode := range []os.FileMode{0o600, 0o666} {
+		socket := filepath.Join(dir, "nomnemonic.sock")
+		ln, err := listenSocket(socket, mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fi, err := os.Stat(socket)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Mode().Perm() != mode {
+			t.Errorf("expected mode %o but actual %o", mode, fi.Mode().Perm())
+		}
+		if _, err := listenSocket(socket, mode); err == nil || err.Error() != socket+" is already served" {
+			t.Errorf("expected already served error but actual %v", err)
+		}
+		ln.Close()
+		if err := os.Remove(socket); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected the private directories to be removed but actual %v", entries)
+	}
+}
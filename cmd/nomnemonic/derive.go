@@ -0,0 +1,293 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic"
+	"github.com/nomnemonic/nomnemonic/bip32"
+	"github.com/nomnemonic/nomnemonic/evm"
+	"github.com/nomnemonic/nomnemonic/utxo"
+)
+
+const _accountDepth = 3
+
+// _chainAliases maps the ticker symbols to the chain names of the utxo
+// registry and the evm package
+var _chainAliases = map[string]string{
+	"btc":  utxo.Bitcoin.Name,
+	"tbtc": utxo.BitcoinTestnet.Name,
+	"ltc":  utxo.Litecoin.Name,
+	"doge": utxo.Dogecoin.Name,
+	"eth":  evm.Ethereum.Name,
+	"bnb":  evm.BSC.Name,
+	"pol":  evm.Polygon.Name,
+	"avax": evm.AvalancheC.Name,
+	"trx":  evm.Tron.Name,
+}
+
+// _descriptors maps the bip44, bip49 and bip84 purposes to the address type
+// and the output descriptor format
+var _descriptors = map[uint32]struct {
+	addressType utxo.AddressType
+	format      string
+}{
+	44: {utxo.AddressP2PKH, "pkh(%s)"},
+	49: {utxo.AddressP2SHP2WPKH, "sh(wpkh(%s))"},
+	84: {utxo.AddressP2WPKH, "wpkh(%s)"},
+}
+
+// chain derives the public outputs of a utxo network or an evm chain
+type chain struct {
+	name    string
+	network *utxo.Network
+	evm     *evm.Chain
+}
+
+func lookupChain(name string) (*chain, error) {
+	if alias, ok := _chainAliases[strings.ToLower(name)]; ok {
+		name = alias
+	}
+	if n, err := utxo.Lookup(name); err == nil {
+		return &chain{name: n.Name, network: &n}, nil
+	}
+	if e, err := evm.ChainByName(name); err == nil {
+		return &chain{name: e.Name, evm: &e}, nil
+	}
+	return nil, fmt.Errorf("unsupported chain %s", name)
+}
+
+// defaultPath returns the path of the first receive address, native segwit
+// for the networks supporting it
+func (ch *chain) defaultPath() string {
+	if ch.evm != nil {
+		return ch.evm.Path(0)
+	}
+	t := utxo.AddressP2PKH
+	if ch.network.Bech32HRP != "" {
+		t = utxo.AddressP2WPKH
+	}
+	path, _ := ch.network.Path(t, 0)
+	return path
+}
+
+// xpubVersion returns the extended public key version, evm chains use the
+// bitcoin mainnet version like the hardware wallets
+func (ch *chain) xpubVersion() uint32 {
+	if ch.evm != nil {
+		return utxo.Bitcoin.XPubVersion
+	}
+	return ch.network.XPubVersion
+}
+
+// address encodes the address of the key, the purpose picks the utxo
+// address type
+func (ch *chain) address(k *bip32.Key, purpose uint32) (string, error) {
+	if ch.evm != nil {
+		return ch.evm.EncodeAddress(evm.PublicKeyHash(k.UncompressedPublicKey())), nil
+	}
+	d, ok := _descriptors[purpose]
+	if !ok {
+		return "", fmt.Errorf("unsupported purpose %d for %s", purpose, ch.name)
+	}
+	return ch.network.EncodeAddress(k.PublicKey(), d.addressType)
+}
+
+// privateKey encodes the private key like the wallets of the chain import it
+func (ch *chain) privateKey(k *bip32.Key) string {
+	switch {
+	case ch.network != nil:
+		return ch.network.EncodeWIF(k.PrivateKey())
+	case ch.evm.Format == evm.FormatTron:
+		return hex.EncodeToString(k.PrivateKey())
+	}
+	return "0x" + hex.EncodeToString(k.PrivateKey())
+}
+
+// descriptor returns the output descriptor of the account xpub with the key
+// origin, the last level of the path becomes the wildcard
+func (ch *chain) descriptor(fingerprint []byte, indexes []uint32, xpub string) string {
+	if ch.evm != nil || len(indexes) < _accountDepth {
+		return ""
+	}
+	d, ok := _descriptors[indexes[0]-bip32.HardenedOffset]
+	if !ok {
+		return ""
+	}
+
+	key := fmt.Sprintf("[%s%s]%s", hex.EncodeToString(fingerprint), formatPath(indexes[:_accountDepth])[1:], xpub)
+	if rest := indexes[_accountDepth:]; len(rest) > 0 {
+		key += formatPath(rest[:len(rest)-1])[1:] + "/*"
+	}
+	return fmt.Sprintf(d.format, key)
+}
+
+// formatPath formats the indexes as a bip32 path using ' for hardened ones
+func formatPath(indexes []uint32) string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, i := range indexes {
+		if i >= bip32.HardenedOffset {
+			fmt.Fprintf(&b, "/%d'", i-bip32.HardenedOffset)
+			continue
+		}
+		fmt.Fprintf(&b, "/%d", i)
+	}
+	return b.String()
+}
+
+// seedFlags are the flags giving the seed either as credentials or as words
+// with an optional passphrase
+type seedFlags struct {
+	identifier *string
+	size       *int
+	password   *secretFlag
+	passcode   *passcodeSource
+	prompt     *bool
+	passphrase *secretFlag
+}
+
+func newSeedFlags(fs *flag.FlagSet) *seedFlags {
+	return &seedFlags{
+		identifier: fs.String("identifier", "", "derive from the credentials instead of mnemonic words"),
+		size:       fs.Int("size", 24, "number of words of the credentials: 12, 15, 18, 21 or 24"),
+		password:   newSecretFlag(fs, "password"),
+		passcode:   newPasscodeFlag(fs),
+		prompt:     fs.Bool("passphrase", false, "prompt for the bip39 passphrase"),
+		passphrase: newSecretFlag(fs, "passphrase"),
+	}
+}
+
+// seedOf generates the words from the credentials, or reads and validates the
+// words of the args, and returns their bip39 seed
+func (c *cli) seedOf(m nomnemonic.Mnemonicer, f *seedFlags, args []string) ([]byte, int) {
+	var words []string
+	var err error
+	if *f.identifier != "" {
+		password, err := f.password.read(c)
+		if err != nil {
+			return nil, c.fail(err, exitError)
+		}
+		passcode, err := f.passcode.read(c)
+		if err != nil {
+			return nil, c.fail(err, exitError)
+		}
+		if words, err = m.Generate(*f.identifier, password, passcode, *f.size); err != nil {
+			return nil, c.fail(err, exitError)
+		}
+	} else {
+		if words, err = c.words(args); err != nil {
+			return nil, c.fail(err, exitError)
+		}
+		if ok, err := m.IsValid(words); err != nil || !ok {
+			return nil, c.fail(invalid(err), exitInvalid)
+		}
+		words = expanded(m, words)
+	}
+	passphrase, err := c.passphrase(*f.prompt, f.passphrase)
+	if err != nil {
+		return nil, c.fail(err, exitError)
+	}
+	seed, err := m.GenerateSeed(strings.Join(words, " "), passphrase)
+	if err != nil {
+		return nil, c.fail(err, exitError)
+	}
+	return seed, exitOK
+}
+
+func (c *cli) derive(args []string) int {
+	fs, common := c.flags("derive")
+	path := fs.String("path", "", "bip32 path of the first address, defaults to the chain receive path")
+	chainName := fs.String("chain", "btc", "btc, tbtc, ltc, doge, eth, bnb, pol, avax, trx or a registered network name")
+	count := fs.Int("count", 1, "number of addresses from the last index of the path")
+	sf := newSeedFlags(fs)
+	m, _, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+	ch, err := lookupChain(*chainName)
+	if err != nil {
+		return c.fail(err, exitUsage)
+	}
+	if *path == "" {
+		*path = ch.defaultPath()
+	}
+	indexes, err := bip32.ParsePath(*path)
+	if err != nil {
+		return c.fail(err, exitUsage)
+	}
+	if len(indexes) == 0 {
+		return c.fail(errors.New("path must have at least one level"), exitUsage)
+	}
+	if *count < 1 {
+		return c.fail(errors.New("count must be at least 1"), exitUsage)
+	}
+
+	seed, code := c.seedOf(m, sf, fs.Args())
+	if seed == nil {
+		return code
+	}
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+
+	r := newResult(*common.language)
+	r.Chain = ch.name
+	r.Fingerprint = hex.EncodeToString(master.Fingerprint())
+	if len(indexes) >= _accountDepth {
+		account, err := master.DerivePath(formatPath(indexes[:_accountDepth]))
+		if err != nil {
+			return c.fail(err, exitError)
+		}
+		r.XPub = account.Neuter().Serialize(ch.xpubVersion())
+		r.Descriptor = ch.descriptor(master.Fingerprint(), indexes, r.XPub)
+	}
+	if r.Addresses, err = ch.addresses(master, indexes, *count, false); err != nil {
+		return c.fail(err, exitUsage)
+	}
+
+	text := make([]string, 0, 3+len(r.Addresses))
+	if r.XPub != "" {
+		text = append(text, "xpub        "+r.XPub)
+	}
+	if r.Descriptor != "" {
+		text = append(text, "descriptor  "+r.Descriptor)
+	}
+	for _, a := range r.Addresses {
+		text = append(text, a.Path+"  "+a.Address)
+	}
+	return c.write(r, *common.output, strings.Join(text, "\n"))
+}
+
+// addresses derives count addresses from the path, incrementing its last
+// index, with the private keys when withKeys is set
+func (ch *chain) addresses(master *bip32.Key, indexes []uint32, count int, withKeys bool) ([]derivedAddress, error) {
+	parent, err := master.DerivePath(formatPath(indexes[:len(indexes)-1]))
+	if err != nil {
+		return nil, err
+	}
+	purpose := indexes[0] - bip32.HardenedOffset
+	last := indexes[len(indexes)-1]
+	addresses := make([]derivedAddress, 0, count)
+	for i := 0; i < count; i++ {
+		k, err := parent.Derive(last + uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		address, err := ch.address(k, purpose)
+		if err != nil {
+			return nil, err
+		}
+		path := append(append([]uint32{}, indexes[:len(indexes)-1]...), last+uint32(i))
+		a := derivedAddress{Path: formatPath(path), Address: address}
+		if withKeys {
+			a.PrivateKey = ch.privateKey(k)
+		}
+		addresses = append(addresses, a)
+	}
+	return addresses, nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const _abandonAbout = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestDerive(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			name: "bip84 default path",
+			args: []string{"derive", _abandonAbout},
+			stdout: "xpub        xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V\n" +
+				"descriptor  wpkh([73c5da0a/84'/0'/0']xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V/0/*)\n" +
+				"m/84'/0'/0'/0/0  bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu\n",
+		},
+		{
+			name: "bip44 with count",
+			args: []string{"derive", "--path", "m/44'/0'/0'/0/0", "--count", "2", _abandonAbout},
+			stdout: "xpub        xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj\n" +
+				"descriptor  pkh([73c5da0a/44'/0'/0']xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj/0/*)\n" +
+				"m/44'/0'/0'/0/0  1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA\n" +
+				"m/44'/0'/0'/0/1  1Ak8PffB2meyfYnbXZR9EGfLfFZVpzJvQP\n",
+		},
+		{
+			name: "bip49",
+			args: []string{"derive", "--path", "m/49h/0h/0h/0/0", _abandonAbout},
+			stdout: "xpub        xpub6C6nQwHaWbSrzs5tZ1q7m5R9cPK9eYpNMFesiXsYrgc1P8bvLLAet9JfHjYXKjToD8cBRswJXXbbFpXgwsswVPAZzKMa1jUp2kVkGVUaJa7\n" +
+				"descriptor  sh(wpkh([73c5da0a/49'/0'/0']xpub6C6nQwHaWbSrzs5tZ1q7m5R9cPK9eYpNMFesiXsYrgc1P8bvLLAet9JfHjYXKjToD8cBRswJXXbbFpXgwsswVPAZzKMa1jUp2kVkGVUaJa7/0/*))\n" +
+				"m/49'/0'/0'/0/0  37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf\n",
+		},
+		{
+			name: "ethereum",
+			args: []string{"derive", "--chain", "eth", _abandonAbout},
+			stdout: "xpub        xpub6DCoCpSuQZB2jawqnGMEPS63ePKWkwWPH4TU45Q7LPXWuNd8TMtVxRrgjtEshuqpK3mdhaWHPFsBngh5GFZaM6si3yZdUsT8ddYM3PwnATt\n" +
+				"m/44'/60'/0'/0/0  0x9858EfFD232B4033E47d90003D41EC34EcaEda94\n",
+		},
+		{
+			name:   "unsupported purpose",
+			args:   []string{"derive", "--path", "m/86'/0'/0'/0/0", _abandonAbout},
+			code:   exitUsage,
+			stderr: "nomnemonic: unsupported purpose 86 for bitcoin\n",
+		},
+		{
+			name:   "unsupported chain",
+			args:   []string{"derive", "--chain", "xyz", _abandonAbout},
+			code:   exitUsage,
+			stderr: "nomnemonic: unsupported chain xyz\n",
+		},
+		{
+			name:   "invalid path",
+			args:   []string{"derive", "--path", "84'/0'", _abandonAbout},
+			code:   exitUsage,
+			stderr: "nomnemonic: path must start with m but given '84'/0''\n",
+		},
+		{
+			name:   "invalid words",
+			args:   []string{"derive", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"},
+			code:   exitInvalid,
+			stderr: "nomnemonic: invalid checksum\n",
+		},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		c := &cli{
+			stdin:  bufio.NewReader(strings.NewReader("")),
+			stdout: &stdout,
+			stderr: &stderr,
+		}
+
+		code := c.run(test.args)
+		if code != test.code {
+			t.Errorf("%s: expected exit code %d but actual %d (%s)", test.name, test.code, code, stderr.String())
+		}
+		if test.stdout != "" && stdout.String() != test.stdout {
+			t.Errorf("%s: expected stdout '%s' but actual '%s'", test.name, test.stdout, stdout.String())
+		}
+		if test.stderr != "" && stderr.String() != test.stderr {
+			t.Errorf("%s: expected stderr '%s' but actual '%s'", test.name, test.stderr, stderr.String())
+		}
+	}
+}
+
+func TestFormatPath(t *testing.T) {
+	expected := "m/84'/0'/0'/0/5"
+	actual := formatPath([]uint32{84 + 0x80000000, 0x80000000, 0x80000000, 0, 5})
+	if actual != expected {
+		t.Errorf("expected %s but actual %s", expected, actual)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+var _kdfProfiles = map[string]nomnemonic.KDFParams{
+	"interactive": nomnemonic.KDFInteractive,
+	"moderate":    nomnemonic.KDFModerate,
+	"sensitive":   nomnemonic.KDFSensitive,
+}
+
+func (c *cli) encrypt(args []string) int {
+	fs, common := c.flags("encrypt")
+	profile := fs.String("profile", "moderate", "argon2id cost: interactive, moderate or sensitive")
+	passphraseFlag := newSecretFlag(fs, "passphrase")
+	m, _, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+	params, ok := _kdfProfiles[*profile]
+	if !ok {
+		return c.fail(fmt.Errorf("unsupported profile %s", *profile), exitUsage)
+	}
+	if err := common.loaded.CheckExport(params); err != nil {
+		return c.fail(err, exitUsage)
+	}
+	words, err := c.words(fs.Args())
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	if ok, err := m.IsValid(words); err != nil || !ok {
+		return c.fail(invalid(err), exitInvalid)
+	}
+	words = expanded(m, words)
+
+	passphrase, err := passphraseFlag.read(c)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	if *passphraseFlag.file == "" && *passphraseFlag.env == "" {
+		confirm, err := c.secret("confirm passphrase: ")
+		if err != nil {
+			return c.fail(err, exitError)
+		}
+		if confirm != passphrase {
+			return c.fail(errors.New("passphrases do not match"), exitError)
+		}
+	}
+
+	container, err := nomnemonic.Export(words, passphrase, params)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	fmt.Fprint(c.stdout, nomnemonic.Armor(container))
+	return exitOK
+}
+
+func (c *cli) decrypt(args []string) int {
+	fs, common := c.flags("decrypt")
+	in := fs.String("in", "-", "file of the armored export, - for stdin after the passphrase")
+	passphraseFlag := newSecretFlag(fs, "passphrase")
+	m, sep, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+
+	passphrase, err := passphraseFlag.read(c)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	var text []byte
+	if *in == "-" {
+		text, err = io.ReadAll(c.stdin)
+	} else {
+		text, err = os.ReadFile(*in)
+	}
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	container, err := nomnemonic.Dearmor(string(text))
+	if err != nil {
+		return c.fail(err, exitInvalid)
+	}
+
+	words, err := nomnemonic.Import(container, passphrase)
+	if err != nil {
+		return c.fail(err, exitInvalid)
+	}
+	if ok, err := m.IsValid(words); err != nil || !ok {
+		return c.fail(invalid(err), exitInvalid)
+	}
+	words = expanded(m, words)
+	r := newResult(*common.language)
+	r.Words = words
+	return c.write(r, *common.output, strings.Join(words, sep))
+}
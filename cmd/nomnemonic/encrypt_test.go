@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	_kdfProfiles["test"] = nomnemonic.KDFParams{Time: 1, Memory: 64, Threads: 1}
+	defer delete(_kdfProfiles, "test")
+
+	const words = "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	var stdout, stderr bytes.Buffer
+	secrets := []string{"correct horse", "correct horse"}
+	c := &cli{
+		stdin:  bufio.NewReader(strings.NewReader("")),
+		stdout: &stdout,
+		stderr: &stderr,
+		secret: func(prompt string) (string, error) {
+			s := secrets[0]
+			secrets = secrets[1:]
+			return s, nil
+		},
+	}
+	if code := c.run([]string{"encrypt", "--profile", "test", words}); code != exitOK {
+		t.Fatalf("expected exit code 0 but actual %d (%s)", code, stderr.String())
+	}
+	armored := stdout.String()
+	if !strings.HasPrefix(armored, "-----BEGIN NOMNEMONIC EXPORT-----\n") || !strings.HasSuffix(armored, "-----END NOMNEMONIC EXPORT-----\n") || strings.Contains(armored, "cinnamon") {
+		t.Fatalf("expected an armored export but actual '%s'", armored)
+	}
+
+	tests := []struct {
+		name       string
+		passphrase string
+		armored    string
+		code       int
+		stdout     string
+		stderr     string
+	}{
+		{name: "decrypt", passphrase: "correct horse", armored: "some mail\n" + armored, stdout: words + "\n"},
+		{name: "wrong passphrase", passphrase: "wrong horse", armored: armored, code: exitInvalid, stderr: "nomnemonic: wrong passphrase or corrupted export\n"},
+		{name: "not armored", passphrase: "correct horse", armored: "hello", code: exitInvalid, stderr: "nomnemonic: no armored export found\n"},
+	}
+
+	for _, test := range tests {
+		stdout.Reset()
+		stderr.Reset()
+		c.stdin = bufio.NewReader(strings.NewReader(test.passphrase + "\n" + test.armored))
+		c.secret = func(prompt string) (string, error) {
+			return readLine(c.stdin)
+		}
+
+		code := c.run([]string{"decrypt"})
+		if code != test.code {
+			t.Errorf("%s: expected exit code %d but actual %d (%s)", test.name, test.code, code, stderr.String())
+		}
+		if test.stdout != "" && stdout.String() != test.stdout {
+			t.Errorf("%s: expected stdout '%s' but actual '%s'", test.name, test.stdout, stdout.String())
+		}
+		if test.stderr != "" && stderr.String() != test.stderr {
+			t.Errorf("%s: expected stderr '%s' but actual '%s'", test.name, test.stderr, stderr.String())
+		}
+	}
+}
+
+func TestEncryptPassphraseMismatch(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	secrets := []string{"correct horse", "correct hose"}
+	c := &cli{
+		stdin:  bufio.NewReader(strings.NewReader("")),
+		stdout: &stdout,
+		stderr: &stderr,
+		secret: func(prompt string) (string, error) {
+			s := secrets[0]
+			secrets = secrets[1:]
+			return s, nil
+		},
+	}
+	code := c.run([]string{"encrypt", "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"})
+	if code != exitError || stderr.String() != "nomnemonic: passphrases do not match\n" {
+		t.Errorf("expected passphrase mismatch but actual %d '%s'", code, stderr.String())
+	}
+}
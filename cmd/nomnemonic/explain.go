@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+type explainStep struct {
+	Name   string `json:"name" yaml:"name"`
+	Detail string `json:"detail" yaml:"detail"`
+	Output string `json:"output" yaml:"output"`
+}
+
+func (c *cli) explain(args []string) int {
+	fs, common := c.flags("explain")
+	identifier := fs.String("identifier", "explain@example.com", "dummy identifier")
+	password := fs.String("password", "dummy password", "dummy password, never a real one")
+	passcode := fs.String("passcode", "123456", "dummy passcode, never a real one")
+	size := fs.Int("size", 12, "number of words: 12, 15, 18, 21 or 24")
+	m, _, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+
+	steps, err := m.Explain(*identifier, *password, *passcode, *size)
+	if err != nil {
+		return c.fail(err, exitUsage)
+	}
+	r := newResult(*common.language)
+	var text strings.Builder
+	fmt.Fprintf(&text, "nomnemonic %s, algorithm %s, dummy inputs only\n", nomnemonic.Version, nomnemonic.VersionAlgorithm)
+	for i, s := range steps {
+		r.Steps = append(r.Steps, explainStep{Name: s.Name, Detail: s.Detail, Output: s.Output})
+		fmt.Fprintf(&text, "\n%d. %s\n   %s\n   %s\n", i+1, s.Name, s.Detail, s.Output)
+	}
+	return c.write(r, *common.output, text.String())
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	c := &cli{stdin: bufio.NewReader(strings.NewReader("")), stdout: &stdout, stderr: &stderr}
+
+	code := c.run([]string{"explain", "--identifier", "nomnemonic_test", "--password", "test12345678", "--passcode", "101938", "--size", "24"})
+	if code != exitOK {
+		t.Fatalf("expected exit code 0 but actual %d (%s)", code, stderr.String())
+	}
+	for _, s := range []string{
+		"1. validate\n",
+		"4. pbkdf2\n   pbkdf2-sha512(input, salt), 262144 iterations, 32 bytes\n",
+		"5. scrypt\n   scrypt(input, salt), n=262144 r=8 p=1, 32 bytes\n",
+		"   dress mule bonus strong village clip volcano public plug fossil travel lobster nerve love gospel dance shove vicious valve else roof observe warrior magic\n",
+	} {
+		if !strings.Contains(stdout.String(), s) {
+			t.Errorf("expected output to contain '%s' but actual '%s'", s, stdout.String())
+		}
+	}
+
+	stderr.Reset()
+	if code := c.run([]string{"explain", "--passcode", "12"}); code != exitUsage || stderr.String() != "nomnemonic: passcode must be 6 digits\n" {
+		t.Errorf("expected passcode usage error but actual %d '%s'", code, stderr.String())
+	}
+}
@@ -0,0 +1,128 @@
+// Command nomnemonic generates deterministic bip39 mnemonics and inspects
+// existing ones from the command line
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/nomnemonic/nomnemonic/keychain"
+)
+
+// exit codes for scripting
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitInvalid  = 3 // the given mnemonic is not valid
+	exitMismatch = 4 // the credentials do not generate the given mnemonic
+)
+
+type (
+	cli struct {
+		stdin  *bufio.Reader
+		stdout io.Writer
+		stderr io.Writer
+
+		// secret reads a secret without echoing it when stdin is a terminal
+		secret func(prompt string) (string, error)
+
+		clipboard clipboard
+		sleep     func(time.Duration)
+		settings  settingsStore
+	}
+
+	command struct {
+		usage string
+		run   func(c *cli, args []string) int
+	}
+)
+
+var _commands = map[string]command{
+	"generate":  {usage: "generate mnemonic words from identifier, password and passcode", run: (*cli).generate},
+	"validate":  {usage: "validate the checksum of mnemonic words", run: (*cli).validate},
+	"entropy":   {usage: "print the entropy of mnemonic words in hex", run: (*cli).entropy},
+	"seed":      {usage: "print the bip39 seed of mnemonic words in hex", run: (*cli).seed},
+	"lastword":  {usage: "list every valid last word of n-1 mnemonic words", run: (*cli).lastword},
+	"derive":    {usage: "derive the xpub, descriptor and addresses of a bip32 path", run: (*cli).derive},
+	"verify":    {usage: "check the credentials still generate a phrase without printing it", run: (*cli).verify},
+	"batch":     {usage: "generate or validate the rows of a jsonl or csv file concurrently", run: (*cli).batch},
+	"bench":     {usage: "measure the kdf cost on the host and estimate attack costs", run: (*cli).bench},
+	"config":    {usage: "save, show or delete the settings kept in the os keychain for --keychain", run: (*cli).config},
+	"compat":    {usage: "print the algorithm versions, kdf profiles and word lists it interoperates with", run: (*cli).compat},
+	"wordlist":  {usage: "list, show and check embedded or custom word lists", run: (*cli).wordlist},
+	"sheet":     {usage: "print an html or pdf recovery sheet, or a blank one to fill by hand", run: (*cli).sheet},
+	"split":     {usage: "split mnemonic words into Seed XOR parts or slip39 shares", run: (*cli).split},
+	"combine":   {usage: "combine Seed XOR parts or slip39 shares entered one by one", run: (*cli).combine},
+	"encrypt":   {usage: "encrypt mnemonic words into an armored argon2id export", run: (*cli).encrypt},
+	"decrypt":   {usage: "decrypt the words of an armored export", run: (*cli).decrypt},
+	"quiz":      {usage: "re-derive the phrase and quiz random word positions without showing it", run: (*cli).quiz},
+	"explain":   {usage: "print the derivation pipeline with intermediate values of dummy inputs", run: (*cli).explain},
+	"addresses": {usage: "export the first addresses of several chains, csv included", run: (*cli).addresses},
+	"daemon":    {usage: "serve generate, validate and seed on a unix socket to processes of allowed uids", run: (*cli).daemon},
+	"tui":       {usage: "guided wizard with word by word reveal and a quiz", run: (*cli).tui},
+	"sign-psbt": {usage: "sign the psbt inputs derived from the master key of the words", run: (*cli).signPSBT},
+}
+
+func main() {
+	c := newCLI(os.Stdin, os.Stdout, os.Stderr)
+	os.Exit(c.run(os.Args[1:]))
+}
+
+func newCLI(stdin *os.File, stdout, stderr io.Writer) *cli {
+	c := &cli{
+		stdin:  bufio.NewReader(stdin),
+		stdout: stdout,
+		stderr: stderr,
+
+		clipboard: systemClipboard{},
+		sleep:     time.Sleep,
+	}
+	c.settings, _ = keychain.New(keychain.DefaultAccount)
+	c.secret = func(prompt string) (string, error) {
+		return readSecret(stdin, c.stdin, stderr, prompt)
+	}
+	return c
+}
+
+func (c *cli) run(args []string) int {
+	if len(args) > 0 && args[0] == _offlineFlag {
+		if code := c.offline(); code != exitOK {
+			return code
+		}
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		c.usage()
+		return exitUsage
+	}
+	cmd, ok := _commands[args[0]]
+	if !ok {
+		fmt.Fprintf(c.stderr, "unknown command %s\n", args[0])
+		c.usage()
+		return exitUsage
+	}
+	return cmd.run(c, args[1:])
+}
+
+func (c *cli) usage() {
+	fmt.Fprintln(c.stderr, "usage: nomnemonic [--offline] <command> [flags]")
+	fmt.Fprintln(c.stderr, "\ncommands:")
+	names := make([]string, 0, len(_commands))
+	for name := range _commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(c.stderr, "  %-9s %s\n", name, _commands[name].usage)
+	}
+}
+
+func (c *cli) fail(err error, code int) int {
+	fmt.Fprintf(c.stderr, "nomnemonic: %s\n", err.Error())
+	return code
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   string
+		secrets []string
+		code    int
+		stdout  string
+		stderr  string
+	}{
+		{
+			name:    "generate",
+			args:    []string{"generate", "--identifier", "nomnemonic_test", "--size", "24"},
+			secrets: []string{"test12345678", "101938"},
+			stdout:  "dress mule bonus strong village clip volcano public plug fossil travel lobster nerve love gospel dance shove vicious valve else roof observe warrior magic\n",
+		},
+		{
+			name:   "generate without identifier",
+			args:   []string{"generate"},
+			code:   exitUsage,
+			stderr: "generate: --identifier is required\n",
+		},
+		{
+			name:    "generate with short password",
+			args:    []string{"generate", "--identifier", "nomnemonic_test"},
+			secrets: []string{"short", "101938"},
+			code:    exitError,
+			stderr:  "nomnemonic: password must be at least 12 chars\n",
+		},
+		{
+			name:    "generate with short password in spanish",
+			args:    []string{"generate", "--identifier", "nomnemonic_test", "--messages", "spanish"},
+			secrets: []string{"short", "101938"},
+			code:    exitError,
+			stderr:  "nomnemonic: la contraseña debe tener al menos 12 caracteres\n",
+		},
+		{
+			name:    "generate with features",
+			args:    []string{"generate", "--identifier", "nomnemonic_test", "--size", "12", "--features", "unicode-normalization, constant-time"},
+			secrets: []string{"test12345678", "101938"},
+			stdout:  "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby\n",
+		},
+		{
+			name:    "generate with purpose",
+			args:    []string{"generate", "--identifier", "nomnemonic_test", "--size", "12", "--purpose", "savings"},
+			secrets: []string{"test12345678", "101938"},
+			stdout:  "goose panel square enforce secret will use lens job glare coach ocean\n",
+		},
+		{
+			name:   "validate unsupported feature",
+			args:   []string{"validate", "--features", "telepathy", "abandon"},
+			code:   exitUsage,
+			stderr: "nomnemonic: unsupported feature telepathy\n",
+		},
+		{
+			name:   "validate unsupported messages language",
+			args:   []string{"validate", "--messages", "klingon", "abandon"},
+			code:   exitUsage,
+			stderr: "nomnemonic: unsupported messages language klingon\n",
+		},
+		{
+			name:   "validate from args",
+			args:   []string{"validate", "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"},
+			stdout: "valid\n",
+		},
+		{
+			name:   "validate from stdin",
+			args:   []string{"validate"},
+			stdin:  "cinnamon venue broken old brass vague paddle unaware critic alarm consider consider\n",
+			code:   exitInvalid,
+			stderr: "nomnemonic: invalid checksum\n",
+		},
+		{
+			name:   "validate unsupported language",
+			args:   []string{"validate", "--language", "klingon", "abandon"},
+			code:   exitUsage,
+			stderr: "nomnemonic: unsupported language klingon\n",
+		},
+		{
+			name:   "entropy",
+			args:   []string{"entropy", "cinnamon", "venue", "broken", "old", "brass", "vague", "paddle", "unaware", "critic", "alarm", "consider", "hobby"},
+			stdout: "291e4c724d01b3e167b76333c0b8bd36\n",
+		},
+		{
+			name:   "entropy seedqr",
+			args:   []string{"entropy", "--seedqr", "standard", "forum undo fragile fade shy sign arrest garment culture tube off merit"},
+			stdout: "073318950739065415961602009907670428187212261116\n",
+		},
+		{
+			name:   "entropy compact seedqr",
+			args:   []string{"entropy", "--seedqr", "compact", "forum undo fragile fade shy sign arrest garment culture tube off merit"},
+			stdout: "5bbd9d71a8ec7990831aff359d426545\n",
+		},
+		{
+			name:   "entropy unsupported seedqr",
+			args:   []string{"entropy", "--seedqr", "tiny", "forum undo fragile fade shy sign arrest garment culture tube off merit"},
+			code:   exitUsage,
+			stderr: "nomnemonic: unsupported seedqr tiny\n",
+		},
+		{
+			name:   "seed",
+			args:   []string{"seed", "edge defense waste choose enrich upon flee junk siren film clown finish luggage leader kid quick brick print evidence swap drill paddle truly occur"},
+			stdout: "7e74b1a8195ae1e8d06f29c9a306f678e5a8cf908075bc52eb3b716f9e50ce8860065c2c18b8a960bb363855d3a340074cba5db505d4f78dd1d94c4e19f20b7a\n",
+		},
+		{
+			name:   "seed abbreviated",
+			args:   []string{"seed", "edge defe wast choo enri upon flee junk sire film clow fini lugg lead kid quic bric prin evid swap dril padd trul occu"},
+			stdout: "7e74b1a8195ae1e8d06f29c9a306f678e5a8cf908075bc52eb3b716f9e50ce8860065c2c18b8a960bb363855d3a340074cba5db505d4f78dd1d94c4e19f20b7a\n",
+		},
+		{
+			name:    "seed with passphrase",
+			args:    []string{"seed", "--passphrase", "edge defense waste choose enrich upon flee junk siren film clown finish luggage leader kid quick brick print evidence swap drill paddle truly occur"},
+			secrets: []string{"some password"},
+			stdout:  "0dc285fde768f7ff29b66ce7252d56ed92fe003b605907f7a4f683c3dc8586d34a914d3c71fc099bb38ee4a59e5b081a3497b7a323e90cc68f67b5837690310c\n",
+		},
+		{
+			name:   "lastword",
+			args:   []string{"lastword", "edge defense waste choose enrich upon flee junk siren film clown finish luggage leader kid quick brick print evidence swap drill paddle truly"},
+			stdout: "afraid\ncake\ndrastic\ninvolve\noccur\nremove\nsphere\nwarrior\n",
+		},
+		{
+			name:    "verify match",
+			args:    []string{"verify", "--identifier", "nomnemonic_test"},
+			secrets: []string{"test12345678", "101938", "dress mule bonus strong village clip volcano public plug fossil travel lobster nerve love gospel dance shove vicious valve else roof observe warrior magic"},
+			stdout:  "match\n",
+		},
+		{
+			name:    "verify mismatch",
+			args:    []string{"verify"},
+			stdin:   "nomnemonic_test\n",
+			secrets: []string{"test12345678", "101939", "dress mule bonus strong village clip volcano public plug fossil travel lobster nerve love gospel dance shove vicious valve else roof observe warrior magic"},
+			code:    exitMismatch,
+			stdout:  "mismatch\n",
+		},
+		{
+			name: "unknown command",
+			args: []string{"explode"},
+			code: exitUsage,
+		},
+		{
+			name: "no command",
+			code: exitUsage,
+		},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		secrets := test.secrets
+		c := &cli{
+			stdin:  bufio.NewReader(strings.NewReader(test.stdin)),
+			stdout: &stdout,
+			stderr: &stderr,
+			secret: func(prompt string) (string, error) {
+				s := secrets[0]
+				secrets = secrets[1:]
+				return s, nil
+			},
+		}
+
+		code := c.run(test.args)
+		if code != test.code {
+			t.Errorf("%s: expected exit code %d but actual %d (%s)", test.name, test.code, code, stderr.String())
+		}
+		if test.stdout != "" && stdout.String() != test.stdout {
+			t.Errorf("%s: expected stdout '%s' but actual '%s'", test.name, test.stdout, stdout.String())
+		}
+		if test.stderr != "" && stderr.String() != test.stderr {
+			t.Errorf("%s: expected stderr '%s' but actual '%s'", test.name, test.stderr, stderr.String())
+		}
+	}
+}
+
+func TestPolicyFlags(t *testing.T) {
+	dir := t.TempDir()
+	policy := filepath.Join(dir, "policy.json")
+	pepper := filepath.Join(dir, "pepper")
+	if err := os.WriteFile(policy, []byte(`{"sizes":[24],"profiles":["sensitive"],"require_pepper":true}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pepper, []byte("organization pepper\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stderr string
+	}{
+		{name: "missing pepper", args: []string{"generate", "--policy", policy, "--identifier", "nomnemonic_test"}, code: exitUsage, stderr: "nomnemonic: the policy requires a pepper\n"},
+		{name: "size", args: []string{"generate", "--policy", policy, "--pepper-file", pepper, "--identifier", "nomnemonic_test", "--size", "12"}, code: exitError, stderr: "nomnemonic: 12 words are not allowed by the policy\n"},
+		{name: "profile", args: []string{"encrypt", "--policy", policy, "--pepper-file", pepper, "--profile", "moderate", "abandon"}, code: exitUsage, stderr: "nomnemonic: export kdf params are not one of the [sensitive] profiles of the policy\n"},
+		{name: "generate", args: []string{"generate", "--policy", policy, "--pepper-file", pepper, "--identifier", "nomnemonic_test"}},
+		{name: "yubikey slot", args: []string{"generate", "--yubikey-slot", "3", "--identifier", "nomnemonic_test"}, code: exitUsage, stderr: "nomnemonic: unsupported yubikey slot 3\n"},
+		{name: "pkcs11 key", args: []string{"generate", "--pkcs11-module", "/usr/lib/softhsm/libsofthsm2.so", "--identifier", "nomnemonic_test"}, code: exitUsage, stderr: "nomnemonic: pkcs11: module and key label are required\n"},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		secrets := []string{"test12345678", "101938"}
+		c := &cli{
+			stdin:  bufio.NewReader(strings.NewReader("")),
+			stdout: &stdout,
+			stderr: &stderr,
+			secret: func(prompt string) (string, error) {
+				s := secrets[0]
+				secrets = secrets[1:]
+				return s, nil
+			},
+		}
+		if code := c.run(test.args); code != test.code || stderr.String() != test.stderr {
+			t.Errorf("%s: expected exit code %d and '%s' but actual %d '%s'", test.name, test.code, test.stderr, code, stderr.String())
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+)
+
+const _offlineFlag = "--offline"
+
+// interfaces and executable are replaced in tests
+var (
+	interfaces = net.Interfaces
+	executable = os.Executable
+)
+
+// checkOffline fails when a network interface other than the loopback is up,
+// it sees the interfaces of the network namespace the process runs in
+func checkOffline() error {
+	ifaces, err := interfaces()
+	if err != nil {
+		return err
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp != 0 && iface.Flags&net.FlagLoopback == 0 {
+			return fmt.Errorf("network interface %s is up, disconnect it or drop --offline", iface.Name)
+		}
+	}
+	return nil
+}
+
+// binaryHash returns the sha256 of the running binary and its path, to be
+// compared with the published release checksums
+func binaryHash() (string, string, error) {
+	path, err := executable()
+	if err != nil {
+		return "", "", err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), path, nil
+}
+
+// offline checks the air gap and prints the binary hash on stderr before the
+// command runs
+func (c *cli) offline() int {
+	if err := checkOffline(); err != nil {
+		return c.fail(err, exitError)
+	}
+	sum, path, err := binaryHash()
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	fmt.Fprintf(c.stderr, "offline: no network interface is up\nbinary sha256 %s %s\n", sum, path)
+	return exitOK
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOffline(t *testing.T) {
+	originalInterfaces, originalExecutable := interfaces, executable
+	defer func() { interfaces, executable = originalInterfaces, originalExecutable }()
+
+	binary := filepath.Join(t.TempDir(), "nomnemonic")
+	if err := os.WriteFile(binary, []byte("binary"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	executable = func() (string, error) { return binary, nil }
+	sum := sha256.Sum256([]byte("binary"))
+
+	loopback := net.Interface{Name: "lo", Flags: net.FlagUp | net.FlagLoopback}
+	tests := []struct {
+		name   string
+		ifaces []net.Interface
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			name:   "air gapped",
+			ifaces: []net.Interface{loopback, {Name: "eth0"}},
+			stdout: "valid\n",
+			stderr: "offline: no network interface is up\nbinary sha256 " + hex.EncodeToString(sum[:]) + " " + binary + "\n",
+		},
+		{
+			name:   "interface up",
+			ifaces: []net.Interface{loopback, {Name: "wlan0", Flags: net.FlagUp}},
+			code:   exitError,
+			stderr: "nomnemonic: network interface wlan0 is up, disconnect it or drop --offline\n",
+		},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		ifaces := test.ifaces
+		interfaces = func() ([]net.Interface, error) { return ifaces, nil }
+		c := &cli{
+			stdin:  bufio.NewReader(strings.NewReader("")),
+			stdout: &stdout,
+			stderr: &stderr,
+		}
+
+		code := c.run([]string{"--offline", "validate", "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"})
+		if code != test.code {
+			t.Errorf("%s: expected exit code %d but actual %d (%s)", test.name, test.code, code, stderr.String())
+		}
+		if stdout.String() != test.stdout {
+			t.Errorf("%s: expected stdout '%s' but actual '%s'", test.name, test.stdout, stdout.String())
+		}
+		if stderr.String() != test.stderr {
+			t.Errorf("%s: expected stderr '%s' but actual '%s'", test.name, test.stderr, stderr.String())
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nomnemonic/nomnemonic"
+	"github.com/nomnemonic/nomnemonic/bip32"
+	"github.com/nomnemonic/nomnemonic/keychain"
+)
+
+const (
+	_outputText = "text"
+	_outputJSON = "json"
+	_outputYAML = "yaml"
+)
+
+type (
+	// result is the stable machine readable output schema, fields are only
+	// set when the command computes them
+	result struct {
+		Words         []string                        `json:"words,omitempty" yaml:"words,omitempty"`
+		Entropy       string                          `json:"entropy,omitempty" yaml:"entropy,omitempty"`
+		Seed          string                          `json:"seed,omitempty" yaml:"seed,omitempty"`
+		Fingerprint   string                          `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+		Valid         *bool                           `json:"valid,omitempty" yaml:"valid,omitempty"`
+		Shares        [][]string                      `json:"shares,omitempty" yaml:"shares,omitempty"`
+		LastWords     []string                        `json:"last_words,omitempty" yaml:"last_words,omitempty"`
+		SeedQR        string                          `json:"seedqr,omitempty" yaml:"seedqr,omitempty"`
+		Chain         string                          `json:"chain,omitempty" yaml:"chain,omitempty"`
+		XPub          string                          `json:"xpub,omitempty" yaml:"xpub,omitempty"`
+		Descriptor    string                          `json:"descriptor,omitempty" yaml:"descriptor,omitempty"`
+		Addresses     []derivedAddress                `json:"addresses,omitempty" yaml:"addresses,omitempty"`
+		Steps         []explainStep                   `json:"steps,omitempty" yaml:"steps,omitempty"`
+		Benchmark     *benchmark                      `json:"benchmark,omitempty" yaml:"benchmark,omitempty"`
+		Compatibility *nomnemonic.CompatibilityMatrix `json:"compatibility,omitempty" yaml:"compatibility,omitempty"`
+		Settings      *keychain.Config                `json:"settings,omitempty" yaml:"settings,omitempty"`
+		PSBT          string                          `json:"psbt,omitempty" yaml:"psbt,omitempty"`
+		Signed        int                             `json:"signed,omitempty" yaml:"signed,omitempty"`
+		Algorithm     algorithm                       `json:"algorithm" yaml:"algorithm"`
+	}
+
+	derivedAddress struct {
+		Chain      string `json:"chain,omitempty" yaml:"chain,omitempty"`
+		Path       string `json:"path" yaml:"path"`
+		Address    string `json:"address" yaml:"address"`
+		PrivateKey string `json:"private_key,omitempty" yaml:"private_key,omitempty"`
+	}
+
+	algorithm struct {
+		Version          string   `json:"version" yaml:"version"`
+		AlgorithmVersion string   `json:"algorithm_version" yaml:"algorithm_version"`
+		Language         string   `json:"language" yaml:"language"`
+		KDFHash          string   `json:"kdf_hash,omitempty" yaml:"kdf_hash,omitempty"`
+		WordlistChecksum string   `json:"wordlist_checksum,omitempty" yaml:"wordlist_checksum,omitempty"`
+		Features         []string `json:"features,omitempty" yaml:"features,omitempty"`
+		Purpose          string   `json:"purpose,omitempty" yaml:"purpose,omitempty"`
+	}
+)
+
+func outputFlag(fs *flag.FlagSet) *string {
+	return fs.String("output", _outputText, "output format: text, json, yaml or a registered encoder")
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case _outputText, _outputJSON, _outputYAML:
+		return nil
+	}
+	if _, ok := nomnemonic.LookupEncoder(output); ok {
+		return nil
+	}
+	return fmt.Errorf("unsupported output %s", output)
+}
+
+func newResult(language string) *result {
+	return &result{
+		Algorithm: algorithm{
+			Version:          nomnemonic.Version,
+			AlgorithmVersion: nomnemonic.VersionAlgorithm,
+			Language:         language,
+		},
+	}
+}
+
+// setSeed sets the seed and the fingerprint of its bip32 master key
+func (r *result) setSeed(seed []byte) error {
+	key, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return err
+	}
+	r.Seed = hex.EncodeToString(seed)
+	r.Fingerprint = hex.EncodeToString(key.Fingerprint())
+	return nil
+}
+
+// write writes the result in the output format, text is the human output
+func (r *result) write(w io.Writer, output, text string) error {
+	switch output {
+	case _outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case _outputYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		defer enc.Close()
+		return enc.Encode(r)
+	}
+	if e, ok := nomnemonic.LookupEncoder(output); ok {
+		b, err := e.Encode(r.encoderResult())
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	}
+	_, err := fmt.Fprintln(w, strings.TrimRight(text, "\n"))
+	return err
+}
+
+// encoderResult converts the result for the registered encoders, the hex
+// fields are set by the commands so they always decode
+func (r *result) encoderResult() nomnemonic.Result {
+	entropy, _ := hex.DecodeString(r.Entropy)
+	seed, _ := hex.DecodeString(r.Seed)
+	fingerprint, _ := hex.DecodeString(r.Fingerprint)
+	return nomnemonic.Result{
+		Words:            r.Words,
+		Entropy:          entropy,
+		Seed:             seed,
+		Fingerprint:      fingerprint,
+		Language:         r.Algorithm.Language,
+		Version:          r.Algorithm.Version,
+		AlgorithmVersion: r.Algorithm.AlgorithmVersion,
+		KDFHash:          r.Algorithm.KDFHash,
+		WordlistChecksum: r.Algorithm.WordlistChecksum,
+		Features:         r.Algorithm.Features,
+		Purpose:          r.Algorithm.Purpose,
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestOutput(t *testing.T) {
+	sentence := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+
+	tests := []struct {
+		args   []string
+		code   int
+		decode func([]byte, interface{}) error
+		result result
+	}{
+		{
+			args:   []string{"seed", "--output", "json", sentence},
+			decode: json.Unmarshal,
+			result: result{
+				Words:       strings.Fields(sentence),
+				Entropy:     "291e4c724d01b3e167b76333c0b8bd36",
+				Seed:        "1616abe6380feb5c39d2d0270393ce53152bd73d7cc604e0facaab4c22c52d09a3c02f485aef4b8196fa78a880df99dcf3ec3c4c25560be153ae96ef56989f88",
+				Fingerprint: "a6d96dc8",
+			},
+		},
+		{
+			args:   []string{"entropy", "--output", "yaml", sentence},
+			decode: yaml.Unmarshal,
+			result: result{
+				Words:   strings.Fields(sentence),
+				Entropy: "291e4c724d01b3e167b76333c0b8bd36",
+			},
+		},
+		{
+			args:   []string{"validate", "--output", "json", strings.Replace(sentence, "hobby", "consider", 1)},
+			code:   exitInvalid,
+			decode: json.Unmarshal,
+			result: result{
+				Words: strings.Fields(strings.Replace(sentence, "hobby", "consider", 1)),
+				Valid: new(bool),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		c := &cli{
+			stdin:  bufio.NewReader(strings.NewReader("")),
+			stdout: &stdout,
+			stderr: &stderr,
+		}
+		code := c.run(test.args)
+		if code != test.code {
+			t.Errorf("%v: expected exit code %d but actual %d (%s)", test.args, test.code, code, stderr.String())
+		}
+
+		var actual result
+		if err := test.decode(stdout.Bytes(), &actual); err != nil {
+			t.Errorf("%v: unexpected error: %s", test.args, err.Error())
+			continue
+		}
+		test.result.Algorithm = algorithm{Version: "0.3.0", AlgorithmVersion: "3.0.0", Language: "english"}
+		expected, _ := json.Marshal(test.result)
+		got, _ := json.Marshal(actual)
+		if !bytes.Equal(expected, got) {
+			t.Errorf("%v: expected %s but actual %s", test.args, expected, got)
+		}
+	}
+
+	var stderr bytes.Buffer
+	c := &cli{stdin: bufio.NewReader(strings.NewReader("")), stdout: &bytes.Buffer{}, stderr: &stderr}
+	if code := c.run([]string{"entropy", "--output", "xml", sentence}); code != exitUsage {
+		t.Errorf("expected exit code %d for unsupported output but actual %d", exitUsage, code)
+	}
+	if stderr.String() != "nomnemonic: unsupported output xml\n" {
+		t.Errorf("expected unsupported output err but actual '%s'", stderr.String())
+	}
+}
+
+type fingerprintEncoder struct{}
+
+func (fingerprintEncoder) Name() string { return "test-fingerprint" }
+
+func (fingerprintEncoder) Encode(r nomnemonic.Result) ([]byte, error) {
+	return []byte(fmt.Sprintf("%s %x %d words\n", r.Language, r.Fingerprint, len(r.Words))), nil
+}
+
+func TestOutputEncoder(t *testing.T) {
+	nomnemonic.RegisterEncoder(fingerprintEncoder{})
+
+	var stdout, stderr bytes.Buffer
+	c := &cli{stdin: bufio.NewReader(strings.NewReader("")), stdout: &stdout, stderr: &stderr}
+	code := c.run([]string{"seed", "--output", "test-fingerprint", "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"})
+	if code != exitOK {
+		t.Errorf("expected exit code %d but actual %d (%s)", exitOK, code, stderr.String())
+	}
+	if stdout.String() != "english a6d96dc8 12 words\n" {
+		t.Errorf("expected 'english a6d96dc8 12 words' but actual '%s'", stdout.String())
+	}
+}
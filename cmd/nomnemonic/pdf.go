@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 page in points and the word box grid of the pdf sheet
+const (
+	_pdfWidth    = 595
+	_pdfHeight   = 842
+	_pdfMargin   = 50
+	_pdfColumns  = 3
+	_pdfBoxGap   = 8
+	_pdfBoxH     = 28
+	_pdfQRWidth  = 160
+	_pdfFontSize = 12
+)
+
+// writePDF writes a single page pdf with the standard Helvetica font, so the
+// words must be latin-1, other word lists have to use the html sheet
+func (s *sheet) writePDF(w io.Writer) error {
+	var content bytes.Buffer
+	text := func(x, y float64, size int, str string) error {
+		encoded, err := pdfString(str)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&content, "BT /F1 %d Tf %.2f %.2f Td %s Tj ET\n", size, x, y, encoded)
+		return nil
+	}
+	line := func(label, value string, y float64) error {
+		if err := text(_pdfMargin, y, _pdfFontSize, label); err != nil {
+			return err
+		}
+		if value == "" {
+			fmt.Fprintf(&content, "%d %.2f m %d %.2f l S\n", _pdfMargin+90, y-2, _pdfMargin+300, y-2)
+			return nil
+		}
+		return text(_pdfMargin+90, y, _pdfFontSize, value)
+	}
+
+	y := float64(_pdfHeight - _pdfMargin - 20)
+	if err := text(_pdfMargin, y, 20, "Recovery sheet"); err != nil {
+		return err
+	}
+	y -= 30
+	rows := []struct{ label, value string }{
+		{"Language", s.Language},
+		{"Fingerprint", s.Fingerprint},
+		{"Created", s.Created},
+		{"Algorithm", "nomnemonic " + s.Version + ", algorithm " + s.Algorithm},
+	}
+	for _, r := range rows {
+		if err := line(r.label, r.value, y); err != nil {
+			return err
+		}
+		y -= 20
+	}
+
+	y -= 10
+	boxW := float64(_pdfWidth-2*_pdfMargin-(_pdfColumns-1)*_pdfBoxGap) / _pdfColumns
+	for i, word := range s.Words {
+		x := _pdfMargin + float64(i%_pdfColumns)*(boxW+_pdfBoxGap)
+		top := y - float64(i/_pdfColumns)*(_pdfBoxH+_pdfBoxGap)
+		fmt.Fprintf(&content, "%.2f %.2f %.2f %d re S\n", x, top-_pdfBoxH, boxW, _pdfBoxH)
+		if err := text(x+6, top-_pdfBoxH+9, 9, fmt.Sprintf("%d", i+1)); err != nil {
+			return err
+		}
+		if err := text(x+28, top-_pdfBoxH+9, _pdfFontSize, word); err != nil {
+			return err
+		}
+	}
+	y -= float64((len(s.Words)+_pdfColumns-1)/_pdfColumns) * (_pdfBoxH + _pdfBoxGap)
+
+	if len(s.QR) > 0 {
+		module := float64(_pdfQRWidth) / float64(len(s.QR))
+		for row, modules := range s.QR {
+			for col, on := range modules {
+				if on {
+					fmt.Fprintf(&content, "%.2f %.2f %.2f %.2f re f\n", _pdfMargin+float64(col)*module, y-10-float64(row+1)*module, module, module)
+				}
+			}
+		}
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>", _pdfWidth, _pdfHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, o := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, o)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// pdfString encodes the string as a pdf literal string in WinAnsiEncoding,
+// which matches latin-1 for the letters of the latin word lists
+func pdfString(s string) (string, error) {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range s {
+		if r > 0xff {
+			return "", fmt.Errorf("pdf sheets support latin-1 words only, use the html format for %s", s)
+		}
+		switch r {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		default:
+			if r < 0x20 || r >= 0x7f {
+				fmt.Fprintf(&b, "\\%03o", r)
+				continue
+			}
+			b.WriteByte(byte(r))
+		}
+	}
+	b.WriteByte(')')
+	return b.String(), nil
+}
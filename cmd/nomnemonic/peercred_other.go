@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+)
+
+// peerUID is not implemented, the daemon refuses every connection
+func peerUID(conn *net.UnixConn) (int, error) {
+	return 0, fmt.Errorf("peer credentials are not supported on %s", runtime.GOOS)
+}
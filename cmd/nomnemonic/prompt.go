@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+// secretFlag lets a secret come from a file or an environment variable
+// instead of the prompt, so scripts and password managers never pass it as an
+// arg
+type secretFlag struct {
+	name string
+	file *string
+	env  *string
+}
+
+func newSecretFlag(fs *flag.FlagSet, name string) *secretFlag {
+	return &secretFlag{
+		name: name,
+		file: fs.String(name+"-file", "", "read the "+name+" from the file"),
+		env:  fs.String(name+"-env", "", "read the "+name+" from the environment variable"),
+	}
+}
+
+// read reads the secret from the file, the environment variable or the
+// prompt, in this order
+func (s *secretFlag) read(c *cli) (string, error) {
+	if *s.file != "" {
+		b, err := os.ReadFile(*s.file)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	}
+	if *s.env != "" {
+		v, ok := os.LookupEnv(*s.env)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s of the %s is not set", *s.env, s.name)
+		}
+		return v, nil
+	}
+	return c.secret(s.name + ": ")
+}
+
+// passcodeSource reads the passcode, or derives it from an hotp secret at
+// --hotp-counter so the passcode is the code an authenticator app shows
+type passcodeSource struct {
+	*secretFlag
+	hotp    *secretFlag
+	counter *int64
+}
+
+func newPasscodeFlag(fs *flag.FlagSet) *passcodeSource {
+	return &passcodeSource{
+		secretFlag: newSecretFlag(fs, "passcode"),
+		hotp:       newSecretFlag(fs, "hotp-secret"),
+		counter:    fs.Int64("hotp-counter", -1, "derive the passcode from the base32 or otpauth hotp secret at the counter"),
+	}
+}
+
+func (p *passcodeSource) read(c *cli) (string, error) {
+	if *p.counter < 0 {
+		if *p.hotp.file != "" || *p.hotp.env != "" {
+			return "", errors.New("--hotp-counter is required with the hotp secret")
+		}
+		return p.secretFlag.read(c)
+	}
+	s, err := p.hotp.read(c)
+	if err != nil {
+		return "", err
+	}
+	secret, err := nomnemonic.DecodeHOTPSecret(s)
+	if err != nil {
+		return "", err
+	}
+	return nomnemonic.HOTPPasscode(secret, uint64(*p.counter))
+}
+
+// readSecret reads a secret with the prompt, without echo from a terminal or a
+// line from the piped stdin
+func readSecret(stdin *os.File, buffered *bufio.Reader, stderr io.Writer, prompt string) (string, error) {
+	if term.IsTerminal(int(stdin.Fd())) {
+		fmt.Fprint(stderr, prompt)
+		secret, err := term.ReadPassword(int(stdin.Fd()))
+		fmt.Fprintln(stderr)
+		return string(secret), err
+	}
+	return readLine(buffered)
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
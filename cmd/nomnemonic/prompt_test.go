@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadLine(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("first secret\r\nsecond secret"))
+
+	tests := []struct {
+		line string
+		err  bool
+	}{
+		{line: "first secret"},
+		{line: "second secret"},
+		{err: true},
+	}
+
+	for _, test := range tests {
+		line, err := readLine(r)
+		if test.err {
+			if err == nil {
+				t.Errorf("expected err but actual nil")
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+		if line != test.line {
+			t.Errorf("expected line '%s' but actual '%s'", test.line, line)
+		}
+	}
+}
+
+func TestSecretFlag(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(file, []byte("from file 123\n"), 0o600); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	t.Setenv("NOMNEMONIC_TEST_PASSCODE", "101938")
+
+	c := &cli{secret: func(prompt string) (string, error) {
+		return "prompted for " + prompt, nil
+	}}
+
+	tests := []struct {
+		args   []string
+		secret string
+		err    string
+	}{
+		{args: []string{"--password-file", file}, secret: "from file 123"},
+		{args: []string{"--password-env", "NOMNEMONIC_TEST_PASSCODE"}, secret: "101938"},
+		{args: []string{}, secret: "prompted for password: "},
+		{args: []string{"--password-env", "NOMNEMONIC_TEST_UNSET"}, err: "environment variable NOMNEMONIC_TEST_UNSET of the password is not set"},
+	}
+
+	for _, test := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		s := newSecretFlag(fs, "password")
+		if err := fs.Parse(test.args); err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		secret, err := s.read(c)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' but actual %v", test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+		if secret != test.secret {
+			t.Errorf("expected secret '%s' but actual '%s'", test.secret, secret)
+		}
+	}
+}
+
+func TestPasscodeFlag(t *testing.T) {
+	t.Setenv("NOMNEMONIC_TEST_PASSCODE", "101938")
+	t.Setenv("NOMNEMONIC_TEST_HOTP", "otpauth://hotp/alice?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ&counter=0")
+
+	c := &cli{secret: func(prompt string) (string, error) {
+		if prompt == "hotp-secret: " {
+			return "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", nil
+		}
+		return "prompted for " + prompt, nil
+	}}
+
+	tests := []struct {
+		args     []string
+		passcode string
+		err      string
+	}{
+		{args: []string{"--passcode-env", "NOMNEMONIC_TEST_PASSCODE"}, passcode: "101938"},
+		{args: []string{}, passcode: "prompted for passcode: "},
+		{args: []string{"--hotp-counter", "3"}, passcode: "969429"},
+		{args: []string{"--hotp-counter", "0", "--hotp-secret-env", "NOMNEMONIC_TEST_HOTP"}, passcode: "755224"},
+		{args: []string{"--hotp-secret-env", "NOMNEMONIC_TEST_HOTP"}, err: "--hotp-counter is required with the hotp secret"},
+		{args: []string{"--hotp-counter", "1", "--hotp-secret-env", "NOMNEMONIC_TEST_PASSCODE"}, err: "invalid base32 hotp secret"},
+	}
+
+	for _, test := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		p := newPasscodeFlag(fs)
+		if err := fs.Parse(test.args); err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		passcode, err := p.read(c)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' but actual %v", test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+		if passcode != test.passcode {
+			t.Errorf("expected passcode '%s' but actual '%s'", test.passcode, passcode)
+		}
+	}
+}
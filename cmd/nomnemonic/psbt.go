@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+	"github.com/nomnemonic/nomnemonic/psbt"
+)
+
+func (c *cli) signPSBT(args []string) int {
+	fs, common := c.flags("sign-psbt")
+	in := fs.String("in", "", "file of the psbt, base64 or binary")
+	sf := newSeedFlags(fs)
+	m, _, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+	if *in == "" {
+		return c.fail(errors.New("--in is required"), exitUsage)
+	}
+	b, err := os.ReadFile(*in)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	var p *psbt.Packet
+	if bytes.HasPrefix(b, []byte("psbt\xff")) {
+		p, err = psbt.Parse(b)
+	} else {
+		p, err = psbt.ParseBase64(string(b))
+	}
+	if err != nil {
+		return c.fail(err, exitInvalid)
+	}
+
+	seed, code := c.seedOf(m, sf, fs.Args())
+	if seed == nil {
+		return code
+	}
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	signed, err := p.Sign(master)
+	if err != nil {
+		return c.fail(err, exitInvalid)
+	}
+	if signed == 0 {
+		return c.fail(fmt.Errorf("no input to sign for the fingerprint %x", master.Fingerprint()), exitInvalid)
+	}
+
+	r := newResult(*common.language)
+	r.Fingerprint = hex.EncodeToString(master.Fingerprint())
+	r.PSBT = p.Base64()
+	r.Signed = signed
+	return c.write(r, *common.output, r.PSBT)
+}
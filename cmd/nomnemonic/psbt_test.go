@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	// _unsignedPSBT spends a p2wpkh output of m/84'/0'/0'/0/0 of the
+	// abandon about words
+	_unsignedPSBT = "cHNidP8BAFICAAAAAevOSKz3VmT4BGgwBOININjW6kmyJdGStVGuZBZ5hpD6AAAAAAD9////AZBfAQAAAAAAFgAUAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAEBHxAnAAAAAAAAFgAUwM681sPTyox13F7GLr5VMw75EOIiBgMw1U/Q3UIKbl+NNiT180gsrjUPedXwdTv1vu+cLZGvPBhzxdoKVAAAgAAAAIAAAACAAAAAAAAAAAAAAA=="
+	_signedPSBT   = "cHNidP8BAFICAAAAAevOSKz3VmT4BGgwBOININjW6kmyJdGStVGuZBZ5hpD6AAAAAAD9////AZBfAQAAAAAAFgAUAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAEBHxAnAAAAAAAAFgAUwM681sPTyox13F7GLr5VMw75EOIiBgMw1U/Q3UIKbl+NNiT180gsrjUPedXwdTv1vu+cLZGvPBhzxdoKVAAAgAAAAIAAAACAAAAAAAAAAAAiAgMw1U/Q3UIKbl+NNiT180gsrjUPedXwdTv1vu+cLZGvPEcwRAIgMco4ELefcC+YqTMVBjblbf5lGN0CZ6IorsqBFCWzljwCIEWSG2JWhRcXtiWVL0udP1lwgopUp365Koh0g8gIEwa1AQAA"
+)
+
+func TestSignPSBT(t *testing.T) {
+	dir := t.TempDir()
+	unsigned := filepath.Join(dir, "unsigned.psbt")
+	binary := filepath.Join(dir, "unsigned.bin")
+	invalid := filepath.Join(dir, "invalid.psbt")
+	raw, _ := base64.StdEncoding.DecodeString(_unsignedPSBT)
+	for name, data := range map[string][]byte{unsigned: []byte(_unsignedPSBT + "\n"), binary: raw, invalid: []byte("psbt")} {
+		if err := os.WriteFile(name, data, 0o600); err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+	}
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			name:   "base64",
+			args:   []string{"sign-psbt", "--in", unsigned, "--passphrase-file", empty, _abandonAbout},
+			stdout: _signedPSBT + "\n",
+		},
+		{
+			name:   "binary",
+			args:   []string{"sign-psbt", "--in", binary, "--passphrase-file", empty, _abandonAbout},
+			stdout: _signedPSBT + "\n",
+		},
+		{
+			name:   "no matching input",
+			args:   []string{"sign-psbt", "--in", unsigned, "--passphrase-file", empty, "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+			code:   exitInvalid,
+			stderr: "nomnemonic: no input to sign for the fingerprint",
+		},
+		{
+			name:   "invalid psbt",
+			args:   []string{"sign-psbt", "--in", invalid, _abandonAbout},
+			code:   exitInvalid,
+			stderr: "nomnemonic: psbt: invalid magic\n",
+		},
+		{
+			name:   "missing in",
+			args:   []string{"sign-psbt", _abandonAbout},
+			code:   exitUsage,
+			stderr: "nomnemonic: --in is required\n",
+		},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		c := &cli{
+			stdin:  bufio.NewReader(strings.NewReader("")),
+			stdout: &stdout,
+			stderr: &stderr,
+		}
+
+		code := c.run(test.args)
+		if code != test.code {
+			t.Errorf("%s: expected exit code %d but actual %d (%s)", test.name, test.code, code, stderr.String())
+		}
+		if test.stdout != "" && stdout.String() != test.stdout {
+			t.Errorf("%s: expected stdout '%s' but actual '%s'", test.name, test.stdout, stdout.String())
+		}
+		if test.stderr != "" && !strings.HasPrefix(stderr.String(), test.stderr) {
+			t.Errorf("%s: expected stderr '%s' but actual '%s'", test.name, test.stderr, stderr.String())
+		}
+	}
+}
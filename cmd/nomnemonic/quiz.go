@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func (c *cli) quiz(args []string) int {
+	fs, common := c.flags("quiz")
+	identifier := fs.String("identifier", "", "identifier, prompted for when not given")
+	size := fs.Int("size", 24, "number of words: 12, 15, 18, 21 or 24")
+	questions := fs.Int("questions", 5, "number of word positions to ask")
+	passwordFlag := newSecretFlag(fs, "password")
+	passcodeFlag := newPasscodeFlag(fs)
+	m, _, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+	if *questions < 1 || *questions > *size {
+		return c.fail(fmt.Errorf("questions must be between 1 and %d", *size), exitUsage)
+	}
+
+	if *identifier == "" {
+		fmt.Fprint(c.stderr, "identifier: ")
+		line, err := readLine(c.stdin)
+		if err != nil {
+			return c.fail(errors.New("no identifier given"), exitError)
+		}
+		*identifier = line
+	}
+	password, err := passwordFlag.read(c)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	passcode, err := passcodeFlag.read(c)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	words, err := m.Generate(*identifier, password, passcode, *size)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+
+	// the answers are read like secrets and a wrong answer never shows the
+	// expected word, so the phrase is not displayed
+	correct := 0
+	for _, i := range quizPositions(len(words), *questions) {
+		answer, err := c.secret(fmt.Sprintf("word %d: ", i+1))
+		if err != nil {
+			return c.fail(err, exitError)
+		}
+		if strings.TrimSpace(strings.ToLower(answer)) == words[i] {
+			correct++
+			fmt.Fprintln(c.stdout, "correct")
+			continue
+		}
+		fmt.Fprintln(c.stdout, "wrong")
+	}
+
+	fmt.Fprintf(c.stdout, "%d/%d correct\n", correct, *questions)
+	if correct != *questions {
+		return exitMismatch
+	}
+	return exitOK
+}
+
+// quizPositions picks count distinct random positions of n words with a
+// partial Fisher-Yates shuffle
+func quizPositions(n, count int) []int {
+	positions := make([]int, n)
+	for i := range positions {
+		positions[i] = i
+	}
+	for i := 0; i < count; i++ {
+		j := i + pick(n-i)
+		positions[i], positions[j] = positions[j], positions[i]
+	}
+	return positions[:count]
+}
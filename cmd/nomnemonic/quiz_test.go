@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestQuiz(t *testing.T) {
+	originalPick := pick
+	defer func() { pick = originalPick }()
+	pick = func(n int) int { return 0 }
+
+	// dress mule bonus ... for nomnemonic_test, test12345678 and 101938
+	tests := []struct {
+		name    string
+		answers []string
+		code    int
+		stdout  string
+	}{
+		{name: "all correct", answers: []string{"dress", " Mule ", "bonus"}, stdout: "correct\ncorrect\ncorrect\n3/3 correct\n"},
+		{name: "one wrong", answers: []string{"dress", "mole", "bonus"}, code: exitMismatch, stdout: "correct\nwrong\ncorrect\n2/3 correct\n"},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		secrets := append([]string{"test12345678", "101938"}, test.answers...)
+		c := &cli{
+			stdin:  bufio.NewReader(strings.NewReader("nomnemonic_test\n")),
+			stdout: &stdout,
+			stderr: &stderr,
+			secret: func(prompt string) (string, error) {
+				s := secrets[0]
+				secrets = secrets[1:]
+				return s, nil
+			},
+		}
+
+		code := c.run([]string{"quiz", "--questions", "3"})
+		if code != test.code {
+			t.Errorf("%s: expected exit code %d but actual %d (%s)", test.name, test.code, code, stderr.String())
+		}
+		if stdout.String() != test.stdout {
+			t.Errorf("%s: expected stdout '%s' but actual '%s'", test.name, test.stdout, stdout.String())
+		}
+	}
+}
+
+func TestQuizPositions(t *testing.T) {
+	originalPick := pick
+	defer func() { pick = originalPick }()
+
+	seen := map[int]bool{}
+	for _, p := range quizPositions(12, 12) {
+		if seen[p] || p < 0 || p >= 12 {
+			t.Fatalf("expected distinct positions but actual %d twice or out of range", p)
+		}
+		seen[p] = true
+	}
+
+	pick = func(n int) int { return n - 1 }
+	actual := quizPositions(5, 3)
+	expected := []int{4, 0, 1}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("expected %v but actual %v", expected, actual)
+			break
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic/slip39"
+)
+
+const (
+	_schemeXOR    = "xor"
+	_schemeSLIP39 = "slip39"
+)
+
+// shareFlags are the flags of split and combine, the slip39 word list is not
+// embedded so it comes from a file
+type shareFlags struct {
+	scheme     *string
+	wordlist   *string
+	prompt     *bool
+	passphrase *secretFlag
+}
+
+func newShareFlags(fs *flag.FlagSet) *shareFlags {
+	return &shareFlags{
+		scheme:     fs.String("scheme", _schemeXOR, "xor (Seed XOR) or slip39"),
+		wordlist:   fs.String("slip39-wordlist", "", "file of a custom 1024 slip39 words list, a word per line, the official list by default"),
+		prompt:     fs.Bool("passphrase", false, "prompt for the slip39 passphrase"),
+		passphrase: newSecretFlag(fs, "passphrase"),
+	}
+}
+
+// shamir returns the slip39 splitter of the word list file and the passphrase
+func (c *cli) shamir(f *shareFlags) (slip39.Shamir, string, error) {
+	words := slip39.Wordlist()
+	if *f.wordlist != "" {
+		var err error
+		if words, err = readWordlist(*f.wordlist); err != nil {
+			return nil, "", err
+		}
+	}
+	s, err := slip39.New(words)
+	if err != nil {
+		return nil, "", err
+	}
+	passphrase, err := c.passphrase(*f.prompt, f.passphrase)
+	return s, passphrase, err
+}
+
+// parseGroups parses groups like 2of3,3of5 into member thresholds and counts
+func parseGroups(s string) ([]slip39.Group, error) {
+	var groups []slip39.Group
+	for _, g := range strings.Split(s, ",") {
+		parts := strings.Split(strings.TrimSpace(g), "of")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid group %s, expected like 2of3", g)
+		}
+		threshold, err1 := strconv.Atoi(parts[0])
+		count, err2 := strconv.Atoi(parts[1])
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("invalid group %s, expected like 2of3", g)
+		}
+		groups = append(groups, slip39.Group{Threshold: threshold, Count: count})
+	}
+	return groups, nil
+}
+
+func (c *cli) split(args []string) int {
+	fs, common := c.flags("split")
+	sf := newShareFlags(fs)
+	parts := fs.Int("parts", 3, "number of Seed XOR parts")
+	groupsFlag := fs.String("groups", "2of3", "slip39 member threshold and count of each group, like 2of3,3of5")
+	groupThreshold := fs.Int("group-threshold", 1, "number of slip39 groups needed to recover")
+	m, sep, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+	words, err := c.words(fs.Args())
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	if ok, err := m.IsValid(words); err != nil || !ok {
+		return c.fail(invalid(err), exitInvalid)
+	}
+	words = expanded(m, words)
+
+	r := newResult(*common.language)
+	var lines []string
+	switch *sf.scheme {
+	case _schemeXOR:
+		if r.Shares, err = m.SplitXOR(words, *parts); err != nil {
+			return c.fail(err, exitUsage)
+		}
+		for i, share := range r.Shares {
+			lines = append(lines, fmt.Sprintf("part %d: %s", i+1, strings.Join(share, sep)))
+		}
+	case _schemeSLIP39:
+		groups, err := parseGroups(*groupsFlag)
+		if err != nil {
+			return c.fail(err, exitUsage)
+		}
+		s, passphrase, err := c.shamir(sf)
+		if err != nil {
+			return c.fail(err, exitError)
+		}
+		mnemonics, err := slip39.FromBIP39(m, s, words, passphrase, *groupThreshold, groups)
+		if err != nil {
+			return c.fail(err, exitUsage)
+		}
+		for g, group := range mnemonics {
+			for i, share := range group {
+				r.Shares = append(r.Shares, share)
+				lines = append(lines, fmt.Sprintf("group %d share %d: %s", g+1, i+1, strings.Join(share, " ")))
+			}
+		}
+	default:
+		return c.fail(fmt.Errorf("unsupported scheme %s", *sf.scheme), exitUsage)
+	}
+	return c.write(r, *common.output, strings.Join(lines, "\n"))
+}
+
+func (c *cli) combine(args []string) int {
+	fs, common := c.flags("combine")
+	sf := newShareFlags(fs)
+	m, sep, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+
+	var (
+		validate func(share []string) error
+		combine  func(shares [][]string) ([]string, error)
+	)
+	switch *sf.scheme {
+	case _schemeXOR:
+		validate = func(share []string) error {
+			ok, err := m.IsValid(share)
+			if err != nil || !ok {
+				return invalid(err)
+			}
+			return nil
+		}
+		combine = m.CombineXOR
+	case _schemeSLIP39:
+		s, passphrase, err := c.shamir(sf)
+		if err != nil {
+			return c.fail(err, exitError)
+		}
+		validate = s.Validate
+		combine = func(shares [][]string) ([]string, error) {
+			return slip39.ToBIP39(m, s, shares, passphrase)
+		}
+	default:
+		return c.fail(fmt.Errorf("unsupported scheme %s", *sf.scheme), exitUsage)
+	}
+
+	shares := c.readShares(validate)
+	words, err := combine(shares)
+	if err != nil {
+		return c.fail(err, exitInvalid)
+	}
+	r := newResult(*common.language)
+	r.Words = words
+	return c.write(r, *common.output, strings.Join(words, sep))
+}
+
+// readShares reads a share per line until an empty line or the end of the
+// input, each share is validated as it is entered and asked again when it is
+// not valid
+func (c *cli) readShares(validate func(share []string) error) [][]string {
+	var shares [][]string
+	for {
+		share, err := c.secret(fmt.Sprintf("share %d (empty line to finish): ", len(shares)+1))
+		if err != nil || strings.TrimSpace(share) == "" {
+			return shares
+		}
+		words := strings.Fields(share)
+		if err := validate(words); err != nil {
+			fmt.Fprintf(c.stderr, "share %d: %s, enter it again\n", len(shares)+1, err.Error())
+			continue
+		}
+		shares = append(shares, words)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSplitCombine(t *testing.T) {
+	const words = "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	wordlist := filepath.Join(t.TempDir(), "slip39.txt")
+	var list strings.Builder
+	for i := 0; i < 1024; i++ {
+		fmt.Fprintf(&list, "w%04d\n", i)
+	}
+	if err := os.WriteFile(wordlist, []byte(list.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		split  []string
+		pick   []int // indexes of the shares entered to combine
+		typo   bool  // enter a share with a typo first
+		flags  []string
+		code   int
+		stderr string
+	}{
+		{name: "xor", split: []string{"--parts", "3"}, pick: []int{0, 1, 2}},
+		{name: "xor with a typo", split: []string{"--parts", "2"}, pick: []int{0, 1}, typo: true, stderr: "share 1: invalid checksum, enter it again\n"},
+		{name: "xor single part", split: []string{"--parts", "2"}, pick: []int{0}, code: exitInvalid, stderr: "nomnemonic: seed xor needs at least 2 parts\n"},
+		{
+			name:  "slip39",
+			split: []string{"--groups", "2of3,1of1", "--group-threshold", "1"},
+			pick:  []int{0, 2},
+			flags: []string{"--scheme", "slip39", "--slip39-wordlist", wordlist},
+		},
+		{
+			name:  "slip39 official list",
+			split: []string{"--groups", "2of3"},
+			pick:  []int{2, 0},
+			flags: []string{"--scheme", "slip39"},
+		},
+		{
+			name:   "slip39 with a typo",
+			split:  []string{"--groups", "2of3"},
+			pick:   []int{1, 2},
+			typo:   true,
+			flags:  []string{"--scheme", "slip39", "--slip39-wordlist", wordlist},
+			stderr: "share 1: invalid checksum, enter it again\n",
+		},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		c := &cli{stdin: bufio.NewReader(strings.NewReader("")), stdout: &stdout, stderr: &stderr}
+		args := append(append(append([]string{"split"}, test.flags...), test.split...), "--output", "json", words)
+		if code := c.run(args); code != exitOK {
+			t.Fatalf("%s: expected split exit code 0 but actual %d (%s)", test.name, code, stderr.String())
+		}
+		shares := jsonShares(t, stdout.Bytes())
+
+		var secrets []string
+		if test.typo {
+			typo := append([]string{}, shares[test.pick[0]]...)
+			typo[0], typo[1] = typo[1], typo[0]
+			secrets = append(secrets, strings.Join(typo, " "))
+		}
+		for _, i := range test.pick {
+			secrets = append(secrets, strings.Join(shares[i], " "))
+		}
+		secrets = append(secrets, "")
+
+		stdout.Reset()
+		stderr.Reset()
+		c.secret = func(prompt string) (string, error) {
+			if len(secrets) == 0 {
+				return "", io.EOF
+			}
+			s := secrets[0]
+			secrets = secrets[1:]
+			return s, nil
+		}
+		code := c.run(append([]string{"combine"}, test.flags...))
+		if code != test.code {
+			t.Errorf("%s: expected combine exit code %d but actual %d (%s)", test.name, test.code, code, stderr.String())
+			continue
+		}
+		if test.code == exitOK && stdout.String() != words+"\n" {
+			t.Errorf("%s: expected '%s' but actual '%s'", test.name, words, stdout.String())
+		}
+		if test.stderr != "" && stderr.String() != test.stderr {
+			t.Errorf("%s: expected stderr '%s' but actual '%s'", test.name, test.stderr, stderr.String())
+		}
+	}
+}
+
+func TestParseGroups(t *testing.T) {
+	groups, err := parseGroups("2of3, 3of5")
+	if err != nil || len(groups) != 2 || groups[1].Threshold != 3 || groups[1].Count != 5 {
+		t.Errorf("expected 2 groups but actual %v %v", groups, err)
+	}
+	if _, err := parseGroups("2/3"); err == nil || err.Error() != "invalid group 2/3, expected like 2of3" {
+		t.Errorf("expected invalid group error but actual %v", err)
+	}
+}
+
+func jsonShares(t *testing.T, data []byte) [][]string {
+	var r result
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatal(err)
+	}
+	return r.Shares
+}
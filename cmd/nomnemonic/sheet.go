@@ -0,0 +1,172 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nomnemonic/nomnemonic"
+	"github.com/nomnemonic/nomnemonic/bip32"
+	"github.com/nomnemonic/nomnemonic/qr"
+)
+
+const (
+	_sheetHTML = "html"
+	_sheetPDF  = "pdf"
+)
+
+// now is the creation date of the sheets, replaced in tests
+var now = time.Now
+
+// sheet is the content of a recovery sheet, the blank sheet has empty words
+// and metadata to write by hand
+type sheet struct {
+	Words       []string
+	Language    string
+	Fingerprint string
+	Created     string
+	Version     string
+	Algorithm   string
+	QR          [][]bool
+}
+
+func (c *cli) sheet(args []string) int {
+	fs, common := c.flags("sheet")
+	format := fs.String("format", _sheetHTML, "sheet format: html or pdf")
+	out := fs.String("out", "", "write the sheet to the file instead of stdout")
+	blank := fs.Bool("blank", false, "empty word boxes to write the words by hand")
+	size := fs.Int("size", 24, "number of word boxes of the blank sheet")
+	seedQR := fs.String("qr", "", "add the standard or compact SeedQR code")
+	m, _, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+	if *format != _sheetHTML && *format != _sheetPDF {
+		return c.fail(fmt.Errorf("unsupported sheet format %s", *format), exitUsage)
+	}
+
+	s := sheet{
+		Language:  *common.language,
+		Version:   nomnemonic.Version,
+		Algorithm: nomnemonic.VersionAlgorithm,
+	}
+	if *blank {
+		if *size < 1 || *size > 24 {
+			return c.fail(errors.New("size must be between 1 and 24"), exitUsage)
+		}
+		s.Words = make([]string, *size)
+	} else {
+		words, err := c.words(fs.Args())
+		if err != nil {
+			return c.fail(err, exitError)
+		}
+		if ok, err := m.IsValid(words); err != nil || !ok {
+			return c.fail(invalid(err), exitInvalid)
+		}
+		words = expanded(m, words)
+		if err := s.fill(m, words, *seedQR); err != nil {
+			return c.fail(err, exitUsage)
+		}
+	}
+
+	w := c.stdout
+	if *out != "" {
+		f, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+		if err != nil {
+			return c.fail(err, exitError)
+		}
+		defer f.Close()
+		w = f
+	}
+	var err error
+	if *format == _sheetPDF {
+		err = s.writePDF(w)
+	} else {
+		err = s.writeHTML(w)
+	}
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	return exitOK
+}
+
+// fill sets the words, their fingerprint without passphrase, the creation
+// date and the SeedQR code of the kind
+func (s *sheet) fill(m nomnemonic.Mnemonicer, words []string, seedQR string) error {
+	seed, err := m.GenerateSeed(strings.Join(words, " "), "")
+	if err != nil {
+		return err
+	}
+	key, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return err
+	}
+	s.Words = words
+	s.Fingerprint = fmt.Sprintf("%x", key.Fingerprint())
+	s.Created = now().Format("2006-01-02")
+
+	if seedQR == "" {
+		return nil
+	}
+	var content string
+	switch seedQR {
+	case "standard":
+		content, err = m.EncodeSeedQR(words)
+	case "compact":
+		var data []byte
+		data, err = m.EncodeCompactSeedQR(words)
+		content = string(data)
+	default:
+		err = fmt.Errorf("unsupported seedqr %s", seedQR)
+	}
+	if err != nil {
+		return err
+	}
+	s.QR, err = qr.Bitmap([]byte(content), qr.Low)
+	return err
+}
+
+var _sheetTemplate = template.Must(template.New("sheet").Funcs(template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>nomnemonic recovery sheet</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table.meta td { padding: 0.2em 1em 0.2em 0; }
+.words { display: grid; grid-template-columns: repeat(3, 1fr); gap: 0.5em; margin: 1.5em 0; }
+.word { border: 1px solid #000; padding: 0.6em; min-height: 1.2em; font-family: monospace; font-size: 1.2em; }
+.word span { color: #666; display: inline-block; width: 2em; }
+.blank { border-bottom: 1px solid #000; display: inline-block; min-width: 12em; }
+.qr { border-collapse: collapse; }
+.qr td { width: 4px; height: 4px; padding: 0; }
+.qr td.on { background: #000; }
+</style>
+</head>
+<body>
+<h1>Recovery sheet</h1>
+<table class="meta">
+<tr><td>Language</td><td>{{.Language}}</td></tr>
+<tr><td>Fingerprint</td><td>{{if .Fingerprint}}{{.Fingerprint}}{{else}}<span class="blank"></span>{{end}}</td></tr>
+<tr><td>Created</td><td>{{if .Created}}{{.Created}}{{else}}<span class="blank"></span>{{end}}</td></tr>
+<tr><td>Algorithm</td><td>nomnemonic {{.Version}}, algorithm {{.Algorithm}}</td></tr>
+</table>
+<div class="words">
+{{range $i, $w := .Words}}<div class="word"><span>{{inc $i}}</span>{{$w}}</div>
+{{end}}</div>
+{{if .QR}}<table class="qr">
+{{range .QR}}<tr>{{range .}}<td{{if .}} class="on"{{end}}></td>{{end}}</tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`))
+
+func (s *sheet) writeHTML(w io.Writer) error {
+	return _sheetTemplate.Execute(w, s)
+}
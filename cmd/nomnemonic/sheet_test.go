@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSheet(t *testing.T) {
+	now = func() time.Time { return time.Date(2022, 11, 4, 0, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	const words = "forum undo fragile fade shy sign arrest garment culture tube off merit"
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "html",
+			args:     []string{"sheet", words},
+			contains: []string{"<span>1</span>forum</div>", "<span>12</span>merit</div>", "2022-11-04", "algorithm 3.0.0"},
+			excludes: []string{`class="qr"`},
+		},
+		{
+			name:     "html with seedqr",
+			args:     []string{"sheet", "--qr", "standard", words},
+			contains: []string{`<table class="qr">`, `<td class="on">`},
+		},
+		{
+			name:     "blank html",
+			args:     []string{"sheet", "--blank", "--size", "12"},
+			contains: []string{"<span>12</span></div>", `<span class="blank"></span>`},
+			excludes: []string{"<span>13</span>", "2022-11-04"},
+		},
+		{
+			name:     "pdf",
+			args:     []string{"sheet", "--format", "pdf", "--qr", "compact", words},
+			contains: []string{"%PDF-1.4", "(forum) Tj", "(merit) Tj", "(2022-11-04) Tj", "re f\n", "%%EOF"},
+		},
+		{
+			name: "unsupported seedqr",
+			args: []string{"sheet", "--qr", "tiny", words},
+			code: exitUsage,
+		},
+		{
+			name:     "blank pdf",
+			args:     []string{"sheet", "--format", "pdf", "--blank"},
+			contains: []string{"(24) Tj", " l S\n"},
+			excludes: []string{"(25) Tj"},
+		},
+		{
+			name: "invalid words",
+			args: []string{"sheet", "forum undo fragile fade shy sign arrest garment culture tube off off"},
+			code: exitInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		c := &cli{
+			stdin:  bufio.NewReader(strings.NewReader("")),
+			stdout: &stdout,
+			stderr: &stderr,
+		}
+
+		code := c.run(test.args)
+		if code != test.code {
+			t.Errorf("%s: expected exit code %d but actual %d (%s)", test.name, test.code, code, stderr.String())
+		}
+		for _, s := range test.contains {
+			if !strings.Contains(stdout.String(), s) {
+				t.Errorf("%s: expected output to contain '%s'", test.name, s)
+			}
+		}
+		for _, s := range test.excludes {
+			if strings.Contains(stdout.String(), s) {
+				t.Errorf("%s: expected output not to contain '%s'", test.name, s)
+			}
+		}
+	}
+}
+
+func TestPDFString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		err      bool
+	}{
+		{input: "abeja", expected: "(abeja)"},
+		{input: "a(b)\\", expected: `(a\(b\)\\)`},
+		{input: "élève", expected: `(\351l\350ve)`},
+		{input: "가격", err: true},
+	}
+
+	for _, test := range tests {
+		actual, err := pdfString(test.input)
+		if (err != nil) != test.err {
+			t.Errorf("%s: expected error %v but actual %v", test.input, test.err, err)
+		}
+		if actual != test.expected {
+			t.Errorf("expected %s but actual %s", test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// the alternate screen keeps the words out of the terminal scrollback
+	_altScreenOn  = "\x1b[?1049h"
+	_altScreenOff = "\x1b[?1049l"
+	_clearScreen  = "\x1b[2J\x1b[H"
+
+	_quizQuestions = 3
+)
+
+// pick returns a random int in [0, n)
+var pick = func(n int) int {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		panic(err)
+	}
+	return int(i.Int64())
+}
+
+// tui is the guided wizard, it asks the inputs, reveals the words one at a
+// time and quizzes the user before leaving the alternate screen
+func (c *cli) tui(args []string) int {
+	fs, common := c.flags("tui")
+	m, _, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+
+	fmt.Fprint(c.stdout, _altScreenOn)
+	defer fmt.Fprint(c.stdout, _clearScreen+_altScreenOff)
+	c.screen("nomnemonic wizard\n\nThe words are never written to the terminal scrollback.")
+
+	identifier, ok := c.ask("identifier (at least 2 chars): ", func(s string) error {
+		if len(s) < 2 {
+			return fmt.Errorf("identifier must be at least 2 chars")
+		}
+		return nil
+	})
+	if !ok {
+		return exitError
+	}
+
+	password := ""
+	for password == "" {
+		p, err := c.secret("password: ")
+		if err != nil {
+			return c.fail(err, exitError)
+		}
+		bits := passwordStrength(p)
+		fmt.Fprintf(c.stdout, "strength: %s ~%d bits\n", strengthMeter(bits), bits)
+		if len(p) < 12 {
+			fmt.Fprintln(c.stdout, "password must be at least 12 chars")
+			continue
+		}
+		password = p
+	}
+
+	passcode := ""
+	for passcode == "" {
+		p, err := c.secret("passcode (6 digits): ")
+		if err != nil {
+			return c.fail(err, exitError)
+		}
+		if _, err := strconv.Atoi(p); err != nil || len(p) != 6 {
+			fmt.Fprintln(c.stdout, "passcode must be 6 digits")
+			continue
+		}
+		confirm, err := c.secret("confirm passcode: ")
+		if err != nil {
+			return c.fail(err, exitError)
+		}
+		if confirm != p {
+			fmt.Fprintln(c.stdout, "passcodes do not match")
+			continue
+		}
+		passcode = p
+	}
+
+	size := 0
+	_, ok = c.ask("number of words (12, 15, 18, 21, 24) [24]: ", func(s string) error {
+		if s == "" {
+			s = "24"
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 12 || n > 24 || n%3 != 0 {
+			return fmt.Errorf("number of words must be 12, 15, 18, 21 or 24")
+		}
+		size = n
+		return nil
+	})
+	if !ok {
+		return exitError
+	}
+
+	fmt.Fprintln(c.stdout, "generating, this takes a few seconds...")
+	words, err := m.Generate(identifier, password, passcode, size)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+
+	for i, w := range words {
+		c.screen(fmt.Sprintf("word %d/%d\n\n    %d. %s\n\nwrite it down and press enter", i+1, len(words), i+1, w))
+		if _, err := readLine(c.stdin); err != nil {
+			return c.fail(err, exitError)
+		}
+	}
+
+	c.screen("quiz, type the requested words")
+	for q := 0; q < _quizQuestions; q++ {
+		i := pick(len(words))
+		for {
+			answer, err := c.secret(fmt.Sprintf("word %d: ", i+1))
+			if err != nil {
+				return c.fail(err, exitError)
+			}
+			if strings.TrimSpace(strings.ToLower(answer)) == words[i] {
+				fmt.Fprintln(c.stdout, "correct")
+				break
+			}
+			fmt.Fprintln(c.stdout, "wrong, check your backup and try again")
+		}
+	}
+
+	c.screen("done, the words are verified. press enter to clear the screen")
+	readLine(c.stdin)
+	return exitOK
+}
+
+func (c *cli) screen(text string) {
+	fmt.Fprint(c.stdout, _clearScreen+text+"\n\n")
+}
+
+// ask prompts until valid returns nil, false is returned when the input ends
+func (c *cli) ask(prompt string, valid func(string) error) (string, bool) {
+	for {
+		fmt.Fprint(c.stdout, prompt)
+		line, err := readLine(c.stdin)
+		if err != nil {
+			fmt.Fprintln(c.stderr, "nomnemonic: input closed")
+			return "", false
+		}
+		if err := valid(line); err != nil {
+			fmt.Fprintln(c.stdout, err.Error())
+			continue
+		}
+		return line, true
+	}
+}
+
+// passwordStrength estimates the password entropy in bits from its length
+// and the char classes it uses, an upper bound for human passwords
+func passwordStrength(password string) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	pool := 0
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {other, 33}} {
+		if class.used {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+	return int(float64(len([]rune(password))) * math.Log2(float64(pool)))
+}
+
+func strengthMeter(bits int) string {
+	filled := bits / 16
+	if filled > 8 {
+		filled = 8
+	}
+	label := "weak"
+	switch {
+	case bits >= 100:
+		label = "strong"
+	case bits >= 64:
+		label = "fair"
+	}
+	return "[" + strings.Repeat("#", filled) + strings.Repeat(".", 8-filled) + "] " + label
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTUI(t *testing.T) {
+	words := strings.Fields("dress mule bonus strong village clip volcano public plug fossil travel lobster nerve love gospel dance shove vicious valve else roof observe warrior magic")
+
+	// identifier twice for the validation, the size, an enter for each
+	// revealed word and the final enter
+	stdin := "x\nnomnemonic_test\n\n" + strings.Repeat("\n", len(words)) + "\n"
+	secrets := []string{
+		"short", "test12345678", // password
+		"12345", "101938", "101939", "101938", "101938", // passcode and confirmations
+		"wrong", words[3], words[3], words[0], // quiz answers
+	}
+	questions := []int{3, 3, 0}
+
+	originalPick := pick
+	defer func() { pick = originalPick }()
+	pick = func(n int) int {
+		q := questions[0]
+		questions = questions[1:]
+		return q
+	}
+
+	var stdout, stderr bytes.Buffer
+	c := &cli{
+		stdin:  bufio.NewReader(strings.NewReader(stdin)),
+		stdout: &stdout,
+		stderr: &stderr,
+		secret: func(prompt string) (string, error) {
+			s := secrets[0]
+			secrets = secrets[1:]
+			return s, nil
+		},
+	}
+
+	if code := c.run([]string{"tui"}); code != exitOK {
+		t.Errorf("expected exit code %d but actual %d (%s)", exitOK, code, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, expected := range []string{
+		_altScreenOn,
+		"identifier must be at least 2 chars",
+		"password must be at least 12 chars",
+		"passcode must be 6 digits",
+		"passcodes do not match",
+		"word 24/24\n\n    24. magic",
+		"wrong, check your backup and try again",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected output to contain %q", expected)
+		}
+	}
+	if !strings.HasSuffix(out, _clearScreen+_altScreenOff) {
+		t.Errorf("expected the screen to be cleared before leaving the alternate screen")
+	}
+	if len(secrets) != 0 {
+		t.Errorf("expected every secret to be read but %d left", len(secrets))
+	}
+}
+
+func TestPasswordStrength(t *testing.T) {
+	tests := []struct {
+		password string
+		bits     int
+		meter    string
+	}{
+		{password: "", bits: 0, meter: "[........] weak"},
+		{password: "test12345678", bits: 62, meter: "[###.....] weak"},
+		{password: "Correct Horse Battery Staple 9", bits: 197, meter: "[########] strong"},
+	}
+
+	for _, test := range tests {
+		bits := passwordStrength(test.password)
+		if bits != test.bits {
+			t.Errorf("expected %d bits for '%s' but actual %d", test.bits, test.password, bits)
+		}
+		if meter := strengthMeter(bits); meter != test.meter {
+			t.Errorf("expected meter '%s' but actual '%s'", test.meter, meter)
+		}
+	}
+}
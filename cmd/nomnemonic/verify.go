@@ -0,0 +1,60 @@
+package main
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func (c *cli) verify(args []string) int {
+	fs, common := c.flags("verify")
+	identifier := fs.String("identifier", "", "identifier, prompted for when not given")
+	passwordFlag := newSecretFlag(fs, "password")
+	passcodeFlag := newPasscodeFlag(fs)
+	phraseFlag := newSecretFlag(fs, "phrase")
+	m, _, code := c.parse(fs, common, args)
+	if m == nil {
+		return code
+	}
+
+	if *identifier == "" {
+		fmt.Fprint(c.stderr, "identifier: ")
+		line, err := readLine(c.stdin)
+		if err != nil {
+			return c.fail(errors.New("no identifier given"), exitError)
+		}
+		*identifier = line
+	}
+	password, err := passwordFlag.read(c)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	passcode, err := passcodeFlag.read(c)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+	phrase, err := phraseFlag.read(c)
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+
+	// the size comes from the phrase, so a wrong number of words fails with
+	// the size error before any comparison
+	given := strings.Fields(strings.ToLower(phrase))
+	words, err := m.Generate(*identifier, password, passcode, len(given))
+	if err != nil {
+		return c.fail(err, exitError)
+	}
+
+	match := subtle.ConstantTimeCompare([]byte(strings.Join(words, " ")), []byte(strings.Join(given, " "))) == 1
+	r := newResult(*common.language)
+	r.Valid = &match
+	if !match {
+		if code := c.write(r, *common.output, "mismatch"); code != exitOK {
+			return code
+		}
+		return exitMismatch
+	}
+	return c.write(r, *common.output, "match")
+}
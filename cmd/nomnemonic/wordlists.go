@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+const _japaneseSeparator = "　"
+
+var _wordlists = map[string][]string{
+	"chinese-simplified":  wordlists.ChineseSimplified,
+	"chinese-traditional": wordlists.ChineseTraditional,
+	"czech":               wordlists.Czech,
+	"english":             wordlists.English,
+	"french":              wordlists.French,
+	"italian":             wordlists.Italian,
+	"japanese":            wordlists.Japanese,
+	"korean":              wordlists.Korean,
+	"spanish":             wordlists.Spanish,
+}
+
+// mnemonicer returns the mnemonicer of the language and the separator its
+// words are printed with
+func mnemonicer(language string, options ...nomnemonic.Option) (nomnemonic.Mnemonicer, string, error) {
+	words, ok := _wordlists[language]
+	if !ok {
+		return nil, "", fmt.Errorf("unsupported language %s", language)
+	}
+	m, err := nomnemonic.New(words, options...)
+	if err != nil {
+		return nil, "", err
+	}
+	if language == "japanese" {
+		return m, _japaneseSeparator, nil
+	}
+	return m, " ", nil
+}
+
+func (c *cli) wordlist(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(c.stderr, "usage: nomnemonic wordlist {list,show,check} [flags] [file]")
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet("wordlist "+args[0], flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	language := fs.String("language", "english", "embedded word list language")
+	prefix := fs.Int("prefix", 4, "check the words have unique prefixes of the chars, 0 to skip")
+	sorted := fs.Bool("sorted", true, "check the words are sorted")
+	diff := fs.Bool("diff", false, "diff the file against the embedded language list")
+	if fs.Parse(args[1:]) != nil {
+		return exitUsage
+	}
+	official, ok := _wordlists[*language]
+	if !ok {
+		return c.fail(fmt.Errorf("unsupported language %s", *language), exitUsage)
+	}
+	words := official
+	if fs.NArg() > 0 {
+		var err error
+		if words, err = readWordlist(fs.Arg(0)); err != nil {
+			return c.fail(err, exitError)
+		}
+	}
+
+	switch args[0] {
+	case "list":
+		names := make([]string, 0, len(_wordlists))
+		for name := range _wordlists {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintln(c.stdout, strings.Join(names, "\n"))
+		return exitOK
+	case "show":
+		for i, w := range words {
+			fmt.Fprintf(c.stdout, "%04d %s\n", i, w)
+		}
+		return exitOK
+	case "check":
+		var issues []nomnemonic.WordlistIssue
+		if *diff {
+			issues = nomnemonic.DiffWordlist(official, words)
+		} else {
+			issues = nomnemonic.CheckWordlist(words, *prefix, *sorted)
+		}
+		for _, issue := range issues {
+			fmt.Fprintln(c.stdout, issue.String())
+		}
+		if len(issues) > 0 {
+			return exitInvalid
+		}
+		fmt.Fprintln(c.stdout, "ok")
+		return exitOK
+	}
+	fmt.Fprintf(c.stderr, "unknown wordlist command %s\n", args[0])
+	return exitUsage
+}
+
+// readWordlist reads a word per line, skipping the blank lines
+func readWordlist(path string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var words []string
+	for _, line := range strings.Split(string(b), "\n") {
+		if w := strings.TrimSpace(line); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWordlist(t *testing.T) {
+	custom := append([]string{}, _wordlists["english"]...)
+	custom[1] = "abandon"
+	path := filepath.Join(t.TempDir(), "custom.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(custom, "\n")+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+	}{
+		{
+			name:   "check embedded",
+			args:   []string{"wordlist", "check"},
+			stdout: "ok\n",
+		},
+		{
+			name:   "check custom",
+			args:   []string{"wordlist", "check", path},
+			code:   exitInvalid,
+			stdout: "1 abandon: duplicate of 0\n",
+		},
+		{
+			name:   "diff custom",
+			args:   []string{"wordlist", "check", "--diff", path},
+			code:   exitInvalid,
+			stdout: "1 abandon: official word is ability\n",
+		},
+		{
+			name:   "show",
+			args:   []string{"wordlist", "show", "--language", "italian"},
+			stdout: "0000 abaco\n",
+		},
+		{
+			name: "unknown",
+			args: []string{"wordlist", "sort"},
+			code: exitUsage,
+		},
+		{
+			name: "without command",
+			args: []string{"wordlist"},
+			code: exitUsage,
+		},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		c := &cli{
+			stdin:  bufio.NewReader(strings.NewReader("")),
+			stdout: &stdout,
+			stderr: &stderr,
+		}
+
+		code := c.run(test.args)
+		if code != test.code {
+			t.Errorf("%s: expected exit code %d but actual %d (%s)", test.name, test.code, code, stderr.String())
+		}
+		if !strings.HasPrefix(stdout.String(), test.stdout) {
+			t.Errorf("%s: expected stdout to start with '%s' but actual '%s'", test.name, test.stdout, stdout.String())
+		}
+	}
+}
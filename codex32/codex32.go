@@ -0,0 +1,212 @@
+// Package codex32 encodes master secrets as bip-0093 codex32 strings, the
+// bech32 based backup format with a BCH checksum strong enough to correct
+// errors by hand
+package codex32
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic/internal/bech32"
+)
+
+const (
+	_hrp          = "ms"
+	_secretIndex  = 's'
+	_headerSize   = 6 // threshold, identifier and share index
+	_checksumSize = 13
+	_maxDataSize  = 93 // short codex32 strings
+	_residueInit  = 0x23181b3
+
+	MinSecretSize = 16
+	MaxSecretSize = 32
+)
+
+// the checksum residue is 65 bits wide, kept as a 1 bit high and 64 bits low
+// word
+type residue struct{ hi, lo uint64 }
+
+var (
+	_checksumConst = residue{1, 0x0ce0795c2fd1e62a}
+	_generator     = []residue{
+		{1, 0x9dc500ce73fde210}, {1, 0xbfae00def77fe529}, {1, 0xfbd920fffe7bee52},
+		{1, 0x739640bdeee3fdad}, {0, 0x7729a039cfc75f5a},
+	}
+)
+
+// Share is a decoded codex32 string
+type Share struct {
+	Threshold  int    // 0 for unshared secrets, 2-9 for shares
+	Identifier string // 4 chars identifier shared by all shares of a secret
+	Index      byte   // s for the secret itself
+	Payload    []byte
+}
+
+// Encode encodes the master secret as an unshared codex32 string with the 4
+// chars bech32 identifier
+func Encode(secret []byte, identifier string) (string, error) {
+	if len(secret) < MinSecretSize || len(secret) > MaxSecretSize {
+		return "", fmt.Errorf("secret must be between %d and %d bytes", MinSecretSize, MaxSecretSize)
+	}
+	identifier = strings.ToLower(identifier)
+	if len(identifier) != 4 || strings.Trim(identifier, bech32.Charset) != "" {
+		return "", errors.New("identifier must be 4 bech32 chars")
+	}
+
+	payload, _ := bech32.ConvertBits(secret, 8, 5, true)
+	data := make([]byte, 0, _headerSize+len(payload)+_checksumSize)
+	data = append(data, charValue('0'))
+	for _, c := range identifier + string(rune(_secretIndex)) {
+		data = append(data, charValue(byte(c)))
+	}
+	data = append(data, payload...)
+	data = append(data, checksum(data)...)
+
+	var b strings.Builder
+	b.WriteString(_hrp + "1")
+	for _, v := range data {
+		b.WriteByte(bech32.Charset[v])
+	}
+	return b.String(), nil
+}
+
+// Decode decodes and verifies the codex32 string
+func Decode(s string) (Share, error) {
+	data, err := parse(s)
+	if err != nil {
+		return Share{}, err
+	}
+	if polymod(data) != _checksumConst {
+		return Share{}, errors.New("invalid codex32 checksum")
+	}
+
+	threshold := bech32.Charset[data[0]]
+	if threshold != '0' && (threshold < '2' || threshold > '9') {
+		return Share{}, fmt.Errorf("invalid threshold %c", threshold)
+	}
+	index := bech32.Charset[data[5]]
+	if threshold == '0' && index != _secretIndex {
+		return Share{}, errors.New("unshared secret must have share index s")
+	}
+
+	identifier := make([]byte, 4)
+	for i, v := range data[1:5] {
+		identifier[i] = bech32.Charset[v]
+	}
+
+	// the padding bits are not part of the payload and can be any value
+	values := data[_headerSize : len(data)-_checksumSize]
+	payload, _ := bech32.ConvertBits(values, 5, 8, true)
+	payload = payload[:len(values)*5/8]
+
+	return Share{
+		Threshold:  int(threshold - '0'),
+		Identifier: string(identifier),
+		Index:      index,
+		Payload:    payload,
+	}, nil
+}
+
+// Correct fixes a single substituted char of the codex32 string. The BCH
+// code corrects up to 4 substitutions, larger errors need a full decoder
+func Correct(s string) (string, error) {
+	data, err := parse(s)
+	if err != nil {
+		return "", err
+	}
+	if polymod(data) == _checksumConst {
+		return s, nil
+	}
+
+	var found []byte
+	for i := range data {
+		original := data[i]
+		for v := byte(0); v < 32; v++ {
+			if v == original {
+				continue
+			}
+			data[i] = v
+			if polymod(data) == _checksumConst {
+				if found != nil {
+					return "", errors.New("ambiguous codex32 correction")
+				}
+				found = append([]byte{}, data...)
+			}
+		}
+		data[i] = original
+	}
+	if found == nil {
+		return "", errors.New("codex32 string has more than one error")
+	}
+
+	var b strings.Builder
+	b.WriteString(_hrp + "1")
+	for _, v := range found {
+		b.WriteByte(bech32.Charset[v])
+	}
+	if strings.ToUpper(s) == s {
+		return strings.ToUpper(b.String()), nil
+	}
+	return b.String(), nil
+}
+
+func parse(s string) ([]byte, error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return nil, errors.New("codex32 string has mixed case")
+	}
+	s = strings.ToLower(s)
+	if !strings.HasPrefix(s, _hrp+"1") {
+		return nil, fmt.Errorf("codex32 string must start with %s1", _hrp)
+	}
+
+	data := make([]byte, 0, len(s)-len(_hrp)-1)
+	for _, c := range s[len(_hrp)+1:] {
+		i := strings.IndexRune(bech32.Charset, c)
+		if i < 0 {
+			return nil, fmt.Errorf("invalid codex32 char %q", c)
+		}
+		data = append(data, byte(i))
+	}
+	if len(data) < _headerSize+_checksumSize+MinSecretSize*8/5 || len(data) > _maxDataSize {
+		return nil, fmt.Errorf("invalid codex32 length: %d", len(s))
+	}
+	return data, nil
+}
+
+func charValue(c byte) byte {
+	return byte(strings.IndexByte(bech32.Charset, c))
+}
+
+func checksum(data []byte) []byte {
+	values := append(append([]byte{}, data...), make([]byte, _checksumSize)...)
+	mod := polymod(values)
+	mod.hi ^= _checksumConst.hi
+	mod.lo ^= _checksumConst.lo
+	cs := make([]byte, _checksumSize)
+	for i := range cs {
+		shift := 5 * (_checksumSize - 1 - i)
+		v := mod.lo >> shift
+		if shift > 0 {
+			v |= mod.hi << (64 - shift)
+		}
+		cs[i] = byte(v & 31)
+	}
+	return cs
+}
+
+func polymod(values []byte) residue {
+	r := residue{lo: _residueInit}
+	for _, v := range values {
+		b := r.hi<<4 | r.lo>>60
+		low := r.lo & 0x0fffffffffffffff
+		r = residue{hi: low >> 59, lo: low<<5 | uint64(v)}
+		for i, g := range _generator {
+			if (b>>i)&1 == 1 {
+				r.hi ^= g.hi
+				r.lo ^= g.lo
+			}
+		}
+	}
+	return r
+}
@@ -0,0 +1,125 @@
+package codex32
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		s          string
+		threshold  int
+		identifier string
+		index      byte
+		payload    string
+		err        string
+	}{
+		{
+			s:          "ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlw",
+			identifier: "test",
+			index:      's',
+			payload:    "318c6318c6318c6318c6318c6318c631",
+		},
+		{
+			s:   "ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlq",
+			err: "invalid codex32 checksum",
+		},
+		{
+			s:   "ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmCzlw",
+			err: "codex32 string has mixed case",
+		},
+		{
+			s:   "bc10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlw",
+			err: "codex32 string must start with ms1",
+		},
+	}
+
+	for _, test := range tests {
+		share, err := Decode(test.s)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' for %s but actual %v", test.err, test.s, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.s, err.Error())
+			continue
+		}
+		if share.Threshold != test.threshold || share.Identifier != test.identifier || share.Index != test.index {
+			t.Errorf("expected header %d%s%c but actual %d%s%c", test.threshold, test.identifier, test.index, share.Threshold, share.Identifier, share.Index)
+		}
+		if actual := hex.EncodeToString(share.Payload); actual != test.payload {
+			t.Errorf("expected payload %s but actual %s", test.payload, actual)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	secret, _ := hex.DecodeString("318c6318c6318c6318c6318c6318c631")
+	s, err := Encode(secret, "test")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	// the last payload char differs from the bip-0093 vector only in the
+	// padding bits which are zero when encoding
+	share, err := Decode(s)
+	if err != nil {
+		t.Errorf("unexpected error for %s: %s", s, err.Error())
+	}
+	if hex.EncodeToString(share.Payload) != hex.EncodeToString(secret) {
+		t.Errorf("expected payload %x but actual %x", secret, share.Payload)
+	}
+
+	secret32 := make([]byte, 32)
+	s, err = Encode(secret32, "CASH")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if len(s) != 74 {
+		t.Errorf("expected 74 chars for 32 bytes secret but actual %d", len(s))
+	}
+
+	_, err = Encode(make([]byte, 15), "test")
+	if err == nil || err.Error() != "secret must be between 16 and 32 bytes" {
+		t.Errorf("expected secret size err but actual %v", err)
+	}
+
+	_, err = Encode(secret, "tesb")
+	if err == nil || err.Error() != "identifier must be 4 bech32 chars" {
+		t.Errorf("expected identifier err but actual %v", err)
+	}
+}
+
+func TestCorrect(t *testing.T) {
+	valid := "ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlw"
+
+	tests := []struct {
+		s   string
+		err string
+	}{
+		{s: valid},
+		{s: "ms10testsxxxxxxxxxxxxxxxxqxxxxxxxxx4nzvca9cmczlw"},
+		{s: "ms10tystsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlw"},
+		{s: "ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlq"},
+		{s: "ms10tystsxxxxxxxxxxxxxxxxqxxxxxxxxx4nzvca9cmczlw", err: "codex32 string has more than one error"},
+	}
+
+	for _, test := range tests {
+		actual, err := Correct(test.s)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' for %s but actual %v", test.err, test.s, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.s, err.Error())
+			continue
+		}
+		if actual != valid {
+			t.Errorf("expected corrected '%s' but actual '%s'", valid, actual)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package nomnemonic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+type (
+	// CompatibilityMatrix lists what interoperates with this build: the same
+	// inputs derive the same phrase on every library version of the
+	// algorithm version with the same word list, and the export containers
+	// of the listed versions are read with any of the kdf profiles
+	CompatibilityMatrix struct {
+		Version          string                   `json:"version" yaml:"version"`
+		AlgorithmVersion string                   `json:"algorithm_version" yaml:"algorithm_version"`
+		Algorithms       []AlgorithmCompatibility `json:"algorithms" yaml:"algorithms"`
+		ExportVersions   []int                    `json:"export_versions" yaml:"export_versions"`
+		KDFProfiles      []KDFProfile             `json:"kdf_profiles" yaml:"kdf_profiles"`
+		Wordlists        []WordlistCompatibility  `json:"wordlists" yaml:"wordlists"`
+	}
+
+	// AlgorithmCompatibility is an algorithm version, the library versions
+	// deriving it and how it derives the entropy
+	AlgorithmCompatibility struct {
+		Version   string `json:"version" yaml:"version"`
+		Libraries string `json:"libraries" yaml:"libraries"`
+		KDF       string `json:"kdf" yaml:"kdf"`
+		KDFHash   string `json:"kdf_hash" yaml:"kdf_hash"`
+		Sizes     []int  `json:"sizes" yaml:"sizes"`
+	}
+
+	// KDFProfile is a named argon2id cost of the export containers
+	KDFProfile struct {
+		Name    string `json:"name" yaml:"name"`
+		Time    uint32 `json:"time" yaml:"time"`
+		Memory  uint32 `json:"memory" yaml:"memory"`
+		Threads uint8  `json:"threads" yaml:"threads"`
+	}
+
+	// WordlistCompatibility is an official bip39 list and its
+	// WordlistChecksum, phrases only interoperate on identical lists
+	WordlistCompatibility struct {
+		Language string `json:"language" yaml:"language"`
+		Checksum string `json:"checksum" yaml:"checksum"`
+	}
+)
+
+// _kdfDescription is the kdf of the algorithm version, its hash changes with
+// any of the parameters
+var _kdfDescription = fmt.Sprintf("pbkdf2-sha512 %d iterations xor scrypt n=%d r=%d p=%d",
+	_kdfPBKDF2Iterations, _kdfScryptN, _kdfScryptR, _kdfScryptP)
+
+// _wordlistChecksums are the WordlistChecksum of the official bip39 lists
+var _wordlistChecksums = []WordlistCompatibility{
+	{Language: "chinese-simplified", Checksum: "106cc8387ac3fc7d44ca1072e30a0b27ed017b1d377501bb909c2833ef60c186"},
+	{Language: "chinese-traditional", Checksum: "407312f9014543242bd157c255125a753ac60128fc15883a33b8685a9328b0cc"},
+	{Language: "czech", Checksum: "63a3babb46c556473cd58ddf195dcd2a91aff3674a9656efa6e0ad8598875f3e"},
+	{Language: "english", Checksum: "187db04a869dd9bc7be80d21a86497d692c0db6abd3aa8cb6be5d618ff757fae"},
+	{Language: "french", Checksum: "b8caec12319d0ffb127c84e42c8866c86a54ac9951fe2cfbf902d35552c65e4f"},
+	{Language: "italian", Checksum: "ffefe450a4be8015d9c291d6ae305ab7e814e822113fa874268c3074af42b27e"},
+	{Language: "japanese", Checksum: "a3c2aa5c689341519e8a579e28d2956910313e372b04cf0f31baef40dc44d69c"},
+	{Language: "korean", Checksum: "e7375c57574d3f2db755dedda43ff20d6166e2f0cad4c9618b6f7929b8b39aed"},
+	{Language: "spanish", Checksum: "2f06d28020d49115a2e502fb6042aaa593e90773edb947685482d05ee2af6a03"},
+}
+
+// Compatibility returns the compatibility matrix of this build
+func Compatibility() CompatibilityMatrix {
+	return CompatibilityMatrix{
+		Version:          Version,
+		AlgorithmVersion: VersionAlgorithm,
+		Algorithms: []AlgorithmCompatibility{{
+			Version:   VersionAlgorithm,
+			Libraries: ">=" + Version,
+			KDF:       _kdfDescription,
+			KDFHash:   kdfHash(),
+			Sizes:     []int{12, 15, 18, 21, 24},
+		}},
+		ExportVersions: []int{_exportVersionWords, _exportVersion, _exportVersionSlots},
+		KDFProfiles: []KDFProfile{
+			kdfProfile("interactive", KDFInteractive),
+			kdfProfile("moderate", KDFModerate),
+			kdfProfile("sensitive", KDFSensitive),
+		},
+		Wordlists: append([]WordlistCompatibility(nil), _wordlistChecksums...),
+	}
+}
+
+func kdfProfile(name string, p KDFParams) KDFProfile {
+	return KDFProfile{Name: name, Time: p.Time, Memory: p.Memory, Threads: p.Threads}
+}
+
+// Check returns an error when this build does not derive the algorithm
+// version or the checksum is not an official list, an empty checksum is not
+// checked
+func (c CompatibilityMatrix) Check(algorithmVersion, wordlistChecksum string) error {
+	supported := false
+	for _, a := range c.Algorithms {
+		supported = supported || a.Version == algorithmVersion
+	}
+	if !supported {
+		return fmt.Errorf("algorithm version %s is not derived by version %s", algorithmVersion, c.Version)
+	}
+	if wordlistChecksum == "" {
+		return nil
+	}
+	if _, ok := c.Language(wordlistChecksum); !ok {
+		return fmt.Errorf("word list %s is not an official bip39 list", wordlistChecksum)
+	}
+	return nil
+}
+
+// Language returns the language of the official list with the checksum
+func (c CompatibilityMatrix) Language(wordlistChecksum string) (string, bool) {
+	for _, w := range c.Wordlists {
+		if w.Checksum == wordlistChecksum {
+			return w.Language, true
+		}
+	}
+	return "", false
+}
+
+// kdfHash is the hex sha256 of the kdf description
+func kdfHash() string {
+	sum := sha256.Sum256([]byte(_kdfDescription))
+	return hex.EncodeToString(sum[:])
+}
+
+// WordlistChecksum is the hex sha256 of the words joined by new lines, it
+// identifies the list a phrase was generated with
+func WordlistChecksum(words []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(words, "\n")))
+	return hex.EncodeToString(sum[:])
+}
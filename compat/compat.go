@@ -0,0 +1,179 @@
+// Package compat embeds known good derivation vectors of the hardware
+// wallets, phrases and their first addresses per path, and derives the
+// addresses a Ledger, Trezor or Coldcard shows for a seed, so users confirm a
+// generated phrase restores identically on their device
+package compat
+
+import (
+	"crypto/sha512"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+	"github.com/nomnemonic/nomnemonic/evm"
+	"github.com/nomnemonic/nomnemonic/utxo"
+)
+
+// Kinds are the address encodings of the vectors and the accounts
+const (
+	KindP2PKH      = "p2pkh"
+	KindP2SHP2WPKH = "p2sh-p2wpkh"
+	KindP2WPKH     = "p2wpkh"
+	KindEVM        = "evm"
+
+	_seedIterations = 2048
+	_seedSize       = 64
+)
+
+// ErrVector is returned when this build does not derive a vector address
+var ErrVector = errors.New("compat: derivation differs from the hardware wallet vectors")
+
+type (
+	// Vector is a phrase and the addresses hardware wallets derive from it
+	// without passphrase
+	Vector struct {
+		Source    string          `json:"source"`
+		Words     string          `json:"words"`
+		Addresses []VectorAddress `json:"addresses"`
+	}
+
+	// VectorAddress is an address of a vector, Kind is the address encoding
+	VectorAddress struct {
+		Kind    string `json:"kind"`
+		Path    string `json:"path"`
+		Address string `json:"address"`
+	}
+
+	// Device is a hardware wallet and the accounts its companion app creates
+	// by default
+	Device struct {
+		Name     string
+		Accounts []Account
+	}
+
+	// Account is an account of a device, Path is the path of its first
+	// receive address
+	Account struct {
+		Name string
+		Kind string
+		Path string
+	}
+
+	// Report is the first receive address of each account of a device
+	Report struct {
+		Device    string    `json:"device"`
+		Addresses []Address `json:"addresses"`
+	}
+
+	// Address is the first receive address of an account
+	Address struct {
+		Account string `json:"account"`
+		Path    string `json:"path"`
+		Address string `json:"address"`
+	}
+)
+
+var (
+	//go:embed vectors.json
+	_vectorsJSON []byte
+
+	_bitcoinAccounts = []Account{
+		{Name: "bitcoin native segwit", Kind: KindP2WPKH, Path: "m/84'/0'/0'/0/0"},
+		{Name: "bitcoin nested segwit", Kind: KindP2SHP2WPKH, Path: "m/49'/0'/0'/0/0"},
+		{Name: "bitcoin legacy", Kind: KindP2PKH, Path: "m/44'/0'/0'/0/0"},
+	}
+
+	// Devices are the supported hardware wallets, Coldcard is bitcoin only
+	Devices = []Device{
+		{Name: "ledger", Accounts: append(append([]Account(nil), _bitcoinAccounts...),
+			Account{Name: "ethereum", Kind: KindEVM, Path: "m/44'/60'/0'/0/0"},
+			Account{Name: "ethereum account 2", Kind: KindEVM, Path: "m/44'/60'/1'/0/0"},
+		)},
+		{Name: "trezor", Accounts: append(append([]Account(nil), _bitcoinAccounts...),
+			Account{Name: "ethereum", Kind: KindEVM, Path: "m/44'/60'/0'/0/0"},
+			Account{Name: "ethereum address 2", Kind: KindEVM, Path: "m/44'/60'/0'/0/1"},
+		)},
+		{Name: "coldcard", Accounts: _bitcoinAccounts},
+	}
+)
+
+// Vectors returns the embedded vectors
+func Vectors() ([]Vector, error) {
+	var vectors []Vector
+	if err := json.Unmarshal(_vectorsJSON, &vectors); err != nil {
+		return nil, err
+	}
+	return vectors, nil
+}
+
+// Check derives every vector address and returns ErrVector when one differs
+func Check() error {
+	vectors, err := Vectors()
+	if err != nil {
+		return err
+	}
+	for _, v := range vectors {
+		master, err := bip32.NewMasterKey(pbkdf2.Key([]byte(v.Words), []byte("mnemonic"), _seedIterations, _seedSize, sha512.New))
+		if err != nil {
+			return err
+		}
+		for _, a := range v.Addresses {
+			address, err := derive(master, a.Kind, a.Path)
+			if err != nil {
+				return err
+			}
+			if address != a.Address {
+				return fmt.Errorf("%w: %s of %s is %s instead of %s", ErrVector, a.Path, v.Source, address, a.Address)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckCompatibility checks this build against the vectors and returns the
+// first receive addresses the devices show for the seed, matching addresses
+// on the device confirm the phrase and its passphrase restore identically
+func CheckCompatibility(seed []byte) ([]Report, error) {
+	if err := Check(); err != nil {
+		return nil, err
+	}
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]Report, len(Devices))
+	for i, d := range Devices {
+		reports[i].Device = d.Name
+		for _, a := range d.Accounts {
+			address, err := derive(master, a.Kind, a.Path)
+			if err != nil {
+				return nil, err
+			}
+			reports[i].Addresses = append(reports[i].Addresses, Address{Account: a.Name, Path: a.Path, Address: address})
+		}
+	}
+	return reports, nil
+}
+
+func derive(master *bip32.Key, kind, path string) (string, error) {
+	k, err := master.DerivePath(path)
+	if err != nil {
+		return "", err
+	}
+	switch kind {
+	case KindP2PKH:
+		return utxo.Bitcoin.EncodeAddress(k.PublicKey(), utxo.AddressP2PKH)
+	case KindP2SHP2WPKH:
+		return utxo.Bitcoin.EncodeAddress(k.PublicKey(), utxo.AddressP2SHP2WPKH)
+	case KindP2WPKH:
+		return utxo.Bitcoin.EncodeAddress(k.PublicKey(), utxo.AddressP2WPKH)
+	case KindEVM:
+		return evm.Ethereum.EncodeAddress(evm.PublicKeyHash(k.UncompressedPublicKey())), nil
+	}
+	return "", fmt.Errorf("compat: unsupported address kind %s", kind)
+}
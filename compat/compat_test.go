@@ -0,0 +1,123 @@
+package compat
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestCheck(t *testing.T) {
+	if err := Check(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	vectors, err := Vectors()
+	if err != nil || len(vectors) != 2 {
+		t.Fatalf("expected 2 vectors but actual %d, %v", len(vectors), err)
+	}
+
+	original := _vectorsJSON
+	defer func() { _vectorsJSON = original }()
+	_vectorsJSON = bytes.Replace(original, []byte("1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"), []byte("1Ak8PffB2meyfYnbXZR9EGfLfFZVpzJvQP"), 1)
+	if err := Check(); !errors.Is(err, ErrVector) {
+		t.Errorf("expected %v but actual %v", ErrVector, err)
+	}
+	if _, err := CheckCompatibility(make([]byte, 64)); !errors.Is(err, ErrVector) {
+		t.Errorf("expected %v but actual %v", ErrVector, err)
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	m := buildMnemonicer(t)
+
+	tests := []struct {
+		name     string
+		words    string
+		expected map[string]string
+	}{
+		{
+			name:  "abandon about",
+			words: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			expected: map[string]string{
+				"ledger bitcoin native segwit":   "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+				"trezor ethereum address 2":      "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0",
+				"coldcard bitcoin nested segwit": "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf",
+			},
+		},
+		{
+			name:  "all all",
+			words: "all all all all all all all all all all all all",
+			expected: map[string]string{
+				"trezor bitcoin legacy": "1JAd7XCBzGudGpJQSDSfpmJhiygtLQWaGL",
+				"ledger ethereum":       "0x73d0385F4d8E00C5e6504C6030F47BF6212736A8",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			seed, err := m.GenerateSeed(test.words, "")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			reports, err := CheckCompatibility(seed)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if len(reports) != len(Devices) || len(reports[2].Addresses) != 3 {
+				t.Fatalf("expected a report per device but actual %v", reports)
+			}
+
+			actual := map[string]string{}
+			for _, r := range reports {
+				for _, a := range r.Addresses {
+					actual[r.Device+" "+a.Account] = a.Address
+				}
+			}
+			for account, expected := range test.expected {
+				if actual[account] != expected {
+					t.Errorf("%s: expected %s but actual %s", account, expected, actual[account])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckCompatibilityPassphrase(t *testing.T) {
+	m := buildMnemonicer(t)
+	words := "all all all all all all all all all all all all"
+	seed, _ := m.GenerateSeed(words, "")
+	hidden, _ := m.GenerateSeed(words, "TREZOR")
+
+	reports, err := CheckCompatibility(seed)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	hiddenReports, err := CheckCompatibility(hidden)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	for i, r := range reports {
+		for j, a := range r.Addresses {
+			if a.Address == hiddenReports[i].Addresses[j].Address {
+				t.Errorf("%s %s: expected another address with the passphrase", r.Device, a.Account)
+			}
+		}
+	}
+}
+
+func buildMnemonicer(t *testing.T) nomnemonic.Mnemonicer {
+	bytes, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	m, err := nomnemonic.New(strings.Split(string(bytes), "\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return m
+}
@@ -0,0 +1,65 @@
+package nomnemonic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+func TestCompatibility(t *testing.T) {
+	official := map[string][]string{
+		"chinese-simplified":  wordlists.ChineseSimplified,
+		"chinese-traditional": wordlists.ChineseTraditional,
+		"czech":               wordlists.Czech,
+		"english":             wordlists.English,
+		"french":              wordlists.French,
+		"italian":             wordlists.Italian,
+		"japanese":            wordlists.Japanese,
+		"korean":              wordlists.Korean,
+		"spanish":             wordlists.Spanish,
+	}
+	c := Compatibility()
+	if len(c.Wordlists) != len(official) {
+		t.Fatalf("expected %d word lists but actual %d", len(official), len(c.Wordlists))
+	}
+	for _, w := range c.Wordlists {
+		if actual := WordlistChecksum(official[w.Language]); actual != w.Checksum {
+			t.Errorf("%s: expected checksum %s but actual %s", w.Language, w.Checksum, actual)
+		}
+	}
+
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	if language, ok := c.Language(WordlistChecksum(words)); !ok || language != "english" {
+		t.Errorf("expected english but actual %s", language)
+	}
+
+	tests := []struct {
+		version  string
+		checksum string
+		err      string
+	}{
+		{version: VersionAlgorithm, checksum: WordlistChecksum(words)},
+		{version: VersionAlgorithm},
+		{version: "2.0.0", err: "algorithm version 2.0.0 is not derived by version " + Version},
+		{version: VersionAlgorithm, checksum: WordlistChecksum(words[1:]), err: "word list " + WordlistChecksum(words[1:]) + " is not an official bip39 list"},
+	}
+	for _, test := range tests {
+		err := c.Check(test.version, test.checksum)
+		if (err == nil && test.err != "") || (err != nil && err.Error() != test.err) {
+			t.Errorf("%s: expected err '%s' but actual %v", test.version, test.err, err)
+		}
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded CompatibilityMatrix
+	if err := json.Unmarshal(b, &decoded); err != nil || decoded.Algorithms[0].KDF != "pbkdf2-sha512 262144 iterations xor scrypt n=262144 r=8 p=1" || decoded.KDFProfiles[2].Memory != KDFSensitive.Memory {
+		t.Errorf("expected the matrix to round trip but actual %s %v", b, err)
+	}
+}
@@ -0,0 +1,104 @@
+package nomnemonic
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Confirmation asks for the words of a phrase at a few positions, the backup
+// confirmation screen of wallets checking the phrase was written down
+type Confirmation struct {
+	// Positions are the asked word numbers, starting from 1
+	Positions []int
+	words     []string
+}
+
+// Challenges returns n distinct random word numbers of a phrase of size
+// words, starting from 1 and increasing, to give to ConfirmWords
+func Challenges(size, n int) ([]int, error) {
+	if _, ok := _sentenceStrengths[size]; !ok {
+		return nil, errorf(ErrUnsupportedSize, "unsupported size: %d", size)
+	}
+	if n < 1 || n > size {
+		return nil, fmt.Errorf("challenges must be between 1 and %d", size)
+	}
+
+	positions := make([]int, size)
+	for i := range positions {
+		positions[i] = i + 1
+	}
+	for i := 0; i < n; i++ {
+		j, err := rand.Int(_random, big.NewInt(int64(size-i)))
+		if err != nil {
+			return nil, err
+		}
+		k := i + int(j.Int64())
+		positions[i], positions[k] = positions[k], positions[i]
+	}
+	challenges := positions[:n]
+	sort.Ints(challenges)
+	return challenges, nil
+}
+
+// ConfirmWords returns the confirmation of the words at the challenged word
+// numbers, starting from 1
+func ConfirmWords(words []string, challenges []int) (Confirmation, error) {
+	if _, ok := _sentenceStrengths[len(words)]; !ok {
+		return Confirmation{}, errorf(ErrUnsupportedSize, "unsupported size: %d", len(words))
+	}
+	if len(challenges) == 0 {
+		return Confirmation{}, errors.New("no challenges")
+	}
+	seen := make(map[int]bool, len(challenges))
+	for _, p := range challenges {
+		if p < 1 || p > len(words) {
+			return Confirmation{}, fmt.Errorf("word #%d is not in a phrase of %d words", p, len(words))
+		}
+		if seen[p] {
+			return Confirmation{}, fmt.Errorf("word #%d is challenged twice", p)
+		}
+		seen[p] = true
+	}
+	return Confirmation{
+		Positions: append([]int(nil), challenges...),
+		words:     append([]string(nil), words...),
+	}, nil
+}
+
+// Prompts returns the questions of the positions, like "what is word #7?"
+func (c Confirmation) Prompts() []string {
+	prompts := make([]string, len(c.Positions))
+	for i, p := range c.Positions {
+		prompts[i] = fmt.Sprintf("what is word #%d?", p)
+	}
+	return prompts
+}
+
+// Check returns the word numbers answered wrong, none when the answers in the
+// order of the positions all match, case, surrounding spaces and the unicode
+// form are ignored
+func (c Confirmation) Check(answers []string) ([]int, error) {
+	if len(answers) != len(c.Positions) {
+		return nil, fmt.Errorf("expected %d answers but got %d", len(c.Positions), len(answers))
+	}
+	var wrong []int
+	for i, p := range c.Positions {
+		if !sameWord(answers[i], c.words[p-1]) {
+			wrong = append(wrong, p)
+		}
+	}
+	return wrong, nil
+}
+
+// sameWord compares the words as written by hand
+func sameWord(a, b string) bool {
+	a = norm.NFKD.String(strings.TrimSpace(a))
+	b = norm.NFKD.String(strings.TrimSpace(b))
+	return strings.EqualFold(a, b)
+}
@@ -0,0 +1,87 @@
+package nomnemonic
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestChallenges(t *testing.T) {
+	for _, size := range []int{12, 24} {
+		for n := 1; n <= size; n++ {
+			challenges, err := Challenges(size, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(challenges) != n {
+				t.Fatalf("expected %d challenges but actual %v", n, challenges)
+			}
+			for i, p := range challenges {
+				if p < 1 || p > size || (i > 0 && p <= challenges[i-1]) {
+					t.Fatalf("expected increasing word numbers of 1 to %d but actual %v", size, challenges)
+				}
+			}
+		}
+	}
+
+	if _, err := Challenges(13, 3); !errors.Is(err, ErrUnsupportedSize) {
+		t.Errorf("expected %v but actual %v", ErrUnsupportedSize, err)
+	}
+	for _, n := range []int{0, 13} {
+		if _, err := Challenges(12, n); err == nil {
+			t.Errorf("%d: expected an error but actual none", n)
+		}
+	}
+}
+
+func TestConfirmWords(t *testing.T) {
+	words := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+
+	c, err := ConfirmWords(words, []int{2, 7, 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"what is word #2?", "what is word #7?", "what is word #12?"}
+	if actual := c.Prompts(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v but actual %v", expected, actual)
+	}
+
+	tests := []struct {
+		answers []string
+		wrong   []int
+	}{
+		{answers: []string{"venue", "paddle", "hobby"}},
+		{answers: []string{" Venue ", "PADDLE", "hobby\n"}},
+		{answers: []string{"venue", "vague", "hobby"}, wrong: []int{7}},
+		{answers: []string{"", "", ""}, wrong: []int{2, 7, 12}},
+	}
+	for _, test := range tests {
+		wrong, err := c.Check(test.answers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(wrong, test.wrong) {
+			t.Errorf("%v: expected wrong %v but actual %v", test.answers, test.wrong, wrong)
+		}
+	}
+	if _, err := c.Check([]string{"venue"}); err == nil {
+		t.Error("expected an error for missing answers but actual none")
+	}
+
+	invalid := []struct {
+		words      []string
+		challenges []int
+	}{
+		{words: words[:11], challenges: []int{1}},
+		{words: words},
+		{words: words, challenges: []int{0}},
+		{words: words, challenges: []int{13}},
+		{words: words, challenges: []int{3, 3}},
+	}
+	for _, test := range invalid {
+		if _, err := ConfirmWords(test.words, test.challenges); err == nil {
+			t.Errorf("%d words %v: expected an error but actual none", len(test.words), test.challenges)
+		}
+	}
+}
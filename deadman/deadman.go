@@ -0,0 +1,285 @@
+// Package deadman schedules the inheritance of an export container: the
+// container is sealed to the owner while they check in, and re-encrypted to
+// the recipients of the next stage and uploaded again once they have been
+// inactive for its delay, heirs after 90 days for instance. The container
+// stays passphrase encrypted, the heirs need the passphrase from the will
+package deadman
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"filippo.io/age"
+
+	"github.com/nomnemonic/nomnemonic"
+	"github.com/nomnemonic/nomnemonic/internal/command"
+	"github.com/nomnemonic/nomnemonic/kms"
+)
+
+// DefaultInterval is the interval of the checks of Run
+const DefaultInterval = time.Hour
+
+type (
+	// Sealer encrypts the container to the recipients of a stage
+	Sealer func(container []byte) ([]byte, error)
+
+	// Uploader stores the sealed container, replacing the previous one
+	Uploader interface {
+		Upload(blob []byte) error
+	}
+
+	// UploaderFunc adapts a function to an Uploader
+	UploaderFunc func(blob []byte) error
+
+	// Stage is a set of recipients, After is the inactivity before the
+	// container is sealed to them, 0 for the owner stage
+	Stage struct {
+		Name  string
+		After time.Duration
+		Seal  Sealer
+	}
+
+	// State is what the switch keeps between runs, persist it so a restart
+	// neither forgets a check in nor uploads a stage again. Stage is -1
+	// before the first upload
+	State struct {
+		CheckIn  time.Time `json:"check_in"`
+		Stage    int       `json:"stage"`
+		Uploaded time.Time `json:"uploaded,omitempty"`
+	}
+
+	// Switch seals and uploads the container of the due stage
+	Switch struct {
+		container []byte
+		stages    []Stage
+		uploader  Uploader
+
+		mu    sync.Mutex
+		state State
+		now   func() time.Time
+	}
+
+	// S3 uploads to an s3 uri through the aws cli, with its credentials
+	S3 struct {
+		URI     string
+		Profile string
+
+		run command.Runner
+	}
+
+	// File writes the blob to a path, a synced folder or a mounted bucket
+	File string
+)
+
+// Upload calls the function
+func (f UploaderFunc) Upload(blob []byte) error {
+	return f(blob)
+}
+
+// New returns the switch of the export container, the first stage is the
+// owner one and the next ones have increasing delays. A zero state starts
+// with a check in now
+func New(container []byte, stages []Stage, uploader Uploader, state State) (*Switch, error) {
+	if _, err := nomnemonic.ContainerCBOR(container); err != nil {
+		return nil, fmt.Errorf("deadman: %w", err)
+	}
+	if len(stages) < 2 || stages[0].After != 0 {
+		return nil, errors.New("deadman: an owner stage without delay and at least one heir stage are required")
+	}
+	for i, s := range stages {
+		if s.Seal == nil {
+			return nil, fmt.Errorf("deadman: stage %s has no sealer", s.Name)
+		}
+		if i > 0 && s.After <= stages[i-1].After {
+			return nil, fmt.Errorf("deadman: stage %s must come after %s", s.Name, stages[i-1].Name)
+		}
+	}
+	if uploader == nil {
+		return nil, errors.New("deadman: no uploader")
+	}
+
+	s := &Switch{
+		container: append([]byte(nil), container...),
+		stages:    append([]Stage(nil), stages...),
+		uploader:  uploader,
+		state:     state,
+		now:       time.Now,
+	}
+	if s.state.CheckIn.IsZero() {
+		s.state = State{CheckIn: s.now(), Stage: -1}
+	}
+	if s.state.Stage < -1 || s.state.Stage >= len(stages) {
+		return nil, fmt.Errorf("deadman: invalid stage %d", s.state.Stage)
+	}
+	return s, nil
+}
+
+// CheckIn records the activity of the owner, the container is sealed to the
+// owner again when a later stage was uploaded
+func (s *Switch) CheckIn() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.CheckIn = s.now()
+	if s.state.Stage > 0 {
+		return s.upload(0)
+	}
+	return nil
+}
+
+// Tick uploads the container of the due stage when it was not uploaded yet
+// and reports whether it did
+func (s *Switch) Tick() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	due := s.due()
+	if due == s.state.Stage {
+		return false, nil
+	}
+	return true, s.upload(due)
+}
+
+// Next returns when the next stage is due, the zero time after the last one
+func (s *Switch) Next() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if next := s.due() + 1; next < len(s.stages) {
+		return s.state.CheckIn.Add(s.stages[next].After)
+	}
+	return time.Time{}
+}
+
+// State returns the state to persist
+func (s *Switch) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// Run ticks every interval until the context is done, the state is passed to
+// save after each upload, a failed upload is retried at the next tick
+func (s *Switch) Run(ctx context.Context, interval time.Duration, save func(State) error) error {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		uploaded, err := s.Tick()
+		if err == nil && uploaded && save != nil {
+			err = save(s.State())
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// due returns the last stage whose delay has passed since the check in
+func (s *Switch) due() int {
+	inactive := s.now().Sub(s.state.CheckIn)
+	due := 0
+	for i, st := range s.stages {
+		if inactive >= st.After {
+			due = i
+		}
+	}
+	return due
+}
+
+// upload seals the container again for the stage, so every upload has a
+// fresh encryption, and uploads it
+func (s *Switch) upload(stage int) error {
+	blob, err := s.stages[stage].Seal(s.container)
+	if err != nil {
+		return fmt.Errorf("deadman: sealing for %s: %w", s.stages[stage].Name, err)
+	}
+	if err := s.uploader.Upload(blob); err != nil {
+		return fmt.Errorf("deadman: uploading for %s: %w", s.stages[stage].Name, err)
+	}
+	s.state.Stage, s.state.Uploaded = stage, s.now()
+	return nil
+}
+
+// AgeRecipients seals to the age x25519 recipients, any of them decrypts
+func AgeRecipients(recipients ...string) (Sealer, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("deadman: no age recipient")
+	}
+	parsed := make([]age.Recipient, len(recipients))
+	for i, r := range recipients {
+		var err error
+		if parsed[i], err = age.ParseX25519Recipient(strings.TrimSpace(r)); err != nil {
+			return nil, fmt.Errorf("deadman: invalid age recipient %s", r)
+		}
+	}
+	return func(container []byte) ([]byte, error) {
+		var buf bytes.Buffer
+		w, err := age.Encrypt(&buf, parsed...)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(container); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}, nil
+}
+
+// Keyring seals with a kms envelope of the keyring, opened with kms.Open
+func Keyring(k kms.Keyring) Sealer {
+	return func(container []byte) ([]byte, error) {
+		return kms.Seal(container, k)
+	}
+}
+
+// NewS3 returns the uploader of the s3 uri, like s3://bucket/backup.age
+func NewS3(uri string) (*S3, error) {
+	if !strings.HasPrefix(uri, "s3://") {
+		return nil, fmt.Errorf("deadman: invalid s3 uri %s", uri)
+	}
+	return &S3{URI: uri, run: command.Run}, nil
+}
+
+// Upload copies the blob to the uri
+func (s *S3) Upload(blob []byte) error {
+	args := []string{"s3", "cp", "-", s.URI}
+	if s.Profile != "" {
+		args = append(args, "--profile", s.Profile)
+	}
+	if _, err := s.run(blob, "aws", args...); err != nil {
+		return fmt.Errorf("aws: %w", err)
+	}
+	return nil
+}
+
+// Upload replaces the file atomically, a reader never sees a partial blob
+func (f File) Upload(blob []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(string(f)), "."+filepath.Base(string(f))+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(blob); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), string(f))
+}
@@ -0,0 +1,227 @@
+package deadman
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func buildContainer(t *testing.T) []byte {
+	t.Helper()
+	words := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	container, err := nomnemonic.Export(words, "will", nomnemonic.KDFParams{Time: 1, Memory: 64, Threads: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return container
+}
+
+// tagged seals by prefixing the name of the stage
+func tagged(name string) Sealer {
+	return func(container []byte) ([]byte, error) {
+		return append([]byte(name+":"), container...), nil
+	}
+}
+
+type fakeUploader struct {
+	blobs [][]byte
+	err   error
+}
+
+func (f *fakeUploader) Upload(blob []byte) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.blobs = append(f.blobs, blob)
+	return nil
+}
+
+func (f *fakeUploader) last() string {
+	if len(f.blobs) == 0 {
+		return ""
+	}
+	return string(f.blobs[len(f.blobs)-1])
+}
+
+func TestSwitch(t *testing.T) {
+	container := buildContainer(t)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	uploader := &fakeUploader{}
+	s, err := New(container, []Stage{
+		{Name: "owner", Seal: tagged("owner")},
+		{Name: "spouse", After: 90 * 24 * time.Hour, Seal: tagged("spouse")},
+		{Name: "lawyer", After: 180 * 24 * time.Hour, Seal: tagged("lawyer")},
+	}, uploader, State{CheckIn: start, Stage: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	s.now = func() time.Time { return now }
+
+	for _, test := range []struct {
+		name     string
+		days     int
+		checkIn  bool
+		uploaded bool
+		stage    int
+		last     string
+		uploads  int
+	}{
+		{name: "first tick", uploaded: true, stage: 0, last: "owner", uploads: 1},
+		{name: "nothing due", days: 30, stage: 0, last: "owner", uploads: 1},
+		{name: "spouse", days: 90, uploaded: true, stage: 1, last: "spouse", uploads: 2},
+		{name: "spouse again", days: 100, stage: 1, last: "spouse", uploads: 2},
+		{name: "lawyer", days: 200, uploaded: true, stage: 2, last: "lawyer", uploads: 3},
+		{name: "check in", days: 201, checkIn: true, stage: 0, last: "owner", uploads: 4},
+		{name: "after the check in", days: 290, stage: 0, last: "owner", uploads: 4},
+		{name: "spouse after the check in", days: 291, uploaded: true, stage: 1, last: "spouse", uploads: 5},
+	} {
+		now = start.Add(time.Duration(test.days) * 24 * time.Hour)
+		uploaded := false
+		if test.checkIn {
+			err = s.CheckIn()
+		} else {
+			uploaded, err = s.Tick()
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", test.name, err.Error())
+		}
+		if uploaded != test.uploaded {
+			t.Errorf("%s: expected uploaded %t but actual %t", test.name, test.uploaded, uploaded)
+		}
+		if state := s.State(); state.Stage != test.stage {
+			t.Errorf("%s: expected stage %d but actual %d", test.name, test.stage, state.Stage)
+		}
+		if len(uploader.blobs) != test.uploads {
+			t.Errorf("%s: expected %d uploads but actual %d", test.name, test.uploads, len(uploader.blobs))
+		}
+		if expected := test.last + ":" + string(container); uploader.last() != expected {
+			t.Errorf("%s: expected the %s blob", test.name, test.last)
+		}
+	}
+
+	if expected, actual := start.Add(381*24*time.Hour), s.Next(); !actual.Equal(expected) {
+		t.Errorf("expected next %s but actual %s", expected, actual)
+	}
+}
+
+func TestSwitchUploadError(t *testing.T) {
+	uploader := &fakeUploader{err: errors.New("offline")}
+	s, err := New(buildContainer(t), []Stage{
+		{Name: "owner", Seal: tagged("owner")},
+		{Name: "heirs", After: time.Hour, Seal: tagged("heirs")},
+	}, uploader, State{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if _, err := s.Tick(); err == nil || err.Error() != "deadman: uploading for owner: offline" {
+		t.Fatalf("expected the upload error but actual %v", err)
+	}
+	if s.State().Stage != -1 {
+		t.Errorf("expected the stage to be retried but actual %d", s.State().Stage)
+	}
+	uploader.err = nil
+	if uploaded, err := s.Tick(); err != nil || !uploaded {
+		t.Errorf("expected the retry to upload but actual %t %v", uploaded, err)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	container := buildContainer(t)
+	owner := Stage{Name: "owner", Seal: tagged("owner")}
+	for _, test := range []struct {
+		name      string
+		container []byte
+		stages    []Stage
+		state     State
+		err       string
+	}{
+		{name: "container", container: []byte("words"), stages: []Stage{owner, {Name: "heirs", After: 1, Seal: tagged("heirs")}}, err: "deadman: not an export container"},
+		{name: "no heirs", container: container, stages: []Stage{owner}, err: "deadman: an owner stage without delay and at least one heir stage are required"},
+		{name: "owner delay", container: container, stages: []Stage{{Name: "owner", After: 1, Seal: tagged("owner")}, {Name: "heirs", After: 2, Seal: tagged("heirs")}}, err: "deadman: an owner stage without delay and at least one heir stage are required"},
+		{name: "no sealer", container: container, stages: []Stage{owner, {Name: "heirs", After: 1}}, err: "deadman: stage heirs has no sealer"},
+		{name: "order", container: container, stages: []Stage{owner, {Name: "spouse", After: 2, Seal: tagged("spouse")}, {Name: "lawyer", After: 1, Seal: tagged("lawyer")}}, err: "deadman: stage lawyer must come after spouse"},
+		{name: "stage", container: container, stages: []Stage{owner, {Name: "heirs", After: 1, Seal: tagged("heirs")}}, state: State{CheckIn: time.Now(), Stage: 2}, err: "deadman: invalid stage 2"},
+	} {
+		if _, err := New(test.container, test.stages, &fakeUploader{}, test.state); err == nil || err.Error() != test.err {
+			t.Errorf("%s: expected error %q but actual %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestAgeRecipients(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	seal, err := AgeRecipients(identity.Recipient().String())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	container := buildContainer(t)
+	blob, err := seal(container)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	r, err := age.Decrypt(bytes.NewReader(blob), identity)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	actual, _ := io.ReadAll(r)
+	if !bytes.Equal(actual, container) {
+		t.Errorf("expected the container back")
+	}
+
+	if _, err := AgeRecipients(); err == nil {
+		t.Errorf("expected an error without recipients")
+	}
+	if _, err := AgeRecipients("age1invalid"); err == nil || err.Error() != "deadman: invalid age recipient age1invalid" {
+		t.Errorf("expected the invalid recipient error but actual %v", err)
+	}
+}
+
+func TestUploaders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.age")
+	if err := File(path).Upload([]byte("first")); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err := File(path).Upload([]byte("second")); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if b, _ := os.ReadFile(path); string(b) != "second" {
+		t.Errorf("expected second but actual %s", b)
+	}
+
+	if _, err := NewS3("https://bucket"); err == nil {
+		t.Errorf("expected an error for a non s3 uri")
+	}
+	s3, err := NewS3("s3://bucket/backup.age")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	var args []string
+	var stdin []byte
+	s3.Profile = "family"
+	s3.run = func(in []byte, name string, a ...string) ([]byte, error) {
+		args, stdin = append([]string{name}, a...), in
+		return nil, nil
+	}
+	if err := s3.Upload([]byte("blob")); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected, actual := "aws s3 cp - s3://bucket/backup.age --profile family", strings.Join(args, " "); expected != actual {
+		t.Errorf("expected %s but actual %s", expected, actual)
+	}
+	if string(stdin) != "blob" {
+		t.Errorf("expected the blob on stdin but actual %s", stdin)
+	}
+}
@@ -0,0 +1,82 @@
+package nomnemonic
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+const (
+	_decoyTagSize     = 4 // bytes, 8 hex chars
+	_decoyTagDomain   = "\x00nomnemonic decoy tag v2\x00"
+	_decoyTagPasscode = "000000"
+)
+
+// MnemonicRole tells a primary mnemonic from its decoy
+type MnemonicRole string
+
+const (
+	RolePrimary MnemonicRole = "primary"
+	RoleDecoy   MnemonicRole = "decoy"
+)
+
+// GenerateWithDecoy generates the primary mnemonic with the passcode and a
+// decoy mnemonic with the decoy passcode from the same identifier and
+// password, the decoy can be revealed under duress while the primary keeps
+// the funds
+func (m *mnemonicer) GenerateWithDecoy(identifier, password, passcode, decoyPasscode string, size int) ([]string, []string, error) {
+	if passcode == decoyPasscode {
+		return nil, nil, errors.New("decoy passcode must differ from the passcode")
+	}
+
+	primary, err := m.Generate(identifier, password, passcode, size)
+	if err != nil {
+		return nil, nil, err
+	}
+	decoy, err := m.Generate(identifier, password, decoyPasscode, size)
+	if err != nil {
+		return nil, nil, err
+	}
+	return primary, decoy, nil
+}
+
+// DecoyTag returns the tag to write next to the mnemonic, an hmac keyed by
+// the kdf of the identifier and password, so testing a password guess
+// against the tag costs a whole derivation
+func (m *mnemonicer) DecoyTag(words []string, identifier, password string, role MnemonicRole) (string, error) {
+	key, err := m.decoyTagKey(identifier, password)
+	if err != nil {
+		return "", err
+	}
+	defer wipe(key)
+	return decoyTag(key, words, role), nil
+}
+
+// VerifyDecoyTag returns the role of the mnemonic the tag was computed for
+func (m *mnemonicer) VerifyDecoyTag(words []string, identifier, password, tag string) (MnemonicRole, error) {
+	key, err := m.decoyTagKey(identifier, password)
+	if err != nil {
+		return "", err
+	}
+	defer wipe(key)
+	for _, role := range []MnemonicRole{RolePrimary, RoleDecoy} {
+		if hmac.Equal([]byte(decoyTag(key, words, role)), []byte(strings.ToLower(tag))) {
+			return role, nil
+		}
+	}
+	return "", errors.New("tag does not match the mnemonic and password")
+}
+
+// decoyTagKey derives the tag key of the identifier and password, the tag
+// checks the phrases of either passcode so the passcode is a fixed one
+func (m *mnemonicer) decoyTagKey(identifier, password string) ([]byte, error) {
+	return m.deriveKey(identifier, password, _decoyTagPasscode, _decoyTagDomain)
+}
+
+func decoyTag(key []byte, words []string, role MnemonicRole) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(string(role) + ":" + strings.Join(words, " ")))
+	return hex.EncodeToString(mac.Sum(nil)[:_decoyTagSize])
+}
@@ -0,0 +1,104 @@
+package nomnemonic
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestGenerateWithDecoy(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	primary, decoy, err := m.GenerateWithDecoy("nomnemonic_test", "test12345678", "101938", "839101", 24)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	expected := "dress mule bonus strong village clip volcano public plug fossil travel lobster nerve love gospel dance shove vicious valve else roof observe warrior magic"
+	if actual := strings.Join(primary, " "); actual != expected {
+		t.Errorf("expected primary '%s' but actual '%s'", expected, actual)
+	}
+	if strings.Join(decoy, " ") == expected {
+		t.Errorf("expected decoy to differ from primary")
+	}
+	if ok, _ := m.IsValid(decoy); !ok {
+		t.Errorf("expected decoy to be a valid mnemonic")
+	}
+
+	_, _, err = m.GenerateWithDecoy("nomnemonic_test", "test12345678", "101938", "101938", 24)
+	if err == nil || err.Error() != "decoy passcode must differ from the passcode" {
+		t.Errorf("expected passcode err but actual %v", err)
+	}
+}
+
+func TestVerifyDecoyTag(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(InsecureFastKDFEnv, "1")
+	m, err := New(words, WithInsecureFastKDF())
+	if err != nil {
+		t.Fatal(err)
+	}
+	primary := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	decoy := strings.Fields("silent toe meat possible chair blossom wait occur this worth option bag nurse find fish scene bench asthma bike wage world quit primary indoor")
+
+	primaryTag, err := m.DecoyTag(primary, "nomnemonic_test", "test12345678", RolePrimary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoyTag, _ := m.DecoyTag(decoy, "nomnemonic_test", "test12345678", RoleDecoy)
+	if len(primaryTag) != 8 {
+		t.Errorf("expected 8 chars tag but actual '%s'", primaryTag)
+	}
+	// the tag is keyed by the kdf, not by the password
+	mac := hmac.New(sha256.New, []byte("nomnemonic decoy tag"+"test12345678"))
+	mac.Write([]byte(string(RolePrimary) + ":" + strings.Join(primary, " ")))
+	if primaryTag == hex.EncodeToString(mac.Sum(nil)[:_decoyTagSize]) {
+		t.Error("expected the tag not to be keyed by the raw password")
+	}
+
+	tests := []struct {
+		words      []string
+		identifier string
+		password   string
+		tag        string
+		role       MnemonicRole
+		err        string
+	}{
+		{words: primary, identifier: "nomnemonic_test", password: "test12345678", tag: primaryTag, role: RolePrimary},
+		{words: decoy, identifier: "nomnemonic_test", password: "test12345678", tag: strings.ToUpper(decoyTag), role: RoleDecoy},
+		{words: decoy, identifier: "nomnemonic_test", password: "test12345678", tag: primaryTag, err: "tag does not match the mnemonic and password"},
+		{words: primary, identifier: "nomnemonic_test", password: "test123456789", tag: primaryTag, err: "tag does not match the mnemonic and password"},
+		{words: primary, identifier: "nomnemonic_other", password: "test12345678", tag: primaryTag, err: "tag does not match the mnemonic and password"},
+		{words: primary, identifier: "nomnemonic_test", password: "short", tag: primaryTag, err: ErrWeakPassword.Error()},
+	}
+
+	for _, test := range tests {
+		role, err := m.VerifyDecoyTag(test.words, test.identifier, test.password, test.tag)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' but actual %v", test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if role != test.role {
+			t.Errorf("expected role %s but actual %s", test.role, role)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package nomnemonic
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	_electrumVersionKey = "Seed version"
+	_electrumSaltPrefix = "electrum"
+	_electrumMaxNonce   = 1 << 20
+)
+
+// ElectrumSeedType is the Electrum v2 seed version prefix
+type ElectrumSeedType string
+
+const (
+	ElectrumStandard  ElectrumSeedType = "01"
+	ElectrumSegwit    ElectrumSeedType = "100"
+	Electrum2FA       ElectrumSeedType = "101"
+	Electrum2FASegwit ElectrumSeedType = "102"
+)
+
+// GenerateElectrum generates Electrum v2 seed words of the seed type from the
+// same entropy Generate derives for identifier, password, passcode and size.
+// Like Electrum, the entropy is incremented until the version hash matches the
+// seed type and the words are not a valid bip39 mnemonic too
+func (m *mnemonicer) GenerateElectrum(identifier, password, passcode string, size int, seedType ElectrumSeedType) ([]string, error) {
+	if err := validateElectrumSeedType(seedType); err != nil {
+		return nil, err
+	}
+
+	entropy, err := m.deriveEntropy(identifier, password, passcode, size)
+	if err != nil {
+		return nil, err
+	}
+
+	i := new(big.Int).SetBytes(entropy)
+	one := big.NewInt(1)
+	for nonce := 0; nonce < _electrumMaxNonce; nonce++ {
+		i.Add(i, one)
+		words := m.electrumEncode(i)
+		if !m.IsValidElectrum(words, seedType) {
+			continue
+		}
+		if ok, _ := m.IsValid(words); ok {
+			continue
+		}
+		return words, nil
+	}
+	return nil, fmt.Errorf("no %s electrum seed found in %d tries", seedType, _electrumMaxNonce)
+}
+
+// IsValidElectrum checks if the words are an Electrum v2 seed of the seed
+// type. Electrum seeds have no checksum over the words, only the version hash
+func (m *mnemonicer) IsValidElectrum(words []string, seedType ElectrumSeedType) bool {
+	if validateElectrumSeedType(seedType) != nil || len(words) == 0 {
+		return false
+	}
+	mac := hmac.New(sha512.New, []byte(_electrumVersionKey))
+	mac.Write([]byte(normalizeElectrum(words)))
+	return strings.HasPrefix(hex.EncodeToString(mac.Sum(nil)), string(seedType))
+}
+
+// GenerateElectrumSeed generates 64 bytes Electrum v2 seed using the sentence
+// and passphrase
+func (m *mnemonicer) GenerateElectrumSeed(sentence, passphrase string) ([]byte, error) {
+	normalized := normalizeElectrum(strings.Fields(sentence))
+	seed := pbkdf2.Key([]byte(normalized), []byte(_electrumSaltPrefix+strings.ToLower(passphrase)), 2048, 64, sha512.New)
+	return seed, nil
+}
+
+// electrumEncode encodes the number in base 2048, least significant word first
+func (m *mnemonicer) electrumEncode(i *big.Int) []string {
+	n := big.NewInt(int64(len(m.words)))
+	rest := new(big.Int).Set(i)
+	x := new(big.Int)
+	words := []string{}
+	for rest.Sign() > 0 {
+		rest.DivMod(rest, n, x)
+		words = append(words, m.words[x.Int64()])
+	}
+	return words
+}
+
+func validateElectrumSeedType(seedType ElectrumSeedType) error {
+	switch seedType {
+	case ElectrumStandard, ElectrumSegwit, Electrum2FA, Electrum2FASegwit:
+		return nil
+	}
+	return fmt.Errorf("unsupported electrum seed type: %s", seedType)
+}
+
+// normalizeElectrum lowercases and joins the words with single spaces, which
+// is Electrum's normalization for the english wordlist
+func normalizeElectrum(words []string) string {
+	return strings.ToLower(strings.Join(words, " "))
+}
@@ -0,0 +1,116 @@
+package nomnemonic
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestIsValidElectrum(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	tests := []struct {
+		sentence string
+		seedType ElectrumSeedType
+		valid    bool
+	}{
+		{
+			sentence: "cycle rocket west magnet parrot shuffle foot correct salt library feed song",
+			seedType: ElectrumStandard,
+			valid:    true,
+		},
+		{
+			sentence: "cycle rocket west magnet parrot shuffle foot correct salt library feed song",
+			seedType: ElectrumSegwit,
+			valid:    false,
+		},
+		{
+			sentence: "wild father tree among universe such mobile favorite target dynamic credit identify",
+			seedType: ElectrumSegwit,
+			valid:    true,
+		},
+		{
+			sentence: "bitter grass shiver impose acquire brush forget axis eager alone wine silver",
+			seedType: ElectrumSegwit,
+			valid:    true,
+		},
+		{
+			sentence: "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby",
+			seedType: ElectrumStandard,
+			valid:    false,
+		},
+		{
+			sentence: "bitter grass shiver impose acquire brush forget axis eager alone wine silver",
+			seedType: ElectrumSeedType("2"),
+			valid:    false,
+		},
+	}
+
+	for _, test := range tests {
+		actual := m.IsValidElectrum(strings.Fields(test.sentence), test.seedType)
+		if actual != test.valid {
+			t.Errorf("expected %v for %s seed '%s' but actual %v", test.valid, test.seedType, test.sentence, actual)
+		}
+	}
+}
+
+func TestGenerateElectrum(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	sentence, err := m.GenerateElectrum("nomnemonic_test", "test12345678", "101938", 12, ElectrumSegwit)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if !m.IsValidElectrum(sentence, ElectrumSegwit) {
+		t.Errorf("expected valid segwit seed but actual '%s'", strings.Join(sentence, " "))
+	}
+	if ok, _ := m.IsValid(sentence); ok {
+		t.Errorf("expected electrum seed not to be a valid bip39 mnemonic")
+	}
+	expected := "genuine toddler theory creek human fly pair accuse endless crack monkey action"
+	if actual := strings.Join(sentence, " "); actual != expected {
+		t.Errorf("expected '%s' but actual '%s'", expected, actual)
+	}
+
+	_, err = m.GenerateElectrum("nomnemonic_test", "test12345678", "101938", 12, ElectrumSeedType("2"))
+	if err == nil || err.Error() != "unsupported electrum seed type: 2" {
+		t.Errorf("expected seed type err but actual %v", err)
+	}
+}
+
+func TestGenerateElectrumSeed(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	seed, err := m.GenerateElectrumSeed("wild father tree among universe such mobile favorite target dynamic credit identify", "")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	expected := "aac2a6302e48577ab4b46f23dbae0774e2e62c796f797d0a1b5faeb528301e3064342dafb79069e7c4c6b8c38ae11d7a973bec0d4f70626f8cc5184a8d0b0756"
+	if actual := hex.EncodeToString(seed); actual != expected {
+		t.Errorf("expected %s but actual %s", expected, actual)
+	}
+}
@@ -0,0 +1,139 @@
+// Package encode converts entropy, seeds and keys from and to the text
+// encodings used around them, every Encoding decodes what it encodes
+package encode
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic/internal/base58"
+	"github.com/nomnemonic/nomnemonic/internal/bech32"
+)
+
+var (
+	// Hex is lowercase hex, uppercase is decoded too
+	Hex Encoding = hexEncoding{}
+	// Base64 is the padded standard base64 of rfc 4648
+	Base64 Encoding = base64Encoding{base64.StdEncoding}
+	// Base64URL is the unpadded url safe base64 of rfc 4648
+	Base64URL Encoding = base64Encoding{base64.RawURLEncoding}
+	// Base58 is base58 with the bitcoin alphabet and no checksum
+	Base58 Encoding = base58Encoding{}
+)
+
+type (
+	// Encoding encodes bytes as text and decodes them back
+	Encoding interface {
+		Encode(b []byte) (string, error)
+		Decode(s string) ([]byte, error)
+	}
+
+	hexEncoding struct{}
+
+	base64Encoding struct {
+		enc *base64.Encoding
+	}
+
+	base58Encoding struct{}
+
+	base58CheckEncoding struct {
+		version []byte
+	}
+
+	bech32Encoding struct {
+		hrp string
+		enc bech32.Encoding
+	}
+)
+
+// Base58Check returns base58 with the version bytes prepended and the double
+// sha256 checksum appended, like the wif keys (0x80) and the extended keys
+// (0x0488ade4). Decode rejects other version bytes
+func Base58Check(version ...byte) Encoding {
+	return base58CheckEncoding{version: append([]byte{}, version...)}
+}
+
+// Bech32 returns bip-0173 bech32 with the human readable part, the strings
+// are limited to 90 chars so about 50 bytes fit
+func Bech32(hrp string) Encoding {
+	return bech32Encoding{hrp: strings.ToLower(hrp), enc: bech32.Bech32}
+}
+
+// Bech32m returns bip-0350 bech32m with the human readable part, the strings
+// are limited to 90 chars so about 50 bytes fit
+func Bech32m(hrp string) Encoding {
+	return bech32Encoding{hrp: strings.ToLower(hrp), enc: bech32.Bech32m}
+}
+
+func (hexEncoding) Encode(b []byte) (string, error) {
+	return hex.EncodeToString(b), nil
+}
+
+func (hexEncoding) Decode(s string) ([]byte, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("invalid hex string")
+	}
+	return b, nil
+}
+
+func (e base64Encoding) Encode(b []byte) (string, error) {
+	return e.enc.EncodeToString(b), nil
+}
+
+func (e base64Encoding) Decode(s string) ([]byte, error) {
+	b, err := e.enc.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("invalid base64 string")
+	}
+	return b, nil
+}
+
+func (base58Encoding) Encode(b []byte) (string, error) {
+	return base58.Encode(b, base58.AlphabetBitcoin), nil
+}
+
+func (base58Encoding) Decode(s string) ([]byte, error) {
+	return base58.Decode(s, base58.AlphabetBitcoin)
+}
+
+func (e base58CheckEncoding) Encode(b []byte) (string, error) {
+	return base58.CheckEncode(append(append([]byte{}, e.version...), b...), base58.AlphabetBitcoin), nil
+}
+
+func (e base58CheckEncoding) Decode(s string) ([]byte, error) {
+	payload, err := base58.CheckDecode(s, base58.AlphabetBitcoin)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) < len(e.version) || !bytes.Equal(payload[:len(e.version)], e.version) {
+		return nil, errors.New("invalid base58check version")
+	}
+	return payload[len(e.version):], nil
+}
+
+func (e bech32Encoding) Encode(b []byte) (string, error) {
+	data, err := bech32.ConvertBits(b, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return bech32.Encode(e.hrp, data, e.enc)
+}
+
+func (e bech32Encoding) Decode(s string) ([]byte, error) {
+	hrp, data, enc, err := bech32.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if hrp != e.hrp {
+		return nil, fmt.Errorf("expected hrp %s but got %s", e.hrp, hrp)
+	}
+	if enc != e.enc {
+		return nil, errors.New("invalid bech32 variant")
+	}
+	return bech32.ConvertBits(data, 5, 8, false)
+}
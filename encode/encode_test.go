@@ -0,0 +1,80 @@
+package encode
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestEncoding(t *testing.T) {
+	key, _ := hex.DecodeString("0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d")
+	entropy, _ := hex.DecodeString("00000000000000000000000000000000")
+
+	tests := []struct {
+		name     string
+		encoding Encoding
+		b        []byte
+		expected string
+	}{
+		{name: "hex", encoding: Hex, b: key, expected: "0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d"},
+		{name: "base64", encoding: Base64, b: []byte{0xfb, 0xff}, expected: "+/8="},
+		{name: "base64url", encoding: Base64URL, b: []byte{0xfb, 0xff}, expected: "-_8"},
+		{name: "base58", encoding: Base58, b: entropy, expected: "1111111111111111"},
+		{name: "wif", encoding: Base58Check(0x80), b: key, expected: "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ"},
+		{name: "bech32", encoding: Bech32("a"), b: []byte{}, expected: "a12uel5l"},
+		{name: "bech32m", encoding: Bech32m("a"), b: []byte{}, expected: "a1lqfn3a"},
+	}
+
+	for _, test := range tests {
+		actual, err := test.encoding.Encode(test.b)
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", test.name, err.Error())
+			continue
+		}
+		if actual != test.expected {
+			t.Errorf("%s: expected %s but actual %s", test.name, test.expected, actual)
+		}
+		decoded, err := test.encoding.Decode(actual)
+		if err != nil || !bytes.Equal(decoded, test.b) {
+			t.Errorf("%s: expected %x but actual %x %v", test.name, test.b, decoded, err)
+		}
+	}
+}
+
+func TestBech32RoundTrip(t *testing.T) {
+	entropy, _ := hex.DecodeString("7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f")
+	for _, e := range []Encoding{Bech32("entropy"), Bech32m("entropy")} {
+		s, err := e.Encode(entropy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := e.Decode(s)
+		if err != nil || !bytes.Equal(decoded, entropy) {
+			t.Errorf("expected %x but actual %x %v", entropy, decoded, err)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding Encoding
+		s        string
+		err      string
+	}{
+		{name: "hex", encoding: Hex, s: "0g", err: "invalid hex string"},
+		{name: "base64", encoding: Base64, s: "+/8", err: "invalid base64 string"},
+		{name: "base58", encoding: Base58, s: "0OIl", err: "invalid base58 char '0'"},
+		{name: "version", encoding: Base58Check(0xef), s: "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ", err: "invalid base58check version"},
+		{name: "checksum", encoding: Base58Check(0x80), s: "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTK", err: "invalid base58check checksum"},
+		{name: "hrp", encoding: Bech32("b"), s: "a12uel5l", err: "expected hrp b but got a"},
+		{name: "variant", encoding: Bech32("a"), s: "a1lqfn3a", err: "invalid bech32 variant"},
+	}
+
+	for _, test := range tests {
+		_, err := test.encoding.Decode(test.s)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%s: expected err '%s' but actual %v", test.name, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package nomnemonic
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Encoder encodes a result into an export format, Name is the format the CLI
+// --output flag and the httpapi format field select it with
+type Encoder interface {
+	Name() string
+	Encode(r Result) ([]byte, error)
+}
+
+var (
+	_encodersMu sync.RWMutex
+	_encoders   = map[string]Encoder{}
+)
+
+// RegisterEncoder makes the encoder available under its name, usually from
+// the init of the package providing it. It panics when the name is empty or
+// already registered
+func RegisterEncoder(e Encoder) {
+	_encodersMu.Lock()
+	defer _encodersMu.Unlock()
+
+	name := e.Name()
+	if name == "" {
+		panic("nomnemonic: encoder name is empty")
+	}
+	if _, ok := _encoders[name]; ok {
+		panic(fmt.Sprintf("nomnemonic: encoder %s is already registered", name))
+	}
+	_encoders[name] = e
+}
+
+// LookupEncoder returns the encoder registered under the name
+func LookupEncoder(name string) (Encoder, bool) {
+	_encodersMu.RLock()
+	defer _encodersMu.RUnlock()
+
+	e, ok := _encoders[name]
+	return e, ok
+}
+
+// Encoders returns the names of the registered encoders in order
+func Encoders() []string {
+	_encodersMu.RLock()
+	defer _encodersMu.RUnlock()
+
+	names := make([]string, 0, len(_encoders))
+	for name := range _encoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
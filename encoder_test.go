@@ -0,0 +1,53 @@
+package nomnemonic
+
+import (
+	"strings"
+	"testing"
+)
+
+type upperEncoder struct{}
+
+func (upperEncoder) Name() string { return "test-upper" }
+
+func (upperEncoder) Encode(r Result) ([]byte, error) {
+	return []byte(strings.ToUpper(strings.Join(r.Words, " "))), nil
+}
+
+func TestRegisterEncoder(t *testing.T) {
+	RegisterEncoder(upperEncoder{})
+	defer func() {
+		_encodersMu.Lock()
+		delete(_encoders, "test-upper")
+		_encodersMu.Unlock()
+	}()
+
+	e, ok := LookupEncoder("test-upper")
+	if !ok {
+		t.Fatal("expected test-upper to be registered")
+	}
+	actual, err := e.Encode(Result{Words: []string{"abandon", "about"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(actual) != "ABANDON ABOUT" {
+		t.Errorf("expected 'ABANDON ABOUT' but actual '%s'", actual)
+	}
+
+	found := false
+	for _, name := range Encoders() {
+		found = found || name == "test-upper"
+	}
+	if !found {
+		t.Errorf("expected test-upper in %v", Encoders())
+	}
+	if _, ok := LookupEncoder("unknown"); ok {
+		t.Error("expected unknown not to be registered")
+	}
+
+	defer func() {
+		if r := recover(); r == nil || r != "nomnemonic: encoder test-upper is already registered" {
+			t.Errorf("expected already registered panic but actual %v", r)
+		}
+	}()
+	RegisterEncoder(upperEncoder{})
+}
@@ -0,0 +1,76 @@
+package nomnemonic
+
+import "fmt"
+
+// GenerateDetailed generates the words like Generate and returns them with
+// their entropy in the envelope of the library and algorithm versions, the
+// kdf hash, the word list checksum, the features changing the phrase and the
+// purpose, keep it with ExportResult so Check tells whether a later build
+// still regenerates the words
+func (m *mnemonicer) GenerateDetailed(identifier, password, passcode string, size int) (Result, error) {
+	entropy, err := m.deriveEntropy(identifier, password, passcode, size)
+	if err != nil {
+		return Result{}, err
+	}
+	words, err := m.generatedWords(entropy)
+	if err != nil {
+		return Result{}, err
+	}
+	checksum := WordlistChecksum(m.words)
+	language, _ := Compatibility().Language(checksum)
+	return Result{
+		Words:            words,
+		Entropy:          entropy,
+		Language:         language,
+		Version:          Version,
+		AlgorithmVersion: VersionAlgorithm,
+		KDFHash:          m.kdfHash(),
+		WordlistChecksum: checksum,
+		Features:         m.derivationFeatures(),
+		Purpose:          m.purpose,
+	}, nil
+}
+
+// Check returns the reasons the current build may not regenerate the words of
+// the envelope, none when it does
+func Check(envelope Result) []string {
+	if envelope.AlgorithmVersion == "" {
+		return []string{"no algorithm version recorded, the words may not be generated"}
+	}
+
+	var warnings []string
+	c := Compatibility()
+	if err := c.Check(envelope.AlgorithmVersion, ""); err != nil {
+		warnings = append(warnings, err.Error())
+	}
+	for _, a := range c.Algorithms {
+		if a.Version != envelope.AlgorithmVersion {
+			continue
+		}
+		switch envelope.KDFHash {
+		case "":
+			warnings = append(warnings, "no kdf hash recorded")
+		case a.KDFHash:
+		default:
+			warnings = append(warnings, fmt.Sprintf("kdf parameters differ from algorithm version %s of version %s", a.Version, c.Version))
+		}
+	}
+
+	for _, f := range envelope.Features {
+		if _, ok := _features[Feature(f)]; !ok {
+			warnings = append(warnings, fmt.Sprintf("feature %s is not supported by version %s", f, c.Version))
+		}
+	}
+
+	if envelope.WordlistChecksum == "" {
+		return append(warnings, "no word list checksum recorded")
+	}
+	language, ok := c.Language(envelope.WordlistChecksum)
+	switch {
+	case !ok:
+		warnings = append(warnings, fmt.Sprintf("word list %s is not an official bip39 list, the same list is needed", envelope.WordlistChecksum))
+	case envelope.Language != "" && envelope.Language != language:
+		warnings = append(warnings, fmt.Sprintf("word list is %s but recorded as %s", language, envelope.Language))
+	}
+	return warnings
+}
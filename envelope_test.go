@@ -0,0 +1,81 @@
+package nomnemonic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateDetailed(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := m.GenerateDetailed("nomnemonic_test", "test12345678", "101938", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	if strings.Join(r.Words, " ") != expected {
+		t.Errorf("expected %s but actual %v", expected, r.Words)
+	}
+	if r.Language != "english" || r.AlgorithmVersion != VersionAlgorithm || r.Version != Version || r.KDFHash != kdfHash() || r.WordlistChecksum != WordlistChecksum(words) || len(r.Entropy) != 16 {
+		t.Errorf("expected the envelope of this build but actual %+v", r)
+	}
+	if warnings := Check(r); len(warnings) != 0 {
+		t.Errorf("expected no warnings but actual %v", warnings)
+	}
+
+	container, err := ExportResult(r, "correct horse", _testKDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported, err := ImportResult(container, "correct horse")
+	if err != nil || imported.KDFHash != r.KDFHash || imported.WordlistChecksum != r.WordlistChecksum || strings.Join(imported.Words, " ") != expected {
+		t.Errorf("expected %+v but actual %+v %v", r, imported, err)
+	}
+
+	if _, err := m.GenerateDetailed("a", "test12345678", "101938", 12); err == nil {
+		t.Error("expected an error for a short identifier")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	english := _wordlistChecksums[3].Checksum
+	tests := []struct {
+		name     string
+		envelope Result
+		expected string
+	}{
+		{name: "current", envelope: Result{AlgorithmVersion: VersionAlgorithm, KDFHash: kdfHash(), WordlistChecksum: english, Language: "english"}},
+		{name: "words only", envelope: Result{Words: []string{"abandon"}}, expected: "no algorithm version recorded, the words may not be generated"},
+		{name: "algorithm", envelope: Result{AlgorithmVersion: "2.0.0", WordlistChecksum: english}, expected: "algorithm version 2.0.0 is not derived by version " + Version},
+		{name: "kdf", envelope: Result{AlgorithmVersion: VersionAlgorithm, KDFHash: "00", WordlistChecksum: english}, expected: "kdf parameters differ from algorithm version " + VersionAlgorithm + " of version " + Version},
+		{name: "no kdf", envelope: Result{AlgorithmVersion: VersionAlgorithm, WordlistChecksum: english}, expected: "no kdf hash recorded"},
+		{name: "no word list", envelope: Result{AlgorithmVersion: VersionAlgorithm, KDFHash: kdfHash()}, expected: "no word list checksum recorded"},
+		{name: "custom word list", envelope: Result{AlgorithmVersion: VersionAlgorithm, KDFHash: kdfHash(), WordlistChecksum: "00"}, expected: "word list 00 is not an official bip39 list, the same list is needed"},
+		{name: "language", envelope: Result{AlgorithmVersion: VersionAlgorithm, KDFHash: kdfHash(), WordlistChecksum: english, Language: "czech"}, expected: "word list is english but recorded as czech"},
+	}
+
+	for _, test := range tests {
+		if actual := strings.Join(Check(test.envelope), "; "); actual != test.expected {
+			t.Errorf("%s: expected '%s' but actual '%s'", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestImportWordsContainer(t *testing.T) {
+	words := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	container, err := exportSeal(_exportVersionWords, []byte(words), "correct horse", _testKDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ImportResult(container, "correct horse")
+	if err != nil || strings.Join(r.Words, " ") != words || r.AlgorithmVersion != "" {
+		t.Errorf("expected the words only but actual %+v %v", r, err)
+	}
+}
@@ -0,0 +1,137 @@
+package nomnemonic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// the kinds of the errors, check them with errors.Is since the messages
+// carry more details
+var (
+	// ErrInvalidWordlist is returned by New for a list without 2048 words
+	ErrInvalidWordlist = errors.New("bip39 is based on 2048 words")
+	// ErrShortIdentifier is returned for an identifier under the minimum length
+	ErrShortIdentifier = fmt.Errorf("identifier must be at least %d chars", _inputIdentifierMinLength)
+	// ErrWeakPassword is returned for a password under the minimum length
+	ErrWeakPassword = fmt.Errorf("password must be at least %d chars", _inputPasswordMinLength)
+	// ErrInvalidPasscode is returned for a passcode which is not 6 digits
+	ErrInvalidPasscode = errors.New("invalid passcode")
+	// ErrUnsupportedSize is returned for a number of words other than 12,
+	// 15, 18, 21 and 24
+	ErrUnsupportedSize = errors.New("unsupported strength")
+	// ErrUnknownWord is returned for a word or a word index which is not in
+	// the word list
+	ErrUnknownWord = errors.New("unrecognized word")
+	// ErrInvalidChecksum is returned for words whose checksum does not match
+	ErrInvalidChecksum = errors.New("invalid checksum")
+	// ErrWrongPassphrase is returned by Import for a wrong passphrase or a
+	// corrupted container, the two can not be told apart
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupted export")
+	// ErrPolicyViolation is returned for the inputs and the settings a Policy
+	// does not allow
+	ErrPolicyViolation = errors.New("policy violation")
+)
+
+// kindError keeps the detailed message of an error while errors.Is matches
+// its kind
+type kindError struct {
+	kind error
+	msg  string
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// errorf returns an error of the kind with the formatted message
+func errorf(kind error, format string, args ...interface{}) error {
+	return &kindError{kind: kind, msg: fmt.Sprintf(format, args...)}
+}
+
+type (
+	// UnknownWordError is returned for a word which is not in the word list,
+	// Index is its position from 0 and Suggestions are the closest words of
+	// the list, best first
+	UnknownWordError struct {
+		Word        string
+		Index       int
+		Suggestions []string
+	}
+
+	// ChecksumError is returned for words whose checksum does not match, the
+	// checksums are strings of bits, Expected is computed from the entropy
+	// and Actual is read from the last word
+	ChecksumError struct {
+		Expected string
+		Actual   string
+	}
+)
+
+func (e *UnknownWordError) Error() string {
+	return "unrecognized word " + e.Word
+}
+
+func (e *UnknownWordError) Unwrap() error {
+	return ErrUnknownWord
+}
+
+func (e *ChecksumError) Error() string {
+	return ErrInvalidChecksum.Error()
+}
+
+func (e *ChecksumError) Unwrap() error {
+	return ErrInvalidChecksum
+}
+
+// joinError lists every problem of the inputs at once, like errors.Join of
+// go 1.20 which it implements Is and As for to work on older versions too
+type joinError struct {
+	errs []error
+}
+
+// joinErrors returns nil without errors, the error itself for one error and
+// the errors joined by new lines for more
+func joinErrors(errs ...error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return &joinError{errs: errs}
+}
+
+func (e *joinError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e *joinError) Unwrap() []error {
+	return e.errs
+}
+
+func (e *joinError) Is(target error) bool {
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *joinError) As(target interface{}) bool {
+	for _, err := range e.errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
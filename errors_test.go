@@ -0,0 +1,146 @@
+package nomnemonic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestErrorKinds(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	generate := func(identifier, password, passcode string, size int) error {
+		_, err := m.Generate(identifier, password, passcode, size)
+		return err
+	}
+	entropy := func(sentence string) error {
+		_, err := m.CalculateEntropy(strings.Fields(sentence))
+		return err
+	}
+	_, wordlistErr := New(words[:2047])
+	_, importErr := Import(mustExport(t), "wrong horse")
+	_, indexErr := m.WordsFromIndexes("0001 2048")
+
+	tests := []struct {
+		name string
+		err  error
+		kind error
+		msg  string
+	}{
+		{name: "wordlist", err: wordlistErr, kind: ErrInvalidWordlist, msg: "bip39 is based on 2048 words"},
+		{name: "identifier", err: generate("a", "test12345678", "101938", 12), kind: ErrShortIdentifier, msg: "identifier must be at least 2 chars"},
+		{name: "password", err: generate("nomnemonic_test", "test", "101938", 12), kind: ErrWeakPassword, msg: "password must be at least 12 chars"},
+		{name: "passcode length", err: generate("nomnemonic_test", "test12345678", "1019", 12), kind: ErrInvalidPasscode, msg: "passcode must be 6 digits"},
+		{name: "passcode digits", err: generate("nomnemonic_test", "test12345678", "abcdef", 12), kind: ErrInvalidPasscode, msg: "passcode must be numeric but given 'abcdef'"},
+		{name: "size", err: generate("nomnemonic_test", "test12345678", "101938", 13), kind: ErrUnsupportedSize, msg: "unsupported strength: 0"},
+		{name: "word", err: entropy("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandonn"), kind: ErrUnknownWord, msg: "unrecognized word abandonn"},
+		{name: "index", err: indexErr, kind: ErrUnknownWord, msg: "invalid word index 2048"},
+		{name: "checksum", err: entropy("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"), kind: ErrInvalidChecksum, msg: "invalid checksum"},
+		{name: "import", err: importErr, kind: ErrWrongPassphrase, msg: "wrong passphrase or corrupted export"},
+	}
+
+	for _, test := range tests {
+		if !errors.Is(test.err, test.kind) {
+			t.Errorf("%s: expected %v to be %v", test.name, test.err, test.kind)
+		}
+		if test.err == nil || test.err.Error() != test.msg {
+			t.Errorf("%s: expected message '%s' but actual %v", test.name, test.msg, test.err)
+		}
+	}
+}
+
+func mustExport(t *testing.T) []byte {
+	container, err := Export([]string{"abandon"}, "correct horse", _testKDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return container
+}
+
+func TestTypedErrors(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		sentence    string
+		index       int
+		suggestions []string
+	}{
+		{sentence: "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobbby", index: 11, suggestions: []string{"hobby"}},
+		{sentence: "cinnamon vnue broken old brass vague paddle unaware critic alarm consider hobby", index: 1, suggestions: []string{"venue", "blue", "glue"}},
+		{sentence: "cinnamon venue broken old brass vague paddle unaware critic alarm sprng hobby", index: 10, suggestions: []string{"spring", "shrug", "sing"}},
+		{sentence: "cinnamon venue broken old brass vague paddle unaware critic alarm zzzzzz hobby", index: 10},
+	}
+
+	for _, test := range tests {
+		_, err := m.CalculateEntropy(strings.Fields(test.sentence))
+		var wordErr *UnknownWordError
+		if !errors.As(err, &wordErr) {
+			t.Errorf("%s: expected an UnknownWordError but actual %v", test.sentence, err)
+			continue
+		}
+		if wordErr.Index != test.index || strings.Join(wordErr.Suggestions, " ") != strings.Join(test.suggestions, " ") {
+			t.Errorf("%s: expected index %d and suggestions %v but actual %d %v", test.sentence, test.index, test.suggestions, wordErr.Index, wordErr.Suggestions)
+		}
+		if !errors.Is(err, ErrUnknownWord) {
+			t.Errorf("%s: expected %v to be ErrUnknownWord", test.sentence, err)
+		}
+	}
+
+	_, err = m.CalculateEntropy(strings.Fields("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"))
+	var checksumErr *ChecksumError
+	if !errors.As(err, &checksumErr) || checksumErr.Expected != "0011" || checksumErr.Actual != "0000" {
+		t.Errorf("expected a ChecksumError with 0011 and 0000 but actual %+v", err)
+	}
+}
+
+func TestJoinedErrors(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = m.Generate("a", "test", "abcdef", 13)
+	expected := "identifier must be at least 2 chars\npassword must be at least 12 chars\npasscode must be numeric but given 'abcdef'\nunsupported strength: 0"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected err '%s' but actual %v", expected, err)
+	}
+	for _, kind := range []error{ErrShortIdentifier, ErrWeakPassword, ErrInvalidPasscode, ErrUnsupportedSize} {
+		if !errors.Is(err, kind) {
+			t.Errorf("expected %v to be %v", err, kind)
+		}
+	}
+	if errors.Is(err, ErrUnknownWord) {
+		t.Errorf("expected %v not to be ErrUnknownWord", err)
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 4 {
+		t.Errorf("expected 4 joined errors but actual %v", err)
+	}
+
+	m, err = New(words, WithLocale("spanish"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.Generate("nomnemonic_test", "test", "1019", 12)
+	expected = "la contraseña debe tener al menos 12 caracteres\nel código de acceso debe tener 6 dígitos"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected err '%s' but actual %v", expected, err)
+	}
+}
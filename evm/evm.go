@@ -0,0 +1,159 @@
+// Package evm derives addresses and keys for ethereum and the evm compatible
+// chains (BSC, Polygon, Avalanche C-Chain, Tron) from the bip39 seed
+package evm
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+	"github.com/nomnemonic/nomnemonic/internal/base58"
+)
+
+const (
+	_tronAddressPrefix = 0x41
+
+	AddressSize = 20
+)
+
+// Format is the address encoding of the chain
+type Format int
+
+const (
+	// FormatHex encodes addresses as eip55 mixed case checksum hex
+	FormatHex Format = iota
+	// FormatTron encodes addresses in base58check with 0x41 prefix
+	FormatTron
+)
+
+// Chain describes an evm compatible chain, CoinType is the bip44 coin type
+// used in the default derivation path m/44'/<coin type>'/0'/0/<index>
+type Chain struct {
+	Name     string
+	CoinType uint32
+	ChainID  uint64
+	Format   Format
+}
+
+var (
+	// Wallets like MetaMask and Ledger derive every evm chain with the
+	// ethereum coin type, so the same phrase gives the same addresses
+	Ethereum   = Chain{Name: "ethereum", CoinType: 60, ChainID: 1}
+	BSC        = Chain{Name: "bsc", CoinType: 60, ChainID: 56}
+	Polygon    = Chain{Name: "polygon", CoinType: 60, ChainID: 137}
+	AvalancheC = Chain{Name: "avalanche-c", CoinType: 60, ChainID: 43114}
+	Tron       = Chain{Name: "tron", CoinType: 195, Format: FormatTron}
+
+	Chains = []Chain{Ethereum, BSC, Polygon, AvalancheC, Tron}
+)
+
+// ChainByName returns the predefined chain by name
+func ChainByName(name string) (Chain, error) {
+	for _, c := range Chains {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return Chain{}, fmt.Errorf("unsupported chain %s", name)
+}
+
+// Path returns the bip44 derivation path of the address index
+func (c Chain) Path(index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/0'/0/%d", c.CoinType, index)
+}
+
+// Key derives the bip32 key of the address index from the seed
+func (c Chain) Key(seed []byte, index uint32) (*bip32.Key, error) {
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	return master.DerivePath(c.Path(index))
+}
+
+// Address derives the address of the index from the seed
+func (c Chain) Address(seed []byte, index uint32) (string, error) {
+	k, err := c.Key(seed, index)
+	if err != nil {
+		return "", err
+	}
+	return c.EncodeAddress(PublicKeyHash(k.UncompressedPublicKey())), nil
+}
+
+// PrivateKey derives the hex encoded private key of the address index
+func (c Chain) PrivateKey(seed []byte, index uint32) (string, error) {
+	k, err := c.Key(seed, index)
+	if err != nil {
+		return "", err
+	}
+	if c.Format == FormatTron {
+		return hex.EncodeToString(k.PrivateKey()), nil
+	}
+	return "0x" + hex.EncodeToString(k.PrivateKey()), nil
+}
+
+// EncodeAddress encodes the 20 bytes address in the chain format
+func (c Chain) EncodeAddress(addr []byte) string {
+	if c.Format == FormatTron {
+		return base58.CheckEncode(append([]byte{_tronAddressPrefix}, addr...), base58.AlphabetBitcoin)
+	}
+	return checksumHex(addr)
+}
+
+// DecodeAddress decodes the address in the chain format into 20 bytes and
+// validates its checksum
+func (c Chain) DecodeAddress(address string) ([]byte, error) {
+	if c.Format == FormatTron {
+		b, err := base58.CheckDecode(address, base58.AlphabetBitcoin)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != AddressSize+1 || b[0] != _tronAddressPrefix {
+			return nil, errors.New("invalid tron address")
+		}
+		return b[1:], nil
+	}
+
+	if !strings.HasPrefix(address, "0x") || len(address) != 2+AddressSize*2 {
+		return nil, errors.New("invalid hex address")
+	}
+	b, err := hex.DecodeString(address[2:])
+	if err != nil {
+		return nil, err
+	}
+	lower, upper := strings.ToLower(address[2:]), strings.ToUpper(address[2:])
+	if address[2:] != lower && address[2:] != upper && checksumHex(b) != address {
+		return nil, errors.New("invalid eip55 checksum")
+	}
+	return b, nil
+}
+
+// PublicKeyHash returns the last 20 bytes of keccak256 of the 65 bytes
+// uncompressed public key without its 0x04 prefix
+func PublicKeyHash(uncompressed []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(uncompressed[1:])
+	return h.Sum(nil)[12:]
+}
+
+// checksumHex encodes the address as eip55 mixed case hex
+func checksumHex(addr []byte) string {
+	lower := []byte(hex.EncodeToString(addr))
+	h := sha3.NewLegacyKeccak256()
+	h.Write(lower)
+	sum := h.Sum(nil)
+	for i, c := range lower {
+		nibble := sum[i/2] >> 4
+		if i%2 == 1 {
+			nibble = sum[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			lower[i] = c - 32
+		}
+	}
+	return "0x" + string(lower)
+}
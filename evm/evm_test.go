@@ -0,0 +1,127 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestAddress(t *testing.T) {
+	seed, err := buildSeed("test test test test test test test test test test test junk")
+	if err != nil {
+		t.Errorf("couldn't generate seed: %s", err.Error())
+	}
+
+	tests := []struct {
+		chain      Chain
+		index      uint32
+		address    string
+		privateKey string
+	}{
+		{
+			chain:      Ethereum,
+			index:      0,
+			address:    "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+			privateKey: "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+		},
+		{
+			chain:      Polygon,
+			index:      1,
+			address:    "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+			privateKey: "0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d",
+		},
+		{
+			chain:      Tron,
+			index:      0,
+			address:    "TWer2Ygk5TEheHp3TPuYeqxmB6SsGZmaL6",
+			privateKey: "15f0bbb1774be40b7a8d7965d637f324bda2f711fc5726a3dcc19585c6950954",
+		},
+	}
+
+	for _, test := range tests {
+		address, err := test.chain.Address(seed, test.index)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.chain.Name, err.Error())
+		}
+		if address != test.address {
+			t.Errorf("expected %s address '%s' but actual '%s'", test.chain.Name, test.address, address)
+		}
+
+		privateKey, err := test.chain.PrivateKey(seed, test.index)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.chain.Name, err.Error())
+		}
+		if privateKey != test.privateKey {
+			t.Errorf("expected %s private key '%s' but actual '%s'", test.chain.Name, test.privateKey, privateKey)
+		}
+	}
+}
+
+func TestEncodeAddress(t *testing.T) {
+	// tron documentation key and address pair
+	key, _ := hex.DecodeString("da146374a75310b9666e834ee4ad0866d6f4035967bfc76217c5a495fff9f0d0")
+	addr := PublicKeyHash(secp256k1.PrivKeyFromBytes(key).PubKey().SerializeUncompressed())
+
+	tests := []struct {
+		chain   Chain
+		address string
+	}{
+		{chain: Tron, address: "TPL66VK2gCXNCD7EJg9pgJRfqcRazjhUZY"},
+		{chain: Ethereum, address: "0x928C9af0651632157ef27A2cf17Ca72c575a4d21"},
+	}
+
+	for _, test := range tests {
+		actual := test.chain.EncodeAddress(addr)
+		if actual != test.address {
+			t.Errorf("expected %s address '%s' but actual '%s'", test.chain.Name, test.address, actual)
+		}
+
+		decoded, err := test.chain.DecodeAddress(test.address)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.address, err.Error())
+		}
+		if !bytes.Equal(decoded, addr) {
+			t.Errorf("expected decoded address %x but actual %x", addr, decoded)
+		}
+	}
+
+	_, err := Ethereum.DecodeAddress("0x928c9af0651632157ef27A2cf17Ca72c575a4d21")
+	if err == nil || err.Error() != "invalid eip55 checksum" {
+		t.Errorf("expected invalid eip55 checksum err but actual %v", err)
+	}
+
+	_, err = Ethereum.DecodeAddress("0x928c9af0651632157ef27a2cf17ca72c575a4d21")
+	if err != nil {
+		t.Errorf("expected lowercase address to be valid but actual %s", err.Error())
+	}
+}
+
+func TestChainByName(t *testing.T) {
+	c, err := ChainByName("bsc")
+	if err != nil || c.ChainID != 56 {
+		t.Errorf("expected bsc chain but actual %v, %v", c, err)
+	}
+
+	_, err = ChainByName("unknown")
+	if err == nil || err.Error() != "unsupported chain unknown" {
+		t.Errorf("expected unsupported chain err but actual %v", err)
+	}
+}
+
+func buildSeed(sentence string) ([]byte, error) {
+	bytes, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		return nil, err
+	}
+	m, err := nomnemonic.New(strings.Split(string(bytes), "\n"))
+	if err != nil {
+		return nil, err
+	}
+	return m.GenerateSeed(sentence, "")
+}
@@ -0,0 +1,32 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// PaymentURI renders the address as an eip-681 payment uri like
+// ethereum:pay-0x...@56?value=1000000000000000000, value is in wei and left
+// out when nil or zero, the chain id is left out for the ethereum mainnet.
+// Tron has no payment uri
+func (c Chain) PaymentURI(address string, value *big.Int) (string, error) {
+	if c.Format != FormatHex {
+		return "", fmt.Errorf("%s has no payment uri", c.Name)
+	}
+	if _, err := c.DecodeAddress(address); err != nil {
+		return "", err
+	}
+	if value != nil && value.Sign() < 0 {
+		return "", errors.New("value must not be negative")
+	}
+
+	uri := "ethereum:pay-" + address
+	if c.ChainID != Ethereum.ChainID {
+		uri += fmt.Sprintf("@%d", c.ChainID)
+	}
+	if value != nil && value.Sign() > 0 {
+		uri += "?value=" + value.String()
+	}
+	return uri, nil
+}
@@ -0,0 +1,56 @@
+package evm
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestPaymentURI(t *testing.T) {
+	wei, _ := new(big.Int).SetString("2014000000000000000", 10)
+
+	tests := []struct {
+		chain    Chain
+		address  string
+		value    *big.Int
+		expected string
+		err      string
+	}{
+		{
+			chain:    Ethereum,
+			address:  "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+			expected: "ethereum:pay-0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		},
+		{
+			chain:    Ethereum,
+			address:  "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+			value:    wei,
+			expected: "ethereum:pay-0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266?value=2014000000000000000",
+		},
+		{
+			chain:    Polygon,
+			address:  "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+			value:    big.NewInt(0),
+			expected: "ethereum:pay-0x70997970C51812dc3A010C7d01b50e0d17dc79C8@137",
+		},
+		{chain: Ethereum, address: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92267", err: "invalid eip55 checksum"},
+		{chain: Ethereum, address: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", value: big.NewInt(-1), err: "value must not be negative"},
+		{chain: Tron, address: "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH", err: "tron has no payment uri"},
+	}
+
+	for _, test := range tests {
+		actual, err := test.chain.PaymentURI(test.address, test.value)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s: expected err '%s' but actual %v", test.address, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", test.address, err.Error())
+			continue
+		}
+		if actual != test.expected {
+			t.Errorf("expected %s but actual %s", test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package nomnemonic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Step is a stage of the derivation pipeline, Output is the hex digest or the
+// value the stage produces
+type Step struct {
+	Name   string
+	Detail string
+	Output string
+}
+
+// Explain runs Generate step by step and returns every stage with its
+// parameters and intermediate values, the values reveal the inputs so it is
+// meant for dummy inputs only
+func (m *mnemonicer) Explain(identifier, password, passcode string, size int) ([]Step, error) {
+	input, salt, strength, err := m.kdfInputs(identifier, password, passcode, size)
+	if err != nil {
+		return nil, err
+	}
+	inputDetail := "<identifier>:<password>|<passcode>=<size>, sha256 of it"
+	if m.purpose != "" {
+		inputDetail = fmt.Sprintf("%q<length of purpose>:<purpose>%s, purpose %q", _purposeTag, inputDetail, m.purpose)
+	}
+	if len(m.pepper) > 0 || len(m.factors) > 0 {
+		pepper := "<pepper>"
+		for range m.factors {
+			pepper += fmt.Sprintf("%q<length of factor response>:<factor response>", _factorTag)
+		}
+		inputDetail = fmt.Sprintf("%q<length of pepper>:%s%s", _pepperTag, pepper, inputDetail)
+	}
+	inputSum := sha256.Sum256(input)
+	saltSum := sha256.Sum256(salt)
+	dkHead, dkTail := m.stretchKeys(input, salt, strength/_bitChunkSizeOneByte)
+	iterations, n := m.kdfCosts()
+	entropy := append([]byte{}, dkHead...)
+	xorBytes(entropy, dkTail)
+	csSize := strength / _bitChunkSizeEntropy
+	words := m.entropyToWords(entropy)
+
+	return []Step{
+		{
+			Name: "validate",
+			Detail: fmt.Sprintf("identifier >= %d chars, password >= %d chars, passcode %d digits, %d words = %d bits of entropy",
+				_inputIdentifierMinLength, _inputPasswordMinLength, _inputPasscodeLength, size, strength),
+			Output: "ok",
+		},
+		{
+			Name:   "input",
+			Detail: inputDetail,
+			Output: hex.EncodeToString(inputSum[:]),
+		},
+		{
+			Name:   "salt",
+			Detail: fmt.Sprintf("%s<password>%s<passcode>, sha256 of it", _saltPrefixPassword, _saltPrefixPasscode),
+			Output: hex.EncodeToString(saltSum[:]),
+		},
+		{
+			Name:   "pbkdf2",
+			Detail: fmt.Sprintf("pbkdf2-sha512(input, salt), %d iterations, %d bytes", iterations, len(dkHead)),
+			Output: hex.EncodeToString(dkHead),
+		},
+		{
+			Name:   "scrypt",
+			Detail: fmt.Sprintf("scrypt(input, salt), n=%d r=%d p=%d, %d bytes", n, _kdfScryptR, _kdfScryptP, len(dkTail)),
+			Output: hex.EncodeToString(dkTail),
+		},
+		{
+			Name:   "entropy",
+			Detail: "pbkdf2 xor scrypt",
+			Output: hex.EncodeToString(entropy),
+		},
+		{
+			Name:   "checksum",
+			Detail: fmt.Sprintf("first %d bits of sha256(entropy), appended to the entropy", csSize),
+			Output: checksum(entropy, csSize),
+		},
+		{
+			Name:   "words",
+			Detail: fmt.Sprintf("%d bits per word index into the word list, algorithm %s", _bitChunkSizeBip39WordIndex, VersionAlgorithm),
+			Output: strings.Join(words, " "),
+		},
+	}, nil
+}
@@ -0,0 +1,48 @@
+package nomnemonic
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _ := New(words)
+
+	steps, err := m.Explain("nomnemonic_test", "test12345678", "101938", 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"validate": "ok",
+		"words":    "dress mule bonus strong village clip volcano public plug fossil travel lobster nerve love gospel dance shove vicious valve else roof observe warrior magic",
+	}
+	for _, step := range steps {
+		if e, ok := expected[step.Name]; ok && step.Output != e {
+			t.Errorf("expected %s step output '%s' but actual '%s'", step.Name, e, step.Output)
+		}
+		if step.Detail == "" || step.Output == "" {
+			t.Errorf("expected %s step detail and output", step.Name)
+		}
+	}
+
+	byName := map[string]Step{}
+	for _, step := range steps {
+		byName[step.Name] = step
+	}
+	entropy, _ := m.CalculateEntropy(strings.Fields(expected["words"]))
+	if byName["entropy"].Output != hex.EncodeToString(entropy) {
+		t.Errorf("expected entropy %x but actual %s", entropy, byName["entropy"].Output)
+	}
+	if len(byName["checksum"].Output) != 8 {
+		t.Errorf("expected 8 checksum bits but actual %s", byName["checksum"].Output)
+	}
+
+	if _, err := m.Explain("x", "test12345678", "101938", 24); err == nil {
+		t.Error("expected validation error")
+	}
+}
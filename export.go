@@ -0,0 +1,181 @@
+package nomnemonic
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	_exportMagic        = "NMEX"
+	_exportVersion      = 2 // the plaintext is the json of a Result
+	_exportVersionWords = 1 // the plaintext is the words, written before 2
+	_exportSaltSize     = 16
+	_exportHeaderSize   = len(_exportMagic) + 1 + 4 + 4 + 1 + _exportSaltSize + chacha20poly1305.NonceSizeX
+	_exportMaxMemory    = 4 << 20  // KiB, refuse containers asking for more than 4 GiB
+	_exportMaxTime      = 16       // refuse containers asking for more passes
+	_exportMaxWork      = 16 << 20 // KiB times passes, 16 passes over 1 GiB
+)
+
+// KDFParams are the argon2id costs of an export container, Memory is in KiB
+type KDFParams struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+}
+
+var (
+	// KDFInteractive takes a fraction of a second on a laptop
+	KDFInteractive = KDFParams{Time: 2, Memory: 64 << 10, Threads: 4}
+	// KDFModerate is the default profile
+	KDFModerate = KDFParams{Time: 3, Memory: 256 << 10, Threads: 4}
+	// KDFSensitive is for long term backups, it needs 1 GiB of memory
+	KDFSensitive = KDFParams{Time: 4, Memory: 1 << 20, Threads: 4}
+)
+
+func (p KDFParams) validate() error {
+	if p.Time < 1 || p.Threads < 1 {
+		return errors.New("argon2id time and threads must be at least 1")
+	}
+	if p.Memory < 8*uint32(p.Threads) {
+		return errors.New("argon2id memory must be at least 8 KiB per thread")
+	}
+	if p.Memory > _exportMaxMemory {
+		return fmt.Errorf("argon2id memory must be at most %d KiB", _exportMaxMemory)
+	}
+	if p.Time > _exportMaxTime {
+		return fmt.Errorf("argon2id time must be at most %d", _exportMaxTime)
+	}
+	if uint64(p.Time)*uint64(p.Memory) > _exportMaxWork {
+		return fmt.Errorf("argon2id time times memory must be at most %d KiB", _exportMaxWork)
+	}
+	return nil
+}
+
+// Export encrypts the words into a container with XChaCha20-Poly1305 and an
+// argon2id key of the passphrase, the header with the costs, salt and nonce
+// is authenticated with the words
+func Export(words []string, passphrase string, params KDFParams) ([]byte, error) {
+	return ExportResult(Result{Words: words, Version: Version}, passphrase, params)
+}
+
+// ExportResult encrypts the result like Export, the envelope of a
+// GenerateDetailed result is kept for ImportResult and Check
+func ExportResult(r Result, passphrase string, params KDFParams) ([]byte, error) {
+	if len(r.Words) == 0 {
+		return nil, errors.New("no words given")
+	}
+	plaintext, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return exportSeal(_exportVersion, plaintext, passphrase, params)
+}
+
+// exportSeal encrypts the plaintext into a container of the version
+func exportSeal(version byte, plaintext []byte, passphrase string, params KDFParams) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is required")
+	}
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, _exportHeaderSize)
+	putExportHeader(header, version, params)
+	if _, err := rand.Read(header[14:]); err != nil {
+		return nil, err
+	}
+	salt := header[14 : 14+_exportSaltSize]
+	nonce := header[14+_exportSaltSize:]
+
+	aead, err := exportCipher(passphrase, salt, params)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(header, nonce, plaintext, header), nil
+}
+
+// Import decrypts the words of an export container
+func Import(container []byte, passphrase string) ([]string, error) {
+	r, err := ImportResult(container, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return r.Words, nil
+}
+
+// ImportResult decrypts the result of an export container, the containers
+// written before the envelope only have the words. The key slots containers
+// open with their passphrase slots
+func ImportResult(container []byte, passphrase string) (Result, error) {
+	if len(container) > 4 && string(container[:4]) == _exportMagic && container[4] == _exportVersionSlots {
+		r, err := ImportIdentities(container, PassphraseIdentity(passphrase))
+		if errors.Is(err, ErrNoKeySlot) {
+			return Result{}, ErrWrongPassphrase
+		}
+		return r, err
+	}
+	if len(container) < _exportHeaderSize+chacha20poly1305.Overhead || string(container[:4]) != _exportMagic {
+		return Result{}, errors.New("not an export container")
+	}
+	if !exportVersionSupported(container[4]) {
+		return Result{}, fmt.Errorf("unsupported export version %d", container[4])
+	}
+	params := exportParams(container)
+	if err := params.validate(); err != nil {
+		return Result{}, err
+	}
+
+	header := container[:_exportHeaderSize]
+	aead, err := exportCipher(passphrase, header[14:14+_exportSaltSize], params)
+	if err != nil {
+		return Result{}, err
+	}
+	plaintext, err := aead.Open(nil, header[14+_exportSaltSize:], container[_exportHeaderSize:], header)
+	if err != nil {
+		return Result{}, ErrWrongPassphrase
+	}
+	if container[4] == _exportVersionWords {
+		return Result{Words: strings.Fields(string(plaintext))}, nil
+	}
+	var r Result
+	if err := json.Unmarshal(plaintext, &r); err != nil {
+		return Result{}, errors.New("invalid export result")
+	}
+	return r, nil
+}
+
+func exportVersionSupported(version byte) bool {
+	return version == _exportVersion || version == _exportVersionWords
+}
+
+// putExportHeader writes the magic, the version and the kdf params of the
+// header, the salt and the nonce follow them
+func putExportHeader(header []byte, version byte, params KDFParams) {
+	copy(header, _exportMagic)
+	header[4] = version
+	binary.BigEndian.PutUint32(header[5:], params.Time)
+	binary.BigEndian.PutUint32(header[9:], params.Memory)
+	header[13] = params.Threads
+}
+
+func exportParams(header []byte) KDFParams {
+	return KDFParams{
+		Time:    binary.BigEndian.Uint32(header[5:]),
+		Memory:  binary.BigEndian.Uint32(header[9:]),
+		Threads: header[13],
+	}
+}
+
+func exportCipher(passphrase string, salt []byte, params KDFParams) (cipher.AEAD, error) {
+	key := argon2.IDKey([]byte(passphrase), salt, params.Time, params.Memory, params.Threads, chacha20poly1305.KeySize)
+	return chacha20poly1305.NewX(key)
+}
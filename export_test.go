@@ -0,0 +1,86 @@
+package nomnemonic
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+var _testKDF = KDFParams{Time: 1, Memory: 64, Threads: 1}
+
+func TestExportImport(t *testing.T) {
+	words := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	container, err := Export(words, "correct horse", _testKDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := Import(container, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(actual, " ") != strings.Join(words, " ") {
+		t.Errorf("expected %v but actual %v", words, actual)
+	}
+
+	again, _ := Export(words, "correct horse", _testKDF)
+	if string(again) == string(container) {
+		t.Error("expected a random salt and nonce per export")
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	words := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	container, err := Export(words, "correct horse", _testKDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := append([]byte{}, container...)
+	tampered[8]++ // argon2id time is authenticated
+	unsupported := append([]byte{}, container...)
+	unsupported[4] = 9
+	slow := append([]byte{}, container...)
+	binary.BigEndian.PutUint32(slow[5:], 1<<31)
+
+	tests := []struct {
+		name       string
+		container  []byte
+		passphrase string
+		err        string
+	}{
+		{name: "wrong passphrase", container: container, passphrase: "wrong horse", err: "wrong passphrase or corrupted export"},
+		{name: "tampered header", container: tampered, passphrase: "correct horse", err: "wrong passphrase or corrupted export"},
+		{name: "slow kdf", container: slow, passphrase: "correct horse", err: "argon2id time must be at most 16"},
+		{name: "unsupported version", container: unsupported, passphrase: "correct horse", err: "unsupported export version 9"},
+		{name: "short", container: container[:20], passphrase: "correct horse", err: "not an export container"},
+	}
+
+	for _, test := range tests {
+		_, err := Import(test.container, test.passphrase)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%s: expected err '%s' but actual %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestExportValidation(t *testing.T) {
+	tests := []struct {
+		params     KDFParams
+		passphrase string
+		err        string
+	}{
+		{params: _testKDF, err: "passphrase is required"},
+		{params: KDFParams{Time: 0, Memory: 64, Threads: 1}, passphrase: "p", err: "argon2id time and threads must be at least 1"},
+		{params: KDFParams{Time: 1, Memory: 8, Threads: 4}, passphrase: "p", err: "argon2id memory must be at least 8 KiB per thread"},
+		{params: KDFParams{Time: 1, Memory: 8 << 20, Threads: 4}, passphrase: "p", err: "argon2id memory must be at most 4194304 KiB"},
+		{params: KDFParams{Time: 1 << 31, Memory: 64, Threads: 1}, passphrase: "p", err: "argon2id time must be at most 16"},
+		{params: KDFParams{Time: 8, Memory: 4 << 20, Threads: 4}, passphrase: "p", err: "argon2id time times memory must be at most 16777216 KiB"},
+	}
+
+	for _, test := range tests {
+		_, err := Export([]string{"abandon"}, test.passphrase, test.params)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+}
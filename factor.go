@@ -0,0 +1,66 @@
+package nomnemonic
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// _factorTag starts the challenge of a factor and the response in the pepper
+const _factorTag = "\x00nomnemonic factor v1\x00"
+
+type (
+	// Factor is a possession factor, like a hardware key, answering a
+	// challenge with a response keyed by a secret it keeps
+	Factor interface {
+		Respond(challenge []byte) ([]byte, error)
+	}
+
+	// FactorFunc adapts a function to a Factor
+	FactorFunc func(challenge []byte) ([]byte, error)
+)
+
+// Respond calls the function
+func (f FactorFunc) Respond(challenge []byte) ([]byte, error) {
+	return f(challenge)
+}
+
+// WithFactor mixes the response of the factor to the challenge of the
+// identifier into the pepper, so the phrases are only regenerated with the
+// factor present. The responses of several factors are mixed in the order
+// they are given. Keep a backup of the secret of the factor, the phrases are
+// lost with it
+func WithFactor(f Factor) Option {
+	return func(m *mnemonicer) {
+		m.factors = append(m.factors, f)
+	}
+}
+
+// FactorChallenge returns the challenge of the identifier, the sha256 of the
+// tag and the identifier, it fits the 64 bytes of a hmac-sha1 slot
+func FactorChallenge(identifier string) []byte {
+	sum := sha256.Sum256([]byte(_factorTag + identifier))
+	return sum[:]
+}
+
+// factorPepper returns the pepper followed by the tag and the length prefixed
+// response of every factor
+func (m *mnemonicer) factorPepper(identifier string) ([]byte, error) {
+	if len(m.factors) == 0 {
+		return m.pepper, nil
+	}
+	pepper := append([]byte(nil), m.pepper...)
+	challenge := FactorChallenge(identifier)
+	for _, f := range m.factors {
+		response, err := f.Respond(challenge)
+		if err != nil {
+			return nil, fmt.Errorf("factor: %w", err)
+		}
+		if len(response) == 0 {
+			return nil, errors.New("factor: empty response")
+		}
+		pepper = append(pepper, fmt.Sprintf("%s%d:", _factorTag, len(response))...)
+		pepper = append(pepper, response...)
+	}
+	return pepper, nil
+}
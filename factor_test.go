@@ -0,0 +1,73 @@
+package nomnemonic
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithFactor(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	var challenges [][]byte
+	factor := FactorFunc(func(challenge []byte) ([]byte, error) {
+		challenges = append(challenges, challenge)
+		mac := hmac.New(sha1.New, []byte("slot secret"))
+		mac.Write(challenge)
+		return mac.Sum(nil), nil
+	})
+
+	m, err := New(words, WithFactor(factor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := m.Generate("nomnemonic_test", "test12345678", "101938", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	phrase := strings.Join(w, " ")
+	if phrase == "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby" {
+		t.Error("expected the factor to change the phrase")
+	}
+	if len(challenges) != 1 || string(challenges[0]) != string(FactorChallenge("nomnemonic_test")) {
+		t.Errorf("expected the challenge of the identifier but actual %x", challenges)
+	}
+	if string(FactorChallenge("nomnemonic_test")) == string(FactorChallenge("nomnemonic_other")) {
+		t.Error("expected distinct challenges per identifier")
+	}
+
+	again, _ := m.Generate("nomnemonic_test", "test12345678", "101938", 12)
+	if strings.Join(again, " ") != phrase {
+		t.Errorf("expected %s but actual %v", phrase, again)
+	}
+
+	withPepper, _ := New(words, WithFactor(factor), WithPepper([]byte("pepper")))
+	peppered, err := withPepper.Generate("nomnemonic_test", "test12345678", "101938", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(peppered, " ") == phrase {
+		t.Error("expected the pepper to change the phrase of the factor")
+	}
+
+	second := FactorFunc(func(challenge []byte) ([]byte, error) { return []byte("hsm"), nil })
+	both, _ := New(words, WithFactor(factor), WithFactor(second))
+	combined, err := both.Generate("nomnemonic_test", "test12345678", "101938", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(combined, " ") == phrase {
+		t.Error("expected the second factor to change the phrase")
+	}
+
+	absent, _ := New(words, WithFactor(FactorFunc(func([]byte) ([]byte, error) {
+		return nil, errors.New("no device")
+	})))
+	if _, err := absent.Generate("nomnemonic_test", "test12345678", "101938", 12); err == nil || err.Error() != "factor: no device" {
+		t.Errorf("expected err 'factor: no device' but actual %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package nomnemonic
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"sort"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Feature is a behavior changing fix kept behind an explicit opt-in until the
+// next algorithm version makes it the default, so the phrases of the existing
+// users never change silently
+type Feature string
+
+const (
+	// FeatureUnicodeNormalization normalizes the identifier and the password
+	// to NFKD before the kdf like bip39 does for the sentence, so the same
+	// text typed on another system derives the same phrase. It changes the
+	// phrase of inputs which are not in NFKD already
+	FeatureUnicodeNormalization Feature = "unicode-normalization"
+	// FeatureConstantTime looks the words up by scanning the whole list and
+	// compares the checksum in constant time, it never changes a phrase
+	FeatureConstantTime Feature = "constant-time"
+)
+
+// _features tells whether a feature changes the derived phrases, those are
+// recorded in the envelope of GenerateDetailed
+var _features = map[Feature]bool{
+	FeatureUnicodeNormalization: true,
+	FeatureConstantTime:         false,
+}
+
+// WithFeatures enables the features, New fails on an unknown one. Enable the
+// features changing the phrases on every later generation as well
+func WithFeatures(features ...Feature) Option {
+	return func(m *mnemonicer) {
+		if m.features == nil {
+			m.features = make(map[Feature]bool, len(features))
+		}
+		for _, f := range features {
+			m.features[f] = true
+		}
+	}
+}
+
+// Features returns the known features in order
+func Features() []Feature {
+	features := make([]Feature, 0, len(_features))
+	for f := range _features {
+		features = append(features, f)
+	}
+	sort.Slice(features, func(i, j int) bool { return features[i] < features[j] })
+	return features
+}
+
+func (m *mnemonicer) validateFeatures() error {
+	for f := range m.features {
+		if _, ok := _features[f]; !ok {
+			return fmt.Errorf("unsupported feature %s", f)
+		}
+	}
+	return nil
+}
+
+// derivationFeatures returns the enabled features changing the phrases in
+// order
+func (m *mnemonicer) derivationFeatures() []string {
+	var features []string
+	for _, f := range Features() {
+		if m.features[f] && _features[f] {
+			features = append(features, string(f))
+		}
+	}
+	return features
+}
+
+// normalize returns the text in NFKD with FeatureUnicodeNormalization
+func (m *mnemonicer) normalize(s string) string {
+	if !m.features[FeatureUnicodeNormalization] {
+		return s
+	}
+	return norm.NFKD.String(s)
+}
+
+// index returns the index of a word of the list
+func (m *mnemonicer) index(word string) int {
+	index, _ := m.lookup(word)
+	return index
+}
+
+// lookup returns the index of a word and whether the list has it, scanning
+// the whole list with FeatureConstantTime
+func (m *mnemonicer) lookup(word string) (int, bool) {
+	if !m.features[FeatureConstantTime] {
+		index, ok := m.dict[word]
+		return index, ok
+	}
+	index, found := 0, 0
+	for i, w := range m.words {
+		match := subtle.ConstantTimeCompare([]byte(w), []byte(word))
+		index = subtle.ConstantTimeSelect(match, i, index)
+		found |= match
+	}
+	return index, found == 1
+}
+
+// equal compares the checksums, in constant time with FeatureConstantTime
+func (m *mnemonicer) equal(a, b string) bool {
+	if !m.features[FeatureConstantTime] {
+		return a == b
+	}
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
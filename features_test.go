@@ -0,0 +1,76 @@
+package nomnemonic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFeatures(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	if _, err := New(words, WithFeatures("telepathy")); err == nil || err.Error() != "unsupported feature telepathy" {
+		t.Errorf("expected err 'unsupported feature telepathy' but actual %v", err)
+	}
+	if actual := Features(); len(actual) != 2 || actual[0] != FeatureConstantTime || actual[1] != FeatureUnicodeNormalization {
+		t.Errorf("expected the features in order but actual %v", actual)
+	}
+
+	plain, _ := New(words)
+	constant, _ := New(words, WithFeatures(FeatureConstantTime))
+	normalized, _ := New(words, WithFeatures(FeatureUnicodeNormalization))
+
+	expected := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	for name, m := range map[string]Mnemonicer{"constant time": constant, "normalized": normalized} {
+		r, err := m.GenerateDetailed("nomnemonic_test", "test12345678", "101938", 12)
+		if err != nil || strings.Join(r.Words, " ") != expected {
+			t.Errorf("%s: expected %s for ascii inputs but actual %v %v", name, expected, r.Words, err)
+		}
+		if name == "normalized" && strings.Join(r.Features, " ") != string(FeatureUnicodeNormalization) {
+			t.Errorf("%s: expected the feature in the envelope but actual %v", name, r.Features)
+		}
+		if name == "constant time" && r.Features != nil {
+			t.Errorf("%s: expected no features in the envelope but actual %v", name, r.Features)
+		}
+	}
+
+	// é composed and decomposed
+	composed, decomposed := "caf\u00e9_password", "cafe\u0301_password"
+	fromComposed, _ := normalized.Generate("nomnemonic_test", composed, "101938", 12)
+	fromDecomposed, _ := plain.Generate("nomnemonic_test", decomposed, "101938", 12)
+	unnormalized, _ := plain.Generate("nomnemonic_test", composed, "101938", 12)
+	if strings.Join(fromComposed, " ") != strings.Join(fromDecomposed, " ") {
+		t.Errorf("expected %v but actual %v", fromDecomposed, fromComposed)
+	}
+	if strings.Join(fromComposed, " ") == strings.Join(unnormalized, " ") {
+		t.Error("expected normalization to be opt-in")
+	}
+
+	for _, sentence := range []string{
+		expected,
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon zzzz",
+		"cinn venu brok old bras vagu padd unaw crit alar cons hobb",
+	} {
+		expectedEntropy, expectedErr := plain.CalculateEntropy(strings.Fields(sentence))
+		entropy, err := constant.CalculateEntropy(strings.Fields(sentence))
+		if string(entropy) != string(expectedEntropy) || (err == nil) != (expectedErr == nil) {
+			t.Errorf("%s: expected %x %v but actual %x %v", sentence, expectedEntropy, expectedErr, entropy, err)
+		}
+		expectedValid, expectedErr := plain.IsValid(strings.Fields(sentence))
+		valid, err := constant.IsValid(strings.Fields(sentence))
+		if valid != expectedValid || (err == nil) != (expectedErr == nil) {
+			t.Errorf("%s: expected valid %t %v but actual %t %v", sentence, expectedValid, expectedErr, valid, err)
+		}
+		expectedWords, _ := plain.ExpandWords(strings.Fields(sentence))
+		if words, _ := constant.ExpandWords(strings.Fields(sentence)); strings.Join(words, " ") != strings.Join(expectedWords, " ") {
+			t.Errorf("%s: expected expanded %v but actual %v", sentence, expectedWords, words)
+		}
+	}
+
+	if warnings := Check(Result{AlgorithmVersion: VersionAlgorithm, KDFHash: kdfHash(), WordlistChecksum: WordlistChecksum(words), Features: []string{"telepathy"}}); len(warnings) != 1 || warnings[0] != "feature telepathy is not supported by version "+Version {
+		t.Errorf("expected an unsupported feature warning but actual %v", warnings)
+	}
+}
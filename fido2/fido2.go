@@ -0,0 +1,161 @@
+// Package fido2 answers the challenges of nomnemonic.WithFactor with the
+// hmac-secret extension of a FIDO2 security key, through fido2-cred and
+// fido2-assert of libfido2, so any modern key contributes a device bound
+// secret to the derivation
+package fido2
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic/internal/command"
+)
+
+const (
+	_commandAssert = "fido2-assert"
+	_commandCred   = "fido2-cred"
+
+	// _saltSize is the size of the hmac-secret salts and outputs
+	_saltSize = 32
+
+	// RelyingParty is the relying party id of the credentials by default
+	RelyingParty = "nomnemonic"
+)
+
+var (
+	// ErrNoCredential is returned when the key has no credential of the id,
+	// the credential was made on another key or the key was reset
+	ErrNoCredential = errors.New("fido2: the security key has no such credential, use the key it was enrolled on or enroll it again")
+	// ErrNoHMACSecret is returned when the key does not support hmac-secret
+	ErrNoHMACSecret = errors.New("fido2: the security key does not support the hmac-secret extension")
+)
+
+type (
+	// Credential is a non resident hmac-secret credential, it is not secret
+	// but the phrases need it again, keep it with the other settings
+	Credential struct {
+		RelyingParty string
+		ID           []byte
+	}
+
+	// Authenticator is a security key, like /dev/hidraw0 as listed by
+	// fido2-token -L
+	Authenticator struct {
+		Device string
+		// UserVerification asks for the pin or the biometrics of the key
+		UserVerification bool
+
+		run command.Runner
+	}
+
+	// Factor answers the challenges with the hmac-secret of the credential
+	Factor struct {
+		Authenticator *Authenticator
+		Credential    Credential
+	}
+)
+
+// New returns the security key of the device
+func New(device string) (*Authenticator, error) {
+	if device == "" {
+		return nil, errors.New("fido2: no device")
+	}
+	return &Authenticator{Device: device, run: command.Run}, nil
+}
+
+// Enroll makes a hmac-secret credential of the relying party, RelyingParty
+// when empty, on the key. It waits for a touch
+func (a *Authenticator) Enroll(relyingParty, user string) (Credential, error) {
+	if relyingParty == "" {
+		relyingParty = RelyingParty
+	}
+	userID := make([]byte, 16)
+	if _, err := rand.Read(userID); err != nil {
+		return Credential{}, err
+	}
+	input, err := clientDataInput(relyingParty, user, b64(userID))
+	if err != nil {
+		return Credential{}, err
+	}
+	out, err := a.run(input, _commandCred, a.args("-M", "-h")...)
+	if err != nil {
+		return Credential{}, keyError(err)
+	}
+	// client data hash, relying party, format, authenticator data, credential
+	// id, signature and the optional certificate
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) < 6 {
+		return Credential{}, errors.New("fido2: invalid credential")
+	}
+	id, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[4]))
+	if err != nil || len(id) == 0 {
+		return Credential{}, errors.New("fido2: invalid credential id")
+	}
+	return Credential{RelyingParty: relyingParty, ID: id}, nil
+}
+
+// Respond returns the hmac-secret of the challenge, a 32 bytes salt, for the
+// credential. It waits for a touch
+func (f Factor) Respond(challenge []byte) ([]byte, error) {
+	if len(f.Credential.ID) == 0 {
+		return nil, ErrNoCredential
+	}
+	if len(challenge) != _saltSize {
+		return nil, fmt.Errorf("fido2: challenge must be %d bytes", _saltSize)
+	}
+	input, err := clientDataInput(f.Credential.RelyingParty, b64(f.Credential.ID), b64(challenge))
+	if err != nil {
+		return nil, err
+	}
+	a := f.Authenticator
+	out, err := a.run(input, _commandAssert, a.args("-G", "-h")...)
+	if err != nil {
+		return nil, keyError(err)
+	}
+	// client data hash, relying party, authenticator data, signature, the
+	// user id of resident credentials and the hmac-secret last
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) < 5 {
+		return nil, ErrNoHMACSecret
+	}
+	secret, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[len(lines)-1]))
+	if err != nil || len(secret) != _saltSize {
+		return nil, ErrNoHMACSecret
+	}
+	return secret, nil
+}
+
+func (a *Authenticator) args(args ...string) []string {
+	if a.UserVerification {
+		args = append(args, "-v")
+	}
+	return append(args, a.Device)
+}
+
+// clientDataInput returns the input lines of the tools starting with a
+// random client data hash, hmac-secret does not depend on it
+func clientDataInput(lines ...string) ([]byte, error) {
+	hash := make([]byte, 32)
+	if _, err := rand.Read(hash); err != nil {
+		return nil, err
+	}
+	return []byte(strings.Join(append([]string{b64(hash)}, lines...), "\n") + "\n"), nil
+}
+
+// keyError maps the libfido2 errors of the tools
+func keyError(err error) error {
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "FIDO_ERR_NO_CREDENTIALS"):
+		return ErrNoCredential
+	case strings.Contains(msg, "FIDO_ERR_UNSUPPORTED_EXTENSION"), strings.Contains(msg, "FIDO_ERR_UNSUPPORTED_OPTION"):
+		return ErrNoHMACSecret
+	}
+	return fmt.Errorf("fido2: %w", err)
+}
+
+func b64(b []byte) string {
+	return base64.StdEncoding.EncodeToString(b)
+}
@@ -0,0 +1,112 @@
+package fido2
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+var _ nomnemonic.Factor = Factor{}
+
+// fakeKey emulates fido2-cred and fido2-assert of a key with one secret
+type fakeKey struct {
+	secret      []byte
+	credentials map[string]bool
+	hmacSecret  bool
+	args        []string
+}
+
+func (k *fakeKey) run(stdin []byte, name string, args ...string) ([]byte, error) {
+	k.args = append([]string{name}, args...)
+	lines := strings.Split(strings.TrimSpace(string(stdin)), "\n")
+	switch name {
+	case _commandCred:
+		id := []byte("credential " + lines[2])
+		k.credentials[string(id)] = true
+		return []byte(strings.Join([]string{lines[0], lines[1], "packed", "YXV0aA==", b64(id), "c2ln"}, "\n") + "\n"), nil
+	case _commandAssert:
+		id, _ := base64.StdEncoding.DecodeString(lines[2])
+		if !k.credentials[string(id)] {
+			return nil, errors.New("exit status 1: fido2-assert: fido_dev_get_assert: FIDO_ERR_NO_CREDENTIALS")
+		}
+		out := []string{lines[0], lines[1], "YXV0aA==", "c2ln"}
+		if k.hmacSecret {
+			salt, _ := base64.StdEncoding.DecodeString(lines[3])
+			mac := hmac.New(sha256.New, k.secret)
+			mac.Write(id)
+			mac.Write(salt)
+			out = append(out, b64(mac.Sum(nil)))
+		}
+		return []byte(strings.Join(out, "\n") + "\n"), nil
+	}
+	return nil, errors.New("unknown command")
+}
+
+func TestFactor(t *testing.T) {
+	if _, err := New(""); err == nil {
+		t.Error("expected an error without a device")
+	}
+	key := &fakeKey{secret: []byte("device secret"), credentials: map[string]bool{}, hmacSecret: true}
+	a, err := New("/dev/hidraw0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	a.run = key.run
+	a.UserVerification = true
+
+	credential, err := a.Enroll("", "alice")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if credential.RelyingParty != RelyingParty || string(credential.ID) != "credential alice" {
+		t.Errorf("expected a credential of alice but actual %+v", credential)
+	}
+	if actual := strings.Join(key.args, " "); actual != "fido2-cred -M -h -v /dev/hidraw0" {
+		t.Errorf("expected the fido2-cred command but actual %s", actual)
+	}
+
+	f := Factor{Authenticator: a, Credential: credential}
+	challenge := nomnemonic.FactorChallenge("nomnemonic_test")
+	first, err := f.Respond(challenge)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	second, _ := f.Respond(challenge)
+	if len(first) != 32 || !bytes.Equal(first, second) {
+		t.Errorf("expected the same 32 bytes secret but actual %x and %x", first, second)
+	}
+	other, _ := f.Respond(nomnemonic.FactorChallenge("nomnemonic_other"))
+	if bytes.Equal(first, other) {
+		t.Error("expected another secret for another challenge")
+	}
+	if actual := strings.Join(key.args, " "); actual != "fido2-assert -G -h -v /dev/hidraw0" {
+		t.Errorf("expected the fido2-assert command but actual %s", actual)
+	}
+
+	tests := []struct {
+		name      string
+		factor    Factor
+		challenge []byte
+		err       string
+	}{
+		{name: "no credential", factor: Factor{Authenticator: a}, challenge: challenge, err: ErrNoCredential.Error()},
+		{name: "unknown credential", factor: Factor{Authenticator: a, Credential: Credential{RelyingParty: RelyingParty, ID: []byte("other")}}, challenge: challenge, err: ErrNoCredential.Error()},
+		{name: "short challenge", factor: f, challenge: challenge[:16], err: "fido2: challenge must be 32 bytes"},
+	}
+	for _, test := range tests {
+		if _, err := test.factor.Respond(test.challenge); err == nil || err.Error() != test.err {
+			t.Errorf("%s: expected err '%s' but actual %v", test.name, test.err, err)
+		}
+	}
+
+	key.hmacSecret = false
+	if _, err := f.Respond(challenge); !errors.Is(err, ErrNoHMACSecret) {
+		t.Errorf("expected %v but actual %v", ErrNoHMACSecret, err)
+	}
+}
@@ -2,4 +2,25 @@ module github.com/nomnemonic/nomnemonic
 
 go 1.19
 
-require golang.org/x/crypto v0.3.0
+require (
+	filippo.io/age v1.0.0
+	filippo.io/edwards25519 v1.0.0
+	github.com/ProtonMail/go-crypto v1.0.0
+	github.com/Yawning/aez v0.0.0-20211027044916-e49e68abd344
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.3.0
+	github.com/fxamacker/cbor/v2 v2.2.0
+	github.com/gtank/ristretto255 v0.1.2
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
+	github.com/tyler-smith/go-bip39 v1.1.0
+	golang.org/x/crypto v0.7.0
+	golang.org/x/sys v0.6.0
+	golang.org/x/term v0.6.0
+	golang.org/x/text v0.8.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/cloudflare/circl v1.3.3 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+	gitlab.com/yawning/bsaes.git v0.0.0-20190805113838-0a714cd429ec // indirect
+)
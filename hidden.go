@@ -0,0 +1,70 @@
+package nomnemonic
+
+import (
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+)
+
+type (
+	// Wallet is the seed of a phrase with a passphrase and the fingerprint of
+	// its bip32 master key, the one hardware wallets show to confirm the
+	// passphrase was typed right
+	Wallet struct {
+		Passphrase  string
+		Seed        []byte
+		Fingerprint []byte
+	}
+
+	// HiddenWallet is a phrase with its open wallet, of the empty passphrase,
+	// and its hidden wallet, of the derived passphrase. The open wallet holds
+	// a small balance to reveal under duress while the hidden one keeps the
+	// funds
+	HiddenWallet struct {
+		Words  []string
+		Open   Wallet
+		Hidden Wallet
+	}
+)
+
+// GenerateHiddenWallet generates the phrase of the inputs and both its open
+// and hidden wallets in one call, the passphrase of the hidden wallet is
+// GeneratePassphrase of the same inputs with the length and charset
+func (m *mnemonicer) GenerateHiddenWallet(in Inputs, length int, charset string) (HiddenWallet, error) {
+	if _, err := passphraseCharset(charset, length); err != nil {
+		return HiddenWallet{}, err
+	}
+	words, err := m.Generate(in.Identifier, in.Password, in.Passcode, in.Size)
+	if err != nil {
+		return HiddenWallet{}, err
+	}
+	passphrase, err := m.GeneratePassphrase(in.Identifier, in.Password, in.Passcode, length, charset)
+	if err != nil {
+		return HiddenWallet{}, err
+	}
+
+	sentence := strings.Join(words, " ")
+	open, err := m.wallet(sentence, "")
+	if err != nil {
+		return HiddenWallet{}, err
+	}
+	hidden, err := m.wallet(sentence, passphrase)
+	if err != nil {
+		return HiddenWallet{}, err
+	}
+	return HiddenWallet{Words: words, Open: open, Hidden: hidden}, nil
+}
+
+// wallet returns the bip39 seed of the sentence and passphrase and the
+// fingerprint of its master key
+func (m *mnemonicer) wallet(sentence, passphrase string) (Wallet, error) {
+	seed, err := m.GenerateSeed(sentence, passphrase)
+	if err != nil {
+		return Wallet{}, err
+	}
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return Wallet{}, err
+	}
+	return Wallet{Passphrase: passphrase, Seed: seed, Fingerprint: master.Fingerprint()}, nil
+}
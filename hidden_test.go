@@ -0,0 +1,93 @@
+package nomnemonic
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+)
+
+func TestGenerateHiddenWallet(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := m.GenerateHiddenWallet(Inputs{Identifier: "nomnemonic_test", Password: "test12345678", Passcode: "101938", Size: 12}, 24, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sentence := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	if actual := strings.Join(w.Words, " "); actual != sentence {
+		t.Errorf("expected %s but actual %s", sentence, actual)
+	}
+	if w.Open.Passphrase != "" || w.Hidden.Passphrase != "GSgVx461jCe8QXnGrRwLKqHF" {
+		t.Errorf("expected the empty and the derived passphrases but actual %q and %q", w.Open.Passphrase, w.Hidden.Passphrase)
+	}
+
+	for _, wallet := range []Wallet{w.Open, w.Hidden} {
+		seed, _ := m.GenerateSeed(sentence, wallet.Passphrase)
+		if !bytes.Equal(seed, wallet.Seed) {
+			t.Errorf("%q: expected seed %x but actual %x", wallet.Passphrase, seed, wallet.Seed)
+		}
+		master, _ := bip32.NewMasterKey(seed)
+		if !bytes.Equal(master.Fingerprint(), wallet.Fingerprint) {
+			t.Errorf("%q: expected fingerprint %x but actual %x", wallet.Passphrase, master.Fingerprint(), wallet.Fingerprint)
+		}
+	}
+	if bytes.Equal(w.Open.Fingerprint, w.Hidden.Fingerprint) {
+		t.Error("expected the wallets to differ")
+	}
+
+	if _, err := m.GenerateHiddenWallet(Inputs{Identifier: "n", Password: "test12345678", Passcode: "101938", Size: 12}, 24, ""); !errors.Is(err, ErrShortIdentifier) {
+		t.Errorf("expected ErrShortIdentifier but actual %v", err)
+	}
+	if _, err := m.GenerateHiddenWallet(Inputs{Identifier: "nomnemonic_test", Password: "test12345678", Passcode: "101938", Size: 12}, 8, ""); err == nil || err.Error() != "passphrase must be at least 22 chars of the charset for 128 bits" {
+		t.Errorf("expected the passphrase length error but actual %v", err)
+	}
+}
+
+func TestGenerateHiddenWalletPolicy(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(InsecureFastKDFEnv, "1")
+	in := Inputs{Identifier: "nomnemonic_test", Password: "test12345678", Passcode: "101938", Size: 12}
+
+	free, err := New(words, WithInsecureFastKDF())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := free.GeneratePassphrase(in.Identifier, in.Password, in.Passcode, 24, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := New(words, WithInsecureFastKDF(), WithPolicy(Policy{Sizes: []int{12}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	passphrase, err := m.GeneratePassphrase(in.Identifier, in.Password, in.Passcode, 24, "")
+	if err != nil || passphrase != expected {
+		t.Errorf("expected passphrase %q but actual %q, %v", expected, passphrase, err)
+	}
+	w, err := m.GenerateHiddenWallet(in, 24, "")
+	if err != nil || w.Hidden.Passphrase != expected {
+		t.Errorf("expected the hidden wallet of %q but actual %q, %v", expected, w.Hidden.Passphrase, err)
+	}
+
+	strict, err := New(words, WithInsecureFastKDF(), WithPolicy(Policy{Sizes: []int{12}, PasswordMinLength: 32}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := strict.GeneratePassphrase(in.Identifier, in.Password, in.Passcode, 24, ""); !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("expected the password rule to apply but actual %v", err)
+	}
+}
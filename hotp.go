@@ -0,0 +1,71 @@
+package nomnemonic
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	_hotpMinSecretSize = 16
+	_otpauthScheme     = "otpauth"
+)
+
+// HOTPPasscode returns the rfc 4226 hotp value of the secret at the counter,
+// the 6 digits an authenticator app enrolled with the secret shows, so the
+// passcode is anchored to the enrollment instead of another memorized number.
+// The phrase depends on the counter, keep it with the identifier
+func HOTPPasscode(secret []byte, counter uint64) (string, error) {
+	if len(secret) < _hotpMinSecretSize {
+		return "", errorf(ErrInvalidPasscode, "hotp secret must be at least %d bytes", _hotpMinSecretSize)
+	}
+	mac := hmac.New(sha1.New, secret)
+	_ = binary.Write(mac, binary.BigEndian, counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff
+	modulo := uint32(1)
+	for i := 0; i < _inputPasscodeLength; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", _inputPasscodeLength, code%modulo), nil
+}
+
+// DecodeHOTPSecret decodes the base32 secret of an authenticator app
+// enrollment, given as is or as the otpauth://hotp uri of its qr code. The
+// uri must be of 6 digits sha1 codes, the others do not show the passcodes
+func DecodeHOTPSecret(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(strings.ToLower(s), _otpauthScheme+":") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, errorf(ErrInvalidPasscode, "invalid otpauth uri")
+		}
+		if !strings.EqualFold(u.Host, "hotp") {
+			return nil, errorf(ErrInvalidPasscode, "otpauth uri of %s instead of hotp", u.Host)
+		}
+		q := u.Query()
+		if a := q.Get("algorithm"); a != "" && !strings.EqualFold(a, "sha1") {
+			return nil, errorf(ErrInvalidPasscode, "hotp algorithm %s is not supported", a)
+		}
+		if d := q.Get("digits"); d != "" && d != fmt.Sprint(_inputPasscodeLength) {
+			return nil, errorf(ErrInvalidPasscode, "hotp codes must be %d digits", _inputPasscodeLength)
+		}
+		s = q.Get("secret")
+	}
+
+	s = strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(s))
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, errorf(ErrInvalidPasscode, "invalid base32 hotp secret")
+	}
+	if len(secret) < _hotpMinSecretSize {
+		return nil, errorf(ErrInvalidPasscode, "hotp secret must be at least %d bytes", _hotpMinSecretSize)
+	}
+	return secret, nil
+}
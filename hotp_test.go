@@ -0,0 +1,76 @@
+package nomnemonic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestHOTPPasscode(t *testing.T) {
+	// rfc 4226 appendix d
+	secret := []byte("12345678901234567890")
+	expected := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+	for counter, e := range expected {
+		actual, err := HOTPPasscode(secret, uint64(counter))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if actual != e {
+			t.Errorf("counter %d: expected %s but actual %s", counter, e, actual)
+		}
+	}
+
+	if _, err := HOTPPasscode(secret[:15], 0); !errors.Is(err, ErrInvalidPasscode) {
+		t.Errorf("expected %v but actual %v", ErrInvalidPasscode, err)
+	}
+
+	list, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	m, err := New(list)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	passcode, _ := HOTPPasscode(secret, 3)
+	words, err := m.Generate("nomnemonic_test", "test12345678", passcode, 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expectedWords, _ := m.Generate("nomnemonic_test", "test12345678", "969429", 12)
+	if strings.Join(words, " ") != strings.Join(expectedWords, " ") {
+		t.Errorf("expected %v but actual %v", expectedWords, words)
+	}
+}
+
+func TestDecodeHOTPSecret(t *testing.T) {
+	// base32 of 12345678901234567890
+	const encoded = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	tests := []struct {
+		s   string
+		err string
+	}{
+		{s: encoded},
+		{s: "gezd gnbv gy3t qojq gezd gnbv gy3t qojq"},
+		{s: "otpauth://hotp/nomnemonic:alice?secret=" + encoded + "&counter=0&issuer=nomnemonic"},
+		{s: "otpauth://hotp/alice?secret=" + encoded + "&algorithm=SHA1&digits=6"},
+		{s: "otpauth://totp/alice?secret=" + encoded, err: "otpauth uri of totp instead of hotp"},
+		{s: "otpauth://hotp/alice?secret=" + encoded + "&digits=8", err: "hotp codes must be 6 digits"},
+		{s: "otpauth://hotp/alice?secret=" + encoded + "&algorithm=SHA256", err: "hotp algorithm SHA256 is not supported"},
+		{s: "GEZDGNBVGY3TQOJQ", err: "hotp secret must be at least 16 bytes"},
+		{s: "not base32!", err: "invalid base32 hotp secret"},
+	}
+	for _, test := range tests {
+		secret, err := DecodeHOTPSecret(test.s)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err || !errors.Is(err, ErrInvalidPasscode) {
+				t.Errorf("%s: expected error %s but actual %v", test.s, test.err, err)
+			}
+			continue
+		}
+		if err != nil || string(secret) != "12345678901234567890" {
+			t.Errorf("%s: expected 12345678901234567890 but actual %s, %v", test.s, secret, err)
+		}
+	}
+}
@@ -0,0 +1,348 @@
+// Package httpapi exposes generate, validate and seed as mountable http
+// handlers with json requests and responses. Request bodies hold secrets, so
+// they are never logged and the error messages never echo them back
+package httpapi
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+const (
+	_maxBodySize = 1 << 16
+
+	_errInvalidBody  = "invalid json body"
+	_errInvalidInput = "invalid input"
+)
+
+type (
+	// GenerateRequest is the body of POST /generate
+	GenerateRequest struct {
+		Identifier string `json:"identifier"`
+		Password   string `json:"password"`
+		Passcode   string `json:"passcode"`
+		Size       int    `json:"size"`
+		// Format selects a registered nomnemonic.Encoder for the response
+		Format string `json:"format,omitempty"`
+		// Recipient is an age recipient or an armored pgp public key the
+		// response is encrypted to
+		Recipient string `json:"recipient,omitempty"`
+	}
+
+	// GenerateResponse is the response of POST /generate
+	GenerateResponse struct {
+		Words []string `json:"words"`
+	}
+
+	// ValidateRequest is the body of POST /validate
+	ValidateRequest struct {
+		Words []string `json:"words"`
+	}
+
+	// ValidateResponse is the response of POST /validate, an invalid mnemonic
+	// is not a request error
+	ValidateResponse struct {
+		Valid bool   `json:"valid"`
+		Error string `json:"error,omitempty"`
+	}
+
+	// SeedRequest is the body of POST /seed
+	SeedRequest struct {
+		Words      []string `json:"words"`
+		Passphrase string   `json:"passphrase"`
+		// Format selects a registered nomnemonic.Encoder for the response
+		Format string `json:"format,omitempty"`
+		// Recipient is an age recipient or an armored pgp public key the
+		// response is encrypted to
+		Recipient string `json:"recipient,omitempty"`
+	}
+
+	// SeedResponse is the response of POST /seed with the hex encoded bip39
+	// seed
+	SeedResponse struct {
+		Seed string `json:"seed"`
+	}
+
+	// VerifyRequest is the body of POST /verify, the size comes from the
+	// number of words
+	VerifyRequest struct {
+		Identifier string   `json:"identifier"`
+		Password   string   `json:"password"`
+		Passcode   string   `json:"passcode"`
+		Words      []string `json:"words"`
+	}
+
+	// VerifyResponse is the response of POST /verify
+	VerifyResponse struct {
+		Match bool `json:"match"`
+	}
+
+	// EncryptedResponse is the response of a request with a recipient, the
+	// armored age file or pgp message of the response it would get without
+	EncryptedResponse struct {
+		Encrypted string `json:"encrypted"`
+	}
+
+	// ErrorResponse is the response of a failed request
+	ErrorResponse struct {
+		Error string `json:"error"`
+	}
+)
+
+// Handler serves POST /generate, /validate, /seed, /verify, /session/add,
+// /session/generate and GET /compatibility, use http.StripPrefix to mount it
+// under a sub path. The requests are limited by a Limiter of the default
+// Limits. The session tokens are only valid for the handler which issued them,
+// the session routes respond 500 when its key could not be generated
+func Handler(m nomnemonic.Mnemonicer) http.Handler {
+	return HandlerWithLimits(m, Limits{})
+}
+
+// HandlerWithLimits is Handler with a Limiter of the limits, like a Client
+// key of the peer uid of a unix socket
+func HandlerWithLimits(m nomnemonic.Mnemonicer, limits Limits) http.Handler {
+	return NewLimiter(limits).Limit(routes(m))
+}
+
+// routes returns the mux of the handlers
+func routes(m nomnemonic.Mnemonicer) http.Handler {
+	s, err := nomnemonic.NewSession(_sessionTTL)
+	mux := http.NewServeMux()
+	mux.Handle("/generate", Generate(m))
+	mux.Handle("/validate", Validate(m))
+	mux.Handle("/seed", Seed(m))
+	mux.Handle("/verify", Verify(m))
+	mux.Handle("/session/add", sessionRoute(s, err, SessionAdd))
+	mux.Handle("/session/generate", sessionRoute(s, err, func(s *nomnemonic.Session) http.Handler {
+		return SessionGenerate(m, s)
+	}))
+	mux.Handle("/compatibility", Compatibility())
+	return mux
+}
+
+// sessionRoute returns the handler of the session, or one responding with an
+// internal server error when the session could not be set up, so the other
+// routes still serve
+func sessionRoute(s *nomnemonic.Session, err error, handler func(*nomnemonic.Session) http.Handler) http.Handler {
+	if err != nil {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			respond(w, http.StatusInternalServerError, ErrorResponse{Error: "session unavailable"})
+		})
+	}
+	return handler(s)
+}
+
+// Compatibility serves the nomnemonic.Compatibility matrix of the server so
+// clients detect mismatches before deriving, it holds no secrets
+func Compatibility() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", http.MethodGet)
+			respond(w, http.StatusMethodNotAllowed, ErrorResponse{Error: "method not allowed"})
+			return
+		}
+		respond(w, http.StatusOK, nomnemonic.Compatibility())
+	})
+}
+
+// Generate serves the GenerateRequest
+func Generate(m nomnemonic.Mnemonicer) http.Handler {
+	return post(func(w http.ResponseWriter, r *http.Request) {
+		var req GenerateRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		e, ok := lookupEncoder(w, req.Format)
+		if !ok {
+			return
+		}
+		seal, ok := parseRecipient(w, req.Recipient)
+		if !ok {
+			return
+		}
+		words, err := m.Generate(req.Identifier, req.Password, req.Passcode, req.Size)
+		if err != nil {
+			respond(w, http.StatusBadRequest, ErrorResponse{Error: redact(err, req.Identifier, req.Password, req.Passcode)})
+			return
+		}
+		entropy, _ := m.CalculateEntropy(words)
+		reply(w, e, seal, nomnemonic.Result{Words: words, Entropy: entropy}, GenerateResponse{Words: words})
+	})
+}
+
+// Validate serves the ValidateRequest
+func Validate(m nomnemonic.Mnemonicer) http.Handler {
+	return post(func(w http.ResponseWriter, r *http.Request) {
+		var req ValidateRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		ok, err := m.IsValid(req.Words)
+		if err != nil {
+			respond(w, http.StatusOK, ValidateResponse{Error: redact(err, req.Words...)})
+			return
+		}
+		respond(w, http.StatusOK, ValidateResponse{Valid: ok})
+	})
+}
+
+// Seed serves the SeedRequest, the words are validated and their
+// abbreviations expanded before the seed is generated
+func Seed(m nomnemonic.Mnemonicer) http.Handler {
+	return post(func(w http.ResponseWriter, r *http.Request) {
+		var req SeedRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		e, ok := lookupEncoder(w, req.Format)
+		if !ok {
+			return
+		}
+		seal, ok := parseRecipient(w, req.Recipient)
+		if !ok {
+			return
+		}
+		if ok, err := m.IsValid(req.Words); err != nil || !ok {
+			if err == nil {
+				err = nomnemonic.ErrInvalidChecksum
+			}
+			respond(w, http.StatusBadRequest, ErrorResponse{Error: redact(err, req.Words...)})
+			return
+		}
+		words, err := m.ExpandWords(req.Words)
+		if err != nil {
+			respond(w, http.StatusBadRequest, ErrorResponse{Error: redact(err, req.Words...)})
+			return
+		}
+		seed, err := m.GenerateSeed(strings.Join(words, " "), req.Passphrase)
+		if err != nil {
+			respond(w, http.StatusInternalServerError, ErrorResponse{Error: redact(err, req.Passphrase)})
+			return
+		}
+		reply(w, e, seal, nomnemonic.Result{Words: words, Seed: seed}, SeedResponse{Seed: hex.EncodeToString(seed)})
+	})
+}
+
+// Verify serves the VerifyRequest, the words are compared in constant time
+// and the outcome is reported to the Limiter for its backoff
+func Verify(m nomnemonic.Mnemonicer) http.Handler {
+	return post(func(w http.ResponseWriter, r *http.Request) {
+		var req VerifyRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		given := make([]string, len(req.Words))
+		for i, word := range req.Words {
+			given[i] = strings.ToLower(strings.TrimSpace(word))
+		}
+		words, err := m.Generate(req.Identifier, req.Password, req.Passcode, len(given))
+		if err != nil {
+			respond(w, http.StatusBadRequest, ErrorResponse{Error: redact(err, append([]string{req.Identifier, req.Password, req.Passcode}, given...)...)})
+			return
+		}
+		match := subtle.ConstantTimeCompare([]byte(strings.Join(words, " ")), []byte(strings.Join(given, " "))) == 1
+		if match {
+			reportSuccess(r)
+		} else {
+			reportFailure(r)
+		}
+		respond(w, http.StatusOK, VerifyResponse{Match: match})
+	})
+}
+
+// post rejects the other methods and disables caching of the responses
+func post(h http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			respond(w, http.StatusMethodNotAllowed, ErrorResponse{Error: "method not allowed"})
+			return
+		}
+		h(w, r)
+	})
+}
+
+// decode reads the json body into v, the decoder errors may quote the body so
+// a fixed message is returned instead
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	d := json.NewDecoder(http.MaxBytesReader(w, r.Body, _maxBodySize))
+	d.DisallowUnknownFields()
+	if err := d.Decode(v); err != nil {
+		respond(w, http.StatusBadRequest, ErrorResponse{Error: _errInvalidBody})
+		return false
+	}
+	return true
+}
+
+// lookupEncoder returns the registered encoder of the format, nil for the
+// json response, and responds with an error when there is none
+func lookupEncoder(w http.ResponseWriter, format string) (nomnemonic.Encoder, bool) {
+	if format == "" {
+		return nil, true
+	}
+	e, ok := nomnemonic.LookupEncoder(format)
+	if !ok {
+		respond(w, http.StatusBadRequest, ErrorResponse{Error: "unsupported format " + format})
+	}
+	return e, ok
+}
+
+// reply writes the secret response v, or the result with the encoder when
+// there is one, encrypted with seal when there is one
+func reply(w http.ResponseWriter, e nomnemonic.Encoder, seal sealer, r nomnemonic.Result, v interface{}) {
+	if e == nil && seal == nil {
+		respond(w, http.StatusOK, v)
+		return
+	}
+
+	var body []byte
+	var err error
+	contentType := "application/json"
+	if e != nil {
+		r.Version, r.AlgorithmVersion = nomnemonic.Version, nomnemonic.VersionAlgorithm
+		body, err = e.Encode(r)
+		contentType = "application/octet-stream"
+	} else {
+		body, err = json.Marshal(v)
+	}
+	if err != nil {
+		respond(w, http.StatusInternalServerError, ErrorResponse{Error: "encoding failed"})
+		return
+	}
+
+	if seal != nil {
+		encrypted, err := seal(body)
+		if err != nil {
+			respond(w, http.StatusInternalServerError, ErrorResponse{Error: "encryption failed"})
+			return
+		}
+		respond(w, http.StatusOK, EncryptedResponse{Encrypted: encrypted})
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+func respond(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// redact returns the error message unless it contains one of the secrets
+func redact(err error, secrets ...string) string {
+	msg := err.Error()
+	for _, s := range secrets {
+		if s != "" && strings.Contains(msg, s) {
+			return _errInvalidInput
+		}
+	}
+	return msg
+}
@@ -0,0 +1,173 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestHandler(t *testing.T) {
+	m, err := buildMnemonicer()
+	if err != nil {
+		t.Fatalf("couldn't build mnemonicer: %s", err.Error())
+	}
+	h := HandlerWithLimits(m, Limits{Burst: 100})
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+		output string
+	}{
+		{
+			method: http.MethodPost,
+			path:   "/generate",
+			body:   `{"identifier":"nomnemonic_test","password":"test12345678","passcode":"101938","size":12}`,
+			status: http.StatusOK,
+			output: `{"words":["cinnamon","venue","broken","old","brass","vague","paddle","unaware","critic","alarm","consider","hobby"]}`,
+		},
+		{
+			method: http.MethodPost,
+			path:   "/generate",
+			body:   `{"identifier":"nomnemonic_test","password":"test12345678","passcode":"abcdef","size":12}`,
+			status: http.StatusBadRequest,
+			output: `{"error":"invalid input"}`,
+		},
+		{
+			method: http.MethodPost,
+			path:   "/validate",
+			body:   `{"words":["abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","about"]}`,
+			status: http.StatusOK,
+			output: `{"valid":true}`,
+		},
+		{
+			method: http.MethodPost,
+			path:   "/validate",
+			body:   `{"words":["abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","secretword"]}`,
+			status: http.StatusOK,
+			output: `{"valid":false,"error":"invalid input"}`,
+		},
+		{
+			method: http.MethodPost,
+			path:   "/seed",
+			body:   `{"words":["abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","about"],"passphrase":"TREZOR"}`,
+			status: http.StatusOK,
+			output: `{"seed":"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"}`,
+		},
+		{
+			method: http.MethodPost,
+			path:   "/seed",
+			body:   `{"words":["aban","aban","aban","aban","aban","aban","aban","aban","aban","aban","aban","about"],"passphrase":"TREZOR"}`,
+			status: http.StatusOK,
+			output: `{"seed":"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"}`,
+		},
+		{
+			method: http.MethodPost,
+			path:   "/seed",
+			body:   `{"words":["abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon"]}`,
+			status: http.StatusBadRequest,
+			output: `{"error":"invalid checksum"}`,
+		},
+		{
+			method: http.MethodPost,
+			path:   "/seed",
+			body:   `{"words":"secret","passphrase":"secret"}`,
+			status: http.StatusBadRequest,
+			output: `{"error":"invalid json body"}`,
+		},
+		{
+			method: http.MethodGet,
+			path:   "/seed",
+			status: http.StatusMethodNotAllowed,
+			output: `{"error":"method not allowed"}`,
+		},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(test.method, test.path, strings.NewReader(test.body)))
+
+		if w.Code != test.status {
+			t.Errorf("%s %s: expected status %d but actual %d", test.method, test.path, test.status, w.Code)
+		}
+		if !strings.HasPrefix(w.Body.String(), test.output) {
+			t.Errorf("%s %s: expected body '%s' but actual '%s'", test.method, test.path, test.output, w.Body.String())
+		}
+		if w.Header().Get("Cache-Control") != "no-store" {
+			t.Errorf("%s %s: expected no-store but actual '%s'", test.method, test.path, w.Header().Get("Cache-Control"))
+		}
+	}
+}
+
+func buildMnemonicer() (nomnemonic.Mnemonicer, error) {
+	content, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		return nil, err
+	}
+	return nomnemonic.New(strings.Split(strings.TrimSpace(string(content)), "\n"))
+}
+
+type seedEncoder struct{}
+
+func (seedEncoder) Name() string { return "test-seed" }
+
+func (seedEncoder) Encode(r nomnemonic.Result) ([]byte, error) {
+	return r.Seed[:4], nil
+}
+
+func TestHandlerEncoder(t *testing.T) {
+	nomnemonic.RegisterEncoder(seedEncoder{})
+	m, err := buildMnemonicer()
+	if err != nil {
+		t.Fatalf("couldn't build mnemonicer: %s", err.Error())
+	}
+
+	tests := []struct {
+		format string
+		status int
+		output string
+	}{
+		{format: "test-seed", status: http.StatusOK, output: "\xc5\x52\x57\xc3"},
+		{format: "unknown", status: http.StatusBadRequest, output: `{"error":"unsupported format unknown"}` + "\n"},
+	}
+
+	for _, test := range tests {
+		body := `{"words":["abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","about"],"passphrase":"TREZOR","format":"` + test.format + `"}`
+		w := httptest.NewRecorder()
+		Handler(m).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/seed", strings.NewReader(body)))
+
+		if w.Code != test.status {
+			t.Errorf("%s: expected status %d but actual %d", test.format, test.status, w.Code)
+		}
+		if w.Body.String() != test.output {
+			t.Errorf("%s: expected body %q but actual %q", test.format, test.output, w.Body.String())
+		}
+	}
+}
+
+func TestCompatibility(t *testing.T) {
+	m, err := buildMnemonicer()
+	if err != nil {
+		t.Fatalf("couldn't build mnemonicer: %s", err.Error())
+	}
+	h := HandlerWithLimits(m, Limits{Burst: 100})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/compatibility", nil))
+	var c nomnemonic.CompatibilityMatrix
+	if err := json.NewDecoder(w.Body).Decode(&c); err != nil || w.Code != http.StatusOK || c.AlgorithmVersion != nomnemonic.VersionAlgorithm {
+		t.Errorf("expected the compatibility matrix but actual %d %+v %v", w.Code, c, err)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/compatibility", nil))
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != http.MethodGet {
+		t.Errorf("expected status %d but actual %d", http.StatusMethodNotAllowed, w.Code)
+	}
+}
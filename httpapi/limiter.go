@@ -0,0 +1,209 @@
+package httpapi
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// _maxClients is the number of tracked clients above which the idle ones are
+// forgotten
+const _maxClients = 10000
+
+type (
+	// Limits configures a Limiter, the zero values pick the defaults
+	Limits struct {
+		// Rate is the sustained number of requests per second of a client,
+		// 1 by default
+		Rate float64
+		// Burst is the number of requests a client can make at once, 5 by
+		// default
+		Burst int
+		// Concurrent is the number of requests of a client served at the
+		// same time, 1 by default so guesses can not run in parallel
+		Concurrent int
+		// Backoff is the wait after a failed verify, doubled on every
+		// consecutive failure up to MaxBackoff, 1s and 15m by default
+		Backoff    time.Duration
+		MaxBackoff time.Duration
+		// Client returns the key a client is limited by, the remote address
+		// host by default
+		Client func(r *http.Request) string
+	}
+
+	// Limiter limits the rate and the concurrency of the requests of every
+	// client, and makes a client wait exponentially longer after each failed
+	// verify, since the slow kdf is useless if guesses can be submitted in
+	// parallel
+	Limiter struct {
+		limits  Limits
+		now     func() time.Time
+		mu      sync.Mutex
+		clients map[string]*client
+	}
+
+	client struct {
+		tokens   float64
+		last     time.Time
+		inflight int
+		failures int
+		until    time.Time
+	}
+
+	// outcome is the result of a guess the handler reports to the Limiter
+	outcome int
+
+	outcomeKey struct{}
+)
+
+const (
+	_outcomeNone outcome = iota
+	_outcomeFailure
+	_outcomeSuccess
+)
+
+// NewLimiter returns a limiter with the limits, defaults filled in
+func NewLimiter(limits Limits) *Limiter {
+	if limits.Rate <= 0 {
+		limits.Rate = 1
+	}
+	if limits.Burst <= 0 {
+		limits.Burst = 5
+	}
+	if limits.Concurrent <= 0 {
+		limits.Concurrent = 1
+	}
+	if limits.Backoff <= 0 {
+		limits.Backoff = time.Second
+	}
+	if limits.MaxBackoff <= 0 {
+		limits.MaxBackoff = 15 * time.Minute
+	}
+	if limits.Client == nil {
+		limits.Client = remoteHost
+	}
+	return &Limiter{limits: limits, now: time.Now, clients: map[string]*client{}}
+}
+
+// Limit serves the requests of h within the limits and answers the others
+// with 429 and a Retry-After header
+func (l *Limiter) Limit(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := l.limits.Client(r)
+		if wait, ok := l.acquire(key); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			respond(w, http.StatusTooManyRequests, ErrorResponse{Error: "too many requests"})
+			return
+		}
+
+		result := new(outcome)
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), outcomeKey{}, result)))
+		l.release(key, *result)
+	})
+}
+
+// acquire takes a token and a concurrency slot of the client, or returns how
+// long it should wait
+func (l *Limiter) acquire(key string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	c, ok := l.clients[key]
+	if !ok {
+		if len(l.clients) >= _maxClients {
+			l.forget(now)
+		}
+		c = &client{tokens: float64(l.limits.Burst), last: now}
+		l.clients[key] = c
+	}
+	c.tokens = math.Min(float64(l.limits.Burst), c.tokens+now.Sub(c.last).Seconds()*l.limits.Rate)
+	c.last = now
+
+	switch {
+	case now.Before(c.until):
+		return c.until.Sub(now), false
+	case c.inflight >= l.limits.Concurrent:
+		return time.Second, false
+	case c.tokens < 1:
+		return time.Duration((1 - c.tokens) / l.limits.Rate * float64(time.Second)), false
+	}
+	c.tokens--
+	c.inflight++
+	return 0, true
+}
+
+// release frees the concurrency slot and updates the backoff of the outcome
+// of a guess
+func (l *Limiter) release(key string, result outcome) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	c := l.clients[key]
+	c.inflight--
+	switch result {
+	case _outcomeFailure:
+		c.failures++
+		backoff := l.limits.Backoff << uint(c.failures-1)
+		if backoff <= 0 || backoff > l.limits.MaxBackoff {
+			backoff = l.limits.MaxBackoff
+		}
+		c.until = l.now().Add(backoff)
+	case _outcomeSuccess:
+		c.failures = 0
+	}
+}
+
+// forget removes the idle clients without failures or used tokens and the
+// ones whose last backoff ended more than MaxBackoff ago, then the least
+// recently seen idle client while the clients are still too many
+func (l *Limiter) forget(now time.Time) {
+	for key, c := range l.clients {
+		full := c.tokens+now.Sub(c.last).Seconds()*l.limits.Rate >= float64(l.limits.Burst)
+		forgiven := c.failures == 0 || now.After(c.until.Add(l.limits.MaxBackoff))
+		if c.inflight == 0 && forgiven && full {
+			delete(l.clients, key)
+		}
+	}
+	for len(l.clients) >= _maxClients {
+		oldest := ""
+		for key, c := range l.clients {
+			if c.inflight == 0 && (oldest == "" || c.last.Before(l.clients[oldest].last)) {
+				oldest = key
+			}
+		}
+		if oldest == "" {
+			return
+		}
+		delete(l.clients, oldest)
+	}
+}
+
+// reportFailure marks the request as a failed guess for the Limiter
+func reportFailure(r *http.Request) {
+	report(r, _outcomeFailure)
+}
+
+// reportSuccess marks the request as a right guess for the Limiter, resetting
+// the backoff of the client
+func reportSuccess(r *http.Request) {
+	report(r, _outcomeSuccess)
+}
+
+func report(r *http.Request, o outcome) {
+	if result, ok := r.Context().Value(outcomeKey{}).(*outcome); ok {
+		*result = o
+	}
+}
+
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
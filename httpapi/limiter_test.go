@@ -0,0 +1,164 @@
+package httpapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewLimiter(Limits{Rate: 1, Burst: 2})
+	l.now = func() time.Time { return now }
+
+	var nested int
+	h := l.Limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch path.Base(r.URL.Path) {
+		case "verify":
+			if r.URL.Query().Get("match") == "true" {
+				reportSuccess(r)
+			} else {
+				reportFailure(r)
+			}
+		case "nested":
+			rec := httptest.NewRecorder()
+			l.Limit(http.NotFoundHandler()).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/seed", nil))
+			nested = rec.Code
+		}
+	}))
+
+	tests := []struct {
+		name       string
+		advance    time.Duration
+		path       string
+		status     int
+		retryAfter string
+	}{
+		{name: "burst 1", path: "/seed", status: http.StatusOK},
+		{name: "burst 2", path: "/seed", status: http.StatusOK},
+		{name: "rate", path: "/seed", status: http.StatusTooManyRequests, retryAfter: "1"},
+		{name: "refilled", advance: time.Second, path: "/verify", status: http.StatusOK},
+		{name: "first backoff", path: "/seed", status: http.StatusTooManyRequests, retryAfter: "1"},
+		{name: "second failure", advance: time.Second, path: "/verify", status: http.StatusOK},
+		{name: "doubled backoff", path: "/seed", status: http.StatusTooManyRequests, retryAfter: "2"},
+		{name: "still backing off", advance: time.Second, path: "/seed", status: http.StatusTooManyRequests, retryAfter: "1"},
+		{name: "after backoff under a prefix", advance: time.Second, path: "/api/verify?match=true", status: http.StatusOK},
+		{name: "reset", path: "/seed", status: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		now = now.Add(test.advance)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, test.path, nil))
+		if w.Code != test.status {
+			t.Errorf("%s: expected status %d but actual %d", test.name, test.status, w.Code)
+		}
+		if w.Header().Get("Retry-After") != test.retryAfter {
+			t.Errorf("%s: expected Retry-After '%s' but actual '%s'", test.name, test.retryAfter, w.Header().Get("Retry-After"))
+		}
+	}
+
+	now = now.Add(time.Minute)
+	other := httptest.NewRequest(http.MethodPost, "/nested", nil)
+	other.RemoteAddr = "192.0.2.2:1234"
+	h.ServeHTTP(httptest.NewRecorder(), other)
+	if nested != http.StatusNotFound {
+		t.Errorf("expected another client to be served but actual %d", nested)
+	}
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/nested", nil))
+	if nested != http.StatusTooManyRequests {
+		t.Errorf("expected a concurrent request to be refused but actual %d", nested)
+	}
+}
+
+func TestLimiterForget(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewLimiter(Limits{MaxBackoff: time.Minute})
+	l.now = func() time.Time { return now }
+
+	for _, key := range []string{"failed", "recent"} {
+		l.acquire(key)
+		l.release(key, _outcomeFailure)
+	}
+	now = now.Add(time.Minute + time.Second)
+	l.acquire("recent")
+	l.release("recent", _outcomeFailure)
+	now = now.Add(time.Minute)
+
+	for i := 0; len(l.clients) < _maxClients; i++ {
+		l.clients[strconv.Itoa(i)] = &client{failures: 1, until: now, last: now}
+	}
+	l.acquire("new")
+	l.release("new", _outcomeNone)
+
+	if _, ok := l.clients["failed"]; ok {
+		t.Error("expected the client whose backoff ended long ago to be forgotten")
+	}
+	if _, ok := l.clients["recent"]; !ok {
+		t.Error("expected the client backing off recently to be kept")
+	}
+	if len(l.clients) > _maxClients {
+		t.Errorf("expected at most %d clients but actual %d", _maxClients, len(l.clients))
+	}
+
+	// every client backing off, the least recently seen one makes room
+	l.clients["0"].last = now.Add(-time.Hour)
+	l.acquire("newer")
+	if _, ok := l.clients["0"]; ok || len(l.clients) > _maxClients {
+		t.Errorf("expected the least recently seen client to be forgotten but actual %d clients", len(l.clients))
+	}
+}
+
+func TestHandlerLimited(t *testing.T) {
+	m, err := buildMnemonicer()
+	if err != nil {
+		t.Fatalf("couldn't build mnemonicer: %s", err.Error())
+	}
+	h := Handler(m)
+
+	body := `{"identifier":"nomnemonic_test","password":"test12345678","passcode":"101938","words":["cinnamon","venue","broken","old","brass","vague","paddle","unaware","critic","alarm","consider","alarm"]}`
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/verify", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d but actual %d", http.StatusOK, w.Code)
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/compatibility", nil))
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected the backoff of the failed verify but actual %d", w.Code)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	m, err := buildMnemonicer()
+	if err != nil {
+		t.Fatalf("couldn't build mnemonicer: %s", err.Error())
+	}
+
+	tests := []struct {
+		words  string
+		status int
+		output string
+	}{
+		{words: "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby", status: http.StatusOK, output: `{"match":true}`},
+		{words: "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobbY", status: http.StatusOK, output: `{"match":true}`},
+		{words: "cinnamon venue broken old brass vague paddle unaware critic alarm hobby consider", status: http.StatusOK, output: `{"match":false}`},
+		{words: "cinnamon venue", status: http.StatusBadRequest, output: `{"error":"unsupported strength: 0"}`},
+	}
+
+	for _, test := range tests {
+		body := `{"identifier":"nomnemonic_test","password":"test12345678","passcode":"101938","words":["` + strings.Join(strings.Fields(test.words), `","`) + `"]}`
+		w := httptest.NewRecorder()
+		Handler(m).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/verify", strings.NewReader(body)))
+		if w.Code != test.status {
+			t.Errorf("%s: expected status %d but actual %d", test.words, test.status, w.Code)
+		}
+		if strings.TrimSpace(w.Body.String()) != test.output {
+			t.Errorf("%s: expected body '%s' but actual '%s'", test.words, test.output, w.Body.String())
+		}
+	}
+}
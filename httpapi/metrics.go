@@ -0,0 +1,160 @@
+package httpapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const _endpointOther = "other"
+
+var (
+	// _buckets are the upper bounds in seconds of the duration histograms,
+	// the kdf takes about a second on a desktop
+	_buckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+	_endpoints = map[string]bool{"/generate": true, "/validate": true, "/seed": true, "/verify": true, "/session/add": true, "/session/generate": true, "/compatibility": true}
+)
+
+type (
+	// Metrics collects the request durations and status codes and the kdf
+	// stage durations, and serves them in the prometheus text format. Give it
+	// to nomnemonic.WithObserver for the stage durations
+	Metrics struct {
+		mu       sync.Mutex
+		requests map[string]*histogram
+		stages   map[string]*histogram
+		statuses map[[2]string]uint64
+		errors   map[string]uint64
+	}
+
+	histogram struct {
+		counts []uint64
+		sum    float64
+		count  uint64
+	}
+
+	statusRecorder struct {
+		http.ResponseWriter
+		status int
+	}
+)
+
+// NewMetrics returns empty metrics
+func NewMetrics() *Metrics {
+	return &Metrics{
+		requests: map[string]*histogram{},
+		stages:   map[string]*histogram{},
+		statuses: map[[2]string]uint64{},
+		errors:   map[string]uint64{},
+	}
+}
+
+// ObserveStage records the duration of a kdf stage
+func (m *Metrics) ObserveStage(stage string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	observe(m.stages, stage, d)
+}
+
+// Instrument records the duration and the status of the requests of h, the
+// paths other than the handler endpoints share one label
+func (m *Metrics) Instrument(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(rec, r)
+
+		endpoint := r.URL.Path
+		if !_endpoints[endpoint] {
+			endpoint = _endpointOther
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		observe(m.requests, endpoint, time.Since(start))
+		m.statuses[[2]string{endpoint, strconv.Itoa(rec.status)}]++
+		if rec.status >= http.StatusBadRequest {
+			m.errors[endpoint]++
+		}
+	})
+}
+
+// ServeHTTP serves the metrics in the prometheus text format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	writeHistograms(w, "nomnemonic_request_duration_seconds", "duration of the requests", "endpoint", m.requests)
+	writeHistograms(w, "nomnemonic_kdf_stage_duration_seconds", "duration of the kdf stages", "stage", m.stages)
+
+	fmt.Fprintln(w, "# HELP nomnemonic_requests_total requests by endpoint and status code")
+	fmt.Fprintln(w, "# TYPE nomnemonic_requests_total counter")
+	keys := make([][2]string, 0, len(m.statuses))
+	for k := range m.statuses {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, k := range keys {
+		fmt.Fprintf(w, "nomnemonic_requests_total{endpoint=%q,code=%q} %d\n", k[0], k[1], m.statuses[k])
+	}
+
+	fmt.Fprintln(w, "# HELP nomnemonic_request_errors_total requests answered with a 4xx or 5xx status")
+	fmt.Fprintln(w, "# TYPE nomnemonic_request_errors_total counter")
+	endpoints := make([]string, 0, len(m.errors))
+	for endpoint := range m.errors {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		fmt.Fprintf(w, "nomnemonic_request_errors_total{endpoint=%q} %d\n", endpoint, m.errors[endpoint])
+	}
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func observe(histograms map[string]*histogram, label string, d time.Duration) {
+	h, ok := histograms[label]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(_buckets))}
+		histograms[label] = h
+	}
+	seconds := d.Seconds()
+	for i, le := range _buckets {
+		if seconds <= le {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+func writeHistograms(w io.Writer, name, help, label string, histograms map[string]*histogram) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	values := make([]string, 0, len(histograms))
+	for value := range histograms {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	for _, value := range values {
+		h := histograms[value]
+		for i, le := range _buckets {
+			fmt.Fprintf(w, "%s_bucket{%s=%q,le=%q} %d\n", name, label, value, strconv.FormatFloat(le, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{%s=%q,le=\"+Inf\"} %d\n", name, label, value, h.count)
+		fmt.Fprintf(w, "%s_sum{%s=%q} %g\n", name, label, value, h.sum)
+		fmt.Fprintf(w, "%s_count{%s=%q} %d\n", name, label, value, h.count)
+	}
+}
@@ -0,0 +1,59 @@
+package httpapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestMetrics(t *testing.T) {
+	content, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics := NewMetrics()
+	m, err := nomnemonic.New(strings.Split(strings.TrimSpace(string(content)), "\n"), nomnemonic.WithObserver(metrics))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := metrics.Instrument(Handler(m))
+
+	requests := []struct {
+		path string
+		body string
+	}{
+		{path: "/generate", body: `{"identifier":"nomnemonic_test","password":"test12345678","passcode":"101938","size":12}`},
+		{path: "/seed", body: `{"words":"invalid"}`},
+		{path: "/unknown/secret"},
+	}
+	for _, r := range requests {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, r.path, strings.NewReader(r.body)))
+	}
+
+	w := httptest.NewRecorder()
+	metrics.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+
+	for _, expected := range []string{
+		`nomnemonic_request_duration_seconds_count{endpoint="/generate"} 1`,
+		`nomnemonic_request_duration_seconds_bucket{endpoint="/seed",le="+Inf"} 1`,
+		`nomnemonic_kdf_stage_duration_seconds_count{stage="pbkdf2"} 1`,
+		`nomnemonic_kdf_stage_duration_seconds_count{stage="scrypt"} 1`,
+		`nomnemonic_requests_total{endpoint="/generate",code="200"} 1`,
+		`nomnemonic_requests_total{endpoint="/seed",code="400"} 1`,
+		`nomnemonic_requests_total{endpoint="other",code="404"} 1`,
+		`nomnemonic_request_errors_total{endpoint="/seed"} 1`,
+		`nomnemonic_request_errors_total{endpoint="other"} 1`,
+	} {
+		if !strings.Contains(body, expected+"\n") {
+			t.Errorf("expected metrics to contain '%s' but actual\n%s", expected, body)
+		}
+	}
+	if strings.Contains(body, "secret") {
+		t.Errorf("expected no request path in metrics but actual\n%s", body)
+	}
+}
@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+
+	"filippo.io/age"
+	agearmor "filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+const _pgpPublicKeyBlock = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+// sealer encrypts a response to the recipient of the request and armors it
+type sealer func(plaintext []byte) (string, error)
+
+// parseRecipient returns the sealer of the recipient, nil when there is none,
+// and responds with an error when it can not be parsed. It runs before the
+// kdf so a bad recipient fails fast
+func parseRecipient(w http.ResponseWriter, recipient string) (sealer, bool) {
+	recipient = strings.TrimSpace(recipient)
+	var seal sealer
+	var err error
+	switch {
+	case recipient == "":
+		return nil, true
+	case strings.HasPrefix(recipient, "age1"):
+		seal, err = ageSealer(recipient)
+	case strings.HasPrefix(recipient, _pgpPublicKeyBlock):
+		seal, err = pgpSealer(recipient)
+	default:
+		err = errors.New("recipient must be an age recipient or an armored pgp public key")
+	}
+	if err != nil {
+		respond(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return nil, false
+	}
+	return seal, true
+}
+
+func ageSealer(recipient string) (sealer, error) {
+	r, err := age.ParseX25519Recipient(recipient)
+	if err != nil {
+		return nil, errors.New("invalid age recipient")
+	}
+	return func(plaintext []byte) (string, error) {
+		var buf bytes.Buffer
+		aw := agearmor.NewWriter(&buf)
+		w, err := age.Encrypt(aw, r)
+		if err != nil {
+			return "", err
+		}
+		if _, err := w.Write(plaintext); err != nil {
+			return "", err
+		}
+		if err := w.Close(); err != nil {
+			return "", err
+		}
+		if err := aw.Close(); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}, nil
+}
+
+func pgpSealer(key string) (sealer, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+	if err != nil || len(entities) == 0 {
+		return nil, errors.New("invalid pgp public key")
+	}
+	return func(plaintext []byte) (string, error) {
+		var buf bytes.Buffer
+		aw, err := pgparmor.Encode(&buf, "PGP MESSAGE", nil)
+		if err != nil {
+			return "", err
+		}
+		w, err := openpgp.Encrypt(aw, entities, nil, &openpgp.FileHints{IsBinary: true}, nil)
+		if err != nil {
+			return "", err
+		}
+		if _, err := w.Write(plaintext); err != nil {
+			return "", err
+		}
+		if err := w.Close(); err != nil {
+			return "", err
+		}
+		if err := aw.Close(); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}, nil
+}
@@ -0,0 +1,129 @@
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	agearmor "filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+const _seedTrezor = `{"seed":"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"}`
+
+func TestRecipient(t *testing.T) {
+	m, err := buildMnemonicer()
+	if err != nil {
+		t.Fatalf("couldn't build mnemonicer: %s", err.Error())
+	}
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entity, err := openpgp.NewEntity("nomnemonic", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// SerializePrivate signs the encryption subkey
+	if err := entity.SerializePrivate(io.Discard, nil); err != nil {
+		t.Fatal(err)
+	}
+	var publicKey bytes.Buffer
+	aw, _ := pgparmor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+	if err := entity.Serialize(aw); err != nil {
+		t.Fatal(err)
+	}
+	aw.Close()
+
+	tests := []struct {
+		name      string
+		recipient string
+		status    int
+		decrypt   func(armored string) ([]byte, error)
+		output    string
+	}{
+		{
+			name:      "age",
+			recipient: identity.Recipient().String(),
+			status:    http.StatusOK,
+			decrypt: func(armored string) ([]byte, error) {
+				r, err := age.Decrypt(agearmor.NewReader(strings.NewReader(armored)), identity)
+				if err != nil {
+					return nil, err
+				}
+				return io.ReadAll(r)
+			},
+			output: _seedTrezor,
+		},
+		{
+			name:      "pgp",
+			recipient: publicKey.String(),
+			status:    http.StatusOK,
+			decrypt: func(armored string) ([]byte, error) {
+				block, err := pgparmor.Decode(strings.NewReader(armored))
+				if err != nil {
+					return nil, err
+				}
+				md, err := openpgp.ReadMessage(block.Body, openpgp.EntityList{entity}, nil, nil)
+				if err != nil {
+					return nil, err
+				}
+				return io.ReadAll(md.UnverifiedBody)
+			},
+			output: _seedTrezor,
+		},
+		{
+			name:      "invalid age",
+			recipient: "age1invalid",
+			status:    http.StatusBadRequest,
+			output:    `{"error":"invalid age recipient"}`,
+		},
+		{
+			name:      "unknown",
+			recipient: "ssh-ed25519 AAAA",
+			status:    http.StatusBadRequest,
+			output:    `{"error":"recipient must be an age recipient or an armored pgp public key"}`,
+		},
+	}
+
+	for _, test := range tests {
+		recipient, _ := json.Marshal(test.recipient)
+		body := `{"words":["abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","about"],"passphrase":"TREZOR","recipient":` + string(recipient) + `}`
+		w := httptest.NewRecorder()
+		Handler(m).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/seed", strings.NewReader(body)))
+
+		if w.Code != test.status {
+			t.Errorf("%s: expected status %d but actual %d (%s)", test.name, test.status, w.Code, w.Body.String())
+			continue
+		}
+		if test.decrypt == nil {
+			if strings.TrimSpace(w.Body.String()) != test.output {
+				t.Errorf("%s: expected body '%s' but actual '%s'", test.name, test.output, w.Body.String())
+			}
+			continue
+		}
+
+		if strings.Contains(w.Body.String(), "c55257c3") {
+			t.Errorf("%s: expected no plaintext seed but actual '%s'", test.name, w.Body.String())
+		}
+		var resp EncryptedResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		plaintext, err := test.decrypt(resp.Encrypted)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+			continue
+		}
+		if string(plaintext) != test.output {
+			t.Errorf("%s: expected '%s' but actual '%s'", test.name, test.output, plaintext)
+		}
+	}
+}
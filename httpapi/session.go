@@ -0,0 +1,109 @@
+package httpapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+// _sessionTTL is how long a session token lives after its first part
+const _sessionTTL = 10 * time.Minute
+
+type (
+	// SessionAddRequest is the body of POST /session/add, an empty token
+	// starts a new session
+	SessionAddRequest struct {
+		Token string `json:"token,omitempty"`
+		Part  string `json:"part"`
+		Value string `json:"value"`
+	}
+
+	// SessionAddResponse is the response of POST /session/add, the token and
+	// the commitments go to the next party while the receipt stays with the
+	// one who gave the part
+	SessionAddResponse struct {
+		Token       string            `json:"token"`
+		Commitments map[string]string `json:"commitments"`
+		Missing     []string          `json:"missing,omitempty"`
+		Expires     time.Time         `json:"expires"`
+		Receipt     SessionReceipt    `json:"receipt"`
+	}
+
+	// SessionReceipt opens the commitment of the added part
+	SessionReceipt struct {
+		Part       string `json:"part"`
+		Nonce      string `json:"nonce"`
+		Commitment string `json:"commitment"`
+	}
+
+	// SessionGenerateRequest is the body of POST /session/generate
+	SessionGenerateRequest struct {
+		Token string `json:"token"`
+		Size  int    `json:"size"`
+		// Format selects a registered nomnemonic.Encoder for the response
+		Format string `json:"format,omitempty"`
+		// Recipient is an age recipient or an armored pgp public key the
+		// response is encrypted to
+		Recipient string `json:"recipient,omitempty"`
+	}
+)
+
+// SessionAdd serves the SessionAddRequest
+func SessionAdd(s *nomnemonic.Session) http.Handler {
+	return post(func(w http.ResponseWriter, r *http.Request) {
+		var req SessionAddRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		state, receipt, err := s.Add(req.Token, req.Part, req.Value)
+		if err != nil {
+			respond(w, http.StatusBadRequest, ErrorResponse{Error: redact(err, req.Value)})
+			return
+		}
+		respond(w, http.StatusOK, SessionAddResponse{
+			Token:       state.Token,
+			Commitments: state.Commitments,
+			Missing:     state.Missing,
+			Expires:     state.Expires.UTC(),
+			Receipt:     SessionReceipt{Part: receipt.Part, Nonce: receipt.Nonce, Commitment: receipt.Commitment},
+		})
+	})
+}
+
+// SessionGenerate serves the SessionGenerateRequest, the token must hold all
+// the parts
+func SessionGenerate(m nomnemonic.Mnemonicer, s *nomnemonic.Session) http.Handler {
+	return post(func(w http.ResponseWriter, r *http.Request) {
+		var req SessionGenerateRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		e, ok := lookupEncoder(w, req.Format)
+		if !ok {
+			return
+		}
+		seal, ok := parseRecipient(w, req.Recipient)
+		if !ok {
+			return
+		}
+		state, err := s.State(req.Token)
+		if err != nil {
+			respond(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+		if len(state.Missing) > 0 {
+			respond(w, http.StatusBadRequest, ErrorResponse{Error: state.Missing[0] + " is missing"})
+			return
+		}
+		words, err := s.Generate(m, req.Token, req.Size)
+		if err != nil {
+			// the parts are not known here to redact them, so the generate
+			// errors which may quote them are never passed through
+			respond(w, http.StatusBadRequest, ErrorResponse{Error: _errInvalidInput})
+			return
+		}
+		entropy, _ := m.CalculateEntropy(words)
+		reply(w, e, seal, nomnemonic.Result{Words: words, Entropy: entropy}, GenerateResponse{Words: words})
+	})
+}
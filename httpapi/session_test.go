@@ -0,0 +1,86 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSession(t *testing.T) {
+	m, err := buildMnemonicer()
+	if err != nil {
+		t.Fatalf("couldn't build mnemonicer: %s", err.Error())
+	}
+	h := HandlerWithLimits(m, Limits{Burst: 100})
+
+	post := func(path string, v interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(v)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(string(body))))
+		return w
+	}
+
+	var token string
+	var receipts []SessionReceipt
+	for _, part := range [][2]string{{"identifier", "nomnemonic_test"}, {"password", "test12345678"}} {
+		w := post("/session/add", SessionAddRequest{Token: token, Part: part[0], Value: part[1]})
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status %d but actual %d %s", part[0], http.StatusOK, w.Code, w.Body.String())
+		}
+		var resp SessionAddResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		token = resp.Token
+		receipts = append(receipts, resp.Receipt)
+	}
+
+	w := post("/session/generate", SessionGenerateRequest{Token: token, Size: 12})
+	if w.Code != http.StatusBadRequest || w.Body.String() != `{"error":"passcode is missing"}`+"\n" {
+		t.Errorf("expected passcode is missing but actual %d %s", w.Code, w.Body.String())
+	}
+
+	w = post("/session/add", SessionAddRequest{Token: token, Part: "passcode", Value: "101938"})
+	var resp SessionAddResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	for _, receipt := range receipts {
+		if resp.Commitments[receipt.Part] != receipt.Commitment {
+			t.Errorf("%s: expected commitment %s but actual %s", receipt.Part, receipt.Commitment, resp.Commitments[receipt.Part])
+		}
+	}
+
+	w = post("/session/add", SessionAddRequest{Token: resp.Token, Part: "passcode", Value: "secret"})
+	if w.Code != http.StatusBadRequest || w.Body.String() != `{"error":"passcode is already set"}`+"\n" {
+		t.Errorf("expected passcode is already set but actual %d %s", w.Code, w.Body.String())
+	}
+
+	w = post("/session/generate", SessionGenerateRequest{Token: resp.Token, Size: 12})
+	expected := `{"words":["cinnamon","venue","broken","old","brass","vague","paddle","unaware","critic","alarm","consider","hobby"]}` + "\n"
+	if w.Code != http.StatusOK || w.Body.String() != expected {
+		t.Errorf("expected %s but actual %d %s", expected, w.Code, w.Body.String())
+	}
+
+	w = post("/session/generate", SessionGenerateRequest{Token: resp.Token, Size: 13})
+	if w.Code != http.StatusBadRequest || w.Body.String() != `{"error":"invalid input"}`+"\n" {
+		t.Errorf("expected invalid input but actual %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	Handler(m).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/session/generate", strings.NewReader(`{"token":"`+resp.Token+`","size":12}`)))
+	if w.Code != http.StatusBadRequest || w.Body.String() != `{"error":"invalid session token"}`+"\n" {
+		t.Errorf("expected invalid session token from another handler but actual %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestSessionRoute(t *testing.T) {
+	w := httptest.NewRecorder()
+	sessionRoute(nil, errors.New("no entropy"), SessionAdd).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/session/add", strings.NewReader(`{}`)))
+	if w.Code != http.StatusInternalServerError || w.Body.String() != `{"error":"session unavailable"}`+"\n" {
+		t.Errorf("expected session unavailable but actual %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,50 @@
+package nomnemonic
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// IndexSentence returns the words as space separated zero padded 4 digits
+// indexes in the word list, starting from 0000 like SeedQR, the format
+// stamped into steel plates
+func (m *mnemonicer) IndexSentence(words []string) (string, error) {
+	digits, err := m.EncodeSeedQR(words)
+	if err != nil {
+		return "", err
+	}
+
+	indexes := make([]string, 0, len(words))
+	for i := 0; i < len(digits); i += _seedQRDigits {
+		indexes = append(indexes, digits[i:i+_seedQRDigits])
+	}
+	return strings.Join(indexes, " "), nil
+}
+
+// WordsFromIndexes returns the words of the whitespace separated indexes,
+// the zero padding is optional since it is often left out by hand
+func (m *mnemonicer) WordsFromIndexes(indexes string) ([]string, error) {
+	fields := strings.Fields(indexes)
+	if len(fields) == 0 {
+		return nil, errors.New("no indexes")
+	}
+
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		index, err := strconv.Atoi(f)
+		if err != nil || len(f) > _seedQRDigits || index < 0 || index >= len(m.words) {
+			return nil, errorf(ErrUnknownWord, "invalid word index %s", f)
+		}
+		words = append(words, m.words[index])
+	}
+
+	ok, err := m.IsValid(words)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidChecksum
+	}
+	return words, nil
+}
@@ -0,0 +1,67 @@
+package nomnemonic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIndexSentence(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sentence := "forum undo fragile fade shy sign arrest garment culture tube off merit"
+	expected := "0733 1895 0739 0654 1596 1602 0099 0767 0428 1872 1226 1116"
+	actual, err := m.IndexSentence(strings.Fields(sentence))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != expected {
+		t.Errorf("expected '%s' but actual '%s'", expected, actual)
+	}
+
+	for _, indexes := range []string{expected, "733 1895 739 654 1596 1602 99 767 428 1872 1226 1116", "0733\n1895\t0739 0654 1596 1602 0099 0767 0428 1872 1226 1116\n"} {
+		words, err := m.WordsFromIndexes(indexes)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", indexes, err.Error())
+			continue
+		}
+		if strings.Join(words, " ") != sentence {
+			t.Errorf("expected '%s' but actual '%s'", sentence, strings.Join(words, " "))
+		}
+	}
+}
+
+func TestWordsFromIndexesErrors(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		indexes string
+		err     string
+	}{
+		{indexes: " ", err: "no indexes"},
+		{indexes: "0733 2048", err: "invalid word index 2048"},
+		{indexes: "0733 00001", err: "invalid word index 00001"},
+		{indexes: "0733 -1", err: "invalid word index -1"},
+		{indexes: "0733 1895 0739 0654 1596 1602 0099 0767 0428 1872 1226 1117", err: "invalid checksum"},
+	}
+
+	for _, test := range tests {
+		_, err := m.WordsFromIndexes(test.indexes)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%q: expected err '%s' but actual %v", test.indexes, test.err, err)
+		}
+	}
+}
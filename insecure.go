@@ -0,0 +1,64 @@
+package nomnemonic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+)
+
+// InsecureFastKDFEnv must be set to 1 for New to accept WithInsecureFastKDF
+const InsecureFastKDFEnv = "NOMNEMONIC_INSECURE_FAST_KDF"
+
+const (
+	_insecurePBKDF2Iterations = 1
+	_insecureScryptN          = 16
+)
+
+// ErrInsecureFastKDF is returned by New for WithInsecureFastKDF without
+// InsecureFastKDFEnv set to 1
+var ErrInsecureFastKDF = fmt.Errorf("insecure fast kdf requires %s=1", InsecureFastKDFEnv)
+
+// WithInsecureFastKDF drops the pbkdf2 and scrypt costs to trivial levels so
+// the test suites and demos of applications run in milliseconds. The phrases
+// differ from the real ones and are brute forced instantly, never use them
+// for funds. New refuses it unless InsecureFastKDFEnv is set to 1 and warns
+// through the logger, GenerateDetailed records another kdf hash so Check
+// flags the phrases
+func WithInsecureFastKDF() Option {
+	return func(m *mnemonicer) {
+		m.insecureFastKDF = true
+	}
+}
+
+// checkInsecureFastKDF refuses the insecure kdf outside of the environment
+// allowing it
+func (m *mnemonicer) checkInsecureFastKDF() error {
+	if !m.insecureFastKDF {
+		return nil
+	}
+	if os.Getenv(InsecureFastKDFEnv) != "1" {
+		return ErrInsecureFastKDF
+	}
+	m.log("INSECURE fast kdf enabled, the phrases must never hold funds")
+	return nil
+}
+
+// kdfCosts returns the pbkdf2 iterations and the scrypt n
+func (m *mnemonicer) kdfCosts() (int, int) {
+	if m.insecureFastKDF {
+		return _insecurePBKDF2Iterations, _insecureScryptN
+	}
+	return _kdfPBKDF2Iterations, _kdfScryptN
+}
+
+// kdfHash is the hex sha256 of the kdf description of the costs
+func (m *mnemonicer) kdfHash() string {
+	if !m.insecureFastKDF {
+		return kdfHash()
+	}
+	iterations, n := m.kdfCosts()
+	sum := sha256.Sum256([]byte(fmt.Sprintf("insecure pbkdf2-sha512 %d iterations xor scrypt n=%d r=%d p=%d",
+		iterations, n, _kdfScryptR, _kdfScryptP)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,63 @@
+package nomnemonic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithInsecureFastKDF(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(InsecureFastKDFEnv, "")
+	if _, err := New(words, WithInsecureFastKDF()); !errors.Is(err, ErrInsecureFastKDF) {
+		t.Fatalf("expected ErrInsecureFastKDF but actual %v", err)
+	}
+
+	t.Setenv(InsecureFastKDFEnv, "1")
+	logger := &recordLogger{}
+	m, err := New(words, WithInsecureFastKDF(), WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logger.msgs) != 1 || !strings.HasPrefix(logger.msgs[0], "INSECURE") {
+		t.Errorf("expected the insecure warning but actual %v", logger.msgs)
+	}
+
+	r, err := m.GenerateDetailed("nomnemonic_test", "test12345678", "101938", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := strings.Join(r.Words, " "); actual == "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby" {
+		t.Error("expected the insecure phrase to differ from the real one")
+	}
+	if ok, err := m.IsValid(r.Words); err != nil || !ok {
+		t.Errorf("expected a valid phrase but actual %v", err)
+	}
+	if warnings := Check(r); len(warnings) != 1 || !strings.HasPrefix(warnings[0], "kdf parameters differ") {
+		t.Errorf("expected Check to flag the kdf but actual %v", warnings)
+	}
+
+	var done float64
+	progressed, err := m.GenerateProgress("nomnemonic_test", "test12345678", "101938", 12, func(d float64) error {
+		done = d
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(progressed, " ") != strings.Join(r.Words, " ") || done != 1 {
+		t.Errorf("expected the same phrase and a completed progress but actual %v %f", progressed, done)
+	}
+
+	steps, err := m.Explain("nomnemonic_test", "test12345678", "101938", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if steps[3].Detail != "pbkdf2-sha512(input, salt), 1 iterations, 16 bytes" {
+		t.Errorf("expected the insecure pbkdf2 costs but actual %s", steps[3].Detail)
+	}
+}
@@ -0,0 +1,99 @@
+package base58
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	_checksumSize = 4
+
+	// AlphabetBitcoin is the alphabet used by bitcoin and most of the chains
+	AlphabetBitcoin = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+var _radix = big.NewInt(58)
+
+// Encode encodes the bytes using the given 58 chars alphabet, leading zero
+// bytes are encoded as the first char of the alphabet
+func Encode(b []byte, alphabet string) string {
+	n := new(big.Int).SetBytes(b)
+	mod := new(big.Int)
+	encoded := make([]byte, 0, len(b)*138/100+1)
+	for n.Sign() > 0 {
+		n.DivMod(n, _radix, mod)
+		encoded = append(encoded, alphabet[mod.Int64()])
+	}
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		encoded = append(encoded, alphabet[0])
+	}
+
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+// Decode decodes the string encoded with the given 58 chars alphabet
+func Decode(s, alphabet string) ([]byte, error) {
+	if len(s) == 0 {
+		return nil, errors.New("empty base58 string")
+	}
+
+	n := new(big.Int)
+	for _, c := range s {
+		i := strings.IndexRune(alphabet, c)
+		if i < 0 {
+			return nil, fmt.Errorf("invalid base58 char %q", c)
+		}
+		n.Mul(n, _radix)
+		n.Add(n, big.NewInt(int64(i)))
+	}
+
+	zeros := 0
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+	decoded := n.Bytes()
+	return append(make([]byte, zeros, zeros+len(decoded)), decoded...), nil
+}
+
+// CheckEncode appends the double sha256 checksum to the payload and encodes
+// it using the given alphabet
+func CheckEncode(payload []byte, alphabet string) string {
+	b := make([]byte, 0, len(payload)+_checksumSize)
+	b = append(b, payload...)
+	b = append(b, checksum(payload)...)
+	return Encode(b, alphabet)
+}
+
+// CheckDecode decodes the string using the given alphabet and verifies the
+// double sha256 checksum, returns the payload without the checksum
+func CheckDecode(s, alphabet string) ([]byte, error) {
+	b, err := Decode(s, alphabet)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < _checksumSize {
+		return nil, errors.New("invalid base58check length")
+	}
+
+	payload, cs := b[:len(b)-_checksumSize], b[len(b)-_checksumSize:]
+	if !bytes.Equal(checksum(payload), cs) {
+		return nil, errors.New("invalid base58check checksum")
+	}
+	return payload, nil
+}
+
+func checksum(payload []byte) []byte {
+	h := sha256.Sum256(payload)
+	h = sha256.Sum256(h[:])
+	return h[:_checksumSize]
+}
@@ -0,0 +1,78 @@
+package base58
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		hex     string
+		encoded string
+	}{
+		{hex: "", encoded: ""},
+		{hex: "61", encoded: "2g"},
+		{hex: "626262", encoded: "a3gV"},
+		{hex: "636363", encoded: "aPEr"},
+		{hex: "73696d706c792061206c6f6e6720737472696e67", encoded: "2cFupjhnEsSn59qHXstmK2ffpLv2"},
+		{hex: "00eb15231dfceb60925886b67d065299925915aeb172c06647", encoded: "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+		{hex: "516b6fcd0f", encoded: "ABnLTmg"},
+		{hex: "00000000000000000000", encoded: "1111111111"},
+	}
+
+	for _, test := range tests {
+		b, _ := hex.DecodeString(test.hex)
+		actual := Encode(b, AlphabetBitcoin)
+		if actual != test.encoded {
+			t.Errorf("expected encoding of %s is '%s' but actual '%s'", test.hex, test.encoded, actual)
+		}
+
+		if test.encoded == "" {
+			continue
+		}
+		decoded, err := Decode(test.encoded, AlphabetBitcoin)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.encoded, err.Error())
+		}
+		if !bytes.Equal(decoded, b) {
+			t.Errorf("expected decoding of %s is %x but actual %x", test.encoded, b, decoded)
+		}
+	}
+
+	_, err := Decode("0OIl", AlphabetBitcoin)
+	if err == nil {
+		t.Errorf("expected err for invalid chars but actual nil")
+	}
+}
+
+func TestCheckEncodeDecode(t *testing.T) {
+	tests := []struct {
+		hex     string
+		encoded string
+	}{
+		{hex: "00f54a5851e9372b87810a8e60cdd2e7cfd80b6e31", encoded: "1PMycacnJaSqwwJqjawXBErnLsZ7RkXUAs"},
+		{hex: "0000000000000000000000000000000000000000000000000000000000000000", encoded: "11111111111111111111111111111111273Yts"},
+	}
+
+	for _, test := range tests {
+		b, _ := hex.DecodeString(test.hex)
+		actual := CheckEncode(b, AlphabetBitcoin)
+		if actual != test.encoded {
+			t.Errorf("expected encoding of %s is '%s' but actual '%s'", test.hex, test.encoded, actual)
+		}
+
+		decoded, err := CheckDecode(test.encoded, AlphabetBitcoin)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.encoded, err.Error())
+		}
+		if !bytes.Equal(decoded, b) {
+			t.Errorf("expected decoding of %s is %x but actual %x", test.encoded, b, decoded)
+		}
+	}
+
+	_, err := CheckDecode("1PMycacnJaSqwwJqjawXBErnLsZ7RkXUAt", AlphabetBitcoin)
+	if err == nil || err.Error() != "invalid base58check checksum" {
+		t.Errorf("expected invalid base58check checksum err but actual %v", err)
+	}
+}
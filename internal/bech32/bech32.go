@@ -0,0 +1,193 @@
+package bech32
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Charset maps the 5 bits values to chars
+	Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	_checksumSize = 6
+	_maxLength    = 90
+)
+
+// Encoding is the checksum constant of the encoding
+type Encoding uint32
+
+const (
+	Bech32  Encoding = 1
+	Bech32m Encoding = 0x2bc830a3
+)
+
+var _generator = []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// Encode encodes the 5 bits data with the human readable part
+func Encode(hrp string, data []byte, enc Encoding) (string, error) {
+	if len(hrp)+1+len(data)+_checksumSize > _maxLength {
+		return "", errors.New("bech32 string too long")
+	}
+
+	hrp = strings.ToLower(hrp)
+	values := append(append([]byte{}, data...), checksum(hrp, data, enc)...)
+
+	var b strings.Builder
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, v := range values {
+		if v >= 32 {
+			return "", fmt.Errorf("invalid 5 bits value %d", v)
+		}
+		b.WriteByte(Charset[v])
+	}
+	return b.String(), nil
+}
+
+// Decode decodes the bech32 string into the human readable part and the 5
+// bits data, returns the encoding detected from the checksum
+func Decode(s string) (string, []byte, Encoding, error) {
+	if len(s) > _maxLength {
+		return "", nil, 0, errors.New("bech32 string too long")
+	}
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, 0, errors.New("bech32 string has mixed case")
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+_checksumSize+1 > len(s) {
+		return "", nil, 0, errors.New("invalid bech32 separator position")
+	}
+
+	hrp := s[:sep]
+	for _, c := range hrp {
+		if c < 33 || c > 126 {
+			return "", nil, 0, fmt.Errorf("invalid bech32 hrp char %q", c)
+		}
+	}
+
+	values := make([]byte, 0, len(s)-sep-1)
+	for _, c := range s[sep+1:] {
+		i := strings.IndexRune(Charset, c)
+		if i < 0 {
+			return "", nil, 0, fmt.Errorf("invalid bech32 char %q", c)
+		}
+		values = append(values, byte(i))
+	}
+
+	enc := Encoding(polymod(append(expandHRP(hrp), values...)))
+	if enc != Bech32 && enc != Bech32m {
+		return "", nil, 0, errors.New("invalid bech32 checksum")
+	}
+	return hrp, values[:len(values)-_checksumSize], enc, nil
+}
+
+// ConvertBits regroups the bits of the data from the given size to the
+// other, pads the last group with zeros when pad is set
+func ConvertBits(data []byte, from, to uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxv := uint32(1)<<to - 1
+	out := make([]byte, 0, len(data)*int(from)/int(to)+1)
+	for _, v := range data {
+		if uint32(v)>>from != 0 {
+			return nil, fmt.Errorf("invalid %d bits value %d", from, v)
+		}
+		acc = acc<<from | uint32(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil, errors.New("invalid padding")
+	}
+	return out, nil
+}
+
+// EncodeSegwit encodes the witness program as segwit address, version 0 uses
+// bech32 and the later versions bech32m
+func EncodeSegwit(hrp string, version byte, program []byte) (string, error) {
+	if version > 16 || len(program) < 2 || len(program) > 40 {
+		return "", errors.New("invalid witness program")
+	}
+
+	data, _ := ConvertBits(program, 8, 5, true)
+	enc := Bech32m
+	if version == 0 {
+		enc = Bech32
+	}
+	return Encode(hrp, append([]byte{version}, data...), enc)
+}
+
+// DecodeSegwit decodes the segwit address for the hrp into witness version
+// and program
+func DecodeSegwit(hrp, address string) (byte, []byte, error) {
+	actualHRP, data, enc, err := Decode(address)
+	if err != nil {
+		return 0, nil, err
+	}
+	if actualHRP != hrp {
+		return 0, nil, fmt.Errorf("expected hrp %s but given %s", hrp, actualHRP)
+	}
+	if len(data) < 1 || data[0] > 16 {
+		return 0, nil, errors.New("invalid witness version")
+	}
+	if (data[0] == 0 && enc != Bech32) || (data[0] != 0 && enc != Bech32m) {
+		return 0, nil, errors.New("invalid encoding for witness version")
+	}
+
+	program, err := ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(program) < 2 || len(program) > 40 || (data[0] == 0 && len(program) != 20 && len(program) != 32) {
+		return 0, nil, errors.New("invalid witness program length")
+	}
+	return data[0], program, nil
+}
+
+func checksum(hrp string, data []byte, enc Encoding) []byte {
+	values := append(expandHRP(hrp), data...)
+	values = append(values, make([]byte, _checksumSize)...)
+	mod := polymod(values) ^ uint32(enc)
+	cs := make([]byte, _checksumSize)
+	for i := range cs {
+		cs[i] = byte(mod >> (5 * (5 - i)) & 31)
+	}
+	return cs
+}
+
+func expandHRP(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+func polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i, g := range _generator {
+			if (top>>i)&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
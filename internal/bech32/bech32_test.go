@@ -0,0 +1,77 @@
+package bech32
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		s   string
+		enc Encoding
+		err string
+	}{
+		{s: "A12UEL5L", enc: Bech32},
+		{s: "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", enc: Bech32},
+		{s: "split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", enc: Bech32},
+		{s: "A1LQFN3A", enc: Bech32m},
+		{s: "abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", enc: Bech32m},
+		{s: "pzry9x0s0muk", err: "invalid bech32 separator position"},
+		{s: "A1G7SGD8", err: "invalid bech32 checksum"},
+		{s: "a12UEL5L", err: "bech32 string has mixed case"},
+	}
+
+	for _, test := range tests {
+		_, _, enc, err := Decode(test.s)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' for %s but actual %v", test.err, test.s, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.s, err.Error())
+			continue
+		}
+		if enc != test.enc {
+			t.Errorf("expected encoding %x for %s but actual %x", test.enc, test.s, enc)
+		}
+	}
+}
+
+func TestSegwit(t *testing.T) {
+	tests := []struct {
+		hrp     string
+		address string
+		version byte
+		program string
+	}{
+		{hrp: "bc", address: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", version: 0, program: "751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{hrp: "tb", address: "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", version: 0, program: "1863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{hrp: "bc", address: "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", version: 1, program: "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+	}
+
+	for _, test := range tests {
+		program, _ := hex.DecodeString(test.program)
+		address, err := EncodeSegwit(test.hrp, test.version, program)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.address, err.Error())
+		}
+		if address != test.address {
+			t.Errorf("expected address '%s' but actual '%s'", test.address, address)
+		}
+
+		version, actual, err := DecodeSegwit(test.hrp, test.address)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.address, err.Error())
+		}
+		if version != test.version || hex.EncodeToString(actual) != test.program {
+			t.Errorf("expected program %d:%s but actual %d:%x", test.version, test.program, version, actual)
+		}
+	}
+
+	_, _, err := DecodeSegwit("bc", "bc1zw508d6qejxtdg4y5r3zarvaryvqyzf3du")
+	if err == nil {
+		t.Errorf("expected err for bech32 encoded version 2 program but actual nil")
+	}
+}
@@ -0,0 +1,32 @@
+// Package command runs the external tools of the hardware and secret store
+// integrations
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Runner runs the command with the stdin and returns its stdout, the
+// integrations keep one so tests replace the tool
+type Runner func(stdin []byte, name string, args ...string) ([]byte, error)
+
+// Run runs the command, the error carries its stderr
+func Run(stdin []byte, name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
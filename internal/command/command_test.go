@@ -0,0 +1,20 @@
+package command
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh")
+	}
+	out, err := Run([]byte("stdin"), "sh", "-c", "cat")
+	if err != nil || string(out) != "stdin" {
+		t.Errorf("expected 'stdin' but actual '%s' %v", out, err)
+	}
+	if _, err := Run(nil, "sh", "-c", "echo no device >&2; exit 1"); err == nil || !strings.HasSuffix(err.Error(), ": no device") {
+		t.Errorf("expected the stderr in the error but actual %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+// Package gf256 splits secrets into GF(256) Shamir shares with a digest share
+// that detects wrong shares on recovery, the slip-0039 sharing scheme
+package gf256
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"io"
+)
+
+const (
+	_secretIndex  = 255
+	_digestIndex  = 254
+	_digestLength = 4
+)
+
+var _exp, _log = buildTables()
+
+// Share is the x coordinate and the value of a share
+type Share struct {
+	X     byte
+	Value []byte
+}
+
+// buildTables builds the exp and log tables of GF(256) with the Rijndael
+// polynomial x^8 + x^4 + x^3 + x + 1 and the generator x + 1
+func buildTables() ([255]byte, [256]int) {
+	var exp [255]byte
+	var log [256]int
+	poly := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(poly)
+		log[poly] = i
+		poly = (poly << 1) ^ poly
+		if poly&0x100 != 0 {
+			poly ^= 0x11b
+		}
+	}
+	return exp, log
+}
+
+// Interpolate evaluates the polynomial passing through the shares at x
+func Interpolate(shares []Share, x byte) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no shares to interpolate")
+	}
+	for _, s := range shares {
+		if s.X == x {
+			return s.Value, nil
+		}
+	}
+
+	size := len(shares[0].Value)
+	logProd := 0
+	for _, s := range shares {
+		if len(s.Value) != size {
+			return nil, errors.New("share values must have the same length")
+		}
+		logProd += _log[s.X^x]
+	}
+
+	result := make([]byte, size)
+	for i, s := range shares {
+		logBasis := logProd - _log[s.X^x]
+		for j, o := range shares {
+			if i != j {
+				logBasis -= _log[s.X^o.X]
+			}
+		}
+		logBasis = ((logBasis % 255) + 255) % 255
+
+		for k, v := range s.Value {
+			if v != 0 {
+				result[k] ^= _exp[(_log[v]+logBasis)%255]
+			}
+		}
+	}
+	return result, nil
+}
+
+// Split splits the secret into count shares where threshold of them
+// recover the secret, the digest share lets recovery detect wrong shares
+func Split(threshold, count int, secret []byte, random io.Reader) ([]Share, error) {
+	if threshold == 1 {
+		shares := make([]Share, count)
+		for i := range shares {
+			shares[i] = Share{X: byte(i), Value: secret}
+		}
+		return shares, nil
+	}
+
+	randomCount := threshold - 2
+	shares := make([]Share, 0, count)
+	for i := 0; i < randomCount; i++ {
+		value := make([]byte, len(secret))
+		if _, err := io.ReadFull(random, value); err != nil {
+			return nil, err
+		}
+		shares = append(shares, Share{X: byte(i), Value: value})
+	}
+
+	randomPart := make([]byte, len(secret)-_digestLength)
+	if _, err := io.ReadFull(random, randomPart); err != nil {
+		return nil, err
+	}
+	digestShare := append(digest(randomPart, secret), randomPart...)
+
+	base := append(append([]Share{}, shares...),
+		Share{X: _digestIndex, Value: digestShare},
+		Share{X: _secretIndex, Value: secret},
+	)
+	for i := randomCount; i < count; i++ {
+		value, err := Interpolate(base, byte(i))
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, Share{X: byte(i), Value: value})
+	}
+	return shares, nil
+}
+
+// Recover recovers the secret from threshold shares and verifies it
+// against the digest share
+func Recover(threshold int, shares []Share) ([]byte, error) {
+	if threshold < 1 || len(shares) < threshold {
+		return nil, errors.New("not enough shares for the threshold")
+	}
+	if threshold == 1 {
+		return shares[0].Value, nil
+	}
+
+	secret, err := Interpolate(shares, _secretIndex)
+	if err != nil {
+		return nil, err
+	}
+	digestShare, err := Interpolate(shares, _digestIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(digestShare[:_digestLength], digest(digestShare[_digestLength:], secret)) {
+		return nil, errors.New("invalid digest of the shared secret")
+	}
+	return secret, nil
+}
+
+func digest(randomPart, secret []byte) []byte {
+	mac := hmac.New(sha256.New, randomPart)
+	mac.Write(secret)
+	return mac.Sum(nil)[:_digestLength]
+}
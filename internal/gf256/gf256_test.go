@@ -0,0 +1,77 @@
+package gf256
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestSplitRecover(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+
+	tests := []struct {
+		threshold int
+		count     int
+		recover   []int
+	}{
+		{threshold: 1, count: 1, recover: []int{0}},
+		{threshold: 2, count: 3, recover: []int{2, 0}},
+		{threshold: 3, count: 5, recover: []int{4, 1, 2}},
+	}
+
+	for _, test := range tests {
+		shares, err := Split(test.threshold, test.count, secret, rand.Reader)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		selected := make([]Share, 0, len(test.recover))
+		for _, i := range test.recover {
+			selected = append(selected, shares[i])
+		}
+		actual, err := Recover(test.threshold, selected)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if !bytes.Equal(actual, secret) {
+			t.Errorf("expected secret %x but actual %x", secret, actual)
+		}
+	}
+}
+
+func TestRecoverInvalidDigest(t *testing.T) {
+	shares, err := Split(2, 3, []byte("0123456789abcdef"), rand.Reader)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	shares[1].Value[0] ^= 1
+	_, err = Recover(2, shares[:2])
+	if err == nil || err.Error() != "invalid digest of the shared secret" {
+		t.Errorf("expected digest err but actual %v", err)
+	}
+}
+
+func TestRecoverNoShares(t *testing.T) {
+	shares, err := Split(2, 3, []byte("0123456789abcdef"), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	tests := []struct {
+		threshold int
+		shares    []Share
+	}{
+		{threshold: 0},
+		{threshold: 0, shares: shares[:1]},
+		{threshold: 1},
+		{threshold: 2, shares: shares[:1]},
+	}
+	for _, test := range tests {
+		if _, err := Recover(test.threshold, test.shares); err == nil {
+			t.Errorf("threshold %d with %d shares: expected an error but actual none", test.threshold, len(test.shares))
+		}
+	}
+	if _, err := Interpolate(nil, 0); err == nil {
+		t.Error("expected an error interpolating no shares but actual none")
+	}
+}
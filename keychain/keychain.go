@@ -0,0 +1,152 @@
+// Package keychain keeps the settings of a user, never the inputs, in the os
+// credential store, the macOS keychain, the secret service of linux desktops
+// or the windows credential manager, so the same word list, kdf profile and
+// algorithm version are restored on a new machine without a config file
+package keychain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic/internal/command"
+)
+
+const (
+	// Service is the service name of the stored settings
+	Service = "nomnemonic"
+
+	// DefaultAccount is the account of the settings by default
+	DefaultAccount = "default"
+
+	// _vault loads the windows password vault into $v
+	_vault = "[void][Windows.Security.Credentials.PasswordVault,Windows.Security.Credentials,ContentType=WindowsRuntime]; " +
+		"$v = New-Object Windows.Security.Credentials.PasswordVault; "
+)
+
+var (
+	// ErrNotFound is returned when no settings are stored for the account
+	ErrNotFound = errors.New("keychain: no settings stored")
+
+	_account = regexp.MustCompile(`^[A-Za-z0-9._@-]{1,64}$`)
+)
+
+type (
+	// Config are the settings restored on a new machine. IdentifierHints
+	// remind the user of the identifiers, like "work email", they are stored
+	// unencrypted by some stores so keep them vague
+	Config struct {
+		Language         string   `json:"language,omitempty" yaml:"language,omitempty"`
+		Profile          string   `json:"profile,omitempty" yaml:"profile,omitempty"`
+		AlgorithmVersion string   `json:"algorithm_version,omitempty" yaml:"algorithm_version,omitempty"`
+		Features         []string `json:"features,omitempty" yaml:"features,omitempty"`
+		Purpose          string   `json:"purpose,omitempty" yaml:"purpose,omitempty"`
+		IdentifierHints  []string `json:"identifier_hints,omitempty" yaml:"identifier_hints,omitempty"`
+	}
+
+	// Store is the settings of an account in the credential store of the os
+	Store struct {
+		account string
+		goos    string
+		run     command.Runner
+	}
+)
+
+// New returns the store of the account, DefaultAccount when empty
+func New(account string) (*Store, error) {
+	if account == "" {
+		account = DefaultAccount
+	}
+	if !_account.MatchString(account) {
+		return nil, fmt.Errorf("keychain: invalid account %s", account)
+	}
+	return &Store{account: account, goos: runtime.GOOS, run: command.Run}, nil
+}
+
+// Save stores the settings, replacing the stored ones
+func (s *Store) Save(c Config) error {
+	value, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(value)
+	switch s.goos {
+	case "darwin":
+		_, err = s.run(nil, "security", "add-generic-password", "-U", "-s", Service, "-a", s.account, "-w", encoded)
+	case "windows":
+		_, err = s.run(nil, "powershell", "-NoProfile", "-NonInteractive", "-Command", _vault+
+			fmt.Sprintf("$v.Add((New-Object Windows.Security.Credentials.PasswordCredential('%s','%s','%s')))", Service, s.account, encoded))
+	default:
+		_, err = s.run([]byte(encoded), "secret-tool", "store", "--label", Service+" settings", "service", Service, "account", s.account)
+	}
+	if err != nil {
+		return fmt.Errorf("keychain: %w", err)
+	}
+	return nil
+}
+
+// Load returns the stored settings, ErrNotFound when there are none
+func (s *Store) Load() (Config, error) {
+	var out []byte
+	var err error
+	switch s.goos {
+	case "darwin":
+		out, err = s.run(nil, "security", "find-generic-password", "-s", Service, "-a", s.account, "-w")
+	case "windows":
+		out, err = s.run(nil, "powershell", "-NoProfile", "-NonInteractive", "-Command", _vault+
+			fmt.Sprintf("$c = $v.Retrieve('%s','%s'); $c.RetrievePassword(); $c.Password", Service, s.account))
+	default:
+		// secret-tool fails without a message when nothing matches
+		out, err = s.run(nil, "secret-tool", "lookup", "service", Service, "account", s.account)
+		if err != nil && err.Error() == "exit status 1" {
+			return Config{}, ErrNotFound
+		}
+	}
+	if err != nil {
+		if notFound(err) {
+			return Config{}, ErrNotFound
+		}
+		return Config{}, fmt.Errorf("keychain: %w", err)
+	}
+	if len(strings.TrimSpace(string(out))) == 0 {
+		return Config{}, ErrNotFound
+	}
+
+	value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(out)))
+	if err != nil {
+		return Config{}, errors.New("keychain: invalid stored settings")
+	}
+	var c Config
+	if err := json.Unmarshal(value, &c); err != nil {
+		return Config{}, errors.New("keychain: invalid stored settings")
+	}
+	return c, nil
+}
+
+// Delete removes the stored settings
+func (s *Store) Delete() error {
+	var err error
+	switch s.goos {
+	case "darwin":
+		_, err = s.run(nil, "security", "delete-generic-password", "-s", Service, "-a", s.account)
+	case "windows":
+		_, err = s.run(nil, "powershell", "-NoProfile", "-NonInteractive", "-Command", _vault+
+			fmt.Sprintf("$v.Remove($v.Retrieve('%s','%s'))", Service, s.account))
+	default:
+		_, err = s.run(nil, "secret-tool", "clear", "service", Service, "account", s.account)
+	}
+	if err != nil && !notFound(err) {
+		return fmt.Errorf("keychain: %w", err)
+	}
+	return nil
+}
+
+// notFound tells whether the error of the tool is a missing item
+func notFound(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "could not be found") || strings.Contains(msg, "Element not found") || strings.Contains(msg, "exit status 44")
+}
@@ -0,0 +1,82 @@
+package keychain
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeStore emulates the tools of the os with one item
+type fakeStore struct {
+	value []byte
+	args  []string
+}
+
+func (f *fakeStore) run(stdin []byte, name string, args ...string) ([]byte, error) {
+	f.args = append([]string{name}, args...)
+	command := strings.Join(f.args, " ")
+	switch {
+	case strings.Contains(command, "add-generic-password"):
+		f.value = []byte(args[len(args)-1])
+	case strings.HasPrefix(command, "secret-tool store"):
+		f.value = stdin
+	case strings.Contains(command, "PasswordCredential("):
+		f.value = []byte(command[strings.LastIndex(command, ",'")+2 : strings.LastIndex(command, "')))")])
+	case strings.Contains(command, "find-generic-password"), strings.Contains(command, "Retrieve(") && !strings.Contains(command, "Remove("):
+		if f.value == nil {
+			return nil, errors.New("exit status 44: security: SecKeychainSearchCopyNext: The specified item could not be found in the keychain.")
+		}
+		return append(f.value, '\n'), nil
+	case strings.HasPrefix(command, "secret-tool lookup"):
+		if f.value == nil {
+			return nil, errors.New("exit status 1")
+		}
+		return f.value, nil
+	default:
+		f.value = nil
+	}
+	return nil, nil
+}
+
+func TestStore(t *testing.T) {
+	if _, err := New("alice'; rm"); err == nil {
+		t.Error("expected an error for an invalid account")
+	}
+
+	config := Config{
+		Language:         "spanish",
+		Profile:          "sensitive",
+		AlgorithmVersion: "3.0.0",
+		Features:         []string{"unicode-normalization"},
+		IdentifierHints:  []string{"work email"},
+	}
+	for _, goos := range []string{"darwin", "linux", "windows"} {
+		f := &fakeStore{}
+		s, err := New("")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		s.goos, s.run = goos, f.run
+
+		if _, err := s.Load(); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: expected %v but actual %v", goos, ErrNotFound, err)
+		}
+		if err := s.Save(config); err != nil {
+			t.Fatalf("%s: unexpected error: %s", goos, err.Error())
+		}
+		loaded, err := s.Load()
+		if err != nil || !reflect.DeepEqual(loaded, config) {
+			t.Errorf("%s: expected %+v but actual %+v %v", goos, config, loaded, err)
+		}
+		if !strings.Contains(strings.Join(f.args, " "), DefaultAccount) {
+			t.Errorf("%s: expected the default account in %v", goos, f.args)
+		}
+		if err := s.Delete(); err != nil {
+			t.Errorf("%s: unexpected error: %s", goos, err.Error())
+		}
+		if _, err := s.Load(); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: expected %v after delete but actual %v", goos, ErrNotFound, err)
+		}
+	}
+}
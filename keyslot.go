@@ -0,0 +1,332 @@
+package nomnemonic
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	_exportVersionSlots = 3 // a data key wrapped in key slots encrypts the json of a Result
+
+	_slotsHeaderSize   = len(_exportMagic) + 1 + chacha20poly1305.NonceSizeX + 1
+	_slotsMax          = 255
+	_slotBodyMaxSize   = 1<<16 - 1
+	_slotPassphrase    = "passphrase"
+	_slotPassphraseKDF = 4 + 4 + 1 + _exportSaltSize + chacha20poly1305.NonceSizeX
+
+	// a hostile container can hold many passphrase slots of the maximal
+	// argon2id work, an import tries this many of them within this work
+	_slotsMaxPassphraseTries = 16
+	_slotsMaxPassphraseWork  = 4 * _exportMaxWork
+)
+
+// ErrNoKeySlot is returned by ImportIdentities when none of the identities
+// opens a key slot of the container
+var ErrNoKeySlot = errors.New("no key slot opens with the given identities")
+
+type (
+	// KeySlot is the data key of a container wrapped for one recipient, Type
+	// tells the identities which slots are theirs
+	KeySlot struct {
+		Type string
+		Body []byte
+	}
+
+	// Recipient wraps the data key of a container into its key slot
+	Recipient interface {
+		Wrap(key []byte) (KeySlot, error)
+	}
+
+	// Identity unwraps the data key from a key slot of its recipient and
+	// returns an error for the other slots
+	Identity interface {
+		Unwrap(slot KeySlot) ([]byte, error)
+	}
+
+	passphraseRecipient struct {
+		passphrase string
+		params     KDFParams
+	}
+
+	passphraseIdentity string
+)
+
+// ExportRecipients encrypts the result into a container with a random data
+// key, wrapped LUKS-style in one key slot per recipient so each of them, the
+// heirs or the co-founders, decrypts it on their own. The slots are
+// authenticated with the result, a slot can not be swapped or dropped
+// unnoticed
+func ExportRecipients(r Result, recipients ...Recipient) ([]byte, error) {
+	if len(r.Words) == 0 {
+		return nil, errors.New("no words given")
+	}
+	if len(recipients) == 0 {
+		return nil, errors.New("no recipient given")
+	}
+	plaintext, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	slots := make([]KeySlot, len(recipients))
+	for i, recipient := range recipients {
+		if slots[i], err = recipient.Wrap(key); err != nil {
+			return nil, fmt.Errorf("key slot %d: %w", i, err)
+		}
+	}
+	return sealSlots(key, slots, plaintext)
+}
+
+// ImportIdentities decrypts the result of a key slots container with the
+// first slot one of the identities opens
+func ImportIdentities(container []byte, identities ...Identity) (Result, error) {
+	key, _, err := openSlots(container, identities)
+	if err != nil {
+		return Result{}, err
+	}
+	plaintext, err := slotsPayload(container, key)
+	if err != nil {
+		return Result{}, err
+	}
+	var r Result
+	if err := json.Unmarshal(plaintext, &r); err != nil {
+		return Result{}, errors.New("invalid export result")
+	}
+	return r, nil
+}
+
+// KeySlots returns the key slots of the container without decrypting it
+func KeySlots(container []byte) ([]KeySlot, error) {
+	slots, _, err := parseSlots(container)
+	return slots, err
+}
+
+// AddRecipient returns the container with a key slot for the recipient, the
+// identity opens an existing slot. The data key is kept and the result is
+// sealed again with a fresh nonce, the old container still opens with the
+// old slots so replace every copy of it
+func AddRecipient(container []byte, identity Identity, recipient Recipient) ([]byte, error) {
+	key, slots, err := openSlots(container, []Identity{identity})
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := slotsPayload(container, key)
+	if err != nil {
+		return nil, err
+	}
+	slot, err := recipient.Wrap(key)
+	if err != nil {
+		return nil, fmt.Errorf("key slot %d: %w", len(slots), err)
+	}
+	return sealSlots(key, append(slots, slot), plaintext)
+}
+
+// RemoveKeySlot returns the container without the key slot at the index,
+// the identity opens one of the remaining slots so access is never lost. A
+// removed recipient who kept the data key or an old copy still decrypts, it
+// revokes future copies only
+func RemoveKeySlot(container []byte, identity Identity, index int) ([]byte, error) {
+	slots, _, err := parseSlots(container)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(slots) {
+		return nil, fmt.Errorf("key slot %d out of range", index)
+	}
+	remaining := append(append([]KeySlot(nil), slots[:index]...), slots[index+1:]...)
+	key, err := unwrapSlots(remaining, []Identity{identity})
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := slotsPayload(container, key)
+	if err != nil {
+		return nil, err
+	}
+	return sealSlots(key, remaining, plaintext)
+}
+
+// PassphraseRecipient wraps the data key with an argon2id key of the
+// passphrase, the slot Import opens like a passphrase container
+func PassphraseRecipient(passphrase string, params KDFParams) Recipient {
+	return passphraseRecipient{passphrase: passphrase, params: params}
+}
+
+// PassphraseIdentity opens the passphrase key slots
+func PassphraseIdentity(passphrase string) Identity {
+	return passphraseIdentity(passphrase)
+}
+
+func (p passphraseRecipient) Wrap(key []byte) (KeySlot, error) {
+	if p.passphrase == "" {
+		return KeySlot{}, errors.New("passphrase is required")
+	}
+	if err := p.params.validate(); err != nil {
+		return KeySlot{}, err
+	}
+	body := make([]byte, _slotPassphraseKDF)
+	binary.BigEndian.PutUint32(body, p.params.Time)
+	binary.BigEndian.PutUint32(body[4:], p.params.Memory)
+	body[8] = p.params.Threads
+	if _, err := rand.Read(body[9:]); err != nil {
+		return KeySlot{}, err
+	}
+	aead, err := exportCipher(p.passphrase, body[9:9+_exportSaltSize], p.params)
+	if err != nil {
+		return KeySlot{}, err
+	}
+	return KeySlot{Type: _slotPassphrase, Body: aead.Seal(body, body[9+_exportSaltSize:], key, body[:9])}, nil
+}
+
+func (p passphraseIdentity) Unwrap(slot KeySlot) ([]byte, error) {
+	if slot.Type != _slotPassphrase {
+		return nil, errors.New("not a passphrase key slot")
+	}
+	if len(slot.Body) != _slotPassphraseKDF+chacha20poly1305.KeySize+chacha20poly1305.Overhead {
+		return nil, errors.New("invalid passphrase key slot")
+	}
+	params := slotParams(slot)
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+	aead, err := exportCipher(string(p), slot.Body[9:9+_exportSaltSize], params)
+	if err != nil {
+		return nil, err
+	}
+	key, err := aead.Open(nil, slot.Body[9+_exportSaltSize:_slotPassphraseKDF], slot.Body[_slotPassphraseKDF:], slot.Body[:9])
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return key, nil
+}
+
+// slotParams returns the argon2id costs of a passphrase slot
+func slotParams(slot KeySlot) KDFParams {
+	return KDFParams{
+		Time:    binary.BigEndian.Uint32(slot.Body),
+		Memory:  binary.BigEndian.Uint32(slot.Body[4:]),
+		Threads: slot.Body[8],
+	}
+}
+
+// sealSlots writes the magic, the version, the nonce and the slots, each a
+// length prefixed type and body, and seals the plaintext with the header as
+// additional data
+func sealSlots(key []byte, slots []KeySlot, plaintext []byte) ([]byte, error) {
+	if len(slots) > _slotsMax {
+		return nil, fmt.Errorf("at most %d key slots", _slotsMax)
+	}
+	header := make([]byte, _slotsHeaderSize, _slotsHeaderSize+len(slots)*64)
+	copy(header, _exportMagic)
+	header[4] = _exportVersionSlots
+	if _, err := rand.Read(header[5 : 5+chacha20poly1305.NonceSizeX]); err != nil {
+		return nil, err
+	}
+	header[_slotsHeaderSize-1] = byte(len(slots))
+	for i, slot := range slots {
+		if slot.Type == "" || len(slot.Type) > 255 || len(slot.Body) > _slotBodyMaxSize {
+			return nil, fmt.Errorf("key slot %d: invalid type or body size", i)
+		}
+		header = append(header, byte(len(slot.Type)))
+		header = append(header, slot.Type...)
+		header = binary.BigEndian.AppendUint16(header, uint16(len(slot.Body)))
+		header = append(header, slot.Body...)
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(header, header[5:5+chacha20poly1305.NonceSizeX], plaintext, header), nil
+}
+
+// parseSlots returns the slots of the container and the size of its header
+func parseSlots(container []byte) ([]KeySlot, int, error) {
+	if len(container) < _slotsHeaderSize || string(container[:4]) != _exportMagic {
+		return nil, 0, errors.New("not an export container")
+	}
+	if container[4] != _exportVersionSlots {
+		return nil, 0, fmt.Errorf("export version %d has no key slots", container[4])
+	}
+	r := bytes.NewReader(container[_slotsHeaderSize:])
+	slots := make([]KeySlot, container[_slotsHeaderSize-1])
+	for i := range slots {
+		n, err := r.ReadByte()
+		if err != nil || int(n) > r.Len() {
+			return nil, 0, errors.New("truncated key slot")
+		}
+		typ := make([]byte, n)
+		_, _ = r.Read(typ)
+		var size [2]byte
+		if _, err := r.Read(size[:]); err != nil || int(binary.BigEndian.Uint16(size[:])) > r.Len() {
+			return nil, 0, errors.New("truncated key slot")
+		}
+		body := make([]byte, binary.BigEndian.Uint16(size[:]))
+		_, _ = r.Read(body)
+		slots[i] = KeySlot{Type: string(typ), Body: body}
+	}
+	if r.Len() < chacha20poly1305.Overhead {
+		return nil, 0, errors.New("truncated export container")
+	}
+	return slots, len(container) - r.Len(), nil
+}
+
+// openSlots returns the data key the identities unwrap and the slots
+func openSlots(container []byte, identities []Identity) ([]byte, []KeySlot, error) {
+	slots, _, err := parseSlots(container)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := unwrapSlots(slots, identities)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, slots, nil
+}
+
+// unwrapSlots returns the data key of the first slot an identity unwraps, the
+// passphrase slots are tried within the limits of their argon2id work
+func unwrapSlots(slots []KeySlot, identities []Identity) ([]byte, error) {
+	tries, work := 0, uint64(0)
+	for _, identity := range identities {
+		_, passphrase := identity.(passphraseIdentity)
+		for _, slot := range slots {
+			if passphrase && slot.Type == _slotPassphrase && len(slot.Body) >= _slotPassphraseKDF {
+				params := slotParams(slot)
+				tries, work = tries+1, work+uint64(params.Time)*uint64(params.Memory)
+				if tries > _slotsMaxPassphraseTries || work > _slotsMaxPassphraseWork {
+					return nil, fmt.Errorf("key slots exceed the %d passphrase tries or the argon2id work limit", _slotsMaxPassphraseTries)
+				}
+			}
+			if key, err := identity.Unwrap(slot); err == nil && len(key) == chacha20poly1305.KeySize {
+				return key, nil
+			}
+		}
+	}
+	return nil, ErrNoKeySlot
+}
+
+// slotsPayload decrypts the plaintext of the container with the data key
+func slotsPayload(container []byte, key []byte) ([]byte, error) {
+	_, size, err := parseSlots(container)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	header := container[:size]
+	plaintext, err := aead.Open(nil, header[5:5+chacha20poly1305.NonceSizeX], container[size:], header)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
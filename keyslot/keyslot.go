@@ -0,0 +1,175 @@
+// Package keyslot wraps the data key of a key slots export container to age
+// and openpgp keys, so heirs or co-founders each decrypt the backup with
+// their own key. The slots of nomnemonic.ExportRecipients are opened with
+// nomnemonic.ImportIdentities
+package keyslot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+const (
+	// TypeAge is the type of the slots wrapped to age x25519 recipients
+	TypeAge = "age"
+	// TypePGP is the type of the slots wrapped to openpgp public keys
+	TypePGP = "pgp"
+
+	_maxKeySize = 64
+)
+
+type (
+	ageRecipient struct {
+		recipients []age.Recipient
+	}
+
+	ageIdentity struct {
+		identities []age.Identity
+	}
+
+	pgpRecipient struct {
+		entities openpgp.EntityList
+	}
+
+	pgpIdentity struct {
+		entities openpgp.EntityList
+	}
+)
+
+// Age returns the recipient of the age x25519 recipients, one slot any of
+// them opens
+func Age(recipients ...string) (nomnemonic.Recipient, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("keyslot: no age recipient")
+	}
+	r := &ageRecipient{}
+	for _, s := range recipients {
+		parsed, err := age.ParseX25519Recipient(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("keyslot: invalid age recipient %s", s)
+		}
+		r.recipients = append(r.recipients, parsed)
+	}
+	return r, nil
+}
+
+// AgeIdentity returns the identity of the age identities file, the lines
+// starting with AGE-SECRET-KEY-1
+func AgeIdentity(identities string) (nomnemonic.Identity, error) {
+	parsed, err := age.ParseIdentities(strings.NewReader(identities))
+	if err != nil {
+		return nil, errors.New("keyslot: invalid age identity")
+	}
+	return &ageIdentity{identities: parsed}, nil
+}
+
+// PGP returns the recipient of the armored openpgp public keys, one slot any
+// of them opens
+func PGP(armoredPublicKeys string) (nomnemonic.Recipient, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armoredPublicKeys))
+	if err != nil || len(entities) == 0 {
+		return nil, errors.New("keyslot: invalid pgp public key")
+	}
+	return &pgpRecipient{entities: entities}, nil
+}
+
+// PGPIdentity returns the identity of the armored openpgp private keys, the
+// passphrase decrypts the protected ones
+func PGPIdentity(armoredPrivateKeys, passphrase string) (nomnemonic.Identity, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armoredPrivateKeys))
+	if err != nil || len(entities) == 0 {
+		return nil, errors.New("keyslot: invalid pgp private key")
+	}
+	keys := entities.DecryptionKeys()
+	if len(keys) == 0 {
+		return nil, errors.New("keyslot: no pgp private key")
+	}
+	for _, k := range keys {
+		if k.PrivateKey.Encrypted {
+			if err := k.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+				return nil, errors.New("keyslot: wrong pgp passphrase")
+			}
+		}
+	}
+	return &pgpIdentity{entities: entities}, nil
+}
+
+func (r *ageRecipient) Wrap(key []byte) (nomnemonic.KeySlot, error) {
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, r.recipients...)
+	if err != nil {
+		return nomnemonic.KeySlot{}, err
+	}
+	if _, err := w.Write(key); err != nil {
+		return nomnemonic.KeySlot{}, err
+	}
+	if err := w.Close(); err != nil {
+		return nomnemonic.KeySlot{}, err
+	}
+	return nomnemonic.KeySlot{Type: TypeAge, Body: buf.Bytes()}, nil
+}
+
+func (i *ageIdentity) Unwrap(slot nomnemonic.KeySlot) ([]byte, error) {
+	if slot.Type != TypeAge {
+		return nil, errors.New("keyslot: not an age slot")
+	}
+	r, err := age.Decrypt(bytes.NewReader(slot.Body), i.identities...)
+	if err != nil {
+		return nil, err
+	}
+	return readKey(r)
+}
+
+func (r *pgpRecipient) Wrap(key []byte) (nomnemonic.KeySlot, error) {
+	var buf bytes.Buffer
+	w, err := openpgp.Encrypt(&buf, r.entities, nil, &openpgp.FileHints{IsBinary: true}, nil)
+	if err != nil {
+		return nomnemonic.KeySlot{}, err
+	}
+	if _, err := w.Write(key); err != nil {
+		return nomnemonic.KeySlot{}, err
+	}
+	if err := w.Close(); err != nil {
+		return nomnemonic.KeySlot{}, err
+	}
+	return nomnemonic.KeySlot{Type: TypePGP, Body: buf.Bytes()}, nil
+}
+
+func (i *pgpIdentity) Unwrap(slot nomnemonic.KeySlot) ([]byte, error) {
+	if slot.Type != TypePGP {
+		return nil, errors.New("keyslot: not a pgp slot")
+	}
+	md, err := openpgp.ReadMessage(bytes.NewReader(slot.Body), i.entities, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	key, err := readKey(md.UnverifiedBody)
+	if err != nil {
+		return nil, err
+	}
+	// the integrity of the message is only checked at its end
+	if md.SignatureError != nil {
+		return nil, md.SignatureError
+	}
+	return key, nil
+}
+
+// readKey reads the bounded data key of a slot
+func readKey(r io.Reader) ([]byte, error) {
+	key, err := io.ReadAll(io.LimitReader(r, _maxKeySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(key) > _maxKeySize {
+		return nil, errors.New("keyslot: key too long")
+	}
+	return key, nil
+}
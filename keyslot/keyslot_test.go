@@ -0,0 +1,102 @@
+package keyslot
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func armoredKeys(t *testing.T, entity *openpgp.Entity) (string, string) {
+	t.Helper()
+	var public, private bytes.Buffer
+	w, _ := pgparmor.Encode(&public, openpgp.PublicKeyType, nil)
+	if err := entity.Serialize(w); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	w.Close()
+	w, _ = pgparmor.Encode(&private, openpgp.PrivateKeyType, nil)
+	if err := entity.SerializePrivate(w, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	w.Close()
+	return public.String(), private.String()
+}
+
+func TestKeySlots(t *testing.T) {
+	spouse, _ := age.GenerateX25519Identity()
+	child, _ := age.GenerateX25519Identity()
+	cofounder, err := openpgp.NewEntity("cofounder", "", "cofounder@example.com", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	public, private := armoredKeys(t, cofounder)
+
+	ageRecipient, err := Age(spouse.Recipient().String(), child.Recipient().String())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	pgpRecipient, err := PGP(public)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	words := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	container, err := nomnemonic.ExportRecipients(nomnemonic.Result{Words: words}, ageRecipient, pgpRecipient)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	slots, _ := nomnemonic.KeySlots(container)
+	if len(slots) != 2 || slots[0].Type != TypeAge || slots[1].Type != TypePGP {
+		t.Errorf("expected an age and a pgp slot but actual %d slots", len(slots))
+	}
+
+	spouseIdentity, _ := AgeIdentity(spouse.String())
+	childIdentity, _ := AgeIdentity("# child\n" + child.String() + "\n")
+	pgpIdentity, err := PGPIdentity(private, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	for name, identity := range map[string]nomnemonic.Identity{"spouse": spouseIdentity, "child": childIdentity, "cofounder": pgpIdentity} {
+		r, err := nomnemonic.ImportIdentities(container, identity)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err.Error())
+			continue
+		}
+		if expected, actual := strings.Join(words, " "), strings.Join(r.Words, " "); expected != actual {
+			t.Errorf("%s: expected %s but actual %s", name, expected, actual)
+		}
+	}
+
+	stranger, _ := age.GenerateX25519Identity()
+	strangerIdentity, _ := AgeIdentity(stranger.String())
+	if _, err := nomnemonic.ImportIdentities(container, strangerIdentity); !errors.Is(err, nomnemonic.ErrNoKeySlot) {
+		t.Errorf("expected ErrNoKeySlot but actual %v", err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := Age(); err == nil || err.Error() != "keyslot: no age recipient" {
+		t.Errorf("expected the no recipient error but actual %v", err)
+	}
+	if _, err := Age("age1invalid"); err == nil || err.Error() != "keyslot: invalid age recipient age1invalid" {
+		t.Errorf("expected the invalid recipient error but actual %v", err)
+	}
+	if _, err := AgeIdentity("AGE-SECRET-KEY-1INVALID"); err == nil || err.Error() != "keyslot: invalid age identity" {
+		t.Errorf("expected the invalid identity error but actual %v", err)
+	}
+	if _, err := PGP("not a key"); err == nil || err.Error() != "keyslot: invalid pgp public key" {
+		t.Errorf("expected the invalid public key error but actual %v", err)
+	}
+
+	entity, _ := openpgp.NewEntity("heir", "", "heir@example.com", nil)
+	public, _ := armoredKeys(t, entity)
+	if _, err := PGPIdentity(public, ""); err == nil || err.Error() != "keyslot: no pgp private key" {
+		t.Errorf("expected the no private key error but actual %v", err)
+	}
+}
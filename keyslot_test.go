@@ -0,0 +1,158 @@
+package nomnemonic
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// xorIdentity is a toy recipient and identity of a one byte key
+type xorIdentity byte
+
+func (x xorIdentity) Wrap(key []byte) (KeySlot, error) {
+	body := make([]byte, len(key))
+	for i := range key {
+		body[i] = key[i] ^ byte(x)
+	}
+	return KeySlot{Type: "xor", Body: append(body, byte(x))}, nil
+}
+
+func (x xorIdentity) Unwrap(slot KeySlot) ([]byte, error) {
+	if slot.Type != "xor" || slot.Body[len(slot.Body)-1] != byte(x) {
+		return nil, errors.New("not the slot")
+	}
+	key := make([]byte, len(slot.Body)-1)
+	for i := range key {
+		key[i] = slot.Body[i] ^ byte(x)
+	}
+	return key, nil
+}
+
+func TestKeySlots(t *testing.T) {
+	words := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	container, err := ExportRecipients(Result{Words: words, Version: Version},
+		PassphraseRecipient("correct horse", _testKDF), xorIdentity(1), xorIdentity(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slots, err := KeySlots(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slots) != 3 || slots[0].Type != "passphrase" || slots[1].Type != "xor" {
+		t.Errorf("expected passphrase, xor and xor slots but actual %v", slots)
+	}
+
+	for _, identity := range []Identity{PassphraseIdentity("correct horse"), xorIdentity(1), xorIdentity(2)} {
+		r, err := ImportIdentities(container, xorIdentity(9), identity)
+		if err != nil {
+			t.Fatalf("%v: unexpected error %s", identity, err)
+		}
+		if strings.Join(r.Words, " ") != strings.Join(words, " ") {
+			t.Errorf("%v: expected %v but actual %v", identity, words, r.Words)
+		}
+	}
+	if actual, err := Import(container, "correct horse"); err != nil || len(actual) != 12 {
+		t.Errorf("expected the passphrase slot to open with Import but actual %v %v", actual, err)
+	}
+	if _, err := Import(container, "wrong horse"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("expected ErrWrongPassphrase but actual %v", err)
+	}
+	if _, err := ImportIdentities(container, xorIdentity(3)); !errors.Is(err, ErrNoKeySlot) {
+		t.Errorf("expected ErrNoKeySlot but actual %v", err)
+	}
+
+	added, err := AddRecipient(container, xorIdentity(2), xorIdentity(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ImportIdentities(added, xorIdentity(3)); err != nil {
+		t.Errorf("expected the added slot to open but actual %v", err)
+	}
+
+	removed, err := RemoveKeySlot(added, xorIdentity(3), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slots, _ := KeySlots(removed); len(slots) != 3 {
+		t.Errorf("expected 3 slots but actual %d", len(slots))
+	}
+	if _, err := ImportIdentities(removed, xorIdentity(1)); !errors.Is(err, ErrNoKeySlot) {
+		t.Errorf("expected the removed slot to be gone but actual %v", err)
+	}
+	if _, err := RemoveKeySlot(removed, xorIdentity(3), 2); !errors.Is(err, ErrNoKeySlot) {
+		t.Errorf("expected removing the only slot of the identity to fail but actual %v", err)
+	}
+}
+
+func TestKeySlotsErrors(t *testing.T) {
+	words := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	container, err := ExportRecipients(Result{Words: words}, xorIdentity(1), xorIdentity(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	swapped := append([]byte{}, container...)
+	swapped[_slotsHeaderSize+4+33]-- // a byte of the wrapped key of the first slot
+	passphrase, _ := Export(words, "correct horse", _testKDF)
+
+	tests := []struct {
+		name      string
+		container []byte
+		err       string
+	}{
+		{name: "tampered slot", container: swapped, err: "wrong passphrase or corrupted export"},
+		{name: "passphrase container", container: passphrase, err: "export version 2 has no key slots"},
+		{name: "truncated", container: container[:_slotsHeaderSize+10], err: "truncated key slot"},
+		{name: "short", container: container[:10], err: "not an export container"},
+	}
+	for _, test := range tests {
+		_, err := ImportIdentities(test.container, xorIdentity(1), xorIdentity(0))
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%s: expected err '%s' but actual %v", test.name, test.err, err)
+		}
+	}
+
+	if _, err := ExportRecipients(Result{Words: words}); err == nil || err.Error() != "no recipient given" {
+		t.Errorf("expected err 'no recipient given' but actual %v", err)
+	}
+	if _, err := ExportRecipients(Result{Words: words}, PassphraseRecipient("", _testKDF)); err == nil || err.Error() != "key slot 0: passphrase is required" {
+		t.Errorf("expected err 'key slot 0: passphrase is required' but actual %v", err)
+	}
+}
+
+func TestKeySlotsLimits(t *testing.T) {
+	words := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	recipients := make([]Recipient, _slotsMaxPassphraseTries+1)
+	for i := range recipients {
+		recipients[i] = PassphraseRecipient("correct horse", _testKDF)
+	}
+	many, err := ExportRecipients(Result{Words: words}, recipients...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// slots asking for the maximal work are refused before the kdf runs
+	slow := func() KeySlot {
+		body := make([]byte, _slotPassphraseKDF+32+16)
+		binary.BigEndian.PutUint32(body, 16)
+		binary.BigEndian.PutUint32(body[4:], _exportMaxMemory)
+		body[8] = 4
+		return KeySlot{Type: _slotPassphrase, Body: body}
+	}
+	heavy, err := sealSlots(make([]byte, 32), []KeySlot{slow(), slow(), slow()}, []byte("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "key slots exceed the 16 passphrase tries or the argon2id work limit"
+	for name, container := range map[string][]byte{"many slots": many, "heavy slots": heavy} {
+		if _, err := ImportIdentities(container, PassphraseIdentity("wrong horse")); err == nil || err.Error() != expected {
+			t.Errorf("%s: expected err '%s' but actual %v", name, expected, err)
+		}
+	}
+	if _, err := ImportIdentities(many, PassphraseIdentity("correct horse")); err != nil {
+		t.Errorf("expected the first slot to open but actual %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+// Package kms envelope encrypts export containers with a cloud kms: a random
+// data key encrypts the container and the keyring wraps the data key, so the
+// recovery material is stored in object storage under the iam controls of the
+// key. The aws and gcloud clis do the kms calls, vault.Transit is a keyring
+// too
+package kms
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/nomnemonic/nomnemonic/internal/command"
+)
+
+const (
+	_version = 1
+
+	// _context is the encryption context of the data keys, kms refuses to
+	// unwrap them without it
+	_context = "purpose=nomnemonic-export"
+)
+
+var _gcpKey = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`)
+
+type (
+	// Keyring wraps and unwraps the data keys
+	Keyring interface {
+		Encrypt(plaintext []byte) ([]byte, error)
+		Decrypt(ciphertext []byte) ([]byte, error)
+	}
+
+	// AWS is a symmetric key of aws kms, the credentials and the region come
+	// from the aws cli configuration unless given
+	AWS struct {
+		KeyID   string
+		Region  string
+		Profile string
+
+		run command.Runner
+	}
+
+	// GCP is a symmetric key of cloud kms, like
+	// projects/p/locations/global/keyRings/r/cryptoKeys/k, the credentials
+	// come from gcloud
+	GCP struct {
+		Key string
+
+		run command.Runner
+	}
+
+	// envelope is the json of an envelope encrypted container
+	envelope struct {
+		Version    int    `json:"version"`
+		WrappedKey []byte `json:"wrapped_key"`
+		Nonce      []byte `json:"nonce"`
+		Ciphertext []byte `json:"ciphertext"`
+	}
+)
+
+// Seal encrypts the container with a random data key wrapped by the keyring
+func Seal(container []byte, k Keyring) ([]byte, error) {
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	wrapped, err := k.Encrypt(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	e := envelope{Version: _version, WrappedKey: wrapped, Nonce: make([]byte, aead.NonceSize())}
+	if _, err := rand.Read(e.Nonce); err != nil {
+		return nil, err
+	}
+	e.Ciphertext = aead.Seal(nil, e.Nonce, container, e.additionalData())
+	return json.Marshal(e)
+}
+
+// Open unwraps the data key with the keyring and returns the container
+func Open(blob []byte, k Keyring) ([]byte, error) {
+	var e envelope
+	if err := json.Unmarshal(blob, &e); err != nil || e.Version != _version || len(e.WrappedKey) == 0 {
+		return nil, errors.New("kms: invalid envelope")
+	}
+	key, err := k.Decrypt(e.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, errors.New("kms: invalid data key")
+	}
+	container, err := aead.Open(nil, e.Nonce, e.Ciphertext, e.additionalData())
+	if err != nil {
+		return nil, errors.New("kms: invalid envelope")
+	}
+	return container, nil
+}
+
+// additionalData binds the ciphertext to the wrapped key
+func (e envelope) additionalData() []byte {
+	return []byte(fmt.Sprintf("nomnemonic kms v%d\x00%x", e.Version, e.WrappedKey))
+}
+
+// NewAWS returns the aws kms key of the id, an arn or an alias/ name
+func NewAWS(keyID string) (*AWS, error) {
+	if keyID == "" {
+		return nil, errors.New("kms: no aws key id")
+	}
+	return &AWS{KeyID: keyID, run: command.Run}, nil
+}
+
+// Encrypt wraps the data key with the key
+func (a *AWS) Encrypt(plaintext []byte) ([]byte, error) {
+	out, err := a.run(plaintext, "aws", a.args("encrypt", "--plaintext", "fileb:///dev/stdin", "--query", "CiphertextBlob")...)
+	if err != nil {
+		return nil, fmt.Errorf("kms: aws: %w", err)
+	}
+	return decodeOutput(out)
+}
+
+// Decrypt unwraps the data key with the key
+func (a *AWS) Decrypt(ciphertext []byte) ([]byte, error) {
+	out, err := a.run(ciphertext, "aws", a.args("decrypt", "--ciphertext-blob", "fileb:///dev/stdin", "--query", "Plaintext")...)
+	if err != nil {
+		return nil, fmt.Errorf("kms: aws: %w", err)
+	}
+	return decodeOutput(out)
+}
+
+func (a *AWS) args(operation string, args ...string) []string {
+	args = append([]string{"kms", operation, "--key-id", a.KeyID, "--encryption-context", _context, "--output", "text"}, args...)
+	if a.Region != "" {
+		args = append(args, "--region", a.Region)
+	}
+	if a.Profile != "" {
+		args = append(args, "--profile", a.Profile)
+	}
+	return args
+}
+
+// NewGCP returns the cloud kms key of the resource name
+func NewGCP(key string) (*GCP, error) {
+	if !_gcpKey.MatchString(key) {
+		return nil, fmt.Errorf("kms: invalid gcp key %s", key)
+	}
+	return &GCP{Key: key, run: command.Run}, nil
+}
+
+// Encrypt wraps the data key with the key
+func (g *GCP) Encrypt(plaintext []byte) ([]byte, error) {
+	out, err := g.run(plaintext, "gcloud", "kms", "encrypt", "--key", g.Key, "--plaintext-file", "-", "--ciphertext-file", "-")
+	if err != nil {
+		return nil, fmt.Errorf("kms: gcp: %w", err)
+	}
+	if len(out) == 0 {
+		return nil, errors.New("kms: gcp: no ciphertext returned")
+	}
+	return out, nil
+}
+
+// Decrypt unwraps the data key with the key
+func (g *GCP) Decrypt(ciphertext []byte) ([]byte, error) {
+	out, err := g.run(ciphertext, "gcloud", "kms", "decrypt", "--key", g.Key, "--ciphertext-file", "-", "--plaintext-file", "-")
+	if err != nil {
+		return nil, fmt.Errorf("kms: gcp: %w", err)
+	}
+	return out, nil
+}
+
+// decodeOutput decodes the base64 text output of the aws cli
+func decodeOutput(out []byte) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(out)))
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("kms: aws: invalid output")
+	}
+	return b, nil
+}
@@ -0,0 +1,100 @@
+package kms
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic/vault"
+)
+
+var _ Keyring = (*vault.Transit)(nil)
+
+// fakeKMS wraps by prefixing the name of the key
+type fakeKMS struct {
+	key    string
+	base64 bool
+	args   []string
+}
+
+func (f *fakeKMS) run(stdin []byte, name string, args ...string) ([]byte, error) {
+	f.args = append([]string{name}, args...)
+	var out []byte
+	switch args[1] {
+	case "encrypt":
+		out = append([]byte(f.key+":"), stdin...)
+	case "decrypt":
+		if !bytes.HasPrefix(stdin, []byte(f.key+":")) {
+			return nil, errors.New("exit status 254: An error occurred (InvalidCiphertextException) when calling the Decrypt operation")
+		}
+		out = stdin[len(f.key)+1:]
+	}
+	if f.base64 {
+		return []byte(base64.StdEncoding.EncodeToString(out) + "\n"), nil
+	}
+	return out, nil
+}
+
+func TestSeal(t *testing.T) {
+	aws, err := NewAWS("alias/backups")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	awsKMS := &fakeKMS{key: "aws", base64: true}
+	aws.run, aws.Region = awsKMS.run, "eu-west-1"
+
+	gcp, err := NewGCP("projects/p/locations/global/keyRings/r/cryptoKeys/backups")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	gcpKMS := &fakeKMS{key: "gcp"}
+	gcp.run = gcpKMS.run
+
+	container := []byte("export container")
+	for _, test := range []struct {
+		name    string
+		keyring Keyring
+		kms     *fakeKMS
+		command string
+	}{
+		{name: "aws", keyring: aws, kms: awsKMS, command: "aws kms decrypt --key-id alias/backups --encryption-context purpose=nomnemonic-export --output text --ciphertext-blob fileb:///dev/stdin --query Plaintext --region eu-west-1"},
+		{name: "gcp", keyring: gcp, kms: gcpKMS, command: "gcloud kms decrypt --key projects/p/locations/global/keyRings/r/cryptoKeys/backups --ciphertext-file - --plaintext-file -"},
+	} {
+		blob, err := Seal(container, test.keyring)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", test.name, err.Error())
+		}
+		if bytes.Contains(blob, container) {
+			t.Errorf("%s: expected the container to be encrypted", test.name)
+		}
+		opened, err := Open(blob, test.keyring)
+		if err != nil || !bytes.Equal(opened, container) {
+			t.Errorf("%s: expected '%s' but actual '%s' %v", test.name, container, opened, err)
+		}
+		if actual := strings.Join(test.kms.args, " "); actual != test.command {
+			t.Errorf("%s: expected %s but actual %s", test.name, test.command, actual)
+		}
+
+		var e envelope
+		_ = json.Unmarshal(blob, &e)
+		e.Ciphertext[0] ^= 1
+		tampered, _ := json.Marshal(e)
+		if _, err := Open(tampered, test.keyring); err == nil || err.Error() != "kms: invalid envelope" {
+			t.Errorf("%s: expected an invalid envelope error but actual %v", test.name, err)
+		}
+	}
+
+	blob, _ := Seal(container, aws)
+	if _, err := Open(blob, gcp); err == nil || !strings.HasPrefix(err.Error(), "kms: gcp: ") {
+		t.Errorf("expected the gcp error for an aws envelope but actual %v", err)
+	}
+	if _, err := NewGCP("backups"); err == nil {
+		t.Error("expected an error for an invalid gcp key")
+	}
+	if _, err := NewAWS(""); err == nil {
+		t.Error("expected an error without an aws key id")
+	}
+}
@@ -0,0 +1,48 @@
+package nomnemonic
+
+import "time"
+
+type (
+	// Logger receives the derivation milestones. The fields are limited to
+	// sizes, versions, stages and durations, the inputs, words, entropy,
+	// keys and error messages, which may quote an input, are never logged
+	Logger interface {
+		Log(msg string, fields ...Field)
+	}
+
+	// Field is a non sensitive key value pair of a log message
+	Field struct {
+		Key   string
+		Value interface{}
+	}
+)
+
+// WithLogger logs the derivation milestones to l
+func WithLogger(l Logger) Option {
+	return func(m *mnemonicer) {
+		m.logger = l
+	}
+}
+
+func (m *mnemonicer) log(msg string, fields ...Field) {
+	if m.logger != nil {
+		m.logger.Log(msg, fields...)
+	}
+}
+
+// logStarted logs the start of a derivation and returns its start time
+func (m *mnemonicer) logStarted(size int) time.Time {
+	m.log("derivation started",
+		Field{Key: "size", Value: size},
+		Field{Key: "version", Value: Version},
+		Field{Key: "algorithm_version", Value: VersionAlgorithm},
+	)
+	return time.Now()
+}
+
+func (m *mnemonicer) logFinished(size int, start time.Time) {
+	m.log("derivation finished",
+		Field{Key: "size", Value: size},
+		Field{Key: "duration", Value: time.Since(start)},
+	)
+}
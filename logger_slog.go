@@ -0,0 +1,26 @@
+//go:build go1.21
+
+package nomnemonic
+
+import (
+	"context"
+	"log/slog"
+)
+
+type slogLogger struct {
+	l     *slog.Logger
+	level slog.Level
+}
+
+// SlogLogger adapts l to Logger, the milestones are logged at level
+func SlogLogger(l *slog.Logger, level slog.Level) Logger {
+	return &slogLogger{l: l, level: level}
+}
+
+func (s *slogLogger) Log(msg string, fields ...Field) {
+	attrs := make([]slog.Attr, len(fields))
+	for i, f := range fields {
+		attrs[i] = slog.Any(f.Key, f.Value)
+	}
+	s.l.LogAttrs(context.Background(), s.level, msg, attrs...)
+}
@@ -0,0 +1,28 @@
+//go:build go1.21
+
+package nomnemonic
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := SlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})), slog.LevelInfo)
+
+	l.Log("derivation started", Field{Key: "size", Value: 24}, Field{Key: "version", Value: Version})
+	expected := "level=INFO msg=\"derivation started\" size=24 version=" + Version
+	if strings.TrimSpace(buf.String()) != expected {
+		t.Errorf("expected '%s' but actual '%s'", expected, strings.TrimSpace(buf.String()))
+	}
+}
@@ -0,0 +1,63 @@
+package nomnemonic
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type recordLogger struct {
+	mu   sync.Mutex
+	msgs []string
+	text []string
+}
+
+func (r *recordLogger) Log(msg string, fields ...Field) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.msgs = append(r.msgs, msg)
+	r.text = append(r.text, fmt.Sprint(msg, fields))
+}
+
+func TestWithLogger(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		passcode string
+		expected []string
+	}{
+		{
+			name:     "generated",
+			passcode: "101938",
+			expected: []string{"derivation started", "kdf stage finished", "kdf stage finished", "derivation finished"},
+		},
+		{
+			name:     "rejected",
+			passcode: "10193a",
+			expected: []string{"derivation rejected"},
+		},
+	}
+
+	for _, test := range tests {
+		l := &recordLogger{}
+		m, _ := New(words, WithLogger(l))
+		generated, _ := m.Generate("nomnemonic_test", "test12345678", test.passcode, 12)
+
+		if strings.Join(l.msgs, ",") != strings.Join(test.expected, ",") {
+			t.Errorf("%s: expected messages %v but actual %v", test.name, test.expected, l.msgs)
+		}
+		secrets := append([]string{"nomnemonic_test", "test12345678", test.passcode}, generated...)
+		for _, text := range l.text {
+			for _, s := range secrets {
+				if strings.Contains(text, s) {
+					t.Errorf("%s: expected no secret in '%s' but actual '%s'", test.name, text, s)
+				}
+			}
+		}
+	}
+}
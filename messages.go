@@ -0,0 +1,163 @@
+package nomnemonic
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// _english is the language of the error messages themselves
+const _english = "english"
+
+// _messages translates the error kinds to the languages of the embedded
+// bip39 word lists, named like the CLI --language values
+var _messages = map[string]map[error]string{
+	"chinese-simplified": {
+		ErrInvalidWordlist: "bip39 基于 2048 个单词",
+		ErrShortIdentifier: "标识符至少需要 %d 个字符",
+		ErrWeakPassword:    "密码至少需要 %d 个字符",
+		ErrInvalidPasscode: "通行码必须是 %d 位数字",
+		ErrUnsupportedSize: "单词数量必须是 12、15、18、21 或 24",
+		ErrUnknownWord:     "无法识别的单词：%s",
+		ErrInvalidChecksum: "校验和无效",
+		ErrWrongPassphrase: "密码短语错误或导出文件已损坏",
+	},
+	"chinese-traditional": {
+		ErrInvalidWordlist: "bip39 基於 2048 個單字",
+		ErrShortIdentifier: "識別碼至少需要 %d 個字元",
+		ErrWeakPassword:    "密碼至少需要 %d 個字元",
+		ErrInvalidPasscode: "通行碼必須是 %d 位數字",
+		ErrUnsupportedSize: "單字數量必須是 12、15、18、21 或 24",
+		ErrUnknownWord:     "無法辨識的單字：%s",
+		ErrInvalidChecksum: "校驗碼無效",
+		ErrWrongPassphrase: "密碼短語錯誤或匯出檔案已損毀",
+	},
+	"czech": {
+		ErrInvalidWordlist: "bip39 je založen na 2048 slovech",
+		ErrShortIdentifier: "identifikátor musí mít alespoň %d znaků",
+		ErrWeakPassword:    "heslo musí mít alespoň %d znaků",
+		ErrInvalidPasscode: "přístupový kód musí mít %d číslic",
+		ErrUnsupportedSize: "počet slov musí být 12, 15, 18, 21 nebo 24",
+		ErrUnknownWord:     "nerozpoznané slovo %s",
+		ErrInvalidChecksum: "neplatný kontrolní součet",
+		ErrWrongPassphrase: "chybná přístupová fráze nebo poškozený export",
+	},
+	"french": {
+		ErrInvalidWordlist: "bip39 repose sur 2048 mots",
+		ErrShortIdentifier: "l'identifiant doit contenir au moins %d caractères",
+		ErrWeakPassword:    "le mot de passe doit contenir au moins %d caractères",
+		ErrInvalidPasscode: "le code d'accès doit comporter %d chiffres",
+		ErrUnsupportedSize: "le nombre de mots doit être 12, 15, 18, 21 ou 24",
+		ErrUnknownWord:     "mot non reconnu : %s",
+		ErrInvalidChecksum: "somme de contrôle invalide",
+		ErrWrongPassphrase: "phrase secrète incorrecte ou export corrompu",
+	},
+	"italian": {
+		ErrInvalidWordlist: "bip39 si basa su 2048 parole",
+		ErrShortIdentifier: "l'identificativo deve contenere almeno %d caratteri",
+		ErrWeakPassword:    "la password deve contenere almeno %d caratteri",
+		ErrInvalidPasscode: "il codice di accesso deve essere di %d cifre",
+		ErrUnsupportedSize: "il numero di parole deve essere 12, 15, 18, 21 o 24",
+		ErrUnknownWord:     "parola non riconosciuta: %s",
+		ErrInvalidChecksum: "checksum non valido",
+		ErrWrongPassphrase: "passphrase errata o esportazione danneggiata",
+	},
+	"japanese": {
+		ErrInvalidWordlist: "bip39 は 2048 語に基づいています",
+		ErrShortIdentifier: "識別子は %d 文字以上必要です",
+		ErrWeakPassword:    "パスワードは %d 文字以上必要です",
+		ErrInvalidPasscode: "パスコードは %d 桁の数字である必要があります",
+		ErrUnsupportedSize: "単語数は 12、15、18、21、24 のいずれかである必要があります",
+		ErrUnknownWord:     "認識できない単語: %s",
+		ErrInvalidChecksum: "チェックサムが無効です",
+		ErrWrongPassphrase: "パスフレーズが間違っているか、エクスポートが破損しています",
+	},
+	"korean": {
+		ErrInvalidWordlist: "bip39는 2048개의 단어를 기반으로 합니다",
+		ErrShortIdentifier: "식별자는 %d자 이상이어야 합니다",
+		ErrWeakPassword:    "비밀번호는 %d자 이상이어야 합니다",
+		ErrInvalidPasscode: "패스코드는 %d자리 숫자여야 합니다",
+		ErrUnsupportedSize: "단어 수는 12, 15, 18, 21 또는 24개여야 합니다",
+		ErrUnknownWord:     "인식할 수 없는 단어: %s",
+		ErrInvalidChecksum: "체크섬이 올바르지 않습니다",
+		ErrWrongPassphrase: "암호 문구가 틀렸거나 내보내기 파일이 손상되었습니다",
+	},
+	"spanish": {
+		ErrInvalidWordlist: "bip39 se basa en 2048 palabras",
+		ErrShortIdentifier: "el identificador debe tener al menos %d caracteres",
+		ErrWeakPassword:    "la contraseña debe tener al menos %d caracteres",
+		ErrInvalidPasscode: "el código de acceso debe tener %d dígitos",
+		ErrUnsupportedSize: "el número de palabras debe ser 12, 15, 18, 21 o 24",
+		ErrUnknownWord:     "palabra no reconocida: %s",
+		ErrInvalidChecksum: "suma de verificación no válida",
+		ErrWrongPassphrase: "frase de contraseña incorrecta o exportación dañada",
+	},
+}
+
+// localizedError is an error with a translated message, errors.Is and
+// errors.As see the original error
+type localizedError struct {
+	err error
+	msg string
+}
+
+func (e *localizedError) Error() string {
+	return e.msg
+}
+
+func (e *localizedError) Unwrap() error {
+	return e.err
+}
+
+// WithLocale translates the messages of the validation errors to the
+// language, one of Locales. The errors still match their kinds
+func WithLocale(language string) Option {
+	return func(m *mnemonicer) {
+		m.locale = language
+	}
+}
+
+// Locales returns the languages of the error messages in order
+func Locales() []string {
+	locales := []string{_english}
+	for language := range _messages {
+		locales = append(locales, language)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Localize returns the message of the error in the language, the message of
+// the error itself when the language or the error kind has no translation
+func Localize(err error, language string) string {
+	messages, ok := _messages[language]
+	if !ok {
+		return err.Error()
+	}
+
+	var wordErr *UnknownWordError
+	switch {
+	case errors.As(err, &wordErr):
+		return fmt.Sprintf(messages[ErrUnknownWord], wordErr.Word)
+	case errors.Is(err, ErrShortIdentifier):
+		return fmt.Sprintf(messages[ErrShortIdentifier], _inputIdentifierMinLength)
+	case errors.Is(err, ErrWeakPassword):
+		return fmt.Sprintf(messages[ErrWeakPassword], _inputPasswordMinLength)
+	case errors.Is(err, ErrInvalidPasscode):
+		return fmt.Sprintf(messages[ErrInvalidPasscode], _inputPasscodeLength)
+	}
+	for _, kind := range []error{ErrInvalidWordlist, ErrUnsupportedSize, ErrInvalidChecksum, ErrWrongPassphrase} {
+		if errors.Is(err, kind) {
+			return messages[kind]
+		}
+	}
+	return err.Error()
+}
+
+// localize translates the error to the language of WithLocale
+func (m *mnemonicer) localize(err error) error {
+	if err == nil || m.locale == "" || m.locale == _english {
+		return err
+	}
+	return &localizedError{err: err, msg: Localize(err, m.locale)}
+}
@@ -0,0 +1,70 @@
+package nomnemonic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithLocale(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+
+	tests := []struct {
+		locale   string
+		password string
+		passcode string
+		size     int
+		kind     error
+		expected string
+	}{
+		{locale: "spanish", password: "test", passcode: "101938", size: 12, kind: ErrWeakPassword, expected: "la contraseña debe tener al menos 12 caracteres"},
+		{locale: "japanese", password: "test12345678", passcode: "abcdef", size: 12, kind: ErrInvalidPasscode, expected: "パスコードは 6 桁の数字である必要があります"},
+		{locale: "french", password: "test12345678", passcode: "101938", size: 13, kind: ErrUnsupportedSize, expected: "le nombre de mots doit être 12, 15, 18, 21 ou 24"},
+		{locale: "english", password: "test", passcode: "101938", size: 12, kind: ErrWeakPassword, expected: "password must be at least 12 chars"},
+		{locale: "klingon", password: "test", passcode: "101938", size: 12, kind: ErrWeakPassword, expected: "password must be at least 12 chars"},
+	}
+
+	for _, test := range tests {
+		m, err := New(words, WithLocale(test.locale))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = m.Generate("nomnemonic_test", test.password, test.passcode, test.size)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("%s: expected err '%s' but actual %v", test.locale, test.expected, err)
+		}
+		if !errors.Is(err, test.kind) {
+			t.Errorf("%s: expected %v to be %v", test.locale, err, test.kind)
+		}
+	}
+
+	m, err := New(words, WithLocale("czech"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.CalculateEntropy(strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobbby"))
+	var wordErr *UnknownWordError
+	if err == nil || err.Error() != "nerozpoznané slovo hobbby" || !errors.As(err, &wordErr) || wordErr.Index != 11 {
+		t.Errorf("expected a czech UnknownWordError but actual %v", err)
+	}
+}
+
+func TestLocales(t *testing.T) {
+	expected := "chinese-simplified chinese-traditional czech english french italian japanese korean spanish"
+	if actual := strings.Join(Locales(), " "); actual != expected {
+		t.Errorf("expected %s but actual %s", expected, actual)
+	}
+	for language, messages := range _messages {
+		for _, kind := range []error{ErrInvalidWordlist, ErrShortIdentifier, ErrWeakPassword, ErrInvalidPasscode, ErrUnsupportedSize, ErrUnknownWord, ErrInvalidChecksum, ErrWrongPassphrase} {
+			if messages[kind] == "" {
+				t.Errorf("%s: expected a message for %v", language, kind)
+			}
+		}
+	}
+	if actual := Localize(errors.New("other"), "korean"); actual != "other" {
+		t.Errorf("expected the message of an unknown kind but actual %s", actual)
+	}
+}
@@ -0,0 +1,125 @@
+package nomnemonic
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	_migrationTag       = "nomnemonic migration v1"
+	_migrationTokenSize = 8
+)
+
+type (
+	// Inputs are the generation inputs of Migrate
+	Inputs struct {
+		Identifier string
+		Password   string
+		Passcode   string
+		Size       int
+	}
+
+	// Migration is the phrase of the same inputs under two algorithm
+	// versions. Changed lists the positions, from 0, of the words that
+	// differ and Token ties both phrases together, record it with both
+	// backups and retire the old one only after Confirm
+	Migration struct {
+		From    Result
+		To      Result
+		Changed []int
+		Token   string
+	}
+
+	// deriver derives the entropy of the inputs under an algorithm version
+	deriver func(m *mnemonicer, in Inputs) ([]byte, error)
+)
+
+// _algorithms are the algorithm versions this build derives, an older version
+// stays here when a new one is added so its phrases can be migrated. This
+// build has no version before VersionAlgorithm, the registry is scaffolding
+// for the next one and Migrate has nothing to migrate yet
+var _algorithms = map[string]deriver{
+	VersionAlgorithm: func(m *mnemonicer, in Inputs) ([]byte, error) {
+		return m.deriveEntropy(in.Identifier, in.Password, in.Passcode, in.Size)
+	},
+}
+
+// Migrate derives the phrase of the inputs under both algorithm versions to
+// re-key from the phrase of fromVersion to the one of toVersion, the versions
+// must differ
+func (m *mnemonicer) Migrate(in Inputs, fromVersion, toVersion string) (Migration, error) {
+	if fromVersion == toVersion {
+		return Migration{}, fmt.Errorf("from and to are both algorithm version %s, nothing to migrate", fromVersion)
+	}
+	from, err := m.deriveVersion(in, fromVersion)
+	if err != nil {
+		return Migration{}, err
+	}
+	to, err := m.deriveVersion(in, toVersion)
+	if err != nil {
+		return Migration{}, err
+	}
+
+	var changed []int
+	for i := range to.Words {
+		if i >= len(from.Words) || from.Words[i] != to.Words[i] {
+			changed = append(changed, i)
+		}
+	}
+	return Migration{From: from, To: to, Changed: changed, Token: migrationToken(from, to)}, nil
+}
+
+// deriveVersion derives the envelope of the inputs under the algorithm
+// version
+func (m *mnemonicer) deriveVersion(in Inputs, version string) (Result, error) {
+	derive, ok := _algorithms[version]
+	if !ok {
+		return Result{}, fmt.Errorf("unsupported algorithm version %s", version)
+	}
+	entropy, err := derive(m, in)
+	if err != nil {
+		return Result{}, err
+	}
+	words, err := m.generatedWords(entropy)
+	if err != nil {
+		return Result{}, err
+	}
+	r := Result{
+		Words:            words,
+		Entropy:          entropy,
+		Version:          Version,
+		AlgorithmVersion: version,
+		WordlistChecksum: WordlistChecksum(m.words),
+		Features:         m.derivationFeatures(),
+		Purpose:          m.purpose,
+	}
+	r.Language, _ = Compatibility().Language(r.WordlistChecksum)
+	if version == VersionAlgorithm {
+		r.KDFHash = m.kdfHash()
+	}
+	return r, nil
+}
+
+// migrationToken is the hex of the first bytes of the sha256 of the versions
+// and both phrases
+func migrationToken(from, to Result) string {
+	h := sha256.New()
+	for _, s := range []string{_migrationTag, from.AlgorithmVersion, to.AlgorithmVersion, strings.Join(from.Words, " "), strings.Join(to.Words, " ")} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:_migrationTokenSize])
+}
+
+// Confirm checks the words read back from the new backup are the phrase of
+// the new version, in constant time
+func (g Migration) Confirm(words []string) error {
+	if subtle.ConstantTimeCompare([]byte(strings.Join(words, " ")), []byte(strings.Join(g.To.Words, " "))) != 1 {
+		return errors.New("the words are not the phrase of the new algorithm version")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package nomnemonic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := Inputs{Identifier: "nomnemonic_test", Password: "test12345678", Passcode: "101938", Size: 12}
+
+	// a stand in for a newer version changing the first byte of the entropy,
+	// this build has no other version
+	_algorithms["test"] = func(m *mnemonicer, in Inputs) ([]byte, error) {
+		entropy, err := m.deriveEntropy(in.Identifier, in.Password, in.Passcode, in.Size)
+		if err == nil {
+			entropy[0] ^= 0xff
+		}
+		return entropy, err
+	}
+	defer delete(_algorithms, "test")
+	g, err := m.Migrate(in, VersionAlgorithm, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	if strings.Join(g.From.Words, " ") != expected || strings.Join(g.To.Words, " ") == expected {
+		t.Errorf("expected %s to change but actual %v and %v", expected, g.From.Words, g.To.Words)
+	}
+	// the first word and the checksum word change
+	if len(g.Changed) != 2 || g.Changed[0] != 0 || g.Changed[1] != 11 || len(g.Token) != 2*_migrationTokenSize {
+		t.Errorf("expected words 0 and 11 to change and a token but actual %v %s", g.Changed, g.Token)
+	}
+	if warnings := Check(g.From); len(warnings) != 0 {
+		t.Errorf("expected no warnings but actual %v", warnings)
+	}
+	if warnings := Check(g.To); len(warnings) != 1 || warnings[0] != "algorithm version test is not derived by version "+Version {
+		t.Errorf("expected an unsupported version warning but actual %v", warnings)
+	}
+	if err := g.Confirm(g.To.Words); err != nil {
+		t.Errorf("expected the words to confirm but actual %v", err)
+	}
+	if err := g.Confirm(g.From.Words); err == nil {
+		t.Error("expected the old words not to confirm")
+	}
+	back, err := m.Migrate(in, "test", VersionAlgorithm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.Token == g.Token {
+		t.Errorf("expected the token to tie the direction too but actual %s", back.Token)
+	}
+
+	tests := []struct {
+		from string
+		to   string
+		in   Inputs
+		err  string
+	}{
+		{from: "1.0.0", to: VersionAlgorithm, in: in, err: "unsupported algorithm version 1.0.0"},
+		{from: VersionAlgorithm, to: "4.0.0", in: in, err: "unsupported algorithm version 4.0.0"},
+		{from: VersionAlgorithm, to: VersionAlgorithm, in: in, err: "from and to are both algorithm version " + VersionAlgorithm + ", nothing to migrate"},
+		{from: VersionAlgorithm, to: "test", in: Inputs{Identifier: "nomnemonic_test", Password: "test", Passcode: "101938", Size: 12}, err: "password must be at least 12 chars"},
+	}
+	for _, test := range tests {
+		_, err := m.Migrate(test.in, test.from, test.to)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Package mobile is a binding layer for gomobile bind, so iOS and Android
+// wallets embed the exact algorithm. It only uses types gomobile can bind:
+// strings instead of slices of words, []byte for binary values, an interface
+// for the progress callbacks and a token to cancel a running generation
+//
+//	gomobile bind -target android ./mobile
+//	gomobile bind -target ios ./mobile
+package mobile
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+const _japaneseSeparator = "　"
+
+var (
+	// ErrCanceled is returned by Generate after CancelToken.Cancel
+	ErrCanceled = errors.New("generation canceled")
+
+	_wordlists = map[string][]string{
+		"chinese-simplified":  wordlists.ChineseSimplified,
+		"chinese-traditional": wordlists.ChineseTraditional,
+		"czech":               wordlists.Czech,
+		"english":             wordlists.English,
+		"french":              wordlists.French,
+		"italian":             wordlists.Italian,
+		"japanese":            wordlists.Japanese,
+		"korean":              wordlists.Korean,
+		"spanish":             wordlists.Spanish,
+	}
+)
+
+type (
+	// Generator generates and inspects the mnemonics of a language
+	Generator struct {
+		m   nomnemonic.Mnemonicer
+		sep string
+	}
+
+	// ProgressListener receives the completed fraction of the kdf, from 0 to
+	// 1, on the goroutine running Generate
+	ProgressListener interface {
+		OnProgress(done float64)
+	}
+
+	// CancelToken cancels the Generate calls it is given, it is safe to
+	// cancel from another thread
+	CancelToken struct {
+		canceled int32
+	}
+
+	// Mnemonic holds generated words, read them one by one with Word or all
+	// at once with Phrase
+	Mnemonic struct {
+		words []string
+		sep   string
+	}
+)
+
+// NewGenerator returns the generator of an embedded bip39 language
+func NewGenerator(language string) (*Generator, error) {
+	words, ok := _wordlists[language]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language %s", language)
+	}
+	m, err := nomnemonic.New(words)
+	if err != nil {
+		return nil, err
+	}
+	sep := " "
+	if language == "japanese" {
+		sep = _japaneseSeparator
+	}
+	return &Generator{m: m, sep: sep}, nil
+}
+
+// NewCancelToken returns a token that is not canceled
+func NewCancelToken() *CancelToken {
+	return &CancelToken{}
+}
+
+// Cancel stops the generations using the token at their next kdf chunk
+func (t *CancelToken) Cancel() {
+	atomic.StoreInt32(&t.canceled, 1)
+}
+
+// Canceled reports whether Cancel was called
+func (t *CancelToken) Canceled() bool {
+	return atomic.LoadInt32(&t.canceled) == 1
+}
+
+// Generate generates the mnemonic of the credentials, listener and token are
+// optional and may be nil
+func (g *Generator) Generate(identifier, password, passcode string, size int, listener ProgressListener, token *CancelToken) (*Mnemonic, error) {
+	words, err := g.m.GenerateProgress(identifier, password, passcode, size, func(done float64) error {
+		if token != nil && token.Canceled() {
+			return ErrCanceled
+		}
+		if listener != nil {
+			listener.OnProgress(done)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Mnemonic{words: words, sep: g.sep}, nil
+}
+
+// IsValid reports whether the phrase is a valid mnemonic
+func (g *Generator) IsValid(phrase string) bool {
+	ok, err := g.m.IsValid(strings.Fields(phrase))
+	return err == nil && ok
+}
+
+// Seed returns the 64 bytes bip39 seed of a valid phrase, abbreviated words
+// are expanded first
+func (g *Generator) Seed(phrase, passphrase string) ([]byte, error) {
+	words, err := g.m.ExpandWords(strings.Fields(phrase))
+	if ok, _ := g.m.IsValid(words); err != nil || !ok {
+		return nil, errors.New("invalid mnemonic")
+	}
+	return g.m.GenerateSeed(strings.Join(words, " "), passphrase)
+}
+
+// Entropy returns the entropy of a valid phrase
+func (g *Generator) Entropy(phrase string) ([]byte, error) {
+	return g.m.CalculateEntropy(strings.Fields(phrase))
+}
+
+// FromEntropy returns the mnemonic of the entropy
+func (g *Generator) FromEntropy(entropy []byte) (*Mnemonic, error) {
+	words, err := g.m.EntropyToWords(entropy)
+	if err != nil {
+		return nil, err
+	}
+	return &Mnemonic{words: words, sep: g.sep}, nil
+}
+
+// Count returns the number of words
+func (m *Mnemonic) Count() int {
+	return len(m.words)
+}
+
+// Word returns the word at the 0 based index, an empty string when it is out
+// of range
+func (m *Mnemonic) Word(index int) string {
+	if index < 0 || index >= len(m.words) {
+		return ""
+	}
+	return m.words[index]
+}
+
+// Phrase returns the words joined with the separator of the language
+func (m *Mnemonic) Phrase() string {
+	return strings.Join(m.words, m.sep)
+}
@@ -0,0 +1,85 @@
+package mobile
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+type listener struct {
+	calls []float64
+	token *CancelToken
+}
+
+func (l *listener) OnProgress(done float64) {
+	l.calls = append(l.calls, done)
+	if l.token != nil && done > 0.5 {
+		l.token.Cancel()
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	g, err := NewGenerator("english")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := &listener{}
+	m, err := g.Generate("nomnemonic_test", "test12345678", "101938", 12, l, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	if m.Phrase() != expected {
+		t.Errorf("expected '%s' but actual '%s'", expected, m.Phrase())
+	}
+	if m.Count() != 12 || m.Word(0) != "cinnamon" || m.Word(11) != "hobby" || m.Word(12) != "" {
+		t.Errorf("expected 12 words from cinnamon to hobby but actual %d words", m.Count())
+	}
+	if len(l.calls) == 0 || l.calls[len(l.calls)-1] != 1 {
+		t.Errorf("expected progress to end at 1 but actual %v", l.calls)
+	}
+	if !g.IsValid(m.Phrase()) {
+		t.Errorf("expected '%s' to be valid", m.Phrase())
+	}
+
+	token := NewCancelToken()
+	if _, err := g.Generate("nomnemonic_test", "test12345678", "101938", 12, &listener{token: token}, token); err != ErrCanceled {
+		t.Errorf("expected err '%v' but actual '%v'", ErrCanceled, err)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	g, err := NewGenerator("english")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	phrase := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	seed, err := g.Seed(phrase, "TREZOR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"
+	if hex.EncodeToString(seed) != expected {
+		t.Errorf("expected seed %s but actual %x", expected, seed)
+	}
+
+	entropy, err := g.Entropy(phrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := g.FromEntropy(entropy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Phrase() != phrase {
+		t.Errorf("expected '%s' but actual '%s'", phrase, m.Phrase())
+	}
+
+	if _, err := g.Seed("abandon abandon", ""); err == nil || err.Error() != "invalid mnemonic" {
+		t.Errorf("expected err 'invalid mnemonic' but actual '%v'", err)
+	}
+	if _, err := NewGenerator("klingon"); err == nil || err.Error() != "unsupported language klingon" {
+		t.Errorf("expected err 'unsupported language klingon' but actual '%v'", err)
+	}
+}
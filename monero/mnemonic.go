@@ -0,0 +1,107 @@
+package monero
+
+import (
+	_ "embed"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+)
+
+const (
+	_mnemonicPrefixLength = 3
+)
+
+var (
+	//go:embed english.txt
+	_englishWords string
+
+	_words = strings.Split(_englishWords, "\n")
+
+	// words are unique by their prefix, so the wallet accepts prefixes too
+	_prefixes = buildPrefixes()
+)
+
+// EncodeMnemonic encodes the secret into the monero electrum style mnemonic,
+// 3 words for each 4 bytes and the checksum word. 32 bytes secrets give the
+// 25 words wallet mnemonic
+func EncodeMnemonic(secret []byte) ([]string, error) {
+	if len(secret) == 0 || len(secret)%4 != 0 {
+		return nil, errors.New("secret must be a multiple of 4 bytes")
+	}
+	return encodeMnemonic(secret), nil
+}
+
+// DecodeMnemonic verifies the checksum word and decodes the mnemonic back to
+// the secret. Words can be given by their first 3 letters
+func DecodeMnemonic(words []string) ([]byte, error) {
+	if len(words) < 4 || len(words)%3 != 1 {
+		return nil, errors.New("mnemonic must be 3 words per 4 bytes and the checksum word")
+	}
+
+	indexes := make([]uint32, len(words))
+	for i, w := range words {
+		index, ok := _prefixes[prefix(strings.ToLower(w))]
+		if !ok {
+			return nil, fmt.Errorf("unrecognized word %s", w)
+		}
+		indexes[i] = index
+	}
+
+	data := words[:len(words)-1]
+	checksum := strings.ToLower(words[len(words)-1])
+	if prefix(checksum) != prefix(strings.ToLower(data[checksumIndex(data)])) {
+		return nil, errors.New("invalid checksum")
+	}
+
+	n := uint32(len(_words))
+	secret := make([]byte, 0, len(data)/3*4)
+	for i := 0; i < len(data); i += 3 {
+		w1, w2, w3 := indexes[i], indexes[i+1], indexes[i+2]
+		x := uint64(w1) + uint64(n)*uint64((n-w1+w2)%n) + uint64(n)*uint64(n)*uint64((n-w2+w3)%n)
+		if x%uint64(n) != uint64(w1) || x > 0xffffffff {
+			return nil, fmt.Errorf("invalid words at %d", i)
+		}
+		secret = binary.LittleEndian.AppendUint32(secret, uint32(x))
+	}
+	return secret, nil
+}
+
+// encodeMnemonic encodes the 32 bytes key into 24 words, 3 words for each 4
+// bytes, and appends the checksum word selected by crc32 of the word prefixes
+func encodeMnemonic(key []byte) []string {
+	n := uint32(len(_words))
+	words := make([]string, 0, len(key)/4*3+1)
+	for i := 0; i+4 <= len(key); i += 4 {
+		x := binary.LittleEndian.Uint32(key[i : i+4])
+		w1 := x % n
+		w2 := (x/n + w1) % n
+		w3 := (x/n/n + w2) % n
+		words = append(words, _words[w1], _words[w2], _words[w3])
+	}
+	return append(words, words[checksumIndex(words)])
+}
+
+func checksumIndex(words []string) int {
+	var prefixes strings.Builder
+	for _, w := range words {
+		prefixes.WriteString(prefix(w))
+	}
+	return int(crc32.ChecksumIEEE([]byte(prefixes.String())) % uint32(len(words)))
+}
+
+func prefix(w string) string {
+	if len(w) > _mnemonicPrefixLength {
+		return w[:_mnemonicPrefixLength]
+	}
+	return w
+}
+
+func buildPrefixes() map[string]uint32 {
+	prefixes := make(map[string]uint32, len(_words))
+	for i, w := range _words {
+		prefixes[prefix(w)] = uint32(i)
+	}
+	return prefixes
+}
@@ -0,0 +1,88 @@
+package monero
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestEncodeMnemonic(t *testing.T) {
+	tests := []struct {
+		secret   string
+		sentence string
+		err      string
+	}{
+		{
+			secret:   "0cca07dc4e90fc738fffdb2561dddd7a94d0dc8977d0229303d7509a10c9d705",
+			sentence: "wiggle drowning auburn aquarium attire meant impel phase soothe heron android mechanic inroads energy smog niece enforce syllabus exquisite lush bluntly rage siblings soda syllabus",
+		},
+		{
+			secret: "0cca07",
+			err:    "secret must be a multiple of 4 bytes",
+		},
+	}
+
+	for _, test := range tests {
+		secret, _ := hex.DecodeString(test.secret)
+		words, err := EncodeMnemonic(secret)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' but actual %v", test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if actual := strings.Join(words, " "); actual != test.sentence {
+			t.Errorf("expected '%s' but actual '%s'", test.sentence, actual)
+		}
+	}
+}
+
+func TestDecodeMnemonic(t *testing.T) {
+	tests := []struct {
+		sentence string
+		secret   string
+		err      string
+	}{
+		{
+			sentence: "wiggle drowning auburn aquarium attire meant impel phase soothe heron android mechanic inroads energy smog niece enforce syllabus exquisite lush bluntly rage siblings soda syllabus",
+			secret:   "0cca07dc4e90fc738fffdb2561dddd7a94d0dc8977d0229303d7509a10c9d705",
+		},
+		{
+			sentence: "wig dro aub aqu att mea imp pha soo her and mec inr ene smo nie enf syl exq lus blu rag sib sod syl",
+			secret:   "0cca07dc4e90fc738fffdb2561dddd7a94d0dc8977d0229303d7509a10c9d705",
+		},
+		{
+			sentence: "wiggle drowning auburn aquarium attire meant impel phase soothe heron android mechanic inroads energy smog niece enforce syllabus exquisite lush bluntly rage siblings soda soda",
+			err:      "invalid checksum",
+		},
+		{
+			sentence: "wiggle drowning auburn aquarium attire meant impel phase soothe heron android mechanic inroads energy smog niece enforce syllabus exquisite lush bluntly rage siblings soda zzzz",
+			err:      "unrecognized word zzzz",
+		},
+		{
+			sentence: "wiggle drowning auburn",
+			err:      "mnemonic must be 3 words per 4 bytes and the checksum word",
+		},
+	}
+
+	for _, test := range tests {
+		secret, err := DecodeMnemonic(strings.Fields(test.sentence))
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' but actual %v", test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if actual := hex.EncodeToString(secret); actual != test.secret {
+			t.Errorf("expected secret %s but actual %s", test.secret, actual)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Package monero derives Monero spend and view keys from the bip39 seed and
+// encodes them as standard addresses and 25 words Monero mnemonics
+package monero
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"filippo.io/edwards25519"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+	"github.com/nomnemonic/nomnemonic/internal/base58"
+)
+
+const (
+	_coinType = 128
+
+	_addressChecksumSize = 4
+	_fullBlockSize       = 8
+	_fullEncodedSize     = 11
+
+	KeySize = 32
+)
+
+var (
+	_encodedBlockSizes = []int{0, 2, 3, 5, 6, 7, 9, 10, 11}
+)
+
+// Network is the address prefix of the monero network
+type Network byte
+
+const (
+	NetworkMainnet  Network = 18
+	NetworkTestnet  Network = 53
+	NetworkStagenet Network = 24
+)
+
+// Keys is the monero account key set
+type Keys struct {
+	SpendPrivate []byte
+	SpendPublic  []byte
+	ViewPrivate  []byte
+	ViewPublic   []byte
+}
+
+// Path returns the bip44 derivation path of the account, it is the path used
+// by the Ledger monero app
+func Path(account uint32) string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0/0", _coinType, account)
+}
+
+// NewKeys derives the monero keys of the account from the bip39 seed. The
+// private spend key is the keccak256 of the bip32 private key reduced to the
+// ed25519 scalar field, same as the Ledger monero app
+func NewKeys(seed []byte, account uint32) (*Keys, error) {
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	k, err := master.DerivePath(Path(account))
+	if err != nil {
+		return nil, err
+	}
+	return KeysFromSpendKey(reduce(keccak256(k.PrivateKey())))
+}
+
+// KeysFromSpendKey derives the view key and public keys from the private
+// spend key the same way as the monero wallet does
+func KeysFromSpendKey(spendPrivate []byte) (*Keys, error) {
+	if len(spendPrivate) != KeySize {
+		return nil, fmt.Errorf("spend key must be %d bytes", KeySize)
+	}
+
+	spend, err := edwards25519.NewScalar().SetCanonicalBytes(spendPrivate)
+	if err != nil {
+		return nil, errors.New("spend key is not reduced")
+	}
+	view, _ := edwards25519.NewScalar().SetCanonicalBytes(reduce(keccak256(spendPrivate)))
+
+	return &Keys{
+		SpendPrivate: spend.Bytes(),
+		SpendPublic:  new(edwards25519.Point).ScalarBaseMult(spend).Bytes(),
+		ViewPrivate:  view.Bytes(),
+		ViewPublic:   new(edwards25519.Point).ScalarBaseMult(view).Bytes(),
+	}, nil
+}
+
+// Address returns the standard primary address of the keys for the network
+func (k *Keys) Address(network Network) string {
+	data := make([]byte, 0, 1+KeySize*2+_addressChecksumSize)
+	data = append(data, byte(network))
+	data = append(data, k.SpendPublic...)
+	data = append(data, k.ViewPublic...)
+	data = append(data, keccak256(data)[:_addressChecksumSize]...)
+	return encodeBase58(data)
+}
+
+// Mnemonic returns the 25 words monero mnemonic of the private spend key
+func (k *Keys) Mnemonic() []string {
+	return encodeMnemonic(k.SpendPrivate)
+}
+
+func keccak256(b []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(b)
+	return h.Sum(nil)
+}
+
+// reduce returns the 32 bytes little endian scalar reduced modulo l
+func reduce(b []byte) []byte {
+	wide := make([]byte, 64)
+	copy(wide, b)
+	s, _ := edwards25519.NewScalar().SetUniformBytes(wide)
+	return s.Bytes()
+}
+
+// encodeBase58 encodes the data in monero base58 which encodes each 8 bytes
+// block separately into 11 chars
+func encodeBase58(data []byte) string {
+	encoded := make([]byte, 0, (len(data)/_fullBlockSize+1)*_fullEncodedSize)
+	for i := 0; i < len(data); i += _fullBlockSize {
+		block := data[i:]
+		if len(block) > _fullBlockSize {
+			block = block[:_fullBlockSize]
+		}
+
+		padded := make([]byte, _fullBlockSize)
+		copy(padded[_fullBlockSize-len(block):], block)
+		n := new(big.Int).SetUint64(binary.BigEndian.Uint64(padded))
+		chars := base58.Encode(n.Bytes(), base58.AlphabetBitcoin)
+
+		size := _encodedBlockSizes[len(block)]
+		for j := len(chars); j < size; j++ {
+			encoded = append(encoded, base58.AlphabetBitcoin[0])
+		}
+		encoded = append(encoded, chars...)
+	}
+	return string(encoded)
+}
@@ -0,0 +1,111 @@
+package monero
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestNewKeys(t *testing.T) {
+	seed, err := buildSeed("test test test test test test test test test test test junk")
+	if err != nil {
+		t.Errorf("couldn't generate seed: %s", err.Error())
+	}
+
+	keys, err := NewKeys(seed, 0)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	expected := "48Xucn75vn7aEEPSksVh3VY1SZEToLh56gbiHKEybgkAMgxr4ehqxaeSF7HzX9e1rAbCXV4Snr8Vwicae6kgX58fHnidf65"
+	if actual := keys.Address(NetworkMainnet); actual != expected {
+		t.Errorf("expected address '%s' but actual '%s'", expected, actual)
+	}
+}
+
+func TestKeysFromSpendKey(t *testing.T) {
+	tests := []struct {
+		spendPrivate string
+		spendPublic  string
+		viewPrivate  string
+		viewPublic   string
+		network      Network
+		address      string
+	}{
+		{
+			spendPrivate: "372fcc2abc6bc5015103aae4763822e45c4cfe775d163f97a9ebdd77b0d12c0c",
+			spendPublic:  "38e9908d33d034de0ba1281aa7afe3907b795cea14852b3d8fe276e8931cb130",
+			viewPrivate:  "8aa763d1c8d9da4ca75cb6ca22a021b5cca376c1367be8d62bcc9cdf4b926009",
+			viewPublic:   "b4cdbf52851002fc7b098b99536df8b9885aa6cb8db24e9fc46103674dc9421a",
+			network:      NetworkStagenet,
+			address:      "53zEYzu2hi3e97tdMTqTvSRAfFYXwxA7LBJEHLWvFnm699WgcsE8CJujENwNAQotKyY2u94vpbGEZTiwahuMcMfX3x6NFwY",
+		},
+	}
+
+	for _, test := range tests {
+		spend, _ := hex.DecodeString(test.spendPrivate)
+		keys, err := KeysFromSpendKey(spend)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+
+		if actual := fmt.Sprintf("%x", keys.SpendPublic); actual != test.spendPublic {
+			t.Errorf("expected public spend key '%s' but actual '%s'", test.spendPublic, actual)
+		}
+		if actual := fmt.Sprintf("%x", keys.ViewPrivate); actual != test.viewPrivate {
+			t.Errorf("expected private view key '%s' but actual '%s'", test.viewPrivate, actual)
+		}
+		if actual := fmt.Sprintf("%x", keys.ViewPublic); actual != test.viewPublic {
+			t.Errorf("expected public view key '%s' but actual '%s'", test.viewPublic, actual)
+		}
+		if actual := keys.Address(test.network); actual != test.address {
+			t.Errorf("expected address '%s' but actual '%s'", test.address, actual)
+		}
+	}
+
+	_, err := KeysFromSpendKey(make([]byte, 31))
+	if err == nil || err.Error() != "spend key must be 32 bytes" {
+		t.Errorf("expected spend key size err but actual %v", err)
+	}
+}
+
+func TestAddress(t *testing.T) {
+	spend, _ := hex.DecodeString("c04ac8adc844e07263bf9a4dd337883eb55db89743c9aece4357381ae6c0b106")
+	view, _ := hex.DecodeString("0ef3c9e1146ed2a05f0eb4b25e41662bed41fa246251257c363a8ba95750cb8b")
+	keys := &Keys{SpendPublic: spend, ViewPublic: view}
+
+	expected := "48ukkZtBSBRL8iva7k3p2sBVMLWTfNwsTbW1aVh5M84g21muDCssvCHTpoZCaSc6rq8M9QLZ3sQMrMn1bq2RD2anGnyHhtq"
+	if actual := keys.Address(NetworkMainnet); actual != expected {
+		t.Errorf("expected address '%s' but actual '%s'", expected, actual)
+	}
+}
+
+func TestMnemonic(t *testing.T) {
+	spend, _ := hex.DecodeString("0cca07dc4e90fc738fffdb2561dddd7a94d0dc8977d0229303d7509a10c9d705")
+	keys, err := KeysFromSpendKey(spend)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	expected := "wiggle drowning auburn aquarium attire meant impel phase soothe heron android mechanic inroads energy smog niece enforce syllabus exquisite lush bluntly rage siblings soda syllabus"
+	if actual := strings.Join(keys.Mnemonic(), " "); actual != expected {
+		t.Errorf("expected mnemonic '%s' but actual '%s'", expected, actual)
+	}
+}
+
+func buildSeed(sentence string) ([]byte, error) {
+	bytes, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		return nil, err
+	}
+	m, err := nomnemonic.New(strings.Split(string(bytes), "\n"))
+	if err != nil {
+		return nil, err
+	}
+	return m.GenerateSeed(sentence, "")
+}
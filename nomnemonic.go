@@ -3,9 +3,11 @@ package nomnemonic
 import (
 	"crypto/sha256"
 	"crypto/sha512"
-	"errors"
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
+	"time"
 
 	"golang.org/x/crypto/pbkdf2"
 	"golang.org/x/crypto/scrypt"
@@ -24,6 +26,13 @@ const (
 	_inputPasscodeLength      = 6
 	_inputPasswordMinLength   = 12
 
+	_keySize = 24 // the words size a key of deriveKey is derived like
+
+	_kdfPBKDF2Iterations = 1 << 18
+	_kdfScryptN          = 1 << 18
+	_kdfScryptR          = 8
+	_kdfScryptP          = 1
+
 	Version          = "0.3.0"
 	VersionAlgorithm = "3.0.0"
 )
@@ -46,83 +55,222 @@ var (
 	}
 )
 
+// SeedMode selects how GenerateSeed derives the seed from the mnemonic
+type SeedMode int
+
+const (
+	// SeedModeBIP39 runs pbkdf2 on the mnemonic sentence, the bip39 standard
+	SeedModeBIP39 SeedMode = iota
+	// SeedModeSubstrate runs pbkdf2 on the mnemonic entropy like substrate,
+	// polkadot.js and subkey do, the first 32 bytes are the sr25519 mini
+	// secret
+	SeedModeSubstrate
+)
+
 type (
 	mnemonicer struct {
-		words []string
-		dict  map[string]int
+		words    []string
+		dict     map[string]int
+		abbrevs  map[string]int
+		observer Observer
+		logger   Logger
+		locale   string
+		features map[Feature]bool
+		purpose  string
+		pepper   []byte
+		factors  []Factor
+		policy   *Policy
+
+		insecureFastKDF bool
+		selfCheck       bool
 	}
 
 	Mnemonicer interface {
 		Generate(identifier, password, passcode string, size int) ([]string, error)
+		GenerateDetailed(identifier, password, passcode string, size int) (Result, error)
+		Migrate(in Inputs, fromVersion, toVersion string) (Migration, error)
+		GenerateForPeriod(in Inputs, t time.Time, period time.Duration) (Rotation, error)
+		RotationWindow(in Inputs, t time.Time, period time.Duration) ([]Rotation, error)
+		GenerateForPath(in Inputs) ([]string, error)
 		CalculateEntropy(words []string) ([]byte, error)
-		GenerateSeed(sentence, passphrase string) ([]byte, error)
+		EntropyToWords(entropy []byte) ([]string, error)
+		GenerateSeed(sentence, passphrase string, mode ...SeedMode) ([]byte, error)
 		GenerateSeed32(sentence, passphrase string) ([]byte, error)
+		GenerateSeedTo(w io.Writer, sentence, passphrase string, mode ...SeedMode) error
+		GenerateSeedXOF(sentence, passphrase, info string, size int) ([]byte, error)
 		IsValid(words []string) (bool, error)
+		ExpandWords(words []string) ([]string, error)
+		CorrectOCR(words []string) ([]string, error)
+		LastWords(words []string) ([]string, error)
+		SplitXOR(words []string, parts int) ([][]string, error)
+		CombineXOR(parts [][]string) ([]string, error)
+		GenerateElectrum(identifier, password, passcode string, size int, seedType ElectrumSeedType) ([]string, error)
+		IsValidElectrum(words []string, seedType ElectrumSeedType) bool
+		GenerateElectrumSeed(sentence, passphrase string) ([]byte, error)
+		EncodeSeedQR(words []string) (string, error)
+		DecodeSeedQR(digits string) ([]string, error)
+		EncodeCompactSeedQR(words []string) ([]byte, error)
+		DecodeCompactSeedQR(data []byte) ([]string, error)
+		IndexSentence(words []string) (string, error)
+		WordsFromIndexes(indexes string) ([]string, error)
+		EncodeStory(words []string) (string, error)
+		DecodeStory(story string) ([]string, error)
+		FromPassphrase(passphrase string, size int) ([]string, error)
+		GeneratePassphrase(identifier, password, passcode string, length int, charset string) (string, error)
+		GenerateHiddenWallet(in Inputs, length int, charset string) (HiddenWallet, error)
+		GenerateWithDecoy(identifier, password, passcode, decoyPasscode string, size int) ([]string, []string, error)
+		DecoyTag(words []string, identifier, password string, role MnemonicRole) (string, error)
+		VerifyDecoyTag(words []string, identifier, password, tag string) (MnemonicRole, error)
+		Explain(identifier, password, passcode string, size int) ([]Step, error)
+		GenerateProgress(identifier, password, passcode string, size int, progress Progress) ([]string, error)
 	}
 )
 
 // New inits a new mnemonic generator
-func New(words []string) (Mnemonicer, error) {
+func New(words []string, options ...Option) (Mnemonicer, error) {
 	if len(words) != 2048 {
-		return nil, errors.New("bip39 is based on 2048 words")
+		return nil, ErrInvalidWordlist
 	}
 	dict := make(map[string]int, len(words))
 	for i, w := range words {
 		dict[w] = i
 	}
-	return &mnemonicer{
-		words: words,
-		dict:  dict,
-	}, nil
+	m := &mnemonicer{
+		words:   words,
+		dict:    dict,
+		abbrevs: abbreviations(words, dict),
+	}
+	for _, o := range options {
+		o(m)
+	}
+	if err := m.validateFeatures(); err != nil {
+		return nil, err
+	}
+	if err := m.checkSettings(); err != nil {
+		return nil, err
+	}
+	if err := m.checkInsecureFastKDF(); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
 // Generate generates mnemonic words for identifier, password, passcode and size
 func (m *mnemonicer) Generate(identifier, password, passcode string, size int) ([]string, error) {
+	entropy, err := m.deriveEntropy(identifier, password, passcode, size)
+	if err != nil {
+		return nil, err
+	}
+	return m.generatedWords(entropy)
+}
+
+// deriveEntropy validates the inputs and derives the entropy of a size words
+// mnemonic from them, the tags prefix the kdf input to derive unrelated
+// entropies of the same inputs
+func (m *mnemonicer) deriveEntropy(identifier, password, passcode string, size int, tags ...string) ([]byte, error) {
+	return m.deriveChecked(m.checkInputs, identifier, password, passcode, size, tags...)
+}
+
+// deriveKey derives a key of the inputs like the entropy of a 24 words
+// mnemonic, the sizes of the policy don't apply since no phrase of that size
+// comes out of it
+func (m *mnemonicer) deriveKey(identifier, password, passcode, tag string) ([]byte, error) {
+	return m.deriveChecked(func(password string, _ int) []error {
+		return m.checkPassword(password)
+	}, identifier, password, passcode, _keySize, tag)
+}
+
+// deriveChecked is deriveEntropy with the policy check of the inputs
+func (m *mnemonicer) deriveChecked(check func(password string, size int) []error, identifier, password, passcode string, size int, tags ...string) ([]byte, error) {
+	input, salt, strength, err := m.checkedKDFInputs(check, identifier, password, passcode, size)
+	if err != nil {
+		m.log("derivation rejected", Field{Key: "size", Value: size})
+		return nil, err
+	}
+	for _, tag := range tags {
+		input = append([]byte(tag), input...)
+	}
+	start := m.logStarted(size)
+	entropy := m.stretch(input, salt, strength/_bitChunkSizeOneByte)
+	m.logFinished(size, start)
+	return entropy, nil
+}
+
+// kdfInputs validates the inputs and returns the kdf input, salt and the
+// entropy strength in bits, every invalid input is reported at once
+func (m *mnemonicer) kdfInputs(identifier, password, passcode string, size int) ([]byte, []byte, int, error) {
+	return m.checkedKDFInputs(m.checkInputs, identifier, password, passcode, size)
+}
+
+// checkedKDFInputs is kdfInputs with the policy check of the inputs
+func (m *mnemonicer) checkedKDFInputs(check func(password string, size int) []error, identifier, password, passcode string, size int) ([]byte, []byte, int, error) {
+	identifier, password = m.normalize(identifier), m.normalize(password)
+	var errs []error
 	if len(identifier) < _inputIdentifierMinLength {
-		return nil, fmt.Errorf("identifier must be at least %d chars", _inputIdentifierMinLength)
+		errs = append(errs, m.localize(ErrShortIdentifier))
 	}
 
 	if len(password) < _inputPasswordMinLength {
-		return nil, fmt.Errorf("password must be at least %d chars", _inputPasswordMinLength)
+		errs = append(errs, m.localize(ErrWeakPassword))
 	}
 
 	if len(passcode) != _inputPasscodeLength {
-		return nil, fmt.Errorf("passcode must be %d digits", _inputPasscodeLength)
+		errs = append(errs, m.localize(errorf(ErrInvalidPasscode, "passcode must be %d digits", _inputPasscodeLength)))
+	} else if _, err := strconv.Atoi(passcode); err != nil {
+		errs = append(errs, m.localize(errorf(ErrInvalidPasscode, "passcode must be numeric but given '%s'", passcode)))
+	}
+
+	strength := _sentenceStrengths[size]
+	if err := m.validateStrength(strength); err != nil {
+		errs = append(errs, err)
+	}
+	errs = append(errs, check(password, size)...)
+	if len(errs) > 0 {
+		return nil, nil, 0, joinErrors(errs...)
 	}
 
-	_, err := strconv.Atoi(passcode)
+	pepper, err := m.factorPepper(identifier)
 	if err != nil {
-		return nil, fmt.Errorf("passcode must be numeric but given '%s'", passcode)
+		return nil, nil, 0, err
 	}
+	input := pepperInput(pepper, m.purposeInput([]byte(fmt.Sprintf("%s:%s|%s=%d", identifier, password, passcode, size))))
+	salt := []byte(_saltPrefixPassword + password + _saltPrefixPasscode + passcode)
+	return input, salt, strength, nil
+}
 
-	strength := _sentenceStrengths[size]
-	err = m.validateStrength(strength)
+// stretch xors the pbkdf2 and scrypt keys of the input and salt
+func (m *mnemonicer) stretch(input, salt []byte, size int) []byte {
+	entropy, dkTail := m.stretchKeys(input, salt, size)
+	xorBytes(entropy, dkTail)
+	return entropy
+}
+
+// stretchKeys derives the pbkdf2 and scrypt keys of the input and salt
+func (m *mnemonicer) stretchKeys(input, salt []byte, size int) ([]byte, []byte) {
+	iterations, n := m.kdfCosts()
+	start := time.Now()
+	dkHead := pbkdf2.Key(input, salt, iterations, size, sha512.New)
+	m.observe(StagePBKDF2, start)
+
+	start = time.Now()
+	dkTail, _ := scrypt.Key(input, salt, n, _kdfScryptR, _kdfScryptP, size)
+	m.observe(StageScrypt, start)
+	return dkHead, dkTail
+}
+
+// EntropyToWords encodes the entropy of any supported strength into mnemonic
+// words
+func (m *mnemonicer) EntropyToWords(entropy []byte) ([]string, error) {
+	err := m.validateStrength(len(entropy) * _bitChunkSizeOneByte)
 	if err != nil {
 		return nil, err
 	}
+	return m.entropyToWords(entropy), nil
+}
 
-	input := []byte(fmt.Sprintf("%s:%s|%s=%d", identifier, password, passcode, size))
-	entropySize := strength / _bitChunkSizeOneByte
-	dkHead := pbkdf2.Key(
-		input,
-		[]byte(_saltPrefixPassword+password+_saltPrefixPasscode+passcode),
-		(1 << 18),
-		entropySize,
-		sha512.New,
-	)
-	dkTail, _ := scrypt.Key(
-		input,
-		[]byte(_saltPrefixPassword+password+_saltPrefixPasscode+passcode),
-		(1 << 18),
-		8,
-		1,
-		entropySize,
-	)
-
-	entropy := make([]byte, entropySize)
-	for i := 0; i < entropySize; i++ {
-		entropy[i] = dkHead[i] ^ dkTail[i]
-	}
+// entropyToWords encodes the entropy and its checksum into mnemonic words
+func (m *mnemonicer) entropyToWords(entropy []byte) []string {
+	strength := len(entropy) * _bitChunkSizeOneByte
 	bins := bytesToBin(entropy)
 
 	// get word indexes
@@ -137,15 +285,17 @@ func (m *mnemonicer) Generate(identifier, password, passcode string, size int) (
 
 	// generate last word from checksum of n-1 words and n-checksum size random
 	// prefix
-	cs := m.checksum(entropy, csSize)
+	cs := checksum(entropy, csSize)
 	prefix := bins[strength-prefixSize:]
 	words[mnemonicSize-1] = m.words[binToInt(prefix+cs)]
 
-	return words, nil
+	return words
 }
 
-// CalculateEntropy calculates entropy from words
+// CalculateEntropy calculates entropy from words, the words may be
+// abbreviated to their first 4 letters
 func (m *mnemonicer) CalculateEntropy(words []string) ([]byte, error) {
+	words = m.expand(words)
 	strength := _sentenceStrengths[len(words)]
 	bins, err := m.buildBins(strength, words)
 	if err != nil {
@@ -154,18 +304,31 @@ func (m *mnemonicer) CalculateEntropy(words []string) ([]byte, error) {
 
 	entropy := binToBytes(bins[:strength])
 	csSize := strength / _bitChunkSizeEntropy
-	cs := m.checksum(entropy, csSize)
-	if cs == bins[strength:] {
+	cs := checksum(entropy, csSize)
+	if m.equal(cs, bins[strength:]) {
 		return entropy, nil
 	}
 
-	return nil, errors.New("invalid checksum")
+	return nil, m.localize(&ChecksumError{Expected: cs, Actual: bins[strength:]})
 }
 
 // GenerateSeed generates 64 bytes seed using the mnemonic sentence and
-// passphrase
-func (m *mnemonicer) GenerateSeed(sentence, passphrase string) ([]byte, error) {
-	seed := pbkdf2.Key([]byte(sentence), []byte(_saltPrefixMnemonic+passphrase), 2048, 64, sha512.New)
+// passphrase, the optional mode selects a non bip39 derivation. The sentence
+// is hashed as given, expand abbreviated words with ExpandWords first
+func (m *mnemonicer) GenerateSeed(sentence, passphrase string, mode ...SeedMode) ([]byte, error) {
+	if len(mode) == 0 || mode[0] == SeedModeBIP39 {
+		seed := pbkdf2.Key([]byte(sentence), []byte(_saltPrefixMnemonic+passphrase), 2048, 64, sha512.New)
+		return seed, nil
+	}
+	if mode[0] != SeedModeSubstrate {
+		return nil, fmt.Errorf("unsupported seed mode: %d", mode[0])
+	}
+
+	entropy, err := m.CalculateEntropy(strings.Fields(sentence))
+	if err != nil {
+		return nil, err
+	}
+	seed := pbkdf2.Key(entropy, []byte(_saltPrefixMnemonic+passphrase), 2048, 64, sha512.New)
 	return seed, nil
 }
 
@@ -177,8 +340,10 @@ func (m *mnemonicer) GenerateSeed32(sentence, passphrase string) ([]byte, error)
 }
 
 // IsValid checks if the given mnemonic words are valid from the bip39 word list
-// and validates checksum from the n-1 words
+// and validates checksum from the n-1 words, the words may be abbreviated to
+// their first 4 letters
 func (m *mnemonicer) IsValid(words []string) (bool, error) {
+	words = m.expand(words)
 	strength := _sentenceStrengths[len(words)]
 	bins, err := m.buildBins(strength, words)
 	if err != nil {
@@ -187,13 +352,38 @@ func (m *mnemonicer) IsValid(words []string) (bool, error) {
 
 	entropy := binToBytes(bins[:strength])
 	csSize := strength / _bitChunkSizeEntropy
-	cs := m.checksum(entropy, csSize)
+	cs := checksum(entropy, csSize)
+
+	return m.equal(cs, bins[strength:]), nil
+}
 
-	if cs == bins[strength:] {
-		return true, nil
+// LastWords returns every word completing the n-1 words into a mnemonic with a
+// valid checksum
+func (m *mnemonicer) LastWords(words []string) ([]string, error) {
+	strength := _sentenceStrengths[len(words)+1]
+	err := m.validateStrength(strength)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.validateWordsPrecense(words)
+	if err != nil {
+		return nil, err
 	}
 
-	return false, nil
+	indexes := make([]int, len(words))
+	for i, w := range words {
+		indexes[i] = m.index(w)
+	}
+	last, err := LastWordIndexes(indexes)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]string, len(last))
+	for i, index := range last {
+		candidates[i] = m.words[index]
+	}
+	return candidates, nil
 }
 
 func (m *mnemonicer) buildBins(strength int, words []string) (string, error) {
@@ -209,12 +399,13 @@ func (m *mnemonicer) buildBins(strength int, words []string) (string, error) {
 
 	bins := ""
 	for _, w := range words {
-		bins += intToBin(m.dict[w], _bitChunkSizeBip39WordIndex)
+		bins += intToBin(m.index(w), _bitChunkSizeBip39WordIndex)
 	}
 	return bins, nil
 }
 
-func (m *mnemonicer) checksum(entropy []byte, size int) string {
+// checksum returns the first size bits of the sha256 of the entropy
+func checksum(entropy []byte, size int) string {
 	sum := sha256.Sum256(entropy)
 	return fmt.Sprintf("%08b", sum[0])[:size]
 }
@@ -222,16 +413,15 @@ func (m *mnemonicer) checksum(entropy []byte, size int) string {
 func (m *mnemonicer) validateStrength(s int) error {
 	_, exists := _strengths[s]
 	if !exists {
-		return fmt.Errorf("unsupported strength: %d", s)
+		return m.localize(errorf(ErrUnsupportedSize, "unsupported strength: %d", s))
 	}
 	return nil
 }
 
 func (m *mnemonicer) validateWordsPrecense(words []string) error {
-	for _, w := range words {
-		_, ok := m.dict[w]
-		if !ok {
-			return fmt.Errorf("unrecognized word %s", w)
+	for i, w := range words {
+		if _, ok := m.lookup(w); !ok {
+			return m.localize(&UnknownWordError{Word: w, Index: i, Suggestions: m.suggest(w)})
 		}
 	}
 	return nil
@@ -220,6 +220,46 @@ func TestCalculateEntropy(t *testing.T) {
 	}
 }
 
+func TestEntropyToWords(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	tests := []struct {
+		entropy  []byte
+		sentence string
+		err      error
+	}{
+		{
+			entropy:  []byte{70, 71, 47, 222, 148, 36, 177, 221, 214, 51, 202, 201, 106, 200, 176, 43, 136, 76, 253, 30, 149, 125, 27, 181, 89, 55, 109, 164, 47, 61, 186, 92},
+			sentence: "edge defense waste choose enrich upon flee junk siren film clown finish luggage leader kid quick brick print evidence swap drill paddle truly occur",
+		},
+		{
+			entropy: []byte{70, 71, 47, 222, 148, 36, 177, 221, 214, 51, 202, 201},
+			err:     errors.New("unsupported strength: 96"),
+		},
+	}
+
+	for _, test := range tests {
+		sentence, err := m.EntropyToWords(test.entropy)
+		if test.err != nil {
+			if err == nil || test.err.Error() != err.Error() {
+				t.Errorf("expected err '%s' but actual %v", test.err.Error(), err)
+			}
+			continue
+		}
+		if actual := strings.Join(sentence, " "); actual != test.sentence {
+			t.Errorf("expected sentence (%s) but actual (%s)", test.sentence, actual)
+		}
+	}
+}
+
 func TestGenerateSeed(t *testing.T) {
 	words, err := buildWords()
 	if err != nil {
@@ -261,6 +301,61 @@ func TestGenerateSeed(t *testing.T) {
 	}
 }
 
+func TestGenerateSeedSubstrate(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	tests := []struct {
+		sentence   string
+		passphrase string
+		miniSecret string
+		err        error
+	}{
+		{
+			sentence:   "bottom drive obey lake curtain smoke basket hold race lonely fit walk",
+			miniSecret: "fac7959dbfe72f052e5a0c3c8d6530f202b02fd8f9f5ca3580ec8deb7797479e",
+		},
+		{
+			sentence:   "edge defense waste choose enrich upon flee junk siren film clown finish luggage leader kid quick brick print evidence swap drill paddle truly occur",
+			passphrase: "some password",
+			miniSecret: "dcb12907610f75c2daa9cd93f46454088fb8b447c2c6267120fed86ae87a618a",
+		},
+		{
+			sentence: "bottom drive obey lake curtain smoke basket hold race lonely fit fit",
+			err:      errors.New("invalid checksum"),
+		},
+	}
+
+	for _, test := range tests {
+		seed, err := m.GenerateSeed(test.sentence, test.passphrase, SeedModeSubstrate)
+		if test.err != nil {
+			if err == nil || test.err.Error() != err.Error() {
+				t.Errorf("expected err '%s' but actual %v", test.err.Error(), err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("couldn't generate seed from sentence: %s", err)
+			continue
+		}
+		if actual := fmt.Sprintf("%x", seed[:32]); actual != test.miniSecret {
+			t.Errorf("expected mini secret: '%s' but actual: '%s'", test.miniSecret, actual)
+		}
+	}
+
+	_, err = m.GenerateSeed("bottom drive obey lake curtain smoke basket hold race lonely fit walk", "", SeedMode(9))
+	if err == nil || err.Error() != "unsupported seed mode: 9" {
+		t.Errorf("expected seed mode err but actual %v", err)
+	}
+}
+
 func TestGenerateSeed32(t *testing.T) {
 	words, err := buildWords()
 	if err != nil {
@@ -341,6 +436,68 @@ func TestIsValid(t *testing.T) {
 	}
 }
 
+func TestLastWords(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	tests := []struct {
+		sentence string
+		count    int
+		last     string
+		err      error
+	}{
+		{
+			sentence: "cinnamon venue broken old brass vague paddle unaware critic alarm consider",
+			count:    128,
+			last:     "hobby",
+		},
+		{
+			sentence: "edge defense waste choose enrich upon flee junk siren film clown finish luggage leader kid quick brick print evidence swap drill paddle truly",
+			count:    8,
+			last:     "occur",
+		},
+		{
+			sentence: "edge defense waste",
+			err:      errors.New("unsupported strength: 0"),
+		},
+		{
+			sentence: "tester venue broken old brass vague paddle unaware critic alarm consider",
+			err:      errors.New("unrecognized word tester"),
+		},
+	}
+
+	for _, test := range tests {
+		candidates, err := m.LastWords(strings.Fields(test.sentence))
+		if test.err != nil {
+			if err == nil || test.err.Error() != err.Error() {
+				t.Errorf("expected err '%s' but actual %v", test.err.Error(), err)
+			}
+			continue
+		}
+		if len(candidates) != test.count {
+			t.Errorf("expected %d candidates but actual %d", test.count, len(candidates))
+		}
+
+		found := false
+		for _, c := range candidates {
+			if ok, _ := m.IsValid(append(strings.Fields(test.sentence), c)); !ok {
+				t.Errorf("expected valid mnemonic with the last word %s", c)
+			}
+			found = found || c == test.last
+		}
+		if !found {
+			t.Errorf("expected %s among the candidates", test.last)
+		}
+	}
+}
+
 func buildWords() ([]string, error) {
 	bytes, err := os.ReadFile("./test/english.txt")
 	if err != nil {
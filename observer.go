@@ -0,0 +1,37 @@
+package nomnemonic
+
+import "time"
+
+// kdf stages reported to the Observer
+const (
+	StagePBKDF2 = "pbkdf2"
+	StageScrypt = "scrypt"
+)
+
+type (
+	// Option configures the mnemonicer returned by New
+	Option func(m *mnemonicer)
+
+	// Observer receives the duration of every kdf stage, it is called on the
+	// goroutine of the generation and must be safe for concurrent use
+	Observer interface {
+		ObserveStage(stage string, d time.Duration)
+	}
+)
+
+// WithObserver reports the kdf stage durations to o, for metrics
+func WithObserver(o Observer) Option {
+	return func(m *mnemonicer) {
+		m.observer = o
+	}
+}
+
+// observe reports the duration of the stage started at start to the observer
+// and the logger
+func (m *mnemonicer) observe(stage string, start time.Time) {
+	d := time.Since(start)
+	if m.observer != nil {
+		m.observer.ObserveStage(stage, d)
+	}
+	m.log("kdf stage finished", Field{Key: "stage", Value: stage}, Field{Key: "duration", Value: d})
+}
@@ -0,0 +1,39 @@
+package nomnemonic
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type stageRecorder struct {
+	mu     sync.Mutex
+	stages []string
+}
+
+func (r *stageRecorder) ObserveStage(stage string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stages = append(r.stages, stage)
+}
+
+func TestWithObserver(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &stageRecorder{}
+	m, _ := New(words, WithObserver(r))
+
+	if _, err := m.Generate("nomnemonic_test", "test12345678", "101938", 12); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Generate("nomnemonic_test", "test12345678", "1019", 12); err == nil {
+		t.Fatal("expected an error for a short passcode")
+	}
+
+	expected := []string{StagePBKDF2, StageScrypt}
+	if len(r.stages) != len(expected) || r.stages[0] != expected[0] || r.stages[1] != expected[1] {
+		t.Errorf("expected stages %v but actual %v", expected, r.stages)
+	}
+}
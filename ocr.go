@@ -0,0 +1,82 @@
+package nomnemonic
+
+import "strings"
+
+// _ocrMaxVariants bounds the spellings tried for one scanned word
+const _ocrMaxVariants = 1024
+
+// _ocrSubstitutions are the misreadings of scanners and photographed
+// backups, the read text and what was printed
+var _ocrSubstitutions = [][2]string{
+	{"0", "o"},
+	{"1", "l"},
+	{"1", "i"},
+	{"|", "l"},
+	{"5", "s"},
+	{"rn", "m"},
+	{"m", "rn"},
+	{"vv", "w"},
+	{"cl", "d"},
+}
+
+// CorrectOCR fixes the usual scanning and photo misreadings of the words
+// before they are looked up, case, 0 for o, 1 for l or i, rn for m and back
+// and a few others, a word is only replaced when exactly one spelling is a
+// word or an abbreviation of the list, show the result to the user before
+// use, the first word still unknown is returned as an UnknownWordError
+func (m *mnemonicer) CorrectOCR(words []string) ([]string, error) {
+	corrected := make([]string, len(words))
+	for i, w := range words {
+		corrected[i] = m.correctOCR(w)
+	}
+	return corrected, m.validateWordsPrecense(corrected)
+}
+
+// correctOCR returns the only word of the list among the spellings of the
+// scanned word, or the word itself
+func (m *mnemonicer) correctOCR(word string) string {
+	if _, ok := m.dict[word]; ok {
+		return word
+	}
+	if w := m.expand([]string{word})[0]; w != word {
+		return w
+	}
+
+	match := ""
+	for _, v := range ocrVariants(strings.ToLower(word)) {
+		w := m.expand([]string{v})[0]
+		if _, ok := m.dict[w]; !ok || w == match {
+			continue
+		}
+		if match != "" {
+			return word
+		}
+		match = w
+	}
+	if match == "" {
+		return word
+	}
+	return match
+}
+
+// ocrVariants returns the word and its spellings with any of the
+// substitutions applied, at most _ocrMaxVariants of them
+func ocrVariants(word string) []string {
+	// prefixes[i] are the spellings of word[:i]
+	prefixes := make([][]string, len(word)+1)
+	prefixes[0] = []string{""}
+	count := 1
+	for i := 0; i < len(word); i++ {
+		for _, p := range prefixes[i] {
+			prefixes[i+1] = append(prefixes[i+1], p+word[i:i+1])
+			for _, s := range _ocrSubstitutions {
+				if count < _ocrMaxVariants && strings.HasPrefix(word[i:], s[0]) {
+					end := i + len(s[0])
+					prefixes[end] = append(prefixes[end], p+s[1])
+					count++
+				}
+			}
+		}
+	}
+	return prefixes[len(word)]
+}
@@ -0,0 +1,67 @@
+package nomnemonic
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCorrectOCR(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby")
+	tests := []string{
+		"cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby",
+		"CINNAMON Venue br0ken 0ld bra5s vague padd1e unaware critic a1arm c0nsider hobby",
+		"cinnarnon venue broken o|d brass vague paddle unavvare critic alarm consider hobby",
+		"cinn venu brok 0ld bras vagu padd unaw crit a1ar cons hobb",
+	}
+	for _, scanned := range tests {
+		actual, err := m.CorrectOCR(strings.Fields(scanned))
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", scanned, err)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: expected %v but actual %v", scanned, expected, actual)
+		}
+	}
+
+	// cl0g is clog or dog, and rn0m is unrelated to any word
+	actual, err := m.CorrectOCR(strings.Fields("cl0g venue 1ink rn0m"))
+	var wordErr *UnknownWordError
+	if !errors.As(err, &wordErr) || wordErr.Index != 0 {
+		t.Errorf("expected an UnknownWordError of the first word but actual %v", err)
+	}
+	if expected := []string{"cl0g", "venue", "link", "rn0m"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v but actual %v", expected, actual)
+	}
+}
+
+func TestOCRVariants(t *testing.T) {
+	tests := []struct {
+		word     string
+		variants []string
+	}{
+		{word: "dog", variants: []string{"dog"}},
+		{word: "c1", variants: []string{"c1", "cl", "ci"}},
+		{word: "cl", variants: []string{"d", "cl"}},
+		{word: "rnm", variants: []string{"mm", "mrn", "rnm", "rnrn"}},
+	}
+	for _, test := range tests {
+		if actual := ocrVariants(test.word); !reflect.DeepEqual(actual, test.variants) {
+			t.Errorf("%s: expected %v but actual %v", test.word, test.variants, actual)
+		}
+	}
+
+	if actual := ocrVariants(strings.Repeat("1", 20)); len(actual) > _ocrMaxVariants {
+		t.Errorf("expected at most %d variants but actual %d", _ocrMaxVariants, len(actual))
+	}
+}
@@ -0,0 +1,68 @@
+package nomnemonic
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/nomnemonic/nomnemonic/internal/base58"
+)
+
+const (
+	_passcodeShareVersion = 2 // the password shares are version 1
+	_passcodeSecretSize   = 8 // the 6 digits zero padded, before the salt
+)
+
+// SplitPasscode escrows the passcode alone into count short base58check
+// shares for trusted contacts, any threshold of them recover it while the
+// identifier and the password stay memorized. It is a lightweight social
+// recovery for a forgotten passcode, the contacts never hold enough to
+// generate the phrase. The passcode only adds a million guesses for someone
+// who knows the password, the password is what protects the phrase. It is
+// salted so a contact can not try the million passcodes against the digest
+// of a single share
+func SplitPasscode(passcode string, threshold, count int) ([]string, error) {
+	if len(passcode) != _inputPasscodeLength {
+		return nil, errorf(ErrInvalidPasscode, "passcode must be %d digits", _inputPasscodeLength)
+	}
+	if _, err := strconv.ParseUint(passcode, 10, 32); err != nil {
+		return nil, errorf(ErrInvalidPasscode, "passcode must be numeric")
+	}
+	if count < 2 || count > _passwordShareMaxCount {
+		return nil, fmt.Errorf("share count must be between 2 and %d", _passwordShareMaxCount)
+	}
+	if threshold < 2 || threshold > count {
+		return nil, errors.New("threshold must be between 2 and the share count")
+	}
+
+	secret := make([]byte, _passcodeSecretSize+_shareSaltSize)
+	copy(secret, passcode)
+	return splitSecret(_passcodeShareVersion, secret, threshold, count)
+}
+
+// CombinePasscode verifies the shares and recovers the passcode
+func CombinePasscode(shares []string) (string, error) {
+	secret, err := combineShares(shares, parsePasscodeShare, "passcodes")
+	if err != nil {
+		return "", err
+	}
+	passcode := string(secret[:_inputPasscodeLength])
+	if _, err := strconv.ParseUint(passcode, 10, 32); err != nil || string(secret[_inputPasscodeLength:_passcodeSecretSize]) != "\x00\x00" {
+		return "", errors.New("invalid passcode padding")
+	}
+	return passcode, nil
+}
+
+func parsePasscodeShare(s string) (secretShare, error) {
+	payload, err := base58.CheckDecode(s, base58.AlphabetBitcoin)
+	if err != nil {
+		return secretShare{}, err
+	}
+	if len(payload) > 0 && payload[0] != _passcodeShareVersion {
+		return secretShare{}, fmt.Errorf("not a passcode share, version %d", payload[0])
+	}
+	if len(payload) != _passwordShareHeaderSize+_passcodeSecretSize+_shareSaltSize {
+		return secretShare{}, errors.New("invalid share length")
+	}
+	return newSecretShare(payload)
+}
@@ -0,0 +1,147 @@
+package nomnemonic
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic/internal/gf256"
+)
+
+func TestSplitCombinePasscode(t *testing.T) {
+	tests := []struct {
+		passcode  string
+		threshold int
+		count     int
+		combine   []int
+	}{
+		{passcode: "101938", threshold: 2, count: 3, combine: []int{0, 2}},
+		{passcode: "000000", threshold: 3, count: 5, combine: []int{4, 1, 3}},
+		{passcode: "999999", threshold: 2, count: 2, combine: []int{1, 0, 1}},
+	}
+
+	for _, test := range tests {
+		shares, err := SplitPasscode(test.passcode, test.threshold, test.count)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if len(shares) != test.count {
+			t.Errorf("expected %d shares but actual %d", test.count, len(shares))
+		}
+		for _, s := range shares {
+			if len(s) > 48 {
+				t.Errorf("expected a short share but actual %s", s)
+			}
+		}
+
+		selected := make([]string, 0, len(test.combine))
+		for _, i := range test.combine {
+			selected = append(selected, shares[i])
+		}
+		passcode, err := CombinePasscode(selected)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if passcode != test.passcode {
+			t.Errorf("expected passcode '%s' but actual '%s'", test.passcode, passcode)
+		}
+	}
+}
+
+func TestSplitPasscode(t *testing.T) {
+	tests := []struct {
+		passcode  string
+		threshold int
+		count     int
+		err       string
+	}{
+		{passcode: "12345", threshold: 2, count: 3, err: "passcode must be 6 digits"},
+		{passcode: "12345a", threshold: 2, count: 3, err: "passcode must be numeric"},
+		{passcode: "101938", threshold: 2, count: 17, err: "share count must be between 2 and 16"},
+		{passcode: "101938", threshold: 4, count: 3, err: "threshold must be between 2 and the share count"},
+	}
+
+	for _, test := range tests {
+		_, err := SplitPasscode(test.passcode, test.threshold, test.count)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+	if _, err := SplitPasscode("12345", 2, 3); !errors.Is(err, ErrInvalidPasscode) {
+		t.Errorf("expected ErrInvalidPasscode but actual %v", err)
+	}
+}
+
+func TestCombinePasscode(t *testing.T) {
+	shares, err := SplitPasscode("101938", 2, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	other, err := SplitPasscode("101938", 2, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	password, err := SplitPassword("test12345678", 2, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	tests := []struct {
+		shares []string
+		err    string
+	}{
+		{shares: nil, err: "no shares given"},
+		{shares: []string{shares[0]}, err: "need 2 shares but given 1"},
+		{shares: []string{shares[0], other[1]}, err: "shares belong to different passcodes"},
+		{shares: []string{shares[0], password[1]}, err: "share 2: not a passcode share, version 1"},
+	}
+	for _, test := range tests {
+		_, err := CombinePasscode(test.shares)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+}
+
+// TestSplitPasscodeSalted tries the million passcodes against the digest of a
+// single share, none matches without the salt while the same attack finds an
+// unsalted passcode
+func TestSplitPasscodeSalted(t *testing.T) {
+	shares, err := SplitPasscode("101938", 2, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	share, err := parsePasscodeShare(shares[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	guess := func(passcode int) []byte {
+		secret := make([]byte, _passcodeSecretSize+_shareSaltSize)
+		copy(secret, fmt.Sprintf("%06d", passcode))
+		return secret
+	}
+
+	unsalted, err := gf256.Split(2, 3, guess(101938), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	var found, foundUnsalted []int
+	for passcode := 0; passcode < 1000000; passcode++ {
+		g := guess(passcode)
+		if digestMatches(t, share.Share, g) {
+			found = append(found, passcode)
+		}
+		if digestMatches(t, unsalted[0], g) {
+			foundUnsalted = append(foundUnsalted, passcode)
+		}
+	}
+	if len(found) != 0 {
+		t.Errorf("expected no passcode to match a single share but actual %v", found)
+	}
+	if len(foundUnsalted) != 1 || foundUnsalted[0] != 101938 {
+		t.Errorf("expected the unsalted passcode 101938 to be found but actual %v", foundUnsalted)
+	}
+}
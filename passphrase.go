@@ -0,0 +1,94 @@
+package nomnemonic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	_passphraseTag      = "\x00nomnemonic passphrase v1\x00"
+	_passphraseFunction = "nomnemonic passphrase v1"
+	_passphraseMinBits  = 128
+	_passphraseMaxSize  = 256
+)
+
+// the charsets of GeneratePassphrase
+const (
+	// CharsetLowercase is easy to type on the buttons of a hardware wallet
+	CharsetLowercase = "abcdefghijklmnopqrstuvwxyz"
+	// CharsetAlphanumeric is the default charset
+	CharsetAlphanumeric = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	// CharsetPrintable is the printable ascii chars without the space
+	CharsetPrintable = "!\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstuvwxyz{|}~"
+)
+
+// GeneratePassphrase derives a bip39 passphrase, the 25th word of a hidden
+// wallet, of length chars of the charset from the identifier, password and
+// passcode, so it never needs to be stored. The kdf runs like for a 24 words
+// phrase with a passphrase tag, the phrase and the passphrase are unrelated,
+// and the sizes of a policy don't restrict it.
+// The charset has between 2 and 256 distinct chars, CharsetAlphanumeric when
+// empty, and the passphrase has at least 128 bits
+func (m *mnemonicer) GeneratePassphrase(identifier, password, passcode string, length int, charset string) (string, error) {
+	if charset == "" {
+		charset = CharsetAlphanumeric
+	}
+	chars, err := passphraseCharset(charset, length)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := m.deriveKey(identifier, password, passcode, _passphraseTag)
+	if err != nil {
+		return "", err
+	}
+	defer wipe(key)
+
+	// rejection sampling of the bytes of the stream keeps the chars uniform
+	stream := sha3.NewCShake256([]byte(_passphraseFunction), []byte(charset))
+	stream.Write(key)
+	limit := 256 - 256%len(chars)
+	out := make([]rune, 0, length)
+	var b [1]byte
+	for len(out) < length {
+		_, _ = stream.Read(b[:])
+		if int(b[0]) < limit {
+			out = append(out, chars[int(b[0])%len(chars)])
+		}
+	}
+	return string(out), nil
+}
+
+// passphraseCharset returns the distinct chars of the charset, the length
+// must give a passphrase of at least 128 bits
+func passphraseCharset(charset string, length int) ([]rune, error) {
+	if charset == "" {
+		charset = CharsetAlphanumeric
+	}
+	if !utf8.ValidString(charset) {
+		return nil, errors.New("charset is not valid utf-8")
+	}
+	chars := []rune(charset)
+	if len(chars) < 2 || len(chars) > 256 {
+		return nil, fmt.Errorf("charset must have between 2 and 256 chars but has %d", len(chars))
+	}
+	seen := make(map[rune]bool, len(chars))
+	for _, r := range chars {
+		if seen[r] {
+			return nil, fmt.Errorf("charset repeats %q", r)
+		}
+		seen[r] = true
+	}
+	if length > _passphraseMaxSize {
+		return nil, fmt.Errorf("passphrase must be at most %d chars", _passphraseMaxSize)
+	}
+	if bits := float64(length) * math.Log2(float64(len(chars))); bits < _passphraseMinBits {
+		minimum := int(math.Ceil(_passphraseMinBits / math.Log2(float64(len(chars)))))
+		return nil, fmt.Errorf("passphrase must be at least %d chars of the charset for %d bits", minimum, _passphraseMinBits)
+	}
+	return chars, nil
+}
@@ -0,0 +1,73 @@
+package nomnemonic
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGeneratePassphrase(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := m.GeneratePassphrase("nomnemonic_test", "test12345678", "101938", 24, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "GSgVx461jCe8QXnGrRwLKqHF"
+	if actual != expected {
+		t.Errorf("expected %s but actual %s", expected, actual)
+	}
+
+	t.Setenv(InsecureFastKDFEnv, "1")
+	fast, err := New(words, WithInsecureFastKDF())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, charset := range []string{CharsetLowercase, CharsetAlphanumeric, CharsetPrintable, "αβγδεζηθ"} {
+		p, err := fast.GeneratePassphrase("nomnemonic_test", "test12345678", "101938", 50, charset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if utf8.RuneCountInString(p) != 50 {
+			t.Errorf("%s: expected 50 chars but actual %d", charset, utf8.RuneCountInString(p))
+		}
+		for _, r := range p {
+			if !strings.ContainsRune(charset, r) {
+				t.Errorf("%s: unexpected char %q", charset, r)
+			}
+		}
+		again, _ := fast.GeneratePassphrase("nomnemonic_test", "test12345678", "101938", 50, charset)
+		if again != p {
+			t.Errorf("%s: expected the same passphrase but actual %s and %s", charset, p, again)
+		}
+		other, _ := fast.GeneratePassphrase("nomnemonic_test", "test12345678", "101939", 50, charset)
+		if other == p {
+			t.Errorf("%s: expected another passcode to give another passphrase", charset)
+		}
+	}
+
+	tests := []struct {
+		length  int
+		charset string
+		err     string
+	}{
+		{length: 21, err: "passphrase must be at least 22 chars of the charset for 128 bits"},
+		{length: 27, charset: CharsetLowercase, err: "passphrase must be at least 28 chars of the charset for 128 bits"},
+		{length: 300, err: "passphrase must be at most 256 chars"},
+		{length: 200, charset: "a", err: "charset must have between 2 and 256 chars but has 1"},
+		{length: 200, charset: "abca", err: "charset repeats 'a'"},
+		{length: 200, charset: "ab\xff", err: "charset is not valid utf-8"},
+	}
+	for _, test := range tests {
+		if _, err := fast.GeneratePassphrase("nomnemonic_test", "test12345678", "101938", test.length, test.charset); err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package nomnemonic
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/nomnemonic/nomnemonic/internal/base58"
+	"github.com/nomnemonic/nomnemonic/internal/gf256"
+)
+
+const (
+	_passwordShareVersion    = 1
+	_passwordShareHeaderSize = 5 // version, identifier, threshold and index
+	_passwordShareMaxCount   = 16
+	_passwordPadSize         = 16
+
+	// _shareSaltSize is the random salt ending the shared secret, a share
+	// holder missing one share can not check a guessed secret against the
+	// digest share without guessing the salt too
+	_shareSaltSize = 16
+)
+
+// secretShare is a parsed password or passcode share
+type secretShare struct {
+	identifier uint16
+	threshold  int
+	gf256.Share
+}
+
+// SplitPassword splits the password into count base58check shares where any
+// threshold of them recover it, so the inputs can only be reconstructed by
+// several share holders. The password is padded to hide its exact length and
+// salted so fewer shares than the threshold do not allow a dictionary attack
+func SplitPassword(password string, threshold, count int) ([]string, error) {
+	if len(password) < _inputPasswordMinLength {
+		return nil, ErrWeakPassword
+	}
+	if len(password) > 255 {
+		return nil, errors.New("password must be at most 255 chars")
+	}
+	if count < 2 || count > _passwordShareMaxCount {
+		return nil, fmt.Errorf("share count must be between 2 and %d", _passwordShareMaxCount)
+	}
+	if threshold < 2 || threshold > count {
+		return nil, errors.New("threshold must be between 2 and the share count")
+	}
+
+	// length prefixed and zero padded to a multiple of the pad size
+	size := (len(password) + 1 + _passwordPadSize - 1) / _passwordPadSize * _passwordPadSize
+	secret := make([]byte, size+_shareSaltSize)
+	secret[0] = byte(len(password))
+	copy(secret[1:], password)
+	return splitSecret(_passwordShareVersion, secret, threshold, count)
+}
+
+// splitSecret salts the end of the secret and encodes its shares, the share
+// index is the x coordinate plus one
+func splitSecret(version byte, secret []byte, threshold, count int) ([]string, error) {
+	defer wipe(secret)
+	if _, err := io.ReadFull(_random, secret[len(secret)-_shareSaltSize:]); err != nil {
+		return nil, err
+	}
+	id := make([]byte, 2)
+	if _, err := io.ReadFull(_random, id); err != nil {
+		return nil, err
+	}
+	shares, err := gf256.Split(threshold, count, secret, _random)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := make([]string, len(shares))
+	for i, s := range shares {
+		payload := append([]byte{version, id[0], id[1], byte(threshold), s.X + 1}, s.Value...)
+		encoded[i] = base58.CheckEncode(payload, base58.AlphabetBitcoin)
+	}
+	return encoded, nil
+}
+
+// CombinePassword verifies the shares and recovers the password
+func CombinePassword(shares []string) (string, error) {
+	secret, err := combineShares(shares, parsePasswordShare, "passwords")
+	if err != nil {
+		return "", err
+	}
+	secret = secret[:len(secret)-_shareSaltSize]
+
+	size := int(secret[0])
+	if size >= len(secret) {
+		return "", errors.New("invalid password padding")
+	}
+	for _, b := range secret[1+size:] {
+		if b != 0 {
+			return "", errors.New("invalid password padding")
+		}
+	}
+	return string(secret[1 : 1+size]), nil
+}
+
+// combineShares parses the shares, skipping the repeated ones, and recovers
+// the secret of the first threshold of them, distinct shares of the same
+// index are refused
+func combineShares(shares []string, parse func(string) (secretShare, error), secrets string) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no shares given")
+	}
+
+	parsed := make([]secretShare, 0, len(shares))
+	seen := map[byte]string{}
+	for i, s := range shares {
+		share, err := parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i+1, err)
+		}
+		if len(parsed) > 0 && (share.identifier != parsed[0].identifier || share.threshold != parsed[0].threshold) {
+			return nil, fmt.Errorf("shares belong to different %s", secrets)
+		}
+		if other, ok := seen[share.X]; ok {
+			if other != s {
+				return nil, fmt.Errorf("share %d: duplicate share index %d", i+1, share.X+1)
+			}
+			continue
+		}
+		seen[share.X] = s
+		parsed = append(parsed, share)
+	}
+
+	threshold := parsed[0].threshold
+	if len(parsed) < threshold {
+		return nil, fmt.Errorf("need %d shares but given %d", threshold, len(parsed))
+	}
+
+	values := make([]gf256.Share, threshold)
+	for i, s := range parsed[:threshold] {
+		values[i] = s.Share
+	}
+	return gf256.Recover(threshold, values)
+}
+
+func parsePasswordShare(s string) (secretShare, error) {
+	payload, err := base58.CheckDecode(s, base58.AlphabetBitcoin)
+	if err != nil {
+		return secretShare{}, err
+	}
+	if len(payload) < _passwordShareHeaderSize+_passwordPadSize+_shareSaltSize || (len(payload)-_passwordShareHeaderSize)%_passwordPadSize != 0 {
+		return secretShare{}, errors.New("invalid share length")
+	}
+	if payload[0] != _passwordShareVersion {
+		return secretShare{}, fmt.Errorf("unsupported share version %d", payload[0])
+	}
+	return newSecretShare(payload)
+}
+
+// newSecretShare reads the header of the payload, the threshold and the index
+// come from the untrusted share and are checked before any interpolation
+func newSecretShare(payload []byte) (secretShare, error) {
+	threshold, index := int(payload[3]), int(payload[4])
+	if threshold < 2 || threshold > _passwordShareMaxCount {
+		return secretShare{}, fmt.Errorf("invalid share threshold %d", threshold)
+	}
+	if index < 1 || index > _passwordShareMaxCount {
+		return secretShare{}, fmt.Errorf("invalid share index %d", index)
+	}
+	return secretShare{
+		identifier: uint16(payload[1])<<8 | uint16(payload[2]),
+		threshold:  threshold,
+		Share:      gf256.Share{X: byte(index - 1), Value: payload[_passwordShareHeaderSize:]},
+	}, nil
+}
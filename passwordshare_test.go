@@ -0,0 +1,197 @@
+package nomnemonic
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic/internal/base58"
+	"github.com/nomnemonic/nomnemonic/internal/gf256"
+)
+
+func TestSplitCombinePassword(t *testing.T) {
+	tests := []struct {
+		password  string
+		threshold int
+		count     int
+		combine   []int
+	}{
+		{password: "test12345678", threshold: 2, count: 3, combine: []int{0, 2}},
+		{password: "correct horse battery staple!", threshold: 3, count: 5, combine: []int{4, 1, 3}},
+		{password: "test12345678test", threshold: 2, count: 2, combine: []int{1, 0, 1}},
+	}
+
+	for _, test := range tests {
+		shares, err := SplitPassword(test.password, test.threshold, test.count)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if len(shares) != test.count {
+			t.Errorf("expected %d shares but actual %d", test.count, len(shares))
+		}
+
+		selected := make([]string, 0, len(test.combine))
+		for _, i := range test.combine {
+			selected = append(selected, shares[i])
+		}
+		password, err := CombinePassword(selected)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if password != test.password {
+			t.Errorf("expected password '%s' but actual '%s'", test.password, password)
+		}
+	}
+}
+
+func TestSplitPassword(t *testing.T) {
+	tests := []struct {
+		password  string
+		threshold int
+		count     int
+		err       string
+	}{
+		{password: "short", threshold: 2, count: 3, err: "password must be at least 12 chars"},
+		{password: "test12345678", threshold: 2, count: 17, err: "share count must be between 2 and 16"},
+		{password: "test12345678", threshold: 1, count: 3, err: "threshold must be between 2 and the share count"},
+		{password: "test12345678", threshold: 4, count: 3, err: "threshold must be between 2 and the share count"},
+	}
+
+	for _, test := range tests {
+		_, err := SplitPassword(test.password, test.threshold, test.count)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+}
+
+func TestCombinePassword(t *testing.T) {
+	shares, err := SplitPassword("test12345678", 2, 3)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	other, err := SplitPassword("test12345678", 2, 3)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	// flip the last char to break the base58check checksum
+	last := shares[1][len(shares[1])-1]
+	replacement := "2"
+	if last == '2' {
+		replacement = "3"
+	}
+	broken := shares[1][:len(shares[1])-1] + replacement
+
+	tests := []struct {
+		shares []string
+		err    string
+	}{
+		{shares: nil, err: "no shares given"},
+		{shares: []string{shares[0]}, err: "need 2 shares but given 1"},
+		{shares: []string{shares[0], shares[0]}, err: "need 2 shares but given 1"},
+		{shares: []string{shares[0], broken}, err: "share 2: invalid base58check checksum"},
+		{shares: []string{shares[0], other[1]}, err: "shares belong to different passwords"},
+	}
+
+	for _, test := range tests {
+		_, err := CombinePassword(test.shares)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+}
+
+// forgeShare rewrites the threshold and the index of the share header, keeping
+// a valid base58check checksum
+func forgeShare(t *testing.T, share string, threshold, index byte) string {
+	t.Helper()
+	payload, err := base58.CheckDecode(share, base58.AlphabetBitcoin)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	payload[3], payload[4] = threshold, index
+	return base58.CheckEncode(payload, base58.AlphabetBitcoin)
+}
+
+func TestCombinePasswordForged(t *testing.T) {
+	shares, err := SplitPassword("test12345678", 2, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	tests := []struct {
+		shares []string
+		err    string
+	}{
+		{shares: []string{forgeShare(t, shares[0], 0, 1)}, err: "share 1: invalid share threshold 0"},
+		{shares: []string{forgeShare(t, shares[0], 1, 1)}, err: "share 1: invalid share threshold 1"},
+		{shares: []string{forgeShare(t, shares[0], 17, 1)}, err: "share 1: invalid share threshold 17"},
+		{shares: []string{shares[0], forgeShare(t, shares[1], 2, 0)}, err: "share 2: invalid share index 0"},
+		{shares: []string{shares[0], forgeShare(t, shares[1], 2, 17)}, err: "share 2: invalid share index 17"},
+		{shares: []string{shares[0], forgeShare(t, shares[1], 2, 255)}, err: "share 2: invalid share index 255"},
+		{shares: []string{shares[0], forgeShare(t, shares[1], 2, 1)}, err: "share 2: duplicate share index 1"},
+	}
+	for _, test := range tests {
+		_, err := CombinePassword(test.shares)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+
+	// every threshold and index byte is either refused or recovers a
+	// password, never panics
+	for threshold := 0; threshold < 256; threshold++ {
+		for index := 0; index < 256; index += 15 {
+			forged := forgeShare(t, shares[1], byte(threshold), byte(index))
+			_, _ = CombinePassword([]string{forgeShare(t, shares[0], byte(threshold), 1), forged})
+		}
+	}
+}
+
+// TestSplitPasswordSalted shows a share holder missing one share can not test
+// a guessed password against the digest share, the salt is unknown
+func TestSplitPasswordSalted(t *testing.T) {
+	const password = "test12345678"
+	shares, err := SplitPassword(password, 2, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	share, err := parsePasswordShare(shares[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	secret := make([]byte, len(share.Value))
+	secret[0] = byte(len(password))
+	copy(secret[1:], password)
+	if digestMatches(t, share.Share, secret) {
+		t.Error("expected the guess without the salt not to match the digest share")
+	}
+
+	// the same guess identifies an unsalted secret from one share
+	unsalted, err := gf256.Split(2, 3, secret, rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !digestMatches(t, unsalted[0], secret) {
+		t.Error("expected the guess to match the digest share of an unsalted secret")
+	}
+}
+
+// digestMatches is the attack of a holder of one share of a 2 of n split, the
+// guessed secret and the share fix the digest share which is checked against
+// its hmac
+func digestMatches(t *testing.T, share gf256.Share, guess []byte) bool {
+	t.Helper()
+	digestShare, err := gf256.Interpolate([]gf256.Share{share, {X: 255, Value: guess}}, 254)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	mac := hmac.New(sha256.New, digestShare[4:])
+	mac.Write(guess)
+	return hmac.Equal(mac.Sum(nil)[:4], digestShare[:4])
+}
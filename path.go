@@ -0,0 +1,47 @@
+package nomnemonic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// _pathTag starts the kdf input of every segment after the root of an
+	// identifier path
+	_pathTag       = "\x00nomnemonic path v1\x00"
+	_pathSeparator = "/"
+)
+
+// GenerateForPath generates the phrase of an identifier path like
+// "alice/work/2025". The first segment is the identifier and every later
+// segment is mixed into the kdf input with its depth, so one memorized root
+// credential derives a tree of unrelated phrases. A path of one segment
+// derives the phrase of Generate
+func (m *mnemonicer) GenerateForPath(in Inputs) ([]string, error) {
+	root, tags, err := identifierPath(in.Identifier)
+	if err != nil {
+		return nil, err
+	}
+	entropy, err := m.deriveEntropy(root, in.Password, in.Passcode, in.Size, tags...)
+	if err != nil {
+		return nil, err
+	}
+	return m.generatedWords(entropy)
+}
+
+// identifierPath returns the root identifier of the path and the tags of the
+// later segments, each one with its depth and length prefixed
+func identifierPath(path string) (string, []string, error) {
+	segments := strings.Split(path, _pathSeparator)
+	for _, s := range segments {
+		if s == "" {
+			return "", nil, errors.New("identifier path has an empty segment")
+		}
+	}
+	tags := make([]string, 0, len(segments)-1)
+	for depth, s := range segments[1:] {
+		tags = append(tags, fmt.Sprintf("%s%d:%d:%s\x00", _pathTag, depth+1, len(s), s))
+	}
+	return segments[0], tags, nil
+}
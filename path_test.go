@@ -0,0 +1,66 @@
+package nomnemonic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIdentifierPath(t *testing.T) {
+	tests := []struct {
+		path string
+		root string
+		tags int
+		err  string
+	}{
+		{path: "alice", root: "alice"},
+		{path: "alice/work/2025", root: "alice", tags: 2},
+		{path: "alice//2025", err: "identifier path has an empty segment"},
+		{path: "/work", err: "identifier path has an empty segment"},
+		{path: "alice/", err: "identifier path has an empty segment"},
+	}
+
+	for _, test := range tests {
+		root, tags, err := identifierPath(test.path)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s: expected err '%s' but actual %v", test.path, test.err, err)
+			}
+			continue
+		}
+		if err != nil || root != test.root || len(tags) != test.tags {
+			t.Errorf("%s: expected root %s with %d tags but actual %s %v %v", test.path, test.root, test.tags, root, tags, err)
+		}
+	}
+}
+
+func TestGenerateForPath(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	phrases := make(map[string]string)
+	for _, path := range []string{"nomnemonic_test", "nomnemonic_test/work", "nomnemonic_test/work/2025", "nomnemonic_test/2025/work"} {
+		w, err := m.GenerateForPath(Inputs{Identifier: path, Password: "test12345678", Passcode: "101938", Size: 12})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", path, err.Error())
+		}
+		phrase := strings.Join(w, " ")
+		if other, ok := phrases[phrase]; ok {
+			t.Errorf("expected unrelated phrases but %s and %s derive %s", other, path, phrase)
+		}
+		phrases[phrase] = path
+	}
+	expected := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	if phrases[expected] != "nomnemonic_test" {
+		t.Errorf("expected the root path to derive %s but actual %v", expected, phrases)
+	}
+
+	if _, err := m.GenerateForPath(Inputs{Identifier: "a/work", Password: "test12345678", Passcode: "101938", Size: 12}); err == nil {
+		t.Error("expected an error for a short root identifier")
+	}
+}
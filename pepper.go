@@ -0,0 +1,25 @@
+package nomnemonic
+
+import "fmt"
+
+// _pepperTag starts the kdf input of a pepper
+const _pepperTag = "\x00nomnemonic pepper v1\x00"
+
+// WithPepper mixes a secret the organization keeps apart from the users, in
+// a vault or an hsm, into the kdf input, so leaked credentials alone do not
+// derive the phrases. The pepper is never recorded, the phrases need it again
+func WithPepper(pepper []byte) Option {
+	return func(m *mnemonicer) {
+		m.pepper = append([]byte(nil), pepper...)
+	}
+}
+
+// pepperInput prefixes the input with the tag and the length prefixed pepper
+// when there is one
+func pepperInput(pepper, input []byte) []byte {
+	if len(pepper) == 0 {
+		return input
+	}
+	prefix := append([]byte(fmt.Sprintf("%s%d:", _pepperTag, len(pepper))), pepper...)
+	return append(prefix, input...)
+}
@@ -0,0 +1,53 @@
+package nomnemonic
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+var (
+	// the pgp even and odd words alternating, the even and odd words of a
+	// byte are at 2*b and 2*b+1
+	//go:embed pgpwords.txt
+	_pgpWordsText string
+
+	_pgpWords     = strings.Split(_pgpWordsText, "\n")
+	_pgpWordIndex = buildPGPWordIndex()
+)
+
+// EncodePGPWords encodes the data with the pgp word list, bytes at even
+// positions use the two syllable even words and bytes at odd positions the
+// three syllable odd words so swapped or dropped words are detected when read
+// aloud
+func EncodePGPWords(data []byte) []string {
+	words := make([]string, len(data))
+	for i, b := range data {
+		words[i] = _pgpWords[int(b)*2+i%2]
+	}
+	return words
+}
+
+// DecodePGPWords decodes the pgp words, case insensitive, back to the data
+func DecodePGPWords(words []string) ([]byte, error) {
+	data := make([]byte, len(words))
+	for i, w := range words {
+		index, ok := _pgpWordIndex[strings.ToLower(w)]
+		if !ok {
+			return nil, errorf(ErrUnknownWord, "unrecognized pgp word %s", w)
+		}
+		if index%2 != i%2 {
+			return nil, fmt.Errorf("pgp word %s at %d is out of order", w, i+1)
+		}
+		data[i] = byte(index / 2)
+	}
+	return data, nil
+}
+
+func buildPGPWordIndex() map[string]int {
+	index := make(map[string]int, len(_pgpWords))
+	for i, w := range _pgpWords {
+		index[strings.ToLower(w)] = i
+	}
+	return index
+}
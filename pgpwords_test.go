@@ -0,0 +1,68 @@
+package nomnemonic
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestEncodePGPWords(t *testing.T) {
+	tests := []struct {
+		data     string
+		sentence string
+	}{
+		{
+			data:     "e58294f2e9a227486e8b061b31cc528fd7fa3f19",
+			sentence: "topmost Istanbul Pluto vagabond treadmill Pacific brackish dictator goldfish Medusa afflict bravado chatter revolver Dupont midsummer stopwatch whimsical cowbell bottomless",
+		},
+		{
+			data:     "d1d464c004f00fb5c9a4c8d8e433e7fb7ff56256",
+			sentence: "stairway souvenir flytrap recipe adrift upcoming artist positive spearhead Pandora spaniel stupendous tonic concurrent transit Wichita lockup visitor flagpole escapade",
+		},
+	}
+
+	for _, test := range tests {
+		data, _ := hex.DecodeString(test.data)
+		if actual := strings.Join(EncodePGPWords(data), " "); actual != test.sentence {
+			t.Errorf("expected '%s' but actual '%s'", test.sentence, actual)
+		}
+	}
+}
+
+func TestDecodePGPWords(t *testing.T) {
+	tests := []struct {
+		sentence string
+		data     string
+		err      string
+	}{
+		{
+			sentence: "topmost istanbul pluto vagabond treadmill pacific brackish dictator goldfish medusa",
+			data:     "e58294f2e9a227486e8b",
+		},
+		{
+			sentence: "Istanbul topmost",
+			err:      "pgp word Istanbul at 1 is out of order",
+		},
+		{
+			sentence: "topmost bitcoin",
+			err:      "unrecognized pgp word bitcoin",
+		},
+	}
+
+	for _, test := range tests {
+		data, err := DecodePGPWords(strings.Fields(test.sentence))
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' but actual %v", test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if actual := hex.EncodeToString(data); actual != test.data {
+			t.Errorf("expected %s but actual %s", test.data, actual)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Package pkcs11 computes the pepper of nomnemonic.WithFactor as a hmac inside
+// an hsm or a token, through pkcs11-tool of OpenSC, so no single laptop ever
+// holds all the inputs of the derivation. The hmac key never leaves the hsm,
+// pkcs11-tool asks the pin on the terminal
+package pkcs11
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic/internal/command"
+)
+
+const (
+	_command = "pkcs11-tool"
+
+	// MechanismSHA256 is the hmac mechanism by default
+	MechanismSHA256 = "SHA256-HMAC"
+)
+
+var (
+	// ErrPIN is returned when the token refuses the pin
+	ErrPIN = errors.New("pkcs11: incorrect pin")
+	// ErrNoKey is returned when the token has no hmac key of the label
+	ErrNoKey = errors.New("pkcs11: no hmac key of the label on the token")
+)
+
+// HMAC is a generic secret hmac key of a token, the factor responds with the
+// hmac of the challenge computed by the hsm
+type HMAC struct {
+	// Module is the path of the pkcs#11 module of the hsm, like
+	// /usr/lib/softhsm/libsofthsm2.so
+	Module string
+	// Token is the label of the token, the first token when empty
+	Token string
+	// Key is the label of the hmac key
+	Key string
+	// Mechanism is the hmac mechanism, MechanismSHA256 by default
+	Mechanism string
+
+	run command.Runner
+}
+
+// New returns the hmac key of the label of the module
+func New(module, key string) (*HMAC, error) {
+	if module == "" || key == "" {
+		return nil, errors.New("pkcs11: module and key label are required")
+	}
+	return &HMAC{Module: module, Key: key, Mechanism: MechanismSHA256, run: command.Run}, nil
+}
+
+// Respond returns the hmac of the challenge computed by the hsm
+func (h *HMAC) Respond(challenge []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "nomnemonic-pkcs11")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	input, output := filepath.Join(dir, "challenge"), filepath.Join(dir, "hmac")
+	if err := os.WriteFile(input, challenge, 0o600); err != nil {
+		return nil, err
+	}
+
+	args := []string{"--module", h.Module, "--login"}
+	if h.Token != "" {
+		args = append(args, "--token-label", h.Token)
+	}
+	mechanism := h.Mechanism
+	if mechanism == "" {
+		mechanism = MechanismSHA256
+	}
+	args = append(args, "--sign", "--mechanism", mechanism, "--label", h.Key, "--input-file", input, "--output-file", output)
+	if _, err := h.run(nil, _command, args...); err != nil {
+		return nil, hsmError(err)
+	}
+	response, err := os.ReadFile(output)
+	if err != nil || len(response) == 0 {
+		return nil, errors.New("pkcs11: no hmac returned")
+	}
+	return response, nil
+}
+
+// hsmError maps the pkcs11-tool errors
+func hsmError(err error) error {
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "CKR_PIN_INCORRECT"):
+		return ErrPIN
+	case strings.Contains(msg, "key not found"):
+		return ErrNoKey
+	}
+	return fmt.Errorf("pkcs11: %w", err)
+}
@@ -0,0 +1,91 @@
+package pkcs11
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+var _ nomnemonic.Factor = &HMAC{}
+
+// fakeHSM emulates pkcs11-tool with one hmac key
+type fakeHSM struct {
+	key  string
+	err  error
+	args []string
+}
+
+func (f *fakeHSM) run(_ []byte, name string, args ...string) ([]byte, error) {
+	f.args = append([]string{name}, args...)
+	if f.err != nil {
+		return nil, f.err
+	}
+	arg := func(flag string) string {
+		for i, a := range args {
+			if a == flag {
+				return args[i+1]
+			}
+		}
+		return ""
+	}
+	if arg("--label") != f.key {
+		return nil, errors.New("exit status 1: error: Private key not found")
+	}
+	challenge, err := os.ReadFile(arg("--input-file"))
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, []byte("hsm secret"))
+	mac.Write(challenge)
+	return nil, os.WriteFile(arg("--output-file"), mac.Sum(nil), 0o600)
+}
+
+func TestHMAC(t *testing.T) {
+	if _, err := New("", "pepper"); err == nil {
+		t.Error("expected an error without a module")
+	}
+	hsm := &fakeHSM{key: "pepper"}
+	h, err := New("/usr/lib/softhsm/libsofthsm2.so", "pepper")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	h.run = hsm.run
+	h.Token = "org"
+
+	challenge := nomnemonic.FactorChallenge("nomnemonic_test")
+	response, err := h.Respond(challenge)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	mac := hmac.New(sha256.New, []byte("hsm secret"))
+	mac.Write(challenge)
+	if !bytes.Equal(response, mac.Sum(nil)) {
+		t.Errorf("expected %x but actual %x", mac.Sum(nil), response)
+	}
+	expected := "pkcs11-tool --module /usr/lib/softhsm/libsofthsm2.so --login --token-label org --sign --mechanism SHA256-HMAC --label pepper --input-file"
+	if actual := strings.Join(hsm.args, " "); !strings.HasPrefix(actual, expected) {
+		t.Errorf("expected the pkcs11-tool command %s but actual %s", expected, actual)
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		err  error
+		want error
+	}{
+		{name: "no key", key: "other", want: ErrNoKey},
+		{name: "pin", key: "pepper", err: errors.New("exit status 1: error: PKCS11 function C_Login failed: rv = CKR_PIN_INCORRECT (0xa0)"), want: ErrPIN},
+	}
+	for _, test := range tests {
+		h.Key, hsm.err = test.key, test.err
+		if _, err := h.Respond(challenge); !errors.Is(err, test.want) {
+			t.Errorf("%s: expected %v but actual %v", test.name, test.want, err)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package nomnemonic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"unicode"
+)
+
+// Policy standardizes how the teams of an organization generate phrases, the
+// zero values allow what the library allows. Load it with LoadPolicy and give
+// it to New with WithPolicy:
+//
+//	{
+//	  "sizes": [24],
+//	  "profiles": ["sensitive"],
+//	  "password_min_length": 16,
+//	  "password_classes": 3,
+//	  "require_pepper": true,
+//	  "languages": ["english"]
+//	}
+type Policy struct {
+	// Sizes are the allowed numbers of words
+	Sizes []int `json:"sizes,omitempty"`
+	// Profiles are the export kdf profiles CheckExport accepts, interactive,
+	// moderate or sensitive
+	Profiles []string `json:"profiles,omitempty"`
+	// PasswordMinLength raises the minimum password length of the library
+	PasswordMinLength int `json:"password_min_length,omitempty"`
+	// PasswordClasses is the number of lowercase, uppercase, digit and other
+	// char classes the password mixes
+	PasswordClasses int `json:"password_classes,omitempty"`
+	// RequirePepper makes a pepper mandatory, of WithPepper or of the
+	// response of a WithFactor like a pkcs11 hsm
+	RequirePepper bool `json:"require_pepper,omitempty"`
+	// Languages are the approved official word lists, custom lists are
+	// refused when it is set
+	Languages []string `json:"languages,omitempty"`
+}
+
+// _profiles are the export kdf profiles by name
+var _profiles = map[string]KDFParams{
+	"interactive": KDFInteractive,
+	"moderate":    KDFModerate,
+	"sensitive":   KDFSensitive,
+}
+
+// LoadPolicy reads a json policy and checks its values, unknown fields are
+// refused so a misspelled rule is not silently ignored
+func LoadPolicy(r io.Reader) (Policy, error) {
+	var p Policy
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&p); err != nil {
+		return Policy{}, fmt.Errorf("invalid policy: %w", err)
+	}
+	return p, p.validate()
+}
+
+func (p Policy) validate() error {
+	for _, size := range p.Sizes {
+		if _, ok := _sentenceStrengths[size]; !ok {
+			return fmt.Errorf("invalid policy: unsupported size %d", size)
+		}
+	}
+	for _, profile := range p.Profiles {
+		if _, ok := _profiles[profile]; !ok {
+			return fmt.Errorf("invalid policy: unknown profile %s", profile)
+		}
+	}
+	if p.PasswordMinLength < 0 || p.PasswordClasses < 0 || p.PasswordClasses > 4 {
+		return fmt.Errorf("invalid policy: password rules out of range")
+	}
+	languages := Compatibility()
+	for _, language := range p.Languages {
+		found := false
+		for _, w := range languages.Wordlists {
+			found = found || w.Language == language
+		}
+		if !found {
+			return fmt.Errorf("invalid policy: unknown language %s", language)
+		}
+	}
+	return nil
+}
+
+// WithPolicy enforces the policy, New checks the word list and the pepper and
+// Generate the size and the password
+func WithPolicy(p Policy) Option {
+	return func(m *mnemonicer) {
+		m.policy = &p
+	}
+}
+
+// CheckExport returns an error unless the params are one of the profiles of
+// the policy
+func (p Policy) CheckExport(params KDFParams) error {
+	if len(p.Profiles) == 0 {
+		return nil
+	}
+	for _, profile := range p.Profiles {
+		if _profiles[profile] == params {
+			return nil
+		}
+	}
+	return errorf(ErrPolicyViolation, "export kdf params are not one of the %v profiles of the policy", p.Profiles)
+}
+
+// checkSettings enforces the word list and the pepper of the policy
+func (m *mnemonicer) checkSettings() error {
+	p := m.policy
+	if p == nil {
+		return nil
+	}
+	if err := p.validate(); err != nil {
+		return err
+	}
+	if p.RequirePepper && len(m.pepper) == 0 && len(m.factors) == 0 {
+		return errorf(ErrPolicyViolation, "the policy requires a pepper")
+	}
+	if len(p.Languages) > 0 {
+		language, _ := Compatibility().Language(WordlistChecksum(m.words))
+		approved := false
+		for _, l := range p.Languages {
+			approved = approved || l == language
+		}
+		if !approved {
+			return errorf(ErrPolicyViolation, "the word list is not one of the %v languages of the policy", p.Languages)
+		}
+	}
+	return nil
+}
+
+// checkInputs returns the violations of the size and the password
+func (m *mnemonicer) checkInputs(password string, size int) []error {
+	p := m.policy
+	if p == nil {
+		return nil
+	}
+	var errs []error
+	if len(p.Sizes) > 0 && !containsInt(p.Sizes, size) {
+		errs = append(errs, errorf(ErrPolicyViolation, "%d words are not allowed by the policy", size))
+	}
+	return append(errs, m.checkPassword(password)...)
+}
+
+// checkPassword returns the violations of the password
+func (m *mnemonicer) checkPassword(password string) []error {
+	p := m.policy
+	if p == nil {
+		return nil
+	}
+	var errs []error
+	if p.PasswordMinLength > 0 && len(password) < p.PasswordMinLength {
+		errs = append(errs, errorf(ErrPolicyViolation, "password must be at least %d chars by the policy", p.PasswordMinLength))
+	}
+	if p.PasswordClasses > 0 && passwordClasses(password) < p.PasswordClasses {
+		errs = append(errs, errorf(ErrPolicyViolation, "password must mix %d of lowercase, uppercase, digits and symbols by the policy", p.PasswordClasses))
+	}
+	return errs
+}
+
+// passwordClasses counts the lowercase, uppercase, digit and other classes of
+// the chars of the password
+func passwordClasses(password string) int {
+	var classes [4]bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			classes[0] = true
+		case unicode.IsUpper(r):
+			classes[1] = true
+		case unicode.IsDigit(r):
+			classes[2] = true
+		default:
+			classes[3] = true
+		}
+	}
+	n := 0
+	for _, c := range classes {
+		if c {
+			n++
+		}
+	}
+	return n
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
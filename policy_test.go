@@ -0,0 +1,92 @@
+package nomnemonic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	tests := []struct {
+		json string
+		err  string
+	}{
+		{json: `{"sizes":[24],"profiles":["sensitive"],"password_min_length":16,"password_classes":3,"require_pepper":true,"languages":["english"]}`},
+		{json: `{}`},
+		{json: `{"sizes":[13]}`, err: "invalid policy: unsupported size 13"},
+		{json: `{"profiles":["paranoid"]}`, err: "invalid policy: unknown profile paranoid"},
+		{json: `{"languages":["klingon"]}`, err: "invalid policy: unknown language klingon"},
+		{json: `{"password_classes":5}`, err: "invalid policy: password rules out of range"},
+		{json: `{"password_min_lenght":16}`, err: `invalid policy: json: unknown field "password_min_lenght"`},
+	}
+
+	for _, test := range tests {
+		_, err := LoadPolicy(strings.NewReader(test.json))
+		if (test.err == "" && err != nil) || (test.err != "" && (err == nil || err.Error() != test.err)) {
+			t.Errorf("%s: expected err '%s' but actual %v", test.json, test.err, err)
+		}
+	}
+}
+
+func TestWithPolicy(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	policy := Policy{Sizes: []int{24}, Profiles: []string{"sensitive"}, PasswordMinLength: 16, PasswordClasses: 3, RequirePepper: true, Languages: []string{"english"}}
+	pepper := []byte("organization pepper")
+
+	if _, err := New(words, WithPolicy(policy)); !errors.Is(err, ErrPolicyViolation) || err.Error() != "the policy requires a pepper" {
+		t.Errorf("expected a missing pepper violation but actual %v", err)
+	}
+	hsm := FactorFunc(func(challenge []byte) ([]byte, error) { return []byte("hsm pepper"), nil })
+	if _, err := New(words, WithPolicy(policy), WithFactor(hsm)); err != nil {
+		t.Errorf("expected the pepper of the factor to satisfy the policy but actual %v", err)
+	}
+	if _, err := New(words, WithPolicy(Policy{Languages: []string{"spanish"}})); !errors.Is(err, ErrPolicyViolation) || err.Error() != "the word list is not one of the [spanish] languages of the policy" {
+		t.Errorf("expected a language violation but actual %v", err)
+	}
+
+	m, err := New(words, WithPolicy(policy), WithPepper(pepper))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.Generate("nomnemonic_test", "test12345678", "101938", 12)
+	expected := "12 words are not allowed by the policy\npassword must be at least 16 chars by the policy\npassword must mix 3 of lowercase, uppercase, digits and symbols by the policy"
+	if !errors.Is(err, ErrPolicyViolation) || err.Error() != expected {
+		t.Errorf("expected err '%s' but actual %v", expected, err)
+	}
+	peppered, err := m.Generate("nomnemonic_test", "Test-12345678-pw", "101938", 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plain, _ := New(words)
+	unpeppered, _ := plain.Generate("nomnemonic_test", "Test-12345678-pw", "101938", 24)
+	if strings.Join(peppered, " ") == strings.Join(unpeppered, " ") {
+		t.Error("expected the pepper to change the phrase")
+	}
+	again, _ := New(words, WithPepper(pepper))
+	if words, _ := again.Generate("nomnemonic_test", "Test-12345678-pw", "101938", 24); strings.Join(words, " ") != strings.Join(peppered, " ") {
+		t.Errorf("expected %v with the same pepper but actual %v", peppered, words)
+	}
+
+	if err := policy.CheckExport(KDFModerate); !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("expected a profile violation but actual %v", err)
+	}
+	if err := policy.CheckExport(KDFSensitive); err != nil {
+		t.Errorf("expected the sensitive profile to pass but actual %v", err)
+	}
+	if err := (Policy{}).CheckExport(_testKDF); err != nil {
+		t.Errorf("expected any params without profiles but actual %v", err)
+	}
+}
+
+func TestPasswordClasses(t *testing.T) {
+	tests := map[string]int{"": 0, "password": 1, "Password": 2, "Password1": 3, "Pass word1": 4, "пароль123": 2}
+	for password, expected := range tests {
+		if actual := passwordClasses(password); actual != expected {
+			t.Errorf("%s: expected %d classes but actual %d", password, expected, actual)
+		}
+	}
+}
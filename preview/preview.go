@@ -0,0 +1,140 @@
+// Package preview derives the first receive addresses of the supported chains
+// in one call, so users can verify a phrase before relying on it
+package preview
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/nomnemonic/nomnemonic/evm"
+	"github.com/nomnemonic/nomnemonic/tezos"
+	"github.com/nomnemonic/nomnemonic/utxo"
+	"github.com/nomnemonic/nomnemonic/xrp"
+)
+
+const (
+	MaxCount = 100
+)
+
+type (
+	// Address is a derived receive address and its derivation path
+	Address struct {
+		Index   uint32
+		Path    string
+		Address string
+	}
+
+	// ChainAddresses is the list of receive addresses of a chain
+	ChainAddresses struct {
+		Chain     string
+		Addresses []Address
+	}
+
+	deriver func(seed []byte, index uint32) (Address, error)
+)
+
+var (
+	_derivers = map[string]deriver{
+		"xrp": func(seed []byte, index uint32) (Address, error) {
+			address, err := xrp.Address(seed, index)
+			return Address{Index: index, Path: xrp.Path(index), Address: address}, err
+		},
+		"tezos": func(seed []byte, index uint32) (Address, error) {
+			address, err := tezos.Address(seed, index)
+			return Address{Index: index, Path: tezos.Path(index), Address: address}, err
+		},
+	}
+)
+
+func init() {
+	for _, c := range evm.Chains {
+		_derivers[c.Name] = evmDeriver(c)
+	}
+	for _, n := range []utxo.Network{utxo.Bitcoin, utxo.Litecoin, utxo.Dogecoin} {
+		_derivers[n.Name] = utxoDeriver(n)
+	}
+}
+
+// Chains returns the names of the supported chains in order
+func Chains() []string {
+	names := make([]string, 0, len(_derivers))
+	for name := range _derivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Preview derives the first count receive addresses of each chain from the
+// seed, all supported chains are derived when chains is empty. Chains are
+// derived in parallel and returned in the requested order
+func Preview(seed []byte, chains []string, count uint32) ([]ChainAddresses, error) {
+	if count == 0 || count > MaxCount {
+		return nil, fmt.Errorf("count must be between 1 and %d", MaxCount)
+	}
+	if len(chains) == 0 {
+		chains = Chains()
+	}
+	for _, c := range chains {
+		if _, ok := _derivers[c]; !ok {
+			return nil, fmt.Errorf("unsupported chain %s", c)
+		}
+	}
+
+	results := make([]ChainAddresses, len(chains))
+	errs := make([]error, len(chains))
+	var wg sync.WaitGroup
+	for i, c := range chains {
+		wg.Add(1)
+		go func(i int, c string) {
+			defer wg.Done()
+			results[i], errs[i] = preview(seed, c, count)
+		}(i, c)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+func preview(seed []byte, chain string, count uint32) (ChainAddresses, error) {
+	derive := _derivers[chain]
+	addresses := make([]Address, count)
+	for i := uint32(0); i < count; i++ {
+		a, err := derive(seed, i)
+		if err != nil {
+			return ChainAddresses{}, fmt.Errorf("%s: %w", chain, err)
+		}
+		addresses[i] = a
+	}
+	return ChainAddresses{Chain: chain, Addresses: addresses}, nil
+}
+
+func evmDeriver(c evm.Chain) deriver {
+	return func(seed []byte, index uint32) (Address, error) {
+		address, err := c.Address(seed, index)
+		return Address{Index: index, Path: c.Path(index), Address: address}, err
+	}
+}
+
+// utxoDeriver derives native segwit addresses when the network supports
+// segwit and legacy addresses otherwise
+func utxoDeriver(n utxo.Network) deriver {
+	t := utxo.AddressP2PKH
+	if n.Bech32HRP != "" {
+		t = utxo.AddressP2WPKH
+	}
+	return func(seed []byte, index uint32) (Address, error) {
+		path, err := n.Path(t, index)
+		if err != nil {
+			return Address{}, err
+		}
+		address, err := n.Address(seed, t, index)
+		return Address{Index: index, Path: path, Address: address}, err
+	}
+}
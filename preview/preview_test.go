@@ -0,0 +1,100 @@
+package preview
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestPreview(t *testing.T) {
+	seed, err := buildSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+	if err != nil {
+		t.Errorf("couldn't generate seed: %s", err.Error())
+	}
+
+	results, err := Preview(seed, []string{"bitcoin", "ethereum", "litecoin"}, 2)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	expected := []ChainAddresses{
+		{
+			Chain: "bitcoin",
+			Addresses: []Address{
+				{Index: 0, Path: "m/84'/0'/0'/0/0", Address: "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+				{Index: 1, Path: "m/84'/0'/0'/0/1", Address: "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g"},
+			},
+		},
+		{
+			Chain: "ethereum",
+			Addresses: []Address{
+				{Index: 0, Path: "m/44'/60'/0'/0/0", Address: "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"},
+				{Index: 1, Path: "m/44'/60'/0'/0/1", Address: "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0"},
+			},
+		},
+		{
+			Chain: "litecoin",
+			Addresses: []Address{
+				{Index: 0, Path: "m/84'/2'/0'/0/0", Address: "ltc1qjmxnz78nmc8nq77wuxh25n2es7rzm5c2rkk4wh"},
+				{Index: 1, Path: "m/84'/2'/0'/0/1", Address: "ltc1qwlezpr3890hcp6vva9twqh27mr6edadreqvhnn"},
+			},
+		},
+	}
+
+	if len(results) != len(expected) {
+		t.Errorf("expected %d chains but actual %d", len(expected), len(results))
+	}
+	for i, r := range results {
+		if r.Chain != expected[i].Chain {
+			t.Errorf("expected chain %s but actual %s", expected[i].Chain, r.Chain)
+		}
+		for j, a := range r.Addresses {
+			if a != expected[i].Addresses[j] {
+				t.Errorf("expected %s address %v but actual %v", r.Chain, expected[i].Addresses[j], a)
+			}
+		}
+	}
+
+	all, err := Preview(seed, nil, 1)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if len(all) != len(Chains()) {
+		t.Errorf("expected all %d chains but actual %d", len(Chains()), len(all))
+	}
+}
+
+func TestPreviewValidation(t *testing.T) {
+	seed := make([]byte, 64)
+
+	tests := []struct {
+		chains []string
+		count  uint32
+		err    string
+	}{
+		{count: 0, err: "count must be between 1 and 100"},
+		{count: 101, err: "count must be between 1 and 100"},
+		{chains: []string{"unknown"}, count: 1, err: "unsupported chain unknown"},
+	}
+
+	for _, test := range tests {
+		_, err := Preview(seed, test.chains, test.count)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+}
+
+func buildSeed(sentence string) ([]byte, error) {
+	bytes, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		return nil, err
+	}
+	m, err := nomnemonic.New(strings.Split(string(bytes), "\n"))
+	if err != nil {
+		return nil, err
+	}
+	return m.GenerateSeed(sentence, "")
+}
@@ -0,0 +1,138 @@
+package nomnemonic
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/salsa20/salsa"
+)
+
+// _kdfChunk is the number of pbkdf2 iterations or scrypt block mixes between
+// two progress calls
+const _kdfChunk = 1 << 12
+
+// Progress is called between the chunks of the kdf with the completed
+// fraction of the work, from 0 to 1. Returning an error aborts the derivation
+// with it, which is how callers cancel a generation or yield to an event loop
+type Progress func(done float64) error
+
+// GenerateProgress generates the same words as Generate computing the kdf in
+// chunks and calling progress between them
+func (m *mnemonicer) GenerateProgress(identifier, password, passcode string, size int, progress Progress) ([]string, error) {
+	input, salt, strength, err := m.kdfInputs(identifier, password, passcode, size)
+	if err != nil {
+		m.log("derivation rejected", Field{Key: "size", Value: size})
+		return nil, err
+	}
+	start := m.logStarted(size)
+	entropy, err := m.stretchProgress(input, salt, strength/_bitChunkSizeOneByte, progress)
+	if err != nil {
+		m.log("derivation aborted", Field{Key: "size", Value: size}, Field{Key: "duration", Value: time.Since(start)})
+		return nil, err
+	}
+	m.logFinished(size, start)
+	return m.generatedWords(entropy)
+}
+
+// stretchProgress computes stretch in chunks, the scrypt block mixes cost
+// about as much as the pbkdf2 iterations so both count as one unit of work
+func (m *mnemonicer) stretchProgress(input, salt []byte, size int, progress Progress) ([]byte, error) {
+	if m.insecureFastKDF {
+		entropy := m.stretch(input, salt, size)
+		return entropy, progress(1)
+	}
+	total := float64(_kdfPBKDF2Iterations + 2*_kdfScryptN)
+	done := 0
+	step := func(n int) error {
+		done += n
+		return progress(float64(done) / total)
+	}
+
+	start := time.Now()
+	entropy, err := pbkdf2Progress(input, salt, size, step)
+	if err != nil {
+		return nil, err
+	}
+	m.observe(StagePBKDF2, start)
+
+	start = time.Now()
+	dkTail, err := scryptProgress(input, salt, size, step)
+	if err != nil {
+		return nil, err
+	}
+	m.observe(StageScrypt, start)
+	xorBytes(entropy, dkTail)
+	return entropy, nil
+}
+
+// pbkdf2Progress is pbkdf2 with hmac-sha512 for keys up to one block, enough
+// for the entropy sizes
+func pbkdf2Progress(input, salt []byte, size int, step func(n int) error) ([]byte, error) {
+	prf := hmac.New(sha512.New, input)
+	prf.Write(salt)
+	prf.Write([]byte{0, 0, 0, 1})
+	u := prf.Sum(nil)
+	t := append([]byte{}, u...)
+	for i := 1; i < _kdfPBKDF2Iterations; i++ {
+		prf.Reset()
+		prf.Write(u)
+		u = prf.Sum(u[:0])
+		xorBytes(t, u)
+		if (i+1)%_kdfChunk == 0 {
+			if err := step(_kdfChunk); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return t[:size], nil
+}
+
+// scryptProgress is scrypt with the parameters of stretchKeys, p is 1 so
+// there is a single romix
+func scryptProgress(input, salt []byte, size int, step func(n int) error) ([]byte, error) {
+	blockSize := 128 * _kdfScryptR
+	b := pbkdf2.Key(input, salt, 1, blockSize, sha256.New)
+
+	v := make([]byte, _kdfScryptN*blockSize)
+	x := b
+	y := make([]byte, blockSize)
+	for i := 0; i < _kdfScryptN; i++ {
+		copy(v[i*blockSize:], x)
+		blockMix(x, y)
+		x, y = y, x
+		if (i+1)%_kdfChunk == 0 {
+			if err := step(_kdfChunk); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for i := 0; i < _kdfScryptN; i++ {
+		j := binary.LittleEndian.Uint64(x[blockSize-64:]) & (_kdfScryptN - 1)
+		xorBytes(x, v[int(j)*blockSize:])
+		blockMix(x, y)
+		x, y = y, x
+		if (i+1)%_kdfChunk == 0 {
+			if err := step(_kdfChunk); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return pbkdf2.Key(input, x, 1, size, sha256.New), nil
+}
+
+// blockMix is the scrypt BlockMix with salsa20/8, the even blocks go to the
+// first half of out and the odd ones to the second half
+func blockMix(in, out []byte) {
+	var x [64]byte
+	copy(x[:], in[len(in)-64:])
+	half := len(in) / 2
+	for i := 0; i < len(in)/64; i++ {
+		xorBytes(x[:], in[i*64:])
+		salsa.Core208(&x, &x)
+		copy(out[(i%2)*half+(i/2)*64:], x[:])
+	}
+}
@@ -0,0 +1,57 @@
+package nomnemonic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateProgress(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _ := New(words)
+
+	expected, err := m.Generate("nomnemonic_test", "test12345678", "101938", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls []float64
+	actual, err := m.GenerateProgress("nomnemonic_test", "test12345678", "101938", 12, func(done float64) error {
+		calls = append(calls, done)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(actual, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected '%s' but actual '%s'", strings.Join(expected, " "), strings.Join(actual, " "))
+	}
+	if len(calls) == 0 || calls[len(calls)-1] != 1 {
+		t.Errorf("expected progress to end at 1 but actual %v", calls)
+	}
+	for i := 1; i < len(calls); i++ {
+		if calls[i] <= calls[i-1] {
+			t.Errorf("expected increasing progress but actual %f after %f", calls[i], calls[i-1])
+			break
+		}
+	}
+
+	canceled := errors.New("canceled")
+	_, err = m.GenerateProgress("nomnemonic_test", "test12345678", "101938", 12, func(done float64) error {
+		if done > 0.5 {
+			return canceled
+		}
+		return nil
+	})
+	if err != canceled {
+		t.Errorf("expected err '%v' but actual '%v'", canceled, err)
+	}
+
+	_, err = m.GenerateProgress("nomnemonic_test", "test12345678", "1019", 12, nil)
+	if err == nil || err.Error() != "passcode must be 6 digits" {
+		t.Errorf("expected err 'passcode must be 6 digits' but actual '%v'", err)
+	}
+}
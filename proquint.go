@@ -0,0 +1,59 @@
+package nomnemonic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	_proquintConsonants = "bdfghjklmnprstvz"
+	_proquintVowels     = "aiou"
+	_proquintSeparator  = "-"
+)
+
+// EncodeProquint encodes the data as pronounceable proquint quintuplets, one
+// for each 16 bits, joined with dashes
+func EncodeProquint(data []byte) (string, error) {
+	if len(data) == 0 || len(data)%2 != 0 {
+		return "", errors.New("proquint data must be a multiple of 2 bytes")
+	}
+
+	quints := make([]string, 0, len(data)/2)
+	for i := 0; i < len(data); i += 2 {
+		v := uint16(data[i])<<8 | uint16(data[i+1])
+		quints = append(quints, string([]byte{
+			_proquintConsonants[v>>12&15],
+			_proquintVowels[v>>10&3],
+			_proquintConsonants[v>>6&15],
+			_proquintVowels[v>>4&3],
+			_proquintConsonants[v&15],
+		}))
+	}
+	return strings.Join(quints, _proquintSeparator), nil
+}
+
+// DecodeProquint decodes the dash separated proquint quintuplets
+func DecodeProquint(s string) ([]byte, error) {
+	quints := strings.Split(strings.ToLower(s), _proquintSeparator)
+	data := make([]byte, 0, len(quints)*2)
+	for _, q := range quints {
+		if len(q) != 5 {
+			return nil, fmt.Errorf("invalid proquint %s", q)
+		}
+		var v uint16
+		for i := 0; i < len(q); i++ {
+			alphabet, bits := _proquintConsonants, 4
+			if i%2 == 1 {
+				alphabet, bits = _proquintVowels, 2
+			}
+			n := strings.IndexByte(alphabet, q[i])
+			if n < 0 {
+				return nil, fmt.Errorf("invalid proquint %s", q)
+			}
+			v = v<<bits | uint16(n)
+		}
+		data = append(data, byte(v>>8), byte(v))
+	}
+	return data, nil
+}
@@ -0,0 +1,69 @@
+package nomnemonic
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestEncodeProquint(t *testing.T) {
+	tests := []struct {
+		data     string
+		proquint string
+		err      string
+	}{
+		{data: "7f000001", proquint: "lusab-babad"},
+		{data: "3f54dcc1", proquint: "gutih-tugad"},
+		{data: "3f760723", proquint: "gutuk-bisog"},
+		{data: "8c62c18d", proquint: "mudof-sakat"},
+		{data: "ffffffff", proquint: "zuzuz-zuzuz"},
+		{data: "7f0000", err: "proquint data must be a multiple of 2 bytes"},
+	}
+
+	for _, test := range tests {
+		data, _ := hex.DecodeString(test.data)
+		actual, err := EncodeProquint(data)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' but actual %v", test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if actual != test.proquint {
+			t.Errorf("expected '%s' but actual '%s'", test.proquint, actual)
+		}
+	}
+}
+
+func TestDecodeProquint(t *testing.T) {
+	tests := []struct {
+		proquint string
+		data     string
+		err      string
+	}{
+		{proquint: "lusab-babad", data: "7f000001"},
+		{proquint: "MUDOF-SAKAT", data: "8c62c18d"},
+		{proquint: "lusab-baba", err: "invalid proquint baba"},
+		{proquint: "lusab-babae", err: "invalid proquint babae"},
+	}
+
+	for _, test := range tests {
+		data, err := DecodeProquint(test.proquint)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' but actual %v", test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if actual := hex.EncodeToString(data); actual != test.data {
+			t.Errorf("expected %s but actual %s", test.data, actual)
+		}
+	}
+}
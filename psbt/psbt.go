@@ -0,0 +1,389 @@
+// Package psbt signs bip174 partially signed bitcoin transactions with the
+// keys of a bip32 master key, so a derived phrase backs a minimal
+// deterministic signer for air-gapped flows. The inputs whose bip32
+// derivations start from the master fingerprint are signed, p2wpkh, p2sh
+// wrapped p2wpkh and p2pkh with SIGHASH_ALL
+package psbt
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	_magic = "psbt\xff"
+
+	_globalUnsignedTx = 0x00
+	_globalVersion    = 0xfb
+
+	_inputNonWitnessUTXO = 0x00
+	_inputWitnessUTXO    = 0x01
+	_inputPartialSig     = 0x02
+	_inputSighashType    = 0x03
+	_inputRedeemScript   = 0x04
+	_inputBIP32          = 0x06
+	_inputFinalScriptSig = 0x07
+	_inputFinalWitness   = 0x08
+
+	// _maxSize bounds the compact sizes read from untrusted packets
+	_maxSize = 1 << 24
+)
+
+var errTruncated = errors.New("psbt: truncated packet")
+
+type (
+	// field is a key value pair of a map, kept as read so the unknown fields
+	// survive signing
+	field struct {
+		key   []byte
+		value []byte
+	}
+
+	// Packet is a version 0 psbt
+	Packet struct {
+		global  []field
+		inputs  [][]field
+		outputs [][]field
+		tx      *transaction
+	}
+
+	transaction struct {
+		version  uint32
+		inputs   []txIn
+		outputs  []txOut
+		locktime uint32
+	}
+
+	txIn struct {
+		hash     [32]byte
+		index    uint32
+		script   []byte
+		sequence uint32
+	}
+
+	txOut struct {
+		value  uint64
+		script []byte
+	}
+)
+
+// Parse parses a binary psbt
+func Parse(b []byte) (*Packet, error) {
+	if !bytes.HasPrefix(b, []byte(_magic)) {
+		return nil, errors.New("psbt: invalid magic")
+	}
+	r := bytes.NewReader(b[len(_magic):])
+
+	p := &Packet{}
+	var err error
+	if p.global, err = readMap(r); err != nil {
+		return nil, err
+	}
+	for _, f := range p.global {
+		switch {
+		case f.key[0] == _globalUnsignedTx && len(f.key) == 1:
+			if p.tx, err = parseTransaction(f.value, false); err != nil {
+				return nil, err
+			}
+		case f.key[0] == _globalVersion && len(f.key) == 1:
+			if len(f.value) != 4 || binary.LittleEndian.Uint32(f.value) != 0 {
+				return nil, errors.New("psbt: unsupported version")
+			}
+		}
+	}
+	if p.tx == nil {
+		return nil, errors.New("psbt: no unsigned transaction")
+	}
+	for _, in := range p.tx.inputs {
+		if len(in.script) > 0 {
+			return nil, errors.New("psbt: unsigned transaction has a script sig")
+		}
+	}
+
+	p.inputs = make([][]field, len(p.tx.inputs))
+	for i := range p.inputs {
+		if p.inputs[i], err = readMap(r); err != nil {
+			return nil, err
+		}
+	}
+	p.outputs = make([][]field, len(p.tx.outputs))
+	for i := range p.outputs {
+		if p.outputs[i], err = readMap(r); err != nil {
+			return nil, err
+		}
+	}
+	if r.Len() > 0 {
+		return nil, errors.New("psbt: trailing data")
+	}
+	return p, nil
+}
+
+// ParseBase64 parses a base64 psbt, the usual text form
+func ParseBase64(s string) (*Packet, error) {
+	b, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace([]byte(s))))
+	if err != nil {
+		return nil, errors.New("psbt: invalid base64")
+	}
+	return Parse(b)
+}
+
+// Serialize returns the binary psbt
+func (p *Packet) Serialize() []byte {
+	var b bytes.Buffer
+	b.WriteString(_magic)
+	writeMap(&b, p.global)
+	for _, m := range p.inputs {
+		writeMap(&b, m)
+	}
+	for _, m := range p.outputs {
+		writeMap(&b, m)
+	}
+	return b.Bytes()
+}
+
+// Base64 returns the base64 psbt
+func (p *Packet) Base64() string {
+	return base64.StdEncoding.EncodeToString(p.Serialize())
+}
+
+// readMap reads the fields up to the separator, keys are unique
+func readMap(r *bytes.Reader) ([]field, error) {
+	var fields []field
+	seen := make(map[string]bool)
+	for {
+		key, err := readVarBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(key) == 0 {
+			return fields, nil
+		}
+		value, err := readVarBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		if seen[string(key)] {
+			return nil, fmt.Errorf("psbt: duplicate key %x", key)
+		}
+		seen[string(key)] = true
+		fields = append(fields, field{key: key, value: value})
+	}
+}
+
+func writeMap(w *bytes.Buffer, fields []field) {
+	for _, f := range fields {
+		writeVarBytes(w, f.key)
+		writeVarBytes(w, f.value)
+	}
+	w.WriteByte(0)
+}
+
+// parseTransaction parses a transaction, the witnesses are skipped when
+// allowed and rejected otherwise
+func parseTransaction(b []byte, witnesses bool) (*transaction, error) {
+	r := bytes.NewReader(b)
+	tx := &transaction{}
+	var err error
+	if tx.version, err = readUint32(r); err != nil {
+		return nil, err
+	}
+	count, err := readCompactSize(r)
+	if err != nil {
+		return nil, err
+	}
+	segwit := false
+	if count == 0 && witnesses {
+		if flag, err := r.ReadByte(); err != nil || flag != 0x01 {
+			return nil, errors.New("psbt: invalid witness flag")
+		}
+		if count, err = readCompactSize(r); err != nil {
+			return nil, err
+		}
+		segwit = true
+	}
+	if count == 0 {
+		return nil, errors.New("psbt: transaction has no inputs or has witnesses")
+	}
+	for i := uint64(0); i < count; i++ {
+		var in txIn
+		if _, err := io.ReadFull(r, in.hash[:]); err != nil {
+			return nil, errTruncated
+		}
+		if in.index, err = readUint32(r); err != nil {
+			return nil, err
+		}
+		if in.script, err = readVarBytes(r); err != nil {
+			return nil, err
+		}
+		if in.sequence, err = readUint32(r); err != nil {
+			return nil, err
+		}
+		tx.inputs = append(tx.inputs, in)
+	}
+	if count, err = readCompactSize(r); err != nil {
+		return nil, err
+	}
+	for i := uint64(0); i < count; i++ {
+		var out txOut
+		if out.value, err = readUint64(r); err != nil {
+			return nil, err
+		}
+		if out.script, err = readVarBytes(r); err != nil {
+			return nil, err
+		}
+		tx.outputs = append(tx.outputs, out)
+	}
+	for i := 0; segwit && i < len(tx.inputs); i++ {
+		items, err := readCompactSize(r)
+		if err != nil {
+			return nil, err
+		}
+		for j := uint64(0); j < items; j++ {
+			if _, err := readVarBytes(r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if tx.locktime, err = readUint32(r); err != nil {
+		return nil, err
+	}
+	if r.Len() > 0 {
+		return nil, errors.New("psbt: trailing transaction data")
+	}
+	return tx, nil
+}
+
+// serialize serializes the transaction with the script of every input
+// replaced by the scripts, the inputs keep theirs when scripts is nil
+func (tx *transaction) serialize(scripts [][]byte) []byte {
+	var b bytes.Buffer
+	writeUint32(&b, tx.version)
+	writeCompactSize(&b, uint64(len(tx.inputs)))
+	for i, in := range tx.inputs {
+		b.Write(in.hash[:])
+		writeUint32(&b, in.index)
+		script := in.script
+		if scripts != nil {
+			script = scripts[i]
+		}
+		writeVarBytes(&b, script)
+		writeUint32(&b, in.sequence)
+	}
+	writeCompactSize(&b, uint64(len(tx.outputs)))
+	for _, out := range tx.outputs {
+		writeOutput(&b, out)
+	}
+	writeUint32(&b, tx.locktime)
+	return b.Bytes()
+}
+
+// txid returns the double sha256 of the transaction, in the byte order of
+// the outpoints
+func (tx *transaction) txid() [32]byte {
+	return doubleSHA256(tx.serialize(nil))
+}
+
+func writeOutput(w *bytes.Buffer, out txOut) {
+	writeUint64(w, out.value)
+	writeVarBytes(w, out.script)
+}
+
+func doubleSHA256(b []byte) [32]byte {
+	first := sha256.Sum256(b)
+	return sha256.Sum256(first[:])
+}
+
+func readCompactSize(r *bytes.Reader) (uint64, error) {
+	prefix, err := r.ReadByte()
+	if err != nil {
+		return 0, errTruncated
+	}
+	var n uint64
+	switch prefix {
+	case 0xfd:
+		var b [2]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return 0, errTruncated
+		}
+		n = uint64(binary.LittleEndian.Uint16(b[:]))
+	case 0xfe:
+		v, err := readUint32(r)
+		if err != nil {
+			return 0, err
+		}
+		n = uint64(v)
+	case 0xff:
+		if n, err = readUint64(r); err != nil {
+			return 0, err
+		}
+	default:
+		n = uint64(prefix)
+	}
+	if n > _maxSize {
+		return 0, errors.New("psbt: size out of range")
+	}
+	return n, nil
+}
+
+func readVarBytes(r *bytes.Reader) ([]byte, error) {
+	n, err := readCompactSize(r)
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(r.Len()) {
+		return nil, errTruncated
+	}
+	b := make([]byte, n)
+	_, _ = io.ReadFull(r, b)
+	return b, nil
+}
+
+func readUint32(r *bytes.Reader) (uint32, error) {
+	var b [4]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, errTruncated
+	}
+	return binary.LittleEndian.Uint32(b[:]), nil
+}
+
+func readUint64(r *bytes.Reader) (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, errTruncated
+	}
+	return binary.LittleEndian.Uint64(b[:]), nil
+}
+
+func writeCompactSize(w *bytes.Buffer, n uint64) {
+	switch {
+	case n < 0xfd:
+		w.WriteByte(byte(n))
+	case n <= 0xffff:
+		w.WriteByte(0xfd)
+		_ = binary.Write(w, binary.LittleEndian, uint16(n))
+	case n <= 0xffffffff:
+		w.WriteByte(0xfe)
+		writeUint32(w, uint32(n))
+	default:
+		w.WriteByte(0xff)
+		writeUint64(w, n)
+	}
+}
+
+func writeVarBytes(w *bytes.Buffer, b []byte) {
+	writeCompactSize(w, uint64(len(b)))
+	w.Write(b)
+}
+
+func writeUint32(w *bytes.Buffer, v uint32) {
+	_ = binary.Write(w, binary.LittleEndian, v)
+}
+
+func writeUint64(w *bytes.Buffer, v uint64) {
+	_ = binary.Write(w, binary.LittleEndian, v)
+}
@@ -0,0 +1,284 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+)
+
+func TestSegwitSighash(t *testing.T) {
+	// bip143 native p2wpkh example, second input
+	b, _ := hex.DecodeString("0100000002fff7f7881a8099afa6940d42d1e7f6362bec38171ea3edf433541db4e4ad969f0000000000eeffffffef51e1b804cc89d182d279655c3aa89e815b1b309fe287d9b2b55d57b90ec68a0100000000ffffffff02202cb206000000001976a9148280b37df378db99f66f85c95a783a76ac7a6d5988ac9093510d000000001976a9143bde42dbee7e4dbe6a21b2d50ce2f0167faa815988ac11000000")
+	tx, err := parseTransaction(b, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if actual := tx.serialize(nil); !bytes.Equal(actual, b) {
+		t.Errorf("expected %x but actual %x", b, actual)
+	}
+	pkh, _ := hex.DecodeString("1d0f172a0ecb48aee1be1f2687d2963ae33f71a1")
+	hash := tx.segwitSighash(1, p2pkhScript(pkh), 600000000, SighashAll)
+	expected := "c37af31116d1b27caf68aae9e3ac82f1477929014d5b917657d0eb49478cb670"
+	if actual := hex.EncodeToString(hash[:]); actual != expected {
+		t.Errorf("expected %s but actual %s", expected, actual)
+	}
+}
+
+func TestSign(t *testing.T) {
+	master := buildMaster(t)
+	p, paths := buildPacket(t, master)
+
+	signed, err := p.Sign(master)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if signed != 3 {
+		t.Fatalf("expected 3 signatures but actual %d", signed)
+	}
+
+	parsed, err := ParseBase64(p.Base64())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !bytes.Equal(parsed.Serialize(), p.Serialize()) {
+		t.Errorf("expected the same packet after parsing")
+	}
+
+	for i, path := range paths {
+		if path == "" {
+			for _, f := range parsed.inputs[i] {
+				if f.key[0] == _inputPartialSig {
+					t.Errorf("input %d: expected no signature of a foreign key", i)
+				}
+			}
+			continue
+		}
+		key, _ := master.DerivePath(path)
+		sig, ok := parsed.lookup(i, append([]byte{_inputPartialSig}, key.PublicKey()...))
+		if !ok {
+			t.Fatalf("input %d: expected a signature", i)
+		}
+		if sig[len(sig)-1] != SighashAll {
+			t.Errorf("input %d: expected sighash all but actual %d", i, sig[len(sig)-1])
+		}
+		parsedSig, err := ecdsa.ParseDERSignature(sig[:len(sig)-1])
+		if err != nil {
+			t.Fatalf("input %d: unexpected error: %s", i, err.Error())
+		}
+		hash := sighash(t, parsed, i, key.PublicKey())
+		pub, _ := secp256k1.ParsePubKey(key.PublicKey())
+		if !parsedSig.Verify(hash[:], pub) {
+			t.Errorf("input %d: expected a valid signature", i)
+		}
+	}
+
+	if signed, err := parsed.Sign(master); err != nil || signed != 0 {
+		t.Errorf("expected no signature twice but actual %d, %v", signed, err)
+	}
+}
+
+func TestSignErrors(t *testing.T) {
+	master := buildMaster(t)
+
+	tests := []struct {
+		name   string
+		mutate func(p *Packet)
+		err    string
+	}{
+		{
+			name: "sighash type",
+			mutate: func(p *Packet) {
+				p.inputs[0] = append(p.inputs[0], field{key: []byte{_inputSighashType}, value: []byte{0x83, 0, 0, 0}})
+			},
+			err: "sighash type 131 is not supported",
+		},
+		{
+			name: "wrong public key",
+			mutate: func(p *Packet) {
+				p.inputs[0][1].key[5] ^= 0xff
+			},
+			err: "the key of the path is not",
+		},
+		{
+			name: "missing redeem script",
+			mutate: func(p *Packet) {
+				p.inputs[1] = p.inputs[1][:2]
+			},
+			err: "no redeem script",
+		},
+		{
+			name: "wrong non witness utxo",
+			mutate: func(p *Packet) {
+				p.tx.inputs[2].hash[0] ^= 0xff
+			},
+			err: "not the spent transaction",
+		},
+		{
+			name: "no utxo",
+			mutate: func(p *Packet) {
+				p.inputs[0] = p.inputs[0][1:]
+			},
+			err: "no utxo",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, _ := buildPacket(t, master)
+			test.mutate(p)
+			_, err := p.Sign(master)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected error %s but actual %v", test.err, err)
+			}
+		})
+	}
+
+	child, _ := master.Derive(0)
+	p, _ := buildPacket(t, master)
+	if _, err := p.Sign(child); err == nil {
+		t.Errorf("expected an error for a child key")
+	}
+}
+
+func TestParse(t *testing.T) {
+	p, _ := buildPacket(t, buildMaster(t))
+	valid := p.Serialize()
+
+	unsigned := p.tx.serialize(nil)
+	withScript := p.tx.serialize([][]byte{{0x51}, nil, nil, nil})
+	global := func(tx []byte, extra ...byte) []byte {
+		var b bytes.Buffer
+		b.WriteString(_magic)
+		writeMap(&b, []field{{key: []byte{_globalUnsignedTx}, value: tx}})
+		b.Write(extra)
+		return b.Bytes()
+	}
+	duplicate := []byte(_magic)
+	duplicate = append(duplicate, 0x01, _globalUnsignedTx, 0x01, 0x00, 0x01, _globalUnsignedTx, 0x01, 0x00, 0x00)
+
+	tests := []struct {
+		name string
+		b    []byte
+		err  string
+	}{
+		{name: "magic", b: []byte("psbt"), err: "invalid magic"},
+		{name: "truncated", b: valid[:len(valid)-3], err: "truncated"},
+		{name: "trailing", b: append(append([]byte{}, valid...), 0x00), err: "trailing data"},
+		{name: "no transaction", b: []byte(_magic + "\x00"), err: "no unsigned transaction"},
+		{name: "duplicate key", b: duplicate, err: "duplicate key"},
+		{name: "script sig", b: global(withScript, make([]byte, 6)...), err: "has a script sig"},
+		{name: "missing maps", b: global(unsigned), err: "truncated"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(test.b)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected error %s but actual %v", test.err, err)
+			}
+		})
+	}
+
+	if _, err := ParseBase64("not base64!"); err == nil {
+		t.Errorf("expected an error for invalid base64")
+	}
+}
+
+func buildMaster(t *testing.T) *bip32.Key {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return master
+}
+
+// buildPacket returns a packet spending a p2wpkh, a p2sh wrapped p2wpkh, a
+// p2pkh output of the master and an output of a foreign key, with the paths
+// of the inputs, empty for the foreign one
+func buildPacket(t *testing.T, master *bip32.Key) (*Packet, []string) {
+	paths := []string{"m/84'/0'/0'/0/0", "m/49'/0'/0'/0/0", "m/44'/0'/0'/0/0", ""}
+	foreign, _ := hex.DecodeString("0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+
+	pubkeys := make([][]byte, len(paths))
+	prev := &transaction{version: 2, inputs: []txIn{{index: 0xffffffff}}}
+	var redeem []byte
+	for i, path := range paths {
+		pubkeys[i] = foreign
+		if path != "" {
+			key, err := master.DerivePath(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			pubkeys[i] = key.PublicKey()
+		}
+		pkh := bip32.Hash160(pubkeys[i])
+		script := append([]byte{0x00, 0x14}, pkh...)
+		switch i {
+		case 1:
+			redeem = script
+			script = append(append([]byte{0xa9, 0x14}, bip32.Hash160(redeem)...), 0x87)
+		case 2:
+			script = p2pkhScript(pkh)
+		}
+		prev.outputs = append(prev.outputs, txOut{value: uint64(10000 * (i + 1)), script: script})
+	}
+
+	p := &Packet{tx: &transaction{version: 2, locktime: 0}}
+	p.tx.outputs = []txOut{{value: 90000, script: append([]byte{0x00, 0x14}, make([]byte, 20)...)}}
+	p.global = []field{{key: []byte{_globalUnsignedTx}}}
+	txid := prev.txid()
+	for i, path := range paths {
+		p.tx.inputs = append(p.tx.inputs, txIn{hash: txid, index: uint32(i), sequence: 0xfffffffd})
+
+		var utxo field
+		if i == 2 {
+			utxo = field{key: []byte{_inputNonWitnessUTXO}, value: prev.serialize(nil)}
+		} else {
+			var b bytes.Buffer
+			writeOutput(&b, prev.outputs[i])
+			utxo = field{key: []byte{_inputWitnessUTXO}, value: b.Bytes()}
+		}
+		origin := []byte{0xde, 0xad, 0xbe, 0xef}
+		if path != "" {
+			origin = master.Fingerprint()
+			indexes, _ := bip32.ParsePath(path)
+			for _, index := range indexes {
+				origin = binary.LittleEndian.AppendUint32(origin, index)
+			}
+		}
+		fields := []field{utxo, {key: append([]byte{_inputBIP32}, pubkeys[i]...), value: origin}}
+		if i == 1 {
+			fields = append(fields, field{key: []byte{_inputRedeemScript}, value: redeem})
+		}
+		p.inputs = append(p.inputs, fields)
+	}
+	p.outputs = make([][]field, len(p.tx.outputs))
+	p.global[0].value = p.tx.serialize(nil)
+
+	parsed, err := Parse(p.Serialize())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return parsed, paths
+}
+
+// sighash recomputes the signature hash of the input independently of Sign
+func sighash(t *testing.T, p *Packet, i int, pubkey []byte) [32]byte {
+	u, err := p.utxo(i)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	pkh := bip32.Hash160(pubkey)
+	if isP2PKH(u.script) {
+		return p.tx.legacySighash(i, u.script, SighashAll)
+	}
+	return p.tx.segwitSighash(i, p2pkhScript(pkh), u.value, SighashAll)
+}
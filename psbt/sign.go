@@ -0,0 +1,223 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+)
+
+// SighashAll is the only sighash type signed
+const SighashAll = 0x01
+
+// utxo is the output an input spends
+type utxo struct {
+	script []byte
+	value  uint64
+	// witness tells whether the value comes from the witness utxo only
+	witness bool
+}
+
+// Sign signs the inputs with a bip32 derivation of the master fingerprint and
+// returns the number of signatures added, the inputs of other keys, already
+// signed or finalized are left as is
+func (p *Packet) Sign(master *bip32.Key) (int, error) {
+	if !master.IsPrivate() || master.Depth() != 0 {
+		return 0, errors.New("psbt: a private master key is required")
+	}
+	fingerprint := master.Fingerprint()
+
+	signed := 0
+	for i := range p.inputs {
+		if p.finalized(i) {
+			continue
+		}
+		for _, f := range p.inputs[i] {
+			if f.key[0] != _inputBIP32 {
+				continue
+			}
+			if len(f.key) != 34 || len(f.value) < 4 || len(f.value)%4 != 0 {
+				return signed, fmt.Errorf("psbt: input %d: invalid bip32 derivation", i)
+			}
+			if !bytes.Equal(f.value[:4], fingerprint) {
+				continue
+			}
+			ok, err := p.signInput(i, master, f.key[1:], f.value[4:])
+			if err != nil {
+				return signed, fmt.Errorf("psbt: input %d: %w", i, err)
+			}
+			if ok {
+				signed++
+			}
+		}
+	}
+	return signed, nil
+}
+
+// signInput derives the key of the path, checks it is the public key of the
+// derivation and signs the input when its script pays to the key
+func (p *Packet) signInput(i int, master *bip32.Key, pubkey, path []byte) (bool, error) {
+	key := master
+	var err error
+	for j := 0; j < len(path); j += 4 {
+		if key, err = key.Derive(binary.LittleEndian.Uint32(path[j:])); err != nil {
+			return false, err
+		}
+	}
+	if !bytes.Equal(key.PublicKey(), pubkey) {
+		return false, fmt.Errorf("the key of the path is not %x", pubkey)
+	}
+	if _, ok := p.lookup(i, append([]byte{_inputPartialSig}, pubkey...)); ok {
+		return false, nil
+	}
+
+	sighashType := uint32(SighashAll)
+	if v, ok := p.lookup(i, []byte{_inputSighashType}); ok {
+		if len(v) != 4 {
+			return false, errors.New("invalid sighash type")
+		}
+		if sighashType = binary.LittleEndian.Uint32(v); sighashType != SighashAll {
+			return false, fmt.Errorf("sighash type %d is not supported", sighashType)
+		}
+	}
+
+	u, err := p.utxo(i)
+	if err != nil {
+		return false, err
+	}
+	pkh := bip32.Hash160(pubkey)
+	script := u.script
+	if isP2SH(script) {
+		redeem, ok := p.lookup(i, []byte{_inputRedeemScript})
+		if !ok || !bytes.Equal(bip32.Hash160(redeem), script[2:22]) {
+			return false, errors.New("no redeem script of the p2sh output")
+		}
+		script = redeem
+	}
+
+	var hash [32]byte
+	switch {
+	case isP2WPKH(script) && bytes.Equal(script[2:], pkh):
+		hash = p.tx.segwitSighash(i, p2pkhScript(pkh), u.value, sighashType)
+	case isP2PKH(script) && bytes.Equal(script[3:23], pkh) && !isP2SH(u.script):
+		if u.witness {
+			return false, errors.New("p2pkh inputs need the non witness utxo")
+		}
+		hash = p.tx.legacySighash(i, script, sighashType)
+	default:
+		return false, nil
+	}
+
+	sig := ecdsa.Sign(secp256k1.PrivKeyFromBytes(key.PrivateKey()), hash[:])
+	p.inputs[i] = append(p.inputs[i], field{
+		key:   append([]byte{_inputPartialSig}, pubkey...),
+		value: append(sig.Serialize(), byte(sighashType)),
+	})
+	return true, nil
+}
+
+// utxo returns the output spent by the input, the non witness utxo is
+// checked against the outpoint
+func (p *Packet) utxo(i int) (utxo, error) {
+	in := p.tx.inputs[i]
+	if v, ok := p.lookup(i, []byte{_inputNonWitnessUTXO}); ok {
+		prev, err := parseTransaction(v, true)
+		if err != nil {
+			return utxo{}, err
+		}
+		if prev.txid() != in.hash || int(in.index) >= len(prev.outputs) {
+			return utxo{}, errors.New("the non witness utxo is not the spent transaction")
+		}
+		out := prev.outputs[in.index]
+		return utxo{script: out.script, value: out.value}, nil
+	}
+	if v, ok := p.lookup(i, []byte{_inputWitnessUTXO}); ok {
+		if len(v) < 9 {
+			return utxo{}, errTruncated
+		}
+		r := bytes.NewReader(v[8:])
+		script, err := readVarBytes(r)
+		if err != nil || r.Len() > 0 {
+			return utxo{}, errors.New("invalid witness utxo")
+		}
+		return utxo{script: script, value: binary.LittleEndian.Uint64(v), witness: true}, nil
+	}
+	return utxo{}, errors.New("no utxo")
+}
+
+// finalized tells whether the input has a final script sig or witness
+func (p *Packet) finalized(i int) bool {
+	_, sig := p.lookup(i, []byte{_inputFinalScriptSig})
+	_, witness := p.lookup(i, []byte{_inputFinalWitness})
+	return sig || witness
+}
+
+func (p *Packet) lookup(i int, key []byte) ([]byte, bool) {
+	for _, f := range p.inputs[i] {
+		if bytes.Equal(f.key, key) {
+			return f.value, true
+		}
+	}
+	return nil, false
+}
+
+// segwitSighash is the bip143 signature hash of the input
+func (tx *transaction) segwitSighash(i int, scriptCode []byte, value uint64, sighashType uint32) [32]byte {
+	var prevouts, sequences, outputs bytes.Buffer
+	for _, in := range tx.inputs {
+		prevouts.Write(in.hash[:])
+		writeUint32(&prevouts, in.index)
+		writeUint32(&sequences, in.sequence)
+	}
+	for _, out := range tx.outputs {
+		writeOutput(&outputs, out)
+	}
+	hashPrevouts := doubleSHA256(prevouts.Bytes())
+	hashSequence := doubleSHA256(sequences.Bytes())
+	hashOutputs := doubleSHA256(outputs.Bytes())
+
+	in := tx.inputs[i]
+	var b bytes.Buffer
+	writeUint32(&b, tx.version)
+	b.Write(hashPrevouts[:])
+	b.Write(hashSequence[:])
+	b.Write(in.hash[:])
+	writeUint32(&b, in.index)
+	writeVarBytes(&b, scriptCode)
+	writeUint64(&b, value)
+	writeUint32(&b, in.sequence)
+	b.Write(hashOutputs[:])
+	writeUint32(&b, tx.locktime)
+	writeUint32(&b, sighashType)
+	return doubleSHA256(b.Bytes())
+}
+
+// legacySighash is the pre segwit signature hash of the input
+func (tx *transaction) legacySighash(i int, script []byte, sighashType uint32) [32]byte {
+	scripts := make([][]byte, len(tx.inputs))
+	scripts[i] = script
+	b := bytes.NewBuffer(tx.serialize(scripts))
+	writeUint32(b, sighashType)
+	return doubleSHA256(b.Bytes())
+}
+
+func p2pkhScript(pkh []byte) []byte {
+	return append(append([]byte{0x76, 0xa9, 0x14}, pkh...), 0x88, 0xac)
+}
+
+func isP2WPKH(script []byte) bool {
+	return len(script) == 22 && script[0] == 0x00 && script[1] == 0x14
+}
+
+func isP2SH(script []byte) bool {
+	return len(script) == 23 && script[0] == 0xa9 && script[1] == 0x14 && script[22] == 0x87
+}
+
+func isP2PKH(script []byte) bool {
+	return len(script) == 25 && script[0] == 0x76 && script[1] == 0xa9 && script[2] == 0x14 && script[23] == 0x88 && script[24] == 0xac
+}
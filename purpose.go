@@ -0,0 +1,25 @@
+package nomnemonic
+
+import "fmt"
+
+// _purposeTag starts the kdf input of a purpose, the input of the credentials
+// alone starts with the identifier
+const _purposeTag = "\x00nomnemonic purpose v1\x00"
+
+// WithPurpose mixes the purpose, like "savings" or "company-treasury", into
+// the kdf input so the same credentials derive unrelated phrases per purpose.
+// The empty purpose derives the phrases of the credentials alone
+func WithPurpose(purpose string) Option {
+	return func(m *mnemonicer) {
+		m.purpose = purpose
+	}
+}
+
+// purposeInput prefixes the input with the tag and the length prefixed
+// purpose when there is one
+func (m *mnemonicer) purposeInput(input []byte) []byte {
+	if m.purpose == "" {
+		return input
+	}
+	return append([]byte(fmt.Sprintf("%s%d:%s", _purposeTag, len(m.purpose), m.purpose)), input...)
+}
@@ -0,0 +1,49 @@
+package nomnemonic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithPurpose(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+
+	phrases := map[string]string{}
+	for _, purpose := range []string{"", "savings", "company-treasury"} {
+		m, err := New(words, WithPurpose(purpose))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := m.GenerateDetailed("nomnemonic_test", "test12345678", "101938", 12)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Purpose != purpose {
+			t.Errorf("expected purpose %s in the envelope but actual %s", purpose, r.Purpose)
+		}
+		phrase := strings.Join(r.Words, " ")
+		for other, otherPhrase := range phrases {
+			if phrase == otherPhrase {
+				t.Errorf("expected unrelated phrases for '%s' and '%s' but both are %s", purpose, other, phrase)
+			}
+		}
+		phrases[purpose] = phrase
+	}
+
+	expected := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	if phrases[""] != expected {
+		t.Errorf("expected %s without a purpose but actual %s", expected, phrases[""])
+	}
+
+	m, _ := New(words, WithPurpose("savings"))
+	again, _ := m.Generate("nomnemonic_test", "test12345678", "101938", 12)
+	if strings.Join(again, " ") != phrases["savings"] {
+		t.Errorf("expected %s but actual %v", phrases["savings"], again)
+	}
+	if input := m.(*mnemonicer).purposeInput([]byte("id:pw|123456=12")); string(input) != "\x00nomnemonic purpose v1\x007:savingsid:pw|123456=12" {
+		t.Errorf("expected the tagged input but actual %q", input)
+	}
+}
@@ -0,0 +1,136 @@
+// Package qr renders mnemonics, seeds, descriptors and addresses as png or
+// svg qr codes, so every integration gets the same symbols from the same
+// library. The content is given as is, use EncodeSeedQR or
+// EncodeCompactSeedQR of the mnemonicer for SeedQR codes
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	// PNG renders a png image
+	PNG Format = "png"
+	// SVG renders an svg document with one path for the dark modules
+	SVG Format = "svg"
+
+	_defaultSize = 256
+)
+
+const (
+	// Low recovers 7% of the symbol, the smallest codes
+	Low Level = iota
+	// Medium recovers 15% of the symbol
+	Medium
+	// Quartile recovers 25% of the symbol
+	Quartile
+	// High recovers 30% of the symbol, for prints that may get damaged
+	High
+)
+
+type (
+	// Format is the image format of a code
+	Format string
+
+	// Level is the error correction level of a code
+	Level int
+
+	// Option configures Encode
+	Option func(o *options)
+
+	options struct {
+		level Level
+		size  int
+	}
+)
+
+// WithLevel sets the error correction level, Medium by default
+func WithLevel(level Level) Option {
+	return func(o *options) {
+		o.level = level
+	}
+}
+
+// WithSize sets the width and height in pixels, 256 by default. The png
+// modules are scaled to whole pixels so the code may be a bit smaller
+func WithSize(size int) Option {
+	return func(o *options) {
+		o.size = size
+	}
+}
+
+// Encode renders the data as a qr code in the format
+func Encode(data []byte, format Format, opts ...Option) ([]byte, error) {
+	o := options{level: Medium, size: _defaultSize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.size < 1 {
+		return nil, errors.New("size must be positive")
+	}
+	code, err := newCode(data, o.level)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case PNG:
+		return code.PNG(o.size)
+	case SVG:
+		return svg(code.Bitmap(), o.size), nil
+	default:
+		return nil, fmt.Errorf("unsupported format %s", format)
+	}
+}
+
+// Bitmap returns the modules of the code with the quiet zone, true is dark,
+// for the renderers of the integrations
+func Bitmap(data []byte, level Level) ([][]bool, error) {
+	code, err := newCode(data, level)
+	if err != nil {
+		return nil, err
+	}
+	return code.Bitmap(), nil
+}
+
+func newCode(data []byte, level Level) (*qrcode.QRCode, error) {
+	if len(data) == 0 {
+		return nil, errors.New("data is empty")
+	}
+	var l qrcode.RecoveryLevel
+	switch level {
+	case Low:
+		l = qrcode.Low
+	case Medium:
+		l = qrcode.Medium
+	case Quartile:
+		l = qrcode.High
+	case High:
+		l = qrcode.Highest
+	default:
+		return nil, fmt.Errorf("unsupported level %d", level)
+	}
+	return qrcode.New(string(data), l)
+}
+
+// svg draws each dark module as a unit square of one path, crisp edges keep
+// the scaled modules from blurring into each other
+func svg(bitmap [][]bool, size int) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, len(bitmap), len(bitmap))
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, len(bitmap), len(bitmap))
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&b, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	b.WriteString("\n")
+	return []byte(b.String())
+}
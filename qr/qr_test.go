@@ -0,0 +1,82 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestEncodePNG(t *testing.T) {
+	data := []byte("bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu")
+	content, err := Encode(data, PNG, WithSize(300))
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("expected a png but actual %v", err)
+	}
+	if size := img.Bounds().Dx(); size < 200 || size > 300 {
+		t.Errorf("expected about 300 pixels but actual %d", size)
+	}
+}
+
+func TestEncodeSVG(t *testing.T) {
+	// version 1 with the 4 modules quiet zone
+	content, err := Encode([]byte("01234567"), SVG, WithLevel(Low), WithSize(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	svg := string(content)
+	if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="100" height="100" viewBox="0 0 29 29"`) {
+		t.Errorf("expected a 29 modules svg but actual %s", svg[:80])
+	}
+	// the top left finder pattern starts after the quiet zone
+	if !strings.Contains(svg, `d="M4 4h1v1h-1z`) {
+		t.Error("expected the finder pattern at 4,4")
+	}
+}
+
+func TestBitmapLevel(t *testing.T) {
+	data := []byte("wpkh([73c5da0a/84'/0'/0']xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V/0/*)")
+
+	var sizes []int
+	for _, level := range []Level{Low, Medium, Quartile, High} {
+		bitmap, err := Bitmap(data, level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sizes = append(sizes, len(bitmap))
+	}
+	for i := 1; i < len(sizes); i++ {
+		if sizes[i] < sizes[i-1] {
+			t.Errorf("expected larger codes for higher levels but actual %v", sizes)
+		}
+	}
+	if sizes[0] == sizes[3] {
+		t.Errorf("expected the high level code to be larger but actual %v", sizes)
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		format Format
+		opts   []Option
+		err    string
+	}{
+		{name: "empty", format: PNG, err: "data is empty"},
+		{name: "format", data: []byte("x"), format: "gif", err: "unsupported format gif"},
+		{name: "level", data: []byte("x"), format: SVG, opts: []Option{WithLevel(7)}, err: "unsupported level 7"},
+		{name: "size", data: []byte("x"), format: PNG, opts: []Option{WithSize(0)}, err: "size must be positive"},
+	}
+
+	for _, test := range tests {
+		_, err := Encode(test.data, test.format, test.opts...)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%s: expected err '%s' but actual %v", test.name, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package nomnemonic
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+type (
+	// Result is a generated or inspected mnemonic handed to the encoders,
+	// binary values are raw bytes and the fields a command does not compute
+	// are empty. Its json is the schema of the CLI --output json:
+	//
+	//	{
+	//	  "words": ["abandon", ...],
+	//	  "entropy": "<hex>",
+	//	  "seed": "<hex>",
+	//	  "fingerprint": "<hex>",
+	//	  "algorithm": {"version": "...", "algorithm_version": "...", "language": "english",
+	//	    "kdf_hash": "<hex>", "wordlist_checksum": "<hex>", "features": [...],
+	//	    "purpose": "..."}
+	//	}
+	//
+	// the empty fields are left out, fields are only ever added to it. The
+	// algorithm fields are the envelope Check tells from whether the current
+	// build regenerates the words
+	Result struct {
+		Words            []string
+		Entropy          []byte
+		Seed             []byte
+		Fingerprint      []byte
+		Language         string
+		Version          string
+		AlgorithmVersion string
+		// KDFHash identifies the kdf parameters of the algorithm version
+		KDFHash string
+		// WordlistChecksum is the WordlistChecksum of the list of the words
+		WordlistChecksum string
+		// Features are the enabled features changing the phrases
+		Features []string
+		// Purpose is the purpose of WithPurpose the words were generated for
+		Purpose string
+	}
+
+	resultJSON struct {
+		Words       []string      `json:"words,omitempty"`
+		Entropy     string        `json:"entropy,omitempty"`
+		Seed        string        `json:"seed,omitempty"`
+		Fingerprint string        `json:"fingerprint,omitempty"`
+		Algorithm   algorithmJSON `json:"algorithm"`
+	}
+
+	algorithmJSON struct {
+		Version          string   `json:"version"`
+		AlgorithmVersion string   `json:"algorithm_version"`
+		Language         string   `json:"language"`
+		KDFHash          string   `json:"kdf_hash,omitempty"`
+		WordlistChecksum string   `json:"wordlist_checksum,omitempty"`
+		Features         []string `json:"features,omitempty"`
+		Purpose          string   `json:"purpose,omitempty"`
+	}
+)
+
+// Redacted returns the result without the words, the entropy and the seed,
+// what is left identifies the wallet without giving access to it
+func (r Result) Redacted() Result {
+	r.Words, r.Entropy, r.Seed = nil, nil, nil
+	return r
+}
+
+// MarshalJSON encodes the result in the documented schema
+func (r Result) MarshalJSON() ([]byte, error) {
+	return json.Marshal(resultJSON{
+		Words:       r.Words,
+		Entropy:     hex.EncodeToString(r.Entropy),
+		Seed:        hex.EncodeToString(r.Seed),
+		Fingerprint: hex.EncodeToString(r.Fingerprint),
+		Algorithm: algorithmJSON{
+			Version:          r.Version,
+			AlgorithmVersion: r.AlgorithmVersion,
+			Language:         r.Language,
+			KDFHash:          r.KDFHash,
+			WordlistChecksum: r.WordlistChecksum,
+			Features:         r.Features,
+			Purpose:          r.Purpose,
+		},
+	})
+}
+
+// UnmarshalJSON decodes the result from the documented schema, unknown fields
+// are ignored so older readers accept newer results
+func (r *Result) UnmarshalJSON(b []byte) error {
+	var v resultJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	entropy, err := hex.DecodeString(v.Entropy)
+	if err != nil {
+		return errors.New("invalid result entropy")
+	}
+	seed, err := hex.DecodeString(v.Seed)
+	if err != nil {
+		return errors.New("invalid result seed")
+	}
+	fingerprint, err := hex.DecodeString(v.Fingerprint)
+	if err != nil {
+		return errors.New("invalid result fingerprint")
+	}
+
+	*r = Result{
+		Words:            v.Words,
+		Language:         v.Algorithm.Language,
+		Version:          v.Algorithm.Version,
+		AlgorithmVersion: v.Algorithm.AlgorithmVersion,
+		KDFHash:          v.Algorithm.KDFHash,
+		WordlistChecksum: v.Algorithm.WordlistChecksum,
+		Features:         v.Algorithm.Features,
+		Purpose:          v.Algorithm.Purpose,
+	}
+	if len(entropy) > 0 {
+		r.Entropy = entropy
+	}
+	if len(seed) > 0 {
+		r.Seed = seed
+	}
+	if len(fingerprint) > 0 {
+		r.Fingerprint = fingerprint
+	}
+	return nil
+}
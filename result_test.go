@@ -0,0 +1,78 @@
+package nomnemonic
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResultJSON(t *testing.T) {
+	r := Result{
+		Words:            strings.Fields("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"),
+		Entropy:          make([]byte, 16),
+		Seed:             []byte{0xc5, 0x52},
+		Fingerprint:      []byte{0x73, 0xc5, 0xda, 0x0a},
+		Language:         "english",
+		Version:          "1.0.0",
+		AlgorithmVersion: "1",
+	}
+
+	tests := []struct {
+		name     string
+		r        Result
+		expected string
+	}{
+		{
+			name:     "full",
+			r:        r,
+			expected: `{"words":["abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","abandon","about"],"entropy":"00000000000000000000000000000000","seed":"c552","fingerprint":"73c5da0a","algorithm":{"version":"1.0.0","algorithm_version":"1","language":"english"}}`,
+		},
+		{
+			name:     "redacted",
+			r:        r.Redacted(),
+			expected: `{"fingerprint":"73c5da0a","algorithm":{"version":"1.0.0","algorithm_version":"1","language":"english"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		b, err := json.Marshal(test.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != test.expected {
+			t.Errorf("%s: expected %s but actual %s", test.name, test.expected, b)
+		}
+
+		var actual Result
+		if err := json.Unmarshal(b, &actual); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(actual, test.r) {
+			t.Errorf("%s: expected %+v but actual %+v", test.name, test.r, actual)
+		}
+	}
+
+	if r.Words == nil || r.Seed == nil {
+		t.Error("expected Redacted to leave the result as is")
+	}
+}
+
+func TestResultUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		json string
+		err  string
+	}{
+		{json: `{"entropy":"zz"}`, err: "invalid result entropy"},
+		{json: `{"seed":"0"}`, err: "invalid result seed"},
+		{json: `{"fingerprint":"x"}`, err: "invalid result fingerprint"},
+	}
+
+	for _, test := range tests {
+		var r Result
+		err := json.Unmarshal([]byte(test.json), &r)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%s: expected err '%s' but actual %v", test.json, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Package rfc1751 encodes keys as rfc-1751 (S/KEY) words, 6 short words for
+// each 64 bits with 2 parity bits
+package rfc1751
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	_wordBits   = 11
+	_blockSize  = 8 // 64 bits
+	_blockWords = 6 // 64 bits and 2 parity bits
+)
+
+// the S/KEY standard form accepts digits mistaken for letters
+var _replacer = strings.NewReplacer("1", "L", "0", "O", "5", "S")
+
+type (
+	encoder struct {
+		words []string
+		dict  map[string]int
+	}
+
+	// Encoder encodes and decodes keys as rfc1751 words
+	Encoder interface {
+		Encode(key []byte) ([]string, error)
+		Decode(words []string) ([]byte, error)
+	}
+)
+
+// New inits a new rfc1751 encoder with the 2048 words rfc1751 dictionary
+func New(words []string) (Encoder, error) {
+	if len(words) != 1<<_wordBits {
+		return nil, errors.New("rfc1751 is based on 2048 words")
+	}
+	dict := make(map[string]int, len(words))
+	for i, w := range words {
+		dict[strings.ToUpper(w)] = i
+	}
+	return &encoder{
+		words: words,
+		dict:  dict,
+	}, nil
+}
+
+// Encode encodes the key, a multiple of 8 bytes, as 6 words for each 8 bytes
+func (e *encoder) Encode(key []byte) ([]string, error) {
+	if len(key) == 0 || len(key)%_blockSize != 0 {
+		return nil, fmt.Errorf("key must be a multiple of %d bytes", _blockSize)
+	}
+
+	words := make([]string, 0, len(key)/_blockSize*_blockWords)
+	for i := 0; i < len(key); i += _blockSize {
+		block := make([]byte, _blockSize+1)
+		copy(block, key[i:i+_blockSize])
+		block[_blockSize] = parity(block[:_blockSize]) << 6
+		for j := 0; j < _blockWords; j++ {
+			words = append(words, e.words[extract(block, j*_wordBits, _wordBits)])
+		}
+	}
+	return words, nil
+}
+
+// Decode decodes the words back to the key verifying the parity of each 6
+// words, the words are case insensitive
+func (e *encoder) Decode(words []string) ([]byte, error) {
+	if len(words) == 0 || len(words)%_blockWords != 0 {
+		return nil, fmt.Errorf("words must be a multiple of %d", _blockWords)
+	}
+
+	key := make([]byte, 0, len(words)/_blockWords*_blockSize)
+	for i := 0; i < len(words); i += _blockWords {
+		block := make([]byte, _blockSize+1)
+		for j, w := range words[i : i+_blockWords] {
+			index, ok := e.dict[_replacer.Replace(strings.ToUpper(w))]
+			if !ok {
+				return nil, fmt.Errorf("unrecognized word %s", w)
+			}
+			insert(block, index, j*_wordBits, _wordBits)
+		}
+		if parity(block[:_blockSize]) != block[_blockSize]>>6 {
+			return nil, fmt.Errorf("invalid parity of words %d-%d", i+1, i+_blockWords)
+		}
+		key = append(key, block[:_blockSize]...)
+	}
+	return key, nil
+}
+
+// parity is the sum of the 2 bits pairs of the block mod 4
+func parity(block []byte) byte {
+	var p byte
+	for _, b := range block {
+		p += b>>6 + b>>4&3 + b>>2&3 + b&3
+	}
+	return p & 3
+}
+
+// extract reads length bits from the start bit offset, big endian
+func extract(b []byte, start, length int) int {
+	v := 0
+	for i := start; i < start+length; i++ {
+		v = v<<1 | int(b[i/8]>>(7-i%8)&1)
+	}
+	return v
+}
+
+// insert writes the length bits of v at the start bit offset, big endian
+func insert(b []byte, v, start, length int) {
+	for i := 0; i < length; i++ {
+		if v>>(length-1-i)&1 == 1 {
+			bit := start + i
+			b[bit/8] |= 1 << (7 - bit%8)
+		}
+	}
+}
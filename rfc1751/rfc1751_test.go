@@ -0,0 +1,137 @@
+package rfc1751
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New([]string{})
+	if err == nil || err.Error() != "rfc1751 is based on 2048 words" {
+		t.Errorf("expected word list size err but actual %v", err)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	words := buildWords()
+	e, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	tests := []struct {
+		key     string
+		indexes []int
+		err     string
+	}{
+		{
+			key:     "0000000000000000",
+			indexes: []int{0, 0, 0, 0, 0, 0},
+		},
+		{
+			// 64 set bits sum to parity 0, the last word is 9 set bits and
+			// the 2 parity bits
+			key:     "ffffffffffffffff",
+			indexes: []int{2047, 2047, 2047, 2047, 2047, 2044},
+		},
+		{
+			// a single low bit gives parity 1
+			key:     "0000000000000001",
+			indexes: []int{0, 0, 0, 0, 0, 1<<2 | 1},
+		},
+		{
+			key: "00000000",
+			err: "key must be a multiple of 8 bytes",
+		},
+	}
+
+	for _, test := range tests {
+		key, _ := hex.DecodeString(test.key)
+		actual, err := e.Encode(key)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' but actual %v", test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		expected := make([]string, len(test.indexes))
+		for i, index := range test.indexes {
+			expected[i] = words[index]
+		}
+		if strings.Join(actual, " ") != strings.Join(expected, " ") {
+			t.Errorf("expected '%s' but actual '%s'", strings.Join(expected, " "), strings.Join(actual, " "))
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	e, err := New(buildWords())
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	key, _ := hex.DecodeString("ccac2aed591056be4f90fd441c534766")
+	words, err := e.Encode(key)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if len(words) != 12 {
+		t.Errorf("expected 12 words but actual %d", len(words))
+	}
+
+	lower := make([]string, len(words))
+	for i, w := range words {
+		lower[i] = strings.ToLower(w)
+	}
+	actual, err := e.Decode(lower)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if !bytes.Equal(actual, key) {
+		t.Errorf("expected key %x but actual %x", key, actual)
+	}
+
+	// flipping the last word bits breaks the parity of the second block
+	broken := append([]string{}, words...)
+	broken[11] = flipLastBit(broken[11])
+	_, err = e.Decode(broken)
+	if err == nil || err.Error() != "invalid parity of words 7-12" {
+		t.Errorf("expected parity err but actual %v", err)
+	}
+
+	_, err = e.Decode(words[:5])
+	if err == nil || err.Error() != "words must be a multiple of 6" {
+		t.Errorf("expected word count err but actual %v", err)
+	}
+
+	broken[11] = "NOPE"
+	_, err = e.Decode(broken)
+	if err == nil || err.Error() != "unrecognized word NOPE" {
+		t.Errorf("expected unrecognized word err but actual %v", err)
+	}
+}
+
+// buildWords returns 2048 distinct letter only placeholders for the rfc1751
+// dictionary
+func buildWords() []string {
+	words := make([]string, 2048)
+	for i := range words {
+		words[i] = string([]byte{'A' + byte(i/676), 'A' + byte(i/26%26), 'A' + byte(i%26)})
+	}
+	return words
+}
+
+func flipLastBit(w string) string {
+	for i, word := range buildWords() {
+		if word == w {
+			return buildWords()[i^1]
+		}
+	}
+	return w
+}
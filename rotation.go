@@ -0,0 +1,82 @@
+package nomnemonic
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// _epochTag starts the kdf input of a rotation epoch
+const _epochTag = "\x00nomnemonic epoch v1\x00"
+
+// Rotation is the phrase of a rotation epoch, the epoch is the number of
+// whole periods since the unix epoch and lasts from Start to End
+type Rotation struct {
+	Epoch int64
+	Start time.Time
+	End   time.Time
+	Words []string
+}
+
+// GenerateForPeriod generates the phrase of the rotation epoch of t, the
+// period and the epoch are mixed into the kdf input so every epoch has an
+// unrelated phrase of the same inputs
+func (m *mnemonicer) GenerateForPeriod(in Inputs, t time.Time, period time.Duration) (Rotation, error) {
+	epoch, err := rotationEpoch(t, period)
+	if err != nil {
+		return Rotation{}, err
+	}
+	return m.generateEpoch(in, epoch, period)
+}
+
+// RotationWindow generates the phrases of the previous, the current and the
+// next epochs of t, so a rotation can accept the outgoing phrase and set up
+// the incoming one
+func (m *mnemonicer) RotationWindow(in Inputs, t time.Time, period time.Duration) ([]Rotation, error) {
+	epoch, err := rotationEpoch(t, period)
+	if err != nil {
+		return nil, err
+	}
+	window := make([]Rotation, 0, 3)
+	for e := epoch - 1; e <= epoch+1; e++ {
+		r, err := m.generateEpoch(in, e, period)
+		if err != nil {
+			return nil, err
+		}
+		window = append(window, r)
+	}
+	return window, nil
+}
+
+func (m *mnemonicer) generateEpoch(in Inputs, epoch int64, period time.Duration) (Rotation, error) {
+	seconds := int64(period / time.Second)
+	tag := fmt.Sprintf("%s%d:%d\x00", _epochTag, seconds, epoch)
+	entropy, err := m.deriveEntropy(in.Identifier, in.Password, in.Passcode, in.Size, tag)
+	if err != nil {
+		return Rotation{}, err
+	}
+	words, err := m.generatedWords(entropy)
+	if err != nil {
+		return Rotation{}, err
+	}
+	return Rotation{
+		Epoch: epoch,
+		Start: time.Unix(epoch*seconds, 0).UTC(),
+		End:   time.Unix((epoch+1)*seconds, 0).UTC(),
+		Words: words,
+	}, nil
+}
+
+// rotationEpoch returns the number of whole periods from the unix epoch to t,
+// the period is whole seconds so the epochs do not depend on the time zone
+func rotationEpoch(t time.Time, period time.Duration) (int64, error) {
+	if period < time.Second || period%time.Second != 0 {
+		return 0, errors.New("period must be a positive number of seconds")
+	}
+	seconds := int64(period / time.Second)
+	epoch := t.Unix() / seconds
+	if t.Unix()%seconds < 0 {
+		epoch--
+	}
+	return epoch, nil
+}
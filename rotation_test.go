@@ -0,0 +1,79 @@
+package nomnemonic
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotationEpoch(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		period   time.Duration
+		expected int64
+		err      string
+	}{
+		{t: time.Unix(0, 0), period: time.Hour, expected: 0},
+		{t: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), period: 24 * time.Hour, expected: 19783},
+		{t: time.Date(2024, 3, 1, 23, 59, 59, 0, time.FixedZone("", -5*3600)), period: 24 * time.Hour, expected: 19784},
+		{t: time.Unix(-1, 0), period: time.Hour, expected: -1},
+		{t: time.Unix(0, 0), period: time.Millisecond, err: "period must be a positive number of seconds"},
+		{t: time.Unix(0, 0), period: 1500 * time.Millisecond, err: "period must be a positive number of seconds"},
+	}
+
+	for _, test := range tests {
+		epoch, err := rotationEpoch(test.t, test.period)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s: expected err '%s' but actual %v", test.t, test.err, err)
+			}
+			continue
+		}
+		if err != nil || epoch != test.expected {
+			t.Errorf("%s: expected epoch %d but actual %d %v", test.t, test.expected, epoch, err)
+		}
+	}
+}
+
+func TestGenerateForPeriod(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := Inputs{Identifier: "nomnemonic_test", Password: "test12345678", Passcode: "101938", Size: 12}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	month := 30 * 24 * time.Hour
+
+	r, err := m.GenerateForPeriod(in, now, month)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Epoch != 659 || !r.Start.Before(now) || !r.End.After(now) || r.End.Sub(r.Start) != month {
+		t.Errorf("expected epoch 659 around %s but actual %d from %s to %s", now, r.Epoch, r.Start, r.End)
+	}
+	if phrase := strings.Join(r.Words, " "); phrase == "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby" {
+		t.Error("expected the epoch phrase to differ from the phrase of the inputs alone")
+	}
+
+	window, err := m.RotationWindow(in, r.Start, month)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(window) != 3 || window[0].Epoch != 658 || window[1].Epoch != 659 || window[2].Epoch != 660 {
+		t.Fatalf("expected epochs 658, 659 and 660 but actual %+v", window)
+	}
+	if strings.Join(window[1].Words, " ") != strings.Join(r.Words, " ") {
+		t.Errorf("expected the current phrase %v but actual %v", r.Words, window[1].Words)
+	}
+	if strings.Join(window[0].Words, " ") == strings.Join(window[2].Words, " ") || !window[0].End.Equal(window[1].Start) {
+		t.Errorf("expected consecutive epochs with unrelated phrases but actual %+v", window)
+	}
+
+	if _, err := m.GenerateForPeriod(Inputs{Identifier: "a", Password: "test12345678", Passcode: "101938", Size: 12}, now, month); err == nil {
+		t.Error("expected an error for a short identifier")
+	}
+}
@@ -0,0 +1,71 @@
+package nomnemonic
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const _seedQRDigits = 4
+
+// EncodeSeedQR encodes the words as the SeedSigner SeedQR digit string, the 4
+// digits zero padded index of each word, meant for a numeric mode qr code
+func (m *mnemonicer) EncodeSeedQR(words []string) (string, error) {
+	ok, err := m.IsValid(words)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", ErrInvalidChecksum
+	}
+
+	var b strings.Builder
+	for _, w := range m.expand(words) {
+		fmt.Fprintf(&b, "%0*d", _seedQRDigits, m.index(w))
+	}
+	return b.String(), nil
+}
+
+// DecodeSeedQR decodes the SeedQR digit string back to the words
+func (m *mnemonicer) DecodeSeedQR(digits string) ([]string, error) {
+	if len(digits)%_seedQRDigits != 0 {
+		return nil, fmt.Errorf("seedqr must be %d digits per word", _seedQRDigits)
+	}
+
+	words := make([]string, 0, len(digits)/_seedQRDigits)
+	for i := 0; i < len(digits); i += _seedQRDigits {
+		index, err := strconv.Atoi(digits[i : i+_seedQRDigits])
+		if err != nil || index < 0 || index >= len(m.words) {
+			return nil, errorf(ErrUnknownWord, "invalid seedqr word index %s", digits[i:i+_seedQRDigits])
+		}
+		words = append(words, m.words[index])
+	}
+
+	ok, err := m.IsValid(words)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidChecksum
+	}
+	return words, nil
+}
+
+// EncodeCompactSeedQR encodes the 12 or 24 words as the SeedSigner
+// CompactSeedQR bytes, the entropy without the checksum meant for a byte mode
+// qr code
+func (m *mnemonicer) EncodeCompactSeedQR(words []string) ([]byte, error) {
+	if len(words) != 12 && len(words) != 24 {
+		return nil, errors.New("compact seedqr supports 12 and 24 words")
+	}
+	return m.CalculateEntropy(words)
+}
+
+// DecodeCompactSeedQR decodes the CompactSeedQR bytes back to the words
+func (m *mnemonicer) DecodeCompactSeedQR(data []byte) ([]string, error) {
+	if len(data) != 16 && len(data) != 32 {
+		return nil, errors.New("compact seedqr must be 16 or 32 bytes")
+	}
+	return m.entropyToWords(data), nil
+}
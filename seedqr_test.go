@@ -0,0 +1,108 @@
+package nomnemonic
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSeedQR(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	tests := []struct {
+		sentence string
+		digits   string
+	}{
+		{
+			sentence: "attack pizza motion avocado network gather crop fresh patrol unusual wild holiday candy pony ranch winter theme error hybrid van cereal salon goddess expire",
+			digits:   "011513251154012711900771041507421289190620080870026613431420201617920614089619290300152408010643",
+		},
+		{
+			sentence: "forum undo fragile fade shy sign arrest garment culture tube off merit",
+			digits:   "073318950739065415961602009907670428187212261116",
+		},
+	}
+
+	for _, test := range tests {
+		digits, err := m.EncodeSeedQR(strings.Fields(test.sentence))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if digits != test.digits {
+			t.Errorf("expected seedqr '%s' but actual '%s'", test.digits, digits)
+		}
+
+		decoded, err := m.DecodeSeedQR(digits)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if actual := strings.Join(decoded, " "); actual != test.sentence {
+			t.Errorf("expected '%s' but actual '%s'", test.sentence, actual)
+		}
+	}
+
+	errs := []struct {
+		digits string
+		err    error
+	}{
+		{digits: "07331895073", err: errors.New("seedqr must be 4 digits per word")},
+		{digits: "0733189507392048", err: errors.New("invalid seedqr word index 2048")},
+		{digits: "073318950739065415961602009907670428187212261117", err: errors.New("invalid checksum")},
+	}
+	for _, test := range errs {
+		_, err := m.DecodeSeedQR(test.digits)
+		if err == nil || err.Error() != test.err.Error() {
+			t.Errorf("expected err '%s' but actual %v", test.err.Error(), err)
+		}
+	}
+}
+
+func TestCompactSeedQR(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	sentence := "forum undo fragile fade shy sign arrest garment culture tube off merit"
+	data, err := m.EncodeCompactSeedQR(strings.Fields(sentence))
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	expected := "5bbd9d71a8ec7990831aff359d426545"
+	if actual := hex.EncodeToString(data); actual != expected {
+		t.Errorf("expected compact seedqr %s but actual %s", expected, actual)
+	}
+
+	decoded, err := m.DecodeCompactSeedQR(data)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if actual := strings.Join(decoded, " "); actual != sentence {
+		t.Errorf("expected '%s' but actual '%s'", sentence, actual)
+	}
+
+	_, err = m.EncodeCompactSeedQR(strings.Fields("edge defense waste choose enrich upon flee junk siren film clown finish luggage leader kid"))
+	if err == nil || err.Error() != "compact seedqr supports 12 and 24 words" {
+		t.Errorf("expected size err but actual %v", err)
+	}
+	_, err = m.DecodeCompactSeedQR(data[:15])
+	if err == nil || err.Error() != "compact seedqr must be 16 or 32 bytes" {
+		t.Errorf("expected size err but actual %v", err)
+	}
+}
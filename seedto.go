@@ -0,0 +1,49 @@
+package nomnemonic
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// GenerateSeedTo writes the 64 bytes seed of GenerateSeed to w, an mlocked
+// buffer or a file descriptor of the caller, without returning a copy of it.
+// The seed buffer is zeroed after the write, the go runtime may still leave
+// copies of it and of the pbkdf2 state on the heap until they are reused
+func (m *mnemonicer) GenerateSeedTo(w io.Writer, sentence, passphrase string, mode ...SeedMode) error {
+	var password []byte
+	switch {
+	case len(mode) == 0 || mode[0] == SeedModeBIP39:
+		password = []byte(sentence)
+	case mode[0] == SeedModeSubstrate:
+		entropy, err := m.CalculateEntropy(strings.Fields(sentence))
+		if err != nil {
+			return err
+		}
+		password = entropy
+	default:
+		return fmt.Errorf("unsupported seed mode: %d", mode[0])
+	}
+	defer wipe(password)
+
+	seed := pbkdf2.Key(password, []byte(_saltPrefixMnemonic+passphrase), 2048, sha512.Size, sha512.New)
+	defer wipe(seed)
+	n, err := w.Write(seed)
+	if err == nil && n != len(seed) {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		return fmt.Errorf("writing the seed: %w", err)
+	}
+	return nil
+}
+
+// wipe zeroes the bytes
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
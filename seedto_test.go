@@ -0,0 +1,72 @@
+package nomnemonic
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// retainingWriter keeps the buffer it is given, not a copy of it
+type retainingWriter struct {
+	b []byte
+}
+
+func (w *retainingWriter) Write(b []byte) (int, error) {
+	w.b = b
+	return len(b), nil
+}
+
+type limitedWriter struct {
+	n int
+}
+
+func (w *limitedWriter) Write(b []byte) (int, error) {
+	if len(b) > w.n {
+		return w.n, errors.New("no space left")
+	}
+	return len(b), nil
+}
+
+func TestGenerateSeedTo(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sentence := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	for _, mode := range []SeedMode{SeedModeBIP39, SeedModeSubstrate} {
+		expected, err := m.GenerateSeed(sentence, "TREZOR", mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := m.GenerateSeedTo(&buf, sentence, "TREZOR", mode); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), expected) {
+			t.Errorf("mode %d: expected %x but actual %x", mode, expected, buf.Bytes())
+		}
+	}
+
+	retained := &retainingWriter{}
+	if err := m.GenerateSeedTo(retained, sentence, ""); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(retained.b, make([]byte, 64)) {
+		t.Errorf("expected the seed buffer to be zeroed after the write but actual %x", retained.b)
+	}
+
+	if err := m.GenerateSeedTo(&limitedWriter{n: 32}, sentence, ""); err == nil || err.Error() != "writing the seed: no space left" {
+		t.Errorf("expected the write error but actual %v", err)
+	}
+	if err := m.GenerateSeedTo(&bytes.Buffer{}, sentence, "", SeedMode(9)); err == nil || err.Error() != "unsupported seed mode: 9" {
+		t.Errorf("expected the mode error but actual %v", err)
+	}
+	if err := m.GenerateSeedTo(&bytes.Buffer{}, "abandon abandon", "", SeedModeSubstrate); !errors.Is(err, ErrUnsupportedSize) {
+		t.Errorf("expected ErrUnsupportedSize but actual %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package nomnemonic
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// ErrSelfCheck is returned by the generations of WithSelfCheck when the words
+// do not decode back to the derived entropy or their seeds differ
+var ErrSelfCheck = errors.New("self check failed")
+
+// WithSelfCheck decodes the generated words back to their entropy and derives
+// their seed twice before returning them, a guard against miscompiles or
+// memory corruption on unreliable hardware. It costs two bip39 seed
+// derivations per phrase, nothing next to the kdf
+func WithSelfCheck() Option {
+	return func(m *mnemonicer) {
+		m.selfCheck = true
+	}
+}
+
+// generatedWords encodes the derived entropy and self checks the words when
+// enabled
+func (m *mnemonicer) generatedWords(entropy []byte) ([]string, error) {
+	words := m.entropyToWords(entropy)
+	if !m.selfCheck {
+		return words, nil
+	}
+	if err := m.checkWords(entropy, words); err != nil {
+		m.log("self check failed", Field{Key: "size", Value: len(words)})
+		return nil, err
+	}
+	return words, nil
+}
+
+// checkWords checks the words decode to the entropy with a valid checksum and
+// the seeds of the words and of the words encoded again from the decoded
+// entropy are equal
+func (m *mnemonicer) checkWords(entropy []byte, words []string) error {
+	decoded, err := m.CalculateEntropy(words)
+	if err != nil || !bytes.Equal(decoded, entropy) {
+		return errorf(ErrSelfCheck, "self check failed: the words do not decode to the derived entropy")
+	}
+	seed, err := m.GenerateSeed(strings.Join(words, " "), "")
+	if err != nil {
+		return errorf(ErrSelfCheck, "self check failed: %s", err)
+	}
+	again, err := m.GenerateSeed(strings.Join(m.entropyToWords(decoded), " "), "")
+	if err != nil || !bytes.Equal(seed, again) {
+		return errorf(ErrSelfCheck, "self check failed: the seed derivations differ")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package nomnemonic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithSelfCheck(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(words, WithSelfCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := m.Generate("nomnemonic_test", "test12345678", "101938", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	if strings.Join(actual, " ") != expected {
+		t.Errorf("expected %s but actual %s", expected, strings.Join(actual, " "))
+	}
+
+	mm := m.(*mnemonicer)
+	entropy, _ := mm.CalculateEntropy(actual)
+	if err := mm.checkWords(entropy, actual); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	corrupted := append([]byte{}, entropy...)
+	corrupted[3] ^= 0x10
+	if err := mm.checkWords(corrupted, actual); !errors.Is(err, ErrSelfCheck) {
+		t.Errorf("expected ErrSelfCheck but actual %v", err)
+	}
+	if err := mm.checkWords(entropy, append(actual[:11:11], "abandon")); !errors.Is(err, ErrSelfCheck) {
+		t.Errorf("expected ErrSelfCheck for a changed word but actual %v", err)
+	}
+}
+
+// TestSelfCheckPaths runs the generating paths on a faulty word list whose
+// last word repeats the first word of their phrase, the words decode to other
+// entropy and only a self checking path notices
+func TestSelfCheckPaths(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(InsecureFastKDFEnv, "1")
+	in := Inputs{Identifier: "nomnemonic_test", Password: "test12345678", Passcode: "101938", Size: 12}
+	// a stand in for a newer version, this build has no other version
+	_algorithms["test"] = _algorithms[VersionAlgorithm]
+	defer delete(_algorithms, "test")
+
+	paths := map[string]func(m Mnemonicer) ([]string, error){
+		"FromPassphrase": func(m Mnemonicer) ([]string, error) {
+			return m.FromPassphrase("correct horse battery staple", 12)
+		},
+		"Migrate": func(m Mnemonicer) ([]string, error) {
+			g, err := m.Migrate(in, VersionAlgorithm, "test")
+			return g.To.Words, err
+		},
+	}
+	for name, generate := range paths {
+		m, err := New(words, WithInsecureFastKDF())
+		if err != nil {
+			t.Fatal(err)
+		}
+		phrase, err := generate(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		faulty := append([]string{}, words...)
+		faulty[len(faulty)-1] = phrase[0]
+		unchecked, err := New(faulty, WithInsecureFastKDF())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := generate(unchecked); err != nil {
+			t.Errorf("%s: expected no error without the self check but actual %v", name, err)
+		}
+		checked, err := New(faulty, WithInsecureFastKDF(), WithSelfCheck())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := generate(checked); !errors.Is(err, ErrSelfCheck) {
+			t.Errorf("%s: expected ErrSelfCheck but actual %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package nomnemonic
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	_sessionAAD       = "nomnemonic session v1"
+	_sessionCommit    = "nomnemonic commitment v1"
+	_sessionNonceSize = 16
+)
+
+// parts of a split input session
+const (
+	PartIdentifier = "identifier"
+	PartPassword   = "password"
+	PartPasscode   = "passcode"
+)
+
+var _sessionParts = []string{PartIdentifier, PartPassword, PartPasscode}
+
+type (
+	// Session combines the identifier, password and passcode given by
+	// different parties in separate requests without keeping state. Every
+	// Add returns a token sealing the parts given so far with an ephemeral
+	// key, the token is handed to the next party and the key dies with the
+	// session, so tokens can not be opened after a restart
+	Session struct {
+		aead cipher.AEAD
+		ttl  time.Duration
+		now  func() time.Time
+	}
+
+	// SessionState is the public state of a token, the commitments let each
+	// party check its part is in the token the words are generated from
+	// without revealing the part to the others
+	SessionState struct {
+		Token       string
+		Commitments map[string]string
+		Missing     []string
+		Expires     time.Time
+	}
+
+	// Receipt opens the commitment of a part, only the party who gave the
+	// part gets it
+	Receipt struct {
+		Part       string
+		Nonce      string
+		Commitment string
+	}
+
+	sessionPayload struct {
+		Parts   map[string]string `json:"parts"`
+		Nonces  map[string][]byte `json:"nonces"`
+		Expires int64             `json:"expires"`
+	}
+)
+
+// NewSession returns a session with a random key whose tokens expire ttl
+// after their first part
+func NewSession(ttl time.Duration) (*Session, error) {
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{aead: aead, ttl: ttl, now: time.Now}, nil
+}
+
+// Add adds a part to the token, an empty token starts a new one, and returns
+// the new state and the receipt of the part. A part can not be replaced
+func (s *Session) Add(token, part, value string) (SessionState, Receipt, error) {
+	if !isSessionPart(part) {
+		return SessionState{}, Receipt{}, fmt.Errorf("unsupported part %s", part)
+	}
+	if value == "" {
+		return SessionState{}, Receipt{}, fmt.Errorf("%s is empty", part)
+	}
+
+	p := &sessionPayload{Parts: map[string]string{}, Nonces: map[string][]byte{}, Expires: s.now().Add(s.ttl).Unix()}
+	if token != "" {
+		var err error
+		if p, err = s.open(token); err != nil {
+			return SessionState{}, Receipt{}, err
+		}
+	}
+	if _, ok := p.Parts[part]; ok {
+		return SessionState{}, Receipt{}, fmt.Errorf("%s is already set", part)
+	}
+
+	nonce := make([]byte, _sessionNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return SessionState{}, Receipt{}, err
+	}
+	p.Parts[part] = value
+	p.Nonces[part] = nonce
+
+	state, err := s.seal(p)
+	if err != nil {
+		return SessionState{}, Receipt{}, err
+	}
+	return state, Receipt{Part: part, Nonce: hex.EncodeToString(nonce), Commitment: state.Commitments[part]}, nil
+}
+
+// State returns the public state of the token
+func (s *Session) State(token string) (SessionState, error) {
+	p, err := s.open(token)
+	if err != nil {
+		return SessionState{}, err
+	}
+	state := p.state()
+	state.Token = token
+	return state, nil
+}
+
+// Generate generates the words of a complete token
+func (s *Session) Generate(m Mnemonicer, token string, size int) ([]string, error) {
+	p, err := s.open(token)
+	if err != nil {
+		return nil, err
+	}
+	if missing := p.state().Missing; len(missing) > 0 {
+		return nil, fmt.Errorf("%s is missing", missing[0])
+	}
+	return m.Generate(p.Parts[PartIdentifier], p.Parts[PartPassword], p.Parts[PartPasscode], size)
+}
+
+// VerifyReceipt reports whether the state holds the commitment of the
+// receipt, the party checks it before the words are generated
+func VerifyReceipt(state SessionState, r Receipt) bool {
+	c, ok := state.Commitments[r.Part]
+	return ok && c == r.Commitment
+}
+
+func (s *Session) seal(p *sessionPayload) (SessionState, error) {
+	plaintext, err := json.Marshal(p)
+	if err != nil {
+		return SessionState{}, err
+	}
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := rand.Read(nonce); err != nil {
+		return SessionState{}, err
+	}
+	sealed := s.aead.Seal(nonce, nonce, plaintext, []byte(_sessionAAD))
+	state := p.state()
+	state.Token = base64.RawURLEncoding.EncodeToString(sealed)
+	return state, nil
+}
+
+func (s *Session) open(token string) (*sessionPayload, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(sealed) < chacha20poly1305.NonceSizeX {
+		return nil, errors.New("invalid session token")
+	}
+	plaintext, err := s.aead.Open(nil, sealed[:chacha20poly1305.NonceSizeX], sealed[chacha20poly1305.NonceSizeX:], []byte(_sessionAAD))
+	if err != nil {
+		return nil, errors.New("invalid session token")
+	}
+	var p sessionPayload
+	if err := json.Unmarshal(plaintext, &p); err != nil {
+		return nil, errors.New("invalid session token")
+	}
+	if !s.now().Before(time.Unix(p.Expires, 0)) {
+		return nil, errors.New("session token expired")
+	}
+	return &p, nil
+}
+
+func (p *sessionPayload) state() SessionState {
+	state := SessionState{Commitments: map[string]string{}, Expires: time.Unix(p.Expires, 0)}
+	for _, part := range _sessionParts {
+		value, ok := p.Parts[part]
+		if !ok {
+			state.Missing = append(state.Missing, part)
+			continue
+		}
+		state.Commitments[part] = commitment(part, p.Nonces[part], value)
+	}
+	sort.Strings(state.Missing)
+	return state
+}
+
+// commitment hashes the part with its nonce, the nonce stops the others from
+// brute forcing a short part like the passcode from the commitment
+func commitment(part string, nonce []byte, value string) string {
+	h := sha256.New()
+	h.Write([]byte(_sessionCommit))
+	h.Write([]byte{byte(len(part))})
+	h.Write([]byte(part))
+	h.Write(nonce)
+	h.Write([]byte(value))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func isSessionPart(part string) bool {
+	for _, p := range _sessionParts {
+		if p == part {
+			return true
+		}
+	}
+	return false
+}
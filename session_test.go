@@ -0,0 +1,100 @@
+package nomnemonic
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSession(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatalf("couldn't build words: %s", err.Error())
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSession(time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, identifier, err := s.Add("", PartIdentifier, "nomnemonic_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(state.Missing, ",") != "passcode,password" {
+		t.Errorf("expected missing passcode,password but actual %v", state.Missing)
+	}
+	if _, err := s.Generate(m, state.Token, 12); err == nil || err.Error() != "passcode is missing" {
+		t.Errorf("expected err 'passcode is missing' but actual %v", err)
+	}
+	state, _, err = s.Add(state.Token, PartPassword, "test12345678")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, passcode, err := s.Add(state.Token, PartPasscode, "101938")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Missing) != 0 {
+		t.Errorf("expected nothing missing but actual %v", state.Missing)
+	}
+	if !VerifyReceipt(state, identifier) || !VerifyReceipt(state, passcode) {
+		t.Error("expected the receipts to match the final state")
+	}
+	if strings.Contains(state.Token, "101938") || strings.Contains(passcode.Commitment, "101938") {
+		t.Error("expected the passcode to be hidden")
+	}
+
+	actual, err := s.Generate(m, state.Token, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	if strings.Join(actual, " ") != expected {
+		t.Errorf("expected '%s' but actual '%s'", expected, strings.Join(actual, " "))
+	}
+}
+
+func TestSessionErrors(t *testing.T) {
+	s, err := NewSession(time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := NewSession(time.Minute)
+	state, _, err := s.Add("", PartPassword, "test12345678")
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign, _, _ := other.Add("", PartPassword, "test12345678")
+	expired, _, _ := s.Add("", PartPasscode, "101938")
+	s.now = func() time.Time { return time.Now().Add(time.Hour) }
+	_, _, expiredErr := s.Add(expired.Token, PartIdentifier, "nomnemonic_test")
+	s.now = time.Now
+
+	tests := []struct {
+		name  string
+		token string
+		part  string
+		value string
+		err   string
+	}{
+		{name: "unsupported part", part: "salt", value: "x", err: "unsupported part salt"},
+		{name: "empty value", part: PartPassword, err: "password is empty"},
+		{name: "replaced part", token: state.Token, part: PartPassword, value: "other", err: "password is already set"},
+		{name: "garbage token", token: "not a token", part: PartPasscode, value: "101938", err: "invalid session token"},
+		{name: "foreign token", token: foreign.Token, part: PartPasscode, value: "101938", err: "invalid session token"},
+	}
+
+	for _, test := range tests {
+		_, _, err := s.Add(test.token, test.part, test.value)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%s: expected err '%s' but actual %v", test.name, test.err, err)
+		}
+	}
+	if expiredErr == nil || expiredErr.Error() != "session token expired" {
+		t.Errorf("expected err 'session token expired' but actual %v", expiredErr)
+	}
+}
@@ -0,0 +1,90 @@
+// Package slip10 implements the slip-0010 ed25519 hierarchical deterministic
+// key derivation which only supports hardened indexes
+package slip10
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+)
+
+const (
+	_masterKey = "ed25519 seed"
+)
+
+// Key is an ed25519 extended private key
+type Key struct {
+	key       []byte
+	chainCode []byte
+}
+
+// NewMasterKey generates the master key from the seed
+func NewMasterKey(seed []byte) (*Key, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, errors.New("seed must be between 16 and 64 bytes")
+	}
+
+	mac := hmac.New(sha512.New, []byte(_masterKey))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	return &Key{key: sum[:32], chainCode: sum[32:]}, nil
+}
+
+// DerivePath derives the descendant key for the path, every segment of the
+// path must be hardened
+func (k *Key) DerivePath(path string) (*Key, error) {
+	indexes, err := bip32.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key := k
+	for _, i := range indexes {
+		key, err = key.Derive(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// Derive derives the hardened child key at the index
+func (k *Key) Derive(index uint32) (*Key, error) {
+	if index < bip32.HardenedOffset {
+		return nil, errors.New("ed25519 only supports hardened derivation")
+	}
+
+	data := make([]byte, 0, 37)
+	data = append(data, 0)
+	data = append(data, k.key...)
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return &Key{key: sum[:32], chainCode: sum[32:]}, nil
+}
+
+// Seed returns the 32 bytes ed25519 private key seed
+func (k *Key) Seed() []byte {
+	return append([]byte(nil), k.key...)
+}
+
+// ChainCode returns the 32 bytes chain code
+func (k *Key) ChainCode() []byte {
+	return append([]byte(nil), k.chainCode...)
+}
+
+// PrivateKey returns the ed25519 private key of the key seed
+func (k *Key) PrivateKey() ed25519.PrivateKey {
+	return ed25519.NewKeyFromSeed(k.key)
+}
+
+// PublicKey returns the ed25519 public key
+func (k *Key) PublicKey() ed25519.PublicKey {
+	return k.PrivateKey().Public().(ed25519.PublicKey)
+}
@@ -0,0 +1,57 @@
+package slip10
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func TestDerivePath(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	tests := []struct {
+		path      string
+		chainCode string
+		seed      string
+		publicKey string
+	}{
+		{
+			path:      "m",
+			chainCode: "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb",
+			seed:      "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+			publicKey: "a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed",
+		},
+		{
+			path:      "m/0'",
+			chainCode: "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69",
+			seed:      "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+			publicKey: "8c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c",
+		},
+	}
+
+	for _, test := range tests {
+		k, err := master.DerivePath(test.path)
+		if err != nil {
+			t.Errorf("unexpected error for path %s: %s", test.path, err.Error())
+			continue
+		}
+		if actual := fmt.Sprintf("%x", k.ChainCode()); actual != test.chainCode {
+			t.Errorf("expected chain code for path %s is '%s' but actual '%s'", test.path, test.chainCode, actual)
+		}
+		if actual := fmt.Sprintf("%x", k.Seed()); actual != test.seed {
+			t.Errorf("expected seed for path %s is '%s' but actual '%s'", test.path, test.seed, actual)
+		}
+		if actual := fmt.Sprintf("%x", k.PublicKey()); actual != test.publicKey {
+			t.Errorf("expected public key for path %s is '%s' but actual '%s'", test.path, test.publicKey, actual)
+		}
+	}
+
+	_, err = master.DerivePath("m/0'/1")
+	if err == nil || err.Error() != "ed25519 only supports hardened derivation" {
+		t.Errorf("expected hardened derivation err but actual %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package slip39
+
+import (
+	"crypto/sha256"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	_baseIterationCount = 10000
+	_roundCount         = 4
+
+	_customizationString = "shamir"
+)
+
+// encrypt encrypts the master secret with the passphrase using the 4 rounds
+// Feistel network of the spec
+func encrypt(masterSecret []byte, passphrase string, exponent int, identifier uint16, extendable bool) []byte {
+	l, r := masterSecret[:len(masterSecret)/2], masterSecret[len(masterSecret)/2:]
+	salt := salt(identifier, extendable)
+	for i := 0; i < _roundCount; i++ {
+		l, r = r, xor(l, roundFunction(i, passphrase, exponent, salt, r))
+	}
+	return append(append([]byte{}, r...), l...)
+}
+
+// decrypt reverses encrypt
+func decrypt(encrypted []byte, passphrase string, exponent int, identifier uint16, extendable bool) []byte {
+	l, r := encrypted[:len(encrypted)/2], encrypted[len(encrypted)/2:]
+	salt := salt(identifier, extendable)
+	for i := _roundCount - 1; i >= 0; i-- {
+		l, r = r, xor(l, roundFunction(i, passphrase, exponent, salt, r))
+	}
+	return append(append([]byte{}, r...), l...)
+}
+
+func roundFunction(i int, passphrase string, exponent int, salt, r []byte) []byte {
+	password := append([]byte{byte(i)}, passphrase...)
+	iterations := (_baseIterationCount << exponent) / _roundCount
+	return pbkdf2.Key(password, append(append([]byte{}, salt...), r...), iterations, len(r), sha256.New)
+}
+
+// salt is empty for extendable backups so shares of different identifiers
+// can be combined into the same secret
+func salt(identifier uint16, extendable bool) []byte {
+	if extendable {
+		return nil
+	}
+	return append([]byte(_customizationString), byte(identifier>>8), byte(identifier))
+}
+
+func xor(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
@@ -0,0 +1,26 @@
+package slip39
+
+import (
+	"github.com/nomnemonic/nomnemonic"
+)
+
+// FromBIP39 extracts the entropy of the bip39 words and splits it into slip39
+// shares, the bip39 passphrase is not carried over so the shares recover the
+// same entropy but not the same bip39 seed
+func FromBIP39(m nomnemonic.Mnemonicer, s Shamir, words []string, passphrase string, groupThreshold int, groups []Group) ([][][]string, error) {
+	entropy, err := m.CalculateEntropy(words)
+	if err != nil {
+		return nil, err
+	}
+	return s.Split(entropy, passphrase, groupThreshold, groups)
+}
+
+// ToBIP39 combines the slip39 shares and encodes the master secret as bip39
+// words, the master secret must be a valid bip39 entropy size
+func ToBIP39(m nomnemonic.Mnemonicer, s Shamir, shares [][]string, passphrase string) ([]string, error) {
+	entropy, err := s.Combine(shares, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return m.EntropyToWords(entropy)
+}
@@ -0,0 +1,51 @@
+package slip39
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestBIP39Conversion(t *testing.T) {
+	bip39Words, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+	m, err := nomnemonic.New(strings.Split(string(bip39Words), "\n"))
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	s, err := New(buildWords())
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	tests := []string{
+		"cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby",
+		"edge defense waste choose enrich upon flee junk siren film clown finish luggage leader kid quick brick print evidence swap drill paddle truly occur",
+	}
+
+	for _, sentence := range tests {
+		shares, err := FromBIP39(m, s, strings.Fields(sentence), "TREZOR", 1, []Group{{Threshold: 2, Count: 3}})
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+
+		words, err := ToBIP39(m, s, [][]string{shares[0][2], shares[0][1]}, "TREZOR")
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+		if actual := strings.Join(words, " "); actual != sentence {
+			t.Errorf("expected '%s' but actual '%s'", sentence, actual)
+		}
+	}
+
+	_, err = FromBIP39(m, s, strings.Fields("cinnamon venue broken old brass vague paddle unaware critic alarm consider consider"), "", 1, []Group{{Threshold: 1, Count: 1}})
+	if err == nil || err.Error() != "invalid checksum" {
+		t.Errorf("expected checksum err but actual %v", err)
+	}
+}
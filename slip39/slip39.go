@@ -0,0 +1,375 @@
+// Package slip39 splits a master secret into slip-0039 Shamir mnemonic shares
+// organized in groups and recombines them
+package slip39
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/nomnemonic/nomnemonic/internal/gf256"
+)
+
+const (
+	_radixBits      = 10
+	_checksumWords  = 3
+	_prefixWords    = 4 // identifier, extendable, exponent and share params
+	_minSecretBytes = 16
+	_maxShares      = 16
+
+	_customizationExtendable = "shamir_extendable"
+
+	_iterationExponent = 1
+)
+
+var (
+	_generator = []uint32{
+		0xe0e040, 0x1c1c080, 0x3838100, 0x7070200, 0xe0e0009,
+		0x1c0c2412, 0x38086c24, 0x3090fc48, 0x21b1f890, 0x3f3f120,
+	}
+)
+
+type (
+	// Group is the member threshold and member count of a share group
+	Group struct {
+		Threshold int
+		Count     int
+	}
+
+	shamir struct {
+		words  []string
+		dict   map[string]int
+		random io.Reader
+	}
+
+	// Shamir splits and combines slip39 shares
+	Shamir interface {
+		Split(masterSecret []byte, passphrase string, groupThreshold int, groups []Group) ([][][]string, error)
+		Combine(shares [][]string, passphrase string) ([]byte, error)
+		Validate(share []string) error
+	}
+
+	shareInfo struct {
+		identifier     uint16
+		extendable     bool
+		exponent       int
+		groupIndex     int
+		groupThreshold int
+		groupCount     int
+		memberIndex    int
+		threshold      int
+		value          []byte
+	}
+)
+
+// New inits a new slip39 share generator with the 1024 words slip39 list,
+// Wordlist is the official one
+func New(words []string) (Shamir, error) {
+	if len(words) != 1<<_radixBits {
+		return nil, errors.New("slip39 is based on 1024 words")
+	}
+	dict := make(map[string]int, len(words))
+	for i, w := range words {
+		dict[w] = i
+	}
+	return &shamir{
+		words:  words,
+		dict:   dict,
+		random: rand.Reader,
+	}, nil
+}
+
+// Split encrypts the master secret with the passphrase and splits it into
+// groups of mnemonic shares, groupThreshold of the groups are needed to
+// recover the secret and each group needs its own member threshold
+func (s *shamir) Split(masterSecret []byte, passphrase string, groupThreshold int, groups []Group) ([][][]string, error) {
+	if len(masterSecret) < _minSecretBytes || len(masterSecret)%2 != 0 {
+		return nil, fmt.Errorf("master secret must be an even number of bytes and at least %d bytes", _minSecretBytes)
+	}
+	for _, c := range passphrase {
+		if c < 32 || c > 126 {
+			return nil, errors.New("passphrase must contain only printable ascii chars")
+		}
+	}
+	if groupThreshold < 1 || groupThreshold > len(groups) || len(groups) > _maxShares {
+		return nil, fmt.Errorf("group threshold must be between 1 and the group count %d", len(groups))
+	}
+	for _, g := range groups {
+		if g.Threshold < 1 || g.Threshold > g.Count || g.Count > _maxShares {
+			return nil, fmt.Errorf("member threshold must be between 1 and the member count, at most %d", _maxShares)
+		}
+		if g.Threshold == 1 && g.Count > 1 {
+			return nil, errors.New("member threshold 1 requires member count 1, use 1-of-1 groups instead")
+		}
+	}
+
+	id := make([]byte, 2)
+	if _, err := io.ReadFull(s.random, id); err != nil {
+		return nil, err
+	}
+	identifier := (uint16(id[0])<<8 | uint16(id[1])) & 0x7fff
+	encrypted := encrypt(masterSecret, passphrase, _iterationExponent, identifier, true)
+
+	groupShares, err := gf256.Split(groupThreshold, len(groups), encrypted, s.random)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonics := make([][][]string, len(groups))
+	for i, gs := range groupShares {
+		memberShares, err := gf256.Split(groups[i].Threshold, groups[i].Count, gs.Value, s.random)
+		if err != nil {
+			return nil, err
+		}
+		for _, ms := range memberShares {
+			mnemonics[i] = append(mnemonics[i], s.encode(shareInfo{
+				identifier:     identifier,
+				extendable:     true,
+				exponent:       _iterationExponent,
+				groupIndex:     int(gs.X),
+				groupThreshold: groupThreshold,
+				groupCount:     len(groups),
+				memberIndex:    int(ms.X),
+				threshold:      groups[i].Threshold,
+				value:          ms.Value,
+			}))
+		}
+	}
+	return mnemonics, nil
+}
+
+// Validate checks the words, length and checksum of a single share, so shares
+// can be checked as they are entered before combining them
+func (s *shamir) Validate(share []string) error {
+	_, err := s.decode(share)
+	return err
+}
+
+// Combine recovers the master secret from the mnemonic shares, a wrong
+// passphrase recovers a different secret without any error
+func (s *shamir) Combine(mnemonics [][]string, passphrase string) ([]byte, error) {
+	if len(mnemonics) == 0 {
+		return nil, errors.New("no shares given")
+	}
+
+	shares := make([]shareInfo, 0, len(mnemonics))
+	for i, m := range mnemonics {
+		sh, err := s.decode(m)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i+1, err)
+		}
+		shares = append(shares, sh)
+	}
+
+	first := shares[0]
+	groups := map[int][]shareInfo{}
+	for _, sh := range shares {
+		if sh.identifier != first.identifier || sh.extendable != first.extendable || sh.exponent != first.exponent {
+			return nil, errors.New("shares belong to different secrets")
+		}
+		if sh.groupThreshold != first.groupThreshold || sh.groupCount != first.groupCount {
+			return nil, errors.New("shares have different group parameters")
+		}
+		for _, o := range groups[sh.groupIndex] {
+			if o.memberIndex == sh.memberIndex {
+				return nil, fmt.Errorf("duplicate member index %d in group %d", sh.memberIndex+1, sh.groupIndex+1)
+			}
+			if o.threshold != sh.threshold {
+				return nil, fmt.Errorf("shares of group %d have different member thresholds", sh.groupIndex+1)
+			}
+		}
+		groups[sh.groupIndex] = append(groups[sh.groupIndex], sh)
+	}
+
+	if len(groups) < first.groupThreshold {
+		return nil, fmt.Errorf("need shares from %d groups but given %d", first.groupThreshold, len(groups))
+	}
+
+	indexes := make([]int, 0, len(groups))
+	for i := range groups {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	groupShares := make([]gf256.Share, 0, first.groupThreshold)
+	for _, i := range indexes {
+		members := groups[i]
+		if len(members) < members[0].threshold {
+			continue
+		}
+		memberShares := make([]gf256.Share, 0, members[0].threshold)
+		for _, m := range members[:members[0].threshold] {
+			memberShares = append(memberShares, gf256.Share{X: byte(m.memberIndex), Value: m.value})
+		}
+		secret, err := gf256.Recover(members[0].threshold, memberShares)
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", i+1, err)
+		}
+		groupShares = append(groupShares, gf256.Share{X: byte(i), Value: secret})
+		if len(groupShares) == first.groupThreshold {
+			break
+		}
+	}
+	if len(groupShares) < first.groupThreshold {
+		return nil, fmt.Errorf("need %d complete groups but given %d", first.groupThreshold, len(groupShares))
+	}
+
+	encrypted, err := gf256.Recover(first.groupThreshold, groupShares)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(encrypted, passphrase, first.exponent, first.identifier, first.extendable), nil
+}
+
+func (s *shamir) encode(sh shareInfo) []string {
+	ext := 0
+	if sh.extendable {
+		ext = 1
+	}
+	prefix := uint64(sh.identifier)<<5 | uint64(ext)<<4 | uint64(sh.exponent)
+	prefix = prefix<<20 | uint64(sh.groupIndex)<<16 | uint64(sh.groupThreshold-1)<<12 |
+		uint64(sh.groupCount-1)<<8 | uint64(sh.memberIndex)<<4 | uint64(sh.threshold-1)
+
+	indexes := []int{int(prefix >> 30 & 1023), int(prefix >> 20 & 1023), int(prefix >> 10 & 1023), int(prefix & 1023)}
+	indexes = append(indexes, bytesToIndexes(sh.value)...)
+	indexes = append(indexes, createChecksum(customization(sh.extendable), indexes)...)
+
+	words := make([]string, len(indexes))
+	for i, idx := range indexes {
+		words[i] = s.words[idx]
+	}
+	return words
+}
+
+func (s *shamir) decode(words []string) (shareInfo, error) {
+	valueWords := len(words) - _prefixWords - _checksumWords
+	if valueWords*_radixBits < _minSecretBytes*8 {
+		return shareInfo{}, fmt.Errorf("invalid share length: %d words", len(words))
+	}
+	padding := valueWords * _radixBits % 16
+	if padding > 8 {
+		return shareInfo{}, fmt.Errorf("invalid share length: %d words", len(words))
+	}
+
+	indexes := make([]int, len(words))
+	for i, w := range words {
+		idx, ok := s.dict[w]
+		if !ok {
+			return shareInfo{}, fmt.Errorf("unrecognized word %s", w)
+		}
+		indexes[i] = idx
+	}
+
+	extendable := indexes[1]>>4&1 == 1
+	if !verifyChecksum(customization(extendable), indexes) {
+		return shareInfo{}, errors.New("invalid checksum")
+	}
+
+	var prefix uint64
+	for _, idx := range indexes[:_prefixWords] {
+		prefix = prefix<<_radixBits | uint64(idx)
+	}
+	sh := shareInfo{
+		identifier:     uint16(prefix >> 25),
+		extendable:     extendable,
+		exponent:       int(prefix >> 20 & 15),
+		groupIndex:     int(prefix >> 16 & 15),
+		groupThreshold: int(prefix>>12&15) + 1,
+		groupCount:     int(prefix>>8&15) + 1,
+		memberIndex:    int(prefix >> 4 & 15),
+		threshold:      int(prefix&15) + 1,
+	}
+	if sh.groupThreshold > sh.groupCount {
+		return shareInfo{}, errors.New("group threshold exceeds the group count")
+	}
+
+	value, err := indexesToBytes(indexes[_prefixWords:len(indexes)-_checksumWords], padding)
+	if err != nil {
+		return shareInfo{}, err
+	}
+	sh.value = value
+	return sh, nil
+}
+
+// bytesToIndexes converts the bytes into 10 bits word indexes, the value is
+// left padded with zero bits to a multiple of 10 bits
+func bytesToIndexes(b []byte) []int {
+	count := (len(b)*8 + _radixBits - 1) / _radixBits
+	indexes := make([]int, count)
+	acc, bits := 0, count*_radixBits-len(b)*8
+	i := 0
+	for _, v := range b {
+		acc = acc<<8 | int(v)
+		bits += 8
+		for bits >= _radixBits {
+			bits -= _radixBits
+			indexes[i] = acc >> bits & 1023
+			i++
+		}
+	}
+	return indexes
+}
+
+func indexesToBytes(indexes []int, padding int) ([]byte, error) {
+	acc, bits := 0, 0
+	out := make([]byte, 0, (len(indexes)*_radixBits-padding)/8)
+	for i, idx := range indexes {
+		acc = acc<<_radixBits | idx
+		bits += _radixBits
+		if i == 0 {
+			if acc>>(_radixBits-padding) != 0 {
+				return nil, errors.New("invalid padding")
+			}
+			bits -= padding
+			acc &= 1<<bits - 1
+		}
+		for bits >= 8 {
+			bits -= 8
+			out = append(out, byte(acc>>bits))
+		}
+		acc &= 1<<bits - 1
+	}
+	return out, nil
+}
+
+func customization(extendable bool) string {
+	if extendable {
+		return _customizationExtendable
+	}
+	return _customizationString
+}
+
+func polymod(values []int) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 20
+		chk = (chk&0xfffff)<<10 ^ uint32(v)
+		for i, g := range _generator {
+			if (b>>i)&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
+
+func createChecksum(cs string, data []int) []int {
+	values := make([]int, 0, len(cs)+len(data)+_checksumWords)
+	for _, c := range []byte(cs) {
+		values = append(values, int(c))
+	}
+	values = append(values, data...)
+	values = append(values, 0, 0, 0)
+	mod := polymod(values) ^ 1
+	return []int{int(mod >> 20 & 1023), int(mod >> 10 & 1023), int(mod & 1023)}
+}
+
+func verifyChecksum(cs string, data []int) bool {
+	values := make([]int, 0, len(cs)+len(data))
+	for _, c := range []byte(cs) {
+		values = append(values, int(c))
+	}
+	return polymod(append(values, data...)) == 1
+}
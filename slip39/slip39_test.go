@@ -0,0 +1,185 @@
+package slip39
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New([]string{})
+	if err == nil || err.Error() != "slip39 is based on 1024 words" {
+		t.Errorf("expected word list size err but actual %v", err)
+	}
+}
+
+func TestSplitCombine(t *testing.T) {
+	s, err := New(buildWords())
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	tests := []struct {
+		secret         []byte
+		groupThreshold int
+		groups         []Group
+		combine        [][2]int // group and member indexes of the shares to combine
+	}{
+		{
+			secret:         bytes.Repeat([]byte{0xab}, 16),
+			groupThreshold: 1,
+			groups:         []Group{{Threshold: 1, Count: 1}},
+			combine:        [][2]int{{0, 0}},
+		},
+		{
+			secret:         []byte("0123456789abcdef0123456789abcdef"),
+			groupThreshold: 1,
+			groups:         []Group{{Threshold: 2, Count: 3}},
+			combine:        [][2]int{{0, 2}, {0, 0}},
+		},
+		{
+			secret:         []byte("0123456789abcdef0123"),
+			groupThreshold: 2,
+			groups:         []Group{{Threshold: 1, Count: 1}, {Threshold: 2, Count: 3}, {Threshold: 3, Count: 5}},
+			combine:        [][2]int{{2, 4}, {0, 0}, {2, 1}, {2, 0}},
+		},
+	}
+
+	for _, test := range tests {
+		mnemonics, err := s.Split(test.secret, "TREZOR", test.groupThreshold, test.groups)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+
+		for i, g := range test.groups {
+			if len(mnemonics[i]) != g.Count {
+				t.Errorf("expected %d shares in group %d but actual %d", g.Count, i, len(mnemonics[i]))
+			}
+		}
+		wordCount := 7 + (len(test.secret)*8+9)/10
+		if len(mnemonics[0][0]) != wordCount {
+			t.Errorf("expected %d words per share but actual %d", wordCount, len(mnemonics[0][0]))
+		}
+
+		shares := make([][]string, 0, len(test.combine))
+		for _, c := range test.combine {
+			shares = append(shares, mnemonics[c[0]][c[1]])
+		}
+		secret, err := s.Combine(shares, "TREZOR")
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+		if !bytes.Equal(secret, test.secret) {
+			t.Errorf("expected secret %x but actual %x", test.secret, secret)
+		}
+
+		secret, _ = s.Combine(shares, "other")
+		if bytes.Equal(secret, test.secret) {
+			t.Errorf("expected different secret for wrong passphrase")
+		}
+	}
+}
+
+func TestCombineErrors(t *testing.T) {
+	s, _ := New(buildWords())
+	mnemonics, err := s.Split([]byte("0123456789abcdef"), "", 2, []Group{{Threshold: 2, Count: 3}, {Threshold: 2, Count: 2}})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	tampered := append([]string{}, mnemonics[0][0]...)
+	tampered[5] = s.(*shamir).words[(s.(*shamir).dict[tampered[5]]+1)%1024]
+
+	tests := []struct {
+		shares [][]string
+		err    string
+	}{
+		{shares: [][]string{}, err: "no shares given"},
+		{shares: [][]string{tampered}, err: "share 1: invalid checksum"},
+		{shares: [][]string{mnemonics[0][0], mnemonics[0][1]}, err: "need shares from 2 groups but given 1"},
+		{shares: [][]string{mnemonics[0][0], mnemonics[0][1], mnemonics[1][0]}, err: "need 2 complete groups but given 1"},
+		{shares: [][]string{mnemonics[0][0], mnemonics[0][0]}, err: "duplicate member index 1 in group 1"},
+		{shares: [][]string{{"w0001", "w0002"}}, err: "share 1: invalid share length: 2 words"},
+	}
+
+	for _, test := range tests {
+		_, err := s.Combine(test.shares, "")
+		if err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s, _ := New(buildWords())
+	mnemonics, err := s.Split([]byte("0123456789abcdef"), "", 1, []Group{{Threshold: 2, Count: 3}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	tampered := append([]string{}, mnemonics[0][1]...)
+	tampered[7] = s.(*shamir).words[(s.(*shamir).dict[tampered[7]]+1)%1024]
+
+	tests := []struct {
+		share []string
+		err   string
+	}{
+		{share: mnemonics[0][0]},
+		{share: tampered, err: "invalid checksum"},
+		{share: append(append([]string{}, mnemonics[0][2][:19]...), "nope"), err: "unrecognized word nope"},
+	}
+
+	for _, test := range tests {
+		err := s.Validate(test.share)
+		if (err == nil) != (test.err == "") || err != nil && err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+}
+
+func TestSplitValidation(t *testing.T) {
+	s, _ := New(buildWords())
+
+	tests := []struct {
+		secret         []byte
+		groupThreshold int
+		groups         []Group
+		err            string
+	}{
+		{secret: make([]byte, 15), groupThreshold: 1, groups: []Group{{1, 1}}, err: "master secret must be an even number of bytes and at least 16 bytes"},
+		{secret: make([]byte, 16), groupThreshold: 2, groups: []Group{{1, 1}}, err: "group threshold must be between 1 and the group count 1"},
+		{secret: make([]byte, 16), groupThreshold: 1, groups: []Group{{3, 2}}, err: "member threshold must be between 1 and the member count, at most 16"},
+		{secret: make([]byte, 16), groupThreshold: 1, groups: []Group{{1, 2}}, err: "member threshold 1 requires member count 1, use 1-of-1 groups instead"},
+	}
+
+	for _, test := range tests {
+		_, err := s.Split(test.secret, "", test.groupThreshold, test.groups)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	for _, extendable := range []bool{true, false} {
+		encrypted := encrypt(secret, "TREZOR", 1, 7945, extendable)
+		if bytes.Equal(encrypted, secret) {
+			t.Errorf("expected encrypted secret to differ")
+		}
+		if actual := decrypt(encrypted, "TREZOR", 1, 7945, extendable); !bytes.Equal(actual, secret) {
+			t.Errorf("expected decrypted secret %x but actual %x", secret, actual)
+		}
+	}
+}
+
+// buildWords builds a placeholder list, the official slip39 list must be
+// supplied by the callers the same way as the bip39 list
+func buildWords() []string {
+	words := make([]string, 1024)
+	for i := range words {
+		words[i] = fmt.Sprintf("w%04d", i)
+	}
+	return words
+}
@@ -0,0 +1,97 @@
+package slip39
+
+import (
+	"encoding/hex"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestWordlist(t *testing.T) {
+	words := Wordlist()
+	if len(words) != 1024 || !sort.StringsAreSorted(words) {
+		t.Fatalf("expected 1024 sorted words but actual %d", len(words))
+	}
+	prefixes := map[string]bool{}
+	for _, w := range words {
+		if len(w) < 4 || len(w) > 8 || prefixes[w[:4]] {
+			t.Errorf("expected 4 to 8 letters unique by the first 4 but actual %s", w)
+		}
+		prefixes[w[:4]] = true
+	}
+	words[0] = "changed"
+	if Wordlist()[0] != "academic" {
+		t.Error("expected a copy of the word list")
+	}
+}
+
+// TestVectors checks the published slip39 test vectors, with the TREZOR
+// passphrase of the spec
+func TestVectors(t *testing.T) {
+	s, err := New(Wordlist())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		shares []string
+		secret string
+		err    string
+	}{
+		{
+			name:   "valid mnemonic without sharing (128 bits)",
+			shares: []string{"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboard"},
+			secret: "bb54aac4b89dc868ba37d9cc21b2cece",
+		},
+		{
+			name:   "mnemonic with invalid checksum (128 bits)",
+			shares: []string{"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision kidney"},
+			err:    "share 1: invalid checksum",
+		},
+		{
+			name:   "mnemonic with invalid padding (128 bits)",
+			shares: []string{"duckling enlarge academic academic email result length solution fridge kidney coal piece deal husband erode duke ajar music cargo fitness"},
+			err:    "share 1: invalid padding",
+		},
+		{
+			name: "basic sharing 2-of-3 (128 bits)",
+			shares: []string{
+				"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+				"shadow pistol academic acid actress prayer class unknown daughter sweater depict flip twice unkind craft early superior advocate guest smoking",
+			},
+			secret: "b43ceb7e57a0ea8766221624d01b0864",
+		},
+		{
+			name:   "valid mnemonic without sharing (256 bits)",
+			shares: []string{"theory painting academic academic armed sweater year military elder discuss acne wildlife boring employer fused large satoshi bundle carbon diagnose anatomy hamster leaves tracks paces beyond phantom capital marvel lips brave detect luck"},
+			secret: "989baf9dcaad5b10ca33dfd8cc75e42477025dce88ae83e75a230086a0e00e92",
+		},
+		{
+			name:   "valid extendable mnemonic without sharing (128 bits)",
+			shares: []string{"testify swimming academic academic column loyalty smear include exotic bedroom exotic wrist lobe cover grief golden smart junior estimate learn"},
+			secret: "1679b4516e0ee5954351d288a838f45e",
+		},
+	}
+
+	for _, test := range tests {
+		shares := make([][]string, len(test.shares))
+		for i, share := range test.shares {
+			shares[i] = strings.Fields(share)
+		}
+		secret, err := s.Combine(shares, "TREZOR")
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s: expected error %s but actual %v", test.name, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+			continue
+		}
+		if actual := hex.EncodeToString(secret); actual != test.secret {
+			t.Errorf("%s: expected %s but actual %s", test.name, test.secret, actual)
+		}
+	}
+}
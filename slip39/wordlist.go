@@ -0,0 +1,21 @@
+package slip39
+
+import (
+	_ "embed"
+	"strings"
+)
+
+var (
+	// the official slip39 word list of the spec, sorted and unique by the
+	// first 4 letters
+	//go:embed wordlist.txt
+	_wordlistText string
+
+	_wordlist = strings.Split(_wordlistText, "\n")
+)
+
+// Wordlist returns a copy of the official 1024 words slip39 list, the one
+// Trezor and every other slip39 wallet use
+func Wordlist() []string {
+	return append([]string(nil), _wordlist...)
+}
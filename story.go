@@ -0,0 +1,103 @@
+package nomnemonic
+
+import (
+	"fmt"
+	"strings"
+)
+
+// story mode is experimental, each word index is split into an adjective (3
+// bits), a noun (4 bits) and a verb (4 bits) and every 2 words make a
+// sentence of the template
+//
+//	The <adjective> <noun> <verb> while the <adjective> <noun> <verb>.
+var (
+	_storyAdjectives = []string{
+		"quiet", "brave", "golden", "little", "ancient", "clever", "sleepy", "wild",
+	}
+	_storyNouns = []string{
+		"fox", "sailor", "dragon", "baker", "owl", "knight", "river", "wizard",
+		"tiger", "queen", "robot", "farmer", "whale", "poet", "giant", "monkey",
+	}
+	_storyVerbs = []string{
+		"laughed", "danced", "slept", "sang", "waited", "jumped", "whispered", "smiled",
+		"wandered", "cried", "listened", "shouted", "rested", "prayed", "dreamed", "hid",
+	}
+)
+
+const (
+	_storyArticle     = "the"
+	_storyConjunction = "while"
+)
+
+// EncodeStory encodes the mnemonic words as a cover story, 1 sentence for
+// each 2 words
+func (m *mnemonicer) EncodeStory(words []string) (string, error) {
+	if len(words)%2 != 0 {
+		return "", fmt.Errorf("story needs an even number of words but given %d", len(words))
+	}
+	err := m.validateWordsPrecense(words)
+	if err != nil {
+		return "", err
+	}
+
+	sentences := make([]string, 0, len(words)/2)
+	for i := 0; i < len(words); i += 2 {
+		first, second := storyClause(m.index(words[i])), storyClause(m.index(words[i+1]))
+		sentences = append(sentences, fmt.Sprintf("The %s %s the %s.", first, _storyConjunction, second))
+	}
+	return strings.Join(sentences, " "), nil
+}
+
+// DecodeStory decodes the cover story back to the mnemonic words and
+// validates their checksum
+func (m *mnemonicer) DecodeStory(story string) ([]string, error) {
+	tokens := strings.Fields(strings.ToLower(strings.ReplaceAll(story, ".", " ")))
+
+	// each sentence is 9 tokens, the article, 3 slots, the conjunction, the
+	// article and 3 slots
+	if len(tokens) == 0 || len(tokens)%9 != 0 {
+		return nil, fmt.Errorf("story must be sentences of 9 words but given %d words", len(tokens))
+	}
+
+	words := make([]string, 0, len(tokens)/9*2)
+	for i := 0; i < len(tokens); i += 9 {
+		s := tokens[i : i+9]
+		if s[0] != _storyArticle || s[4] != _storyConjunction || s[5] != _storyArticle {
+			return nil, fmt.Errorf("sentence %d does not match the story template", i/9+1)
+		}
+		for _, slots := range [][]string{s[1:4], s[6:9]} {
+			index, err := storyIndex(slots)
+			if err != nil {
+				return nil, fmt.Errorf("sentence %d: %w", i/9+1, err)
+			}
+			words = append(words, m.words[index])
+		}
+	}
+
+	_, err := m.CalculateEntropy(words)
+	if err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+func storyClause(index int) string {
+	return fmt.Sprintf("%s %s %s", _storyAdjectives[index>>8&7], _storyNouns[index>>4&15], _storyVerbs[index&15])
+}
+
+func storyIndex(slots []string) (int, error) {
+	adjective, noun, verb := indexOf(_storyAdjectives, slots[0]), indexOf(_storyNouns, slots[1]), indexOf(_storyVerbs, slots[2])
+	if adjective < 0 || noun < 0 || verb < 0 {
+		return 0, fmt.Errorf("unrecognized clause %s", strings.Join(slots, " "))
+	}
+	return adjective<<8 | noun<<4 | verb, nil
+}
+
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
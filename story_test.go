@@ -0,0 +1,111 @@
+package nomnemonic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncodeStory(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	tests := []struct {
+		sentence string
+		story    string
+		err      error
+	}{
+		{
+			// abandon is 0, zoo is 2047 and about is 3
+			sentence: "abandon zoo",
+			story:    "The quiet fox laughed while the wild monkey hid.",
+		},
+		{
+			sentence: "abandon about",
+			story:    "The quiet fox laughed while the quiet fox sang.",
+		},
+		{
+			sentence: "abandon",
+			err:      errors.New("story needs an even number of words but given 1"),
+		},
+		{
+			sentence: "abandon tester",
+			err:      errors.New("unrecognized word tester"),
+		},
+	}
+
+	for _, test := range tests {
+		story, err := m.EncodeStory(strings.Fields(test.sentence))
+		if test.err != nil {
+			if err == nil || test.err.Error() != err.Error() {
+				t.Errorf("expected err '%s' but actual %v", test.err.Error(), err)
+			}
+			continue
+		}
+		if story != test.story {
+			t.Errorf("expected story '%s' but actual '%s'", test.story, story)
+		}
+	}
+}
+
+func TestDecodeStory(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	sentence := "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby"
+	story, err := m.EncodeStory(strings.Fields(sentence))
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	decoded, err := m.DecodeStory(strings.ToUpper(story))
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if actual := strings.Join(decoded, " "); actual != sentence {
+		t.Errorf("expected '%s' but actual '%s'", sentence, actual)
+	}
+
+	tests := []struct {
+		story string
+		err   error
+	}{
+		{
+			story: "The quiet fox laughed.",
+			err:   errors.New("story must be sentences of 9 words but given 4 words"),
+		},
+		{
+			story: "A quiet fox laughed while the wild monkey hid.",
+			err:   errors.New("sentence 1 does not match the story template"),
+		},
+		{
+			story: "The quiet cat laughed while the wild monkey hid.",
+			err:   errors.New("sentence 1: unrecognized clause quiet cat laughed"),
+		},
+		{
+			story: "The quiet fox laughed while the wild monkey hid.",
+			err:   errors.New("unsupported strength: 0"),
+		},
+	}
+
+	for _, test := range tests {
+		_, err := m.DecodeStory(test.story)
+		if err == nil || test.err.Error() != err.Error() {
+			t.Errorf("expected err '%s' but actual %v", test.err.Error(), err)
+		}
+	}
+}
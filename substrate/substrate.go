@@ -0,0 +1,141 @@
+// Package substrate derives Polkadot/Substrate sr25519 keys and SS58
+// addresses from bip39 entropy the same way polkadot.js and subkey do
+package substrate
+
+import (
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	"github.com/gtank/ristretto255"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/nomnemonic/nomnemonic/internal/base58"
+)
+
+const (
+	_saltPrefixMnemonic = "mnemonic"
+	_ss58Prefix         = "SS58PRE"
+	_ss58ChecksumSize   = 2
+
+	MiniSecretSize = 32
+	PublicKeySize  = 32
+
+	// Well known SS58 network prefixes
+	PrefixPolkadot  uint16 = 0
+	PrefixKusama    uint16 = 2
+	PrefixSubstrate uint16 = 42
+)
+
+// MiniSecret derives the sr25519 mini secret key from the bip39 entropy and
+// passphrase. Substrate does not use the bip39 seed but runs pbkdf2 directly
+// on the entropy bytes and keeps the first 32 bytes of the output, the same
+// as the first 32 bytes of GenerateSeed with SeedModeSubstrate
+func MiniSecret(entropy []byte, passphrase string) ([]byte, error) {
+	if len(entropy) < 16 || len(entropy) > 32 || len(entropy)%4 != 0 {
+		return nil, fmt.Errorf("invalid entropy size: %d", len(entropy))
+	}
+
+	seed := pbkdf2.Key(entropy, []byte(_saltPrefixMnemonic+passphrase), 2048, 64, sha512.New)
+	return seed[:MiniSecretSize], nil
+}
+
+// PublicKey derives the sr25519 public key of the mini secret using the
+// ed25519 style expansion which is the default for substrate keypairs
+func PublicKey(miniSecret []byte) ([]byte, error) {
+	if len(miniSecret) != MiniSecretSize {
+		return nil, fmt.Errorf("mini secret must be %d bytes", MiniSecretSize)
+	}
+
+	h := sha512.Sum512(miniSecret)
+	key := h[:32]
+	key[0] &= 248
+	key[31] &= 63
+	key[31] |= 64
+	divideByCofactor(key)
+
+	s := ristretto255.NewScalar()
+	err := s.Decode(key)
+	if err != nil {
+		return nil, err
+	}
+	return ristretto255.NewElement().ScalarBaseMult(s).Encode(nil), nil
+}
+
+// Address encodes the public key as SS58 address for the network prefix
+func Address(publicKey []byte, prefix uint16) (string, error) {
+	if len(publicKey) != PublicKeySize {
+		return "", fmt.Errorf("public key must be %d bytes", PublicKeySize)
+	}
+
+	var payload []byte
+	switch {
+	case prefix < 64:
+		payload = []byte{byte(prefix)}
+	case prefix < 16384:
+		payload = []byte{
+			byte((prefix&0xfc)>>2) | 0x40,
+			byte(prefix>>8) | byte(prefix&0x03)<<6,
+		}
+	default:
+		return "", fmt.Errorf("unsupported ss58 prefix: %d", prefix)
+	}
+	payload = append(payload, publicKey...)
+	payload = append(payload, checksum(payload)...)
+
+	return base58.Encode(payload, base58.AlphabetBitcoin), nil
+}
+
+// DecodeAddress decodes the SS58 address and returns the public key and
+// network prefix
+func DecodeAddress(address string) ([]byte, uint16, error) {
+	payload, err := base58.Decode(address, base58.AlphabetBitcoin)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(payload) < 1 {
+		return nil, 0, errors.New("invalid ss58 address")
+	}
+
+	var prefix uint16
+	var prefixSize int
+	switch {
+	case payload[0] < 64:
+		prefix, prefixSize = uint16(payload[0]), 1
+	case payload[0] < 128 && len(payload) > 1:
+		prefix = uint16(payload[0]&0x3f)<<2 | uint16(payload[1]>>6) | uint16(payload[1]&0x3f)<<8
+		prefixSize = 2
+	default:
+		return nil, 0, errors.New("invalid ss58 address prefix")
+	}
+
+	if len(payload) != prefixSize+PublicKeySize+_ss58ChecksumSize {
+		return nil, 0, errors.New("invalid ss58 address length")
+	}
+
+	body := payload[:prefixSize+PublicKeySize]
+	cs := checksum(body)
+	if cs[0] != payload[len(body)] || cs[1] != payload[len(body)+1] {
+		return nil, 0, errors.New("invalid ss58 checksum")
+	}
+	return body[prefixSize:], prefix, nil
+}
+
+func checksum(payload []byte) []byte {
+	h, _ := blake2b.New512(nil)
+	h.Write([]byte(_ss58Prefix))
+	h.Write(payload)
+	return h.Sum(nil)[:_ss58ChecksumSize]
+}
+
+// divideByCofactor divides the little endian scalar bytes by 8
+func divideByCofactor(s []byte) {
+	var low byte
+	for i := len(s) - 1; i >= 0; i-- {
+		r := s[i] & 0x07
+		s[i] >>= 3
+		s[i] += low
+		low = r << 5
+	}
+}
@@ -0,0 +1,132 @@
+package substrate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestMiniSecret(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := nomnemonic.New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	tests := []struct {
+		sentence   string
+		passphrase string
+		miniSecret string
+		publicKey  string
+		address    string
+	}{
+		{
+			sentence:   "bottom drive obey lake curtain smoke basket hold race lonely fit walk",
+			miniSecret: "fac7959dbfe72f052e5a0c3c8d6530f202b02fd8f9f5ca3580ec8deb7797479e",
+			publicKey:  "46ebddef8cd9bb167dc30878d7113b7e168e6f0646beffd77d69d39bad76b47a",
+			address:    "5DfhGyQdFobKM8NsWvEeAKk5EQQgYe9AydgJ7rMB6E1EqRzV",
+		},
+		{
+			sentence:   "edge defense waste choose enrich upon flee junk siren film clown finish luggage leader kid quick brick print evidence swap drill paddle truly occur",
+			miniSecret: "e68d734b863d2412bd000070a5a5b11b0439e3911e07dbb32dea1b3878c82fc3",
+			publicKey:  "c4ecd2ea2785539c7a06da0486ba5c93924c5a37d9cc089586e932a03c3d0857",
+		},
+		{
+			sentence:   "edge defense waste choose enrich upon flee junk siren film clown finish luggage leader kid quick brick print evidence swap drill paddle truly occur",
+			passphrase: "some password",
+			miniSecret: "dcb12907610f75c2daa9cd93f46454088fb8b447c2c6267120fed86ae87a618a",
+			publicKey:  "66b9d6ff30bf52ee0dfe401a34134c94b7fa67bac48190d9b26446172326844d",
+		},
+	}
+
+	for _, test := range tests {
+		entropy, err := m.CalculateEntropy(strings.Split(test.sentence, " "))
+		if err != nil {
+			t.Errorf("unexpected error for the sentence (%s): %s", test.sentence, err.Error())
+		}
+
+		miniSecret, err := MiniSecret(entropy, test.passphrase)
+		if err != nil {
+			t.Errorf("unexpected error for the sentence (%s): %s", test.sentence, err.Error())
+		}
+		if actual := fmt.Sprintf("%x", miniSecret); actual != test.miniSecret {
+			t.Errorf("expected mini secret '%s' but actual '%s'", test.miniSecret, actual)
+		}
+
+		publicKey, err := PublicKey(miniSecret)
+		if err != nil {
+			t.Errorf("unexpected error for the sentence (%s): %s", test.sentence, err.Error())
+		}
+		if actual := fmt.Sprintf("%x", publicKey); actual != test.publicKey {
+			t.Errorf("expected public key '%s' but actual '%s'", test.publicKey, actual)
+		}
+
+		if test.address == "" {
+			continue
+		}
+		address, err := Address(publicKey, PrefixSubstrate)
+		if err != nil {
+			t.Errorf("unexpected error for the sentence (%s): %s", test.sentence, err.Error())
+		}
+		if address != test.address {
+			t.Errorf("expected address '%s' but actual '%s'", test.address, address)
+		}
+	}
+
+	_, err = MiniSecret([]byte{1, 2, 3}, "")
+	if err == nil || err.Error() != "invalid entropy size: 3" {
+		t.Errorf("expected invalid entropy size err but actual %v", err)
+	}
+}
+
+func TestDecodeAddress(t *testing.T) {
+	publicKey := []byte{
+		70, 235, 221, 239, 140, 217, 187, 22, 125, 195, 8, 120, 215, 17, 59, 126,
+		22, 142, 111, 6, 70, 190, 255, 215, 125, 105, 211, 155, 173, 118, 180, 122,
+	}
+
+	for _, prefix := range []uint16{PrefixPolkadot, PrefixKusama, PrefixSubstrate, 255, 1284, 16383} {
+		address, err := Address(publicKey, prefix)
+		if err != nil {
+			t.Errorf("unexpected error for prefix %d: %s", prefix, err.Error())
+		}
+
+		actualKey, actualPrefix, err := DecodeAddress(address)
+		if err != nil {
+			t.Errorf("unexpected error for address %s: %s", address, err.Error())
+		}
+		if actualPrefix != prefix {
+			t.Errorf("expected prefix %d but actual %d", prefix, actualPrefix)
+		}
+		if !bytes.Equal(actualKey, publicKey) {
+			t.Errorf("expected public key %x but actual %x", publicKey, actualKey)
+		}
+	}
+
+	_, err := Address(publicKey, 16384)
+	if err == nil {
+		t.Errorf("expected err for prefix 16384 but actual nil")
+	}
+
+	_, _, err = DecodeAddress("5DfhGyQdFobKM8NsWvEeAKk5EQQgYe9AydgJ7rMB6E1EqRzW")
+	if err == nil || err.Error() != "invalid ss58 checksum" {
+		t.Errorf("expected invalid ss58 checksum err but actual %v", err)
+	}
+}
+
+func buildWords() ([]string, error) {
+	bytes, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		return nil, err
+	}
+	words := strings.Split(string(bytes), "\n")
+	return words, nil
+}
@@ -0,0 +1,79 @@
+package nomnemonic
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	_suggestMax      = 3
+	_suggestDistance = 2
+)
+
+// suggest returns the words of the list closest to the unknown word, the one
+// with the same 4 letters prefix first since bip39 words are unique by it,
+// then the ones within 2 edits
+func (m *mnemonicer) suggest(word string) []string {
+	type candidate struct {
+		word     string
+		distance int
+	}
+	var candidates []candidate
+	prefix := []rune(strings.ToLower(word))
+	if len(prefix) > _cardPrefix {
+		prefix = prefix[:_cardPrefix]
+	}
+	for _, w := range m.words {
+		if len(prefix) == _cardPrefix && strings.HasPrefix(w, string(prefix)) {
+			candidates = append(candidates, candidate{word: w})
+			continue
+		}
+		if d := editDistance(word, w); d <= _suggestDistance {
+			candidates = append(candidates, candidate{word: w, distance: d})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+	if len(candidates) > _suggestMax {
+		candidates = candidates[:_suggestMax]
+	}
+	suggestions := make([]string, len(candidates))
+	for i, c := range candidates {
+		suggestions[i] = c.word
+	}
+	return suggestions
+}
+
+// editDistance is the levenshtein distance of the runes of a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,225 @@
+package testutil
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+// Kind is how a corpus sentence was made
+type Kind string
+
+// the kinds of GenerateCorpus, a valid phrase and the near misses of it
+const (
+	KindValid      Kind = "valid"      // a valid phrase
+	KindChecksum   Kind = "checksum"   // the checksum bits of the last word changed
+	KindSwap       Kind = "swap"       // two words swapped, the checksum usually fails
+	KindTypo       Kind = "typo"       // one char of a word inserted, deleted or replaced
+	KindLength     Kind = "length"     // a word dropped or repeated
+	KindMixed      Kind = "mixed"      // words replaced by the words of another language at the same indexes
+	KindFormatting Kind = "formatting" // extra whitespace, upper case or separators
+	KindGarbage    Kind = "garbage"    // random tokens
+)
+
+var (
+	_kinds = []Kind{KindValid, KindChecksum, KindSwap, KindTypo, KindLength, KindMixed, KindFormatting, KindGarbage}
+
+	_corpusWordlists = map[string][]string{
+		"chinese-simplified":  wordlists.ChineseSimplified,
+		"chinese-traditional": wordlists.ChineseTraditional,
+		"czech":               wordlists.Czech,
+		"english":             wordlists.English,
+		"french":              wordlists.French,
+		"italian":             wordlists.Italian,
+		"japanese":            wordlists.Japanese,
+		"korean":              wordlists.Korean,
+		"spanish":             wordlists.Spanish,
+	}
+
+	_corpusSizes = []int{12, 15, 18, 21, 24}
+)
+
+// Sample is a sentence of the corpus, Valid reports whether its whitespace
+// separated words, exactly as written, are a valid phrase of the language
+type Sample struct {
+	Kind     Kind
+	Language string
+	Sentence string
+	Valid    bool
+}
+
+// GenerateCorpus returns n sentences to fuzz the phrase import of a wallet:
+// valid phrases of every official list and their near misses, changed
+// checksums, swapped words, typos, wrong lengths, mixed languages and odd
+// formatting. The same seed always gives the same corpus, half of it is
+// english
+func GenerateCorpus(seed int64, n int) []Sample {
+	rng := rand.New(rand.NewSource(seed))
+	languages := make([]string, 0, len(_corpusWordlists))
+	for l := range _corpusWordlists {
+		languages = append(languages, l)
+	}
+	sort.Strings(languages)
+
+	mnemonicers := make(map[string]nomnemonic.Mnemonicer, len(languages))
+	for _, l := range languages {
+		mnemonicers[l], _ = nomnemonic.New(_corpusWordlists[l])
+	}
+
+	corpus := make([]Sample, 0, n)
+	for i := 0; i < n; i++ {
+		language := "english"
+		if rng.Intn(2) == 0 {
+			language = languages[rng.Intn(len(languages))]
+		}
+		m := mnemonicers[language]
+		words := validPhrase(rng, m)
+
+		s := Sample{Kind: _kinds[rng.Intn(len(_kinds))], Language: language}
+		separator := " "
+		if language == "japanese" {
+			separator = "　"
+		}
+		list := _corpusWordlists[language]
+		switch s.Kind {
+		case KindChecksum:
+			words = changeChecksum(rng, list, words)
+		case KindSwap:
+			i := rng.Intn(len(words))
+			j := (i + 1 + rng.Intn(len(words)-1)) % len(words)
+			words[i], words[j] = words[j], words[i]
+		case KindTypo:
+			i := rng.Intn(len(words))
+			words[i] = typo(rng, words[i], list)
+		case KindLength:
+			i := rng.Intn(len(words))
+			if rng.Intn(2) == 0 {
+				words = append(words[:i], words[i+1:]...)
+			} else {
+				words = append(words[:i+1], words[i:]...)
+			}
+		case KindMixed:
+			other := languages[rng.Intn(len(languages))]
+			for other == language {
+				other = languages[rng.Intn(len(languages))]
+			}
+			words = mix(rng, list, _corpusWordlists[other], words)
+		case KindFormatting:
+			s.Sentence = format(rng, words)
+		case KindGarbage:
+			words = garbage(rng, list)
+		}
+		if s.Sentence == "" {
+			s.Sentence = strings.Join(words, separator)
+		}
+		s.Valid, _ = m.IsValid(strings.Fields(s.Sentence))
+		corpus = append(corpus, s)
+	}
+	return corpus
+}
+
+// validPhrase returns the phrase of random entropy of a random size
+func validPhrase(rng *rand.Rand, m nomnemonic.Mnemonicer) []string {
+	size := _corpusSizes[rng.Intn(len(_corpusSizes))]
+	entropy := make([]byte, size*4/3)
+	rng.Read(entropy)
+	words, _ := m.EntropyToWords(entropy)
+	return words
+}
+
+// changeChecksum flips some of the checksum bits ending the last word, the
+// entropy is unchanged so only the checksum fails
+func changeChecksum(rng *rand.Rand, list, words []string) []string {
+	bits := len(words) / 3
+	last := index(list, words[len(words)-1])
+	words[len(words)-1] = list[last^(1+rng.Intn(1<<bits-1))]
+	return words
+}
+
+// typo inserts, deletes or replaces a char of the word with one of another
+// word of the list
+func typo(rng *rand.Rand, word string, list []string) string {
+	runes := []rune(word)
+	donor := []rune(list[rng.Intn(len(list))])
+	r := donor[rng.Intn(len(donor))]
+	i := rng.Intn(len(runes))
+	switch {
+	case rng.Intn(3) == 0:
+		runes = append(runes[:i], append([]rune{r}, runes[i:]...)...)
+	case rng.Intn(2) == 0 && len(runes) > 1:
+		runes = append(runes[:i], runes[i+1:]...)
+	default:
+		runes[i] = r
+	}
+	return string(runes)
+}
+
+// mix replaces some of the words with the words of the other list at the
+// same indexes, like a wallet mixing up the languages
+func mix(rng *rand.Rand, list, other, words []string) []string {
+	for replaced := 0; replaced == 0; {
+		for i, w := range words {
+			if rng.Intn(3) == 0 {
+				words[i] = other[index(list, w)]
+				replaced++
+			}
+		}
+	}
+	return words
+}
+
+// format keeps the words and changes the way they are written
+func format(rng *rand.Rand, words []string) string {
+	separators := []string{"  ", "\t", "\n", ",", ", ", "-", " "}
+	var b strings.Builder
+	if rng.Intn(3) == 0 {
+		b.WriteString(" \n")
+	}
+	for i, w := range words {
+		if i > 0 {
+			b.WriteString(separators[rng.Intn(len(separators))])
+		}
+		switch rng.Intn(4) {
+		case 0:
+			w = strings.ToUpper(w)
+		case 1:
+			r, size := utf8.DecodeRuneInString(w)
+			w = string(unicode.ToTitle(r)) + w[size:]
+		}
+		b.WriteString(w)
+	}
+	if rng.Intn(3) == 0 {
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
+
+// garbage returns random tokens of the chars of the list, sometimes with
+// digits and punctuation
+func garbage(rng *rand.Rand, list []string) []string {
+	words := make([]string, 1+rng.Intn(30))
+	for i := range words {
+		donor := []rune(list[rng.Intn(len(list))] + "0123456789.;'")
+		token := make([]rune, 1+rng.Intn(10))
+		for j := range token {
+			token[j] = donor[rng.Intn(len(donor))]
+		}
+		words[i] = string(token)
+	}
+	return words
+}
+
+func index(list []string, word string) int {
+	for i, w := range list {
+		if w == word {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,66 @@
+package testutil
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestGenerateCorpus(t *testing.T) {
+	corpus := GenerateCorpus(42, 500)
+	if len(corpus) != 500 {
+		t.Fatalf("expected 500 samples but actual %d", len(corpus))
+	}
+	if again := GenerateCorpus(42, 500); !reflect.DeepEqual(corpus, again) {
+		t.Error("expected the same corpus for the same seed")
+	}
+	if other := GenerateCorpus(43, 500); reflect.DeepEqual(corpus, other) {
+		t.Error("expected another corpus for another seed")
+	}
+
+	kinds := make(map[Kind]int)
+	languages := make(map[string]int)
+	valid := 0
+	for _, s := range corpus {
+		kinds[s.Kind]++
+		languages[s.Language]++
+		if s.Valid {
+			valid++
+		}
+		m, err := nomnemonic.New(_corpusWordlists[s.Language])
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		words := strings.Fields(s.Sentence)
+		ok, err := m.IsValid(words)
+		if ok != s.Valid {
+			t.Errorf("%s %s: expected valid %t but actual %t for %s", s.Kind, s.Language, s.Valid, ok, s.Sentence)
+		}
+		switch s.Kind {
+		case KindValid:
+			if !ok {
+				t.Errorf("expected a valid phrase but actual %s: %v", s.Sentence, err)
+			}
+		case KindChecksum:
+			if ok || err != nil {
+				t.Errorf("expected known words with a wrong checksum but actual %v for %s", err, s.Sentence)
+			}
+		case KindLength:
+			if !errors.Is(err, nomnemonic.ErrUnsupportedSize) {
+				t.Errorf("expected a size error but actual %v for %s", err, s.Sentence)
+			}
+		}
+	}
+	if len(kinds) != len(_kinds) {
+		t.Errorf("expected every kind but actual %v", kinds)
+	}
+	if len(languages) != len(_corpusWordlists) || languages["english"] < 200 {
+		t.Errorf("expected every language and mostly english but actual %v", languages)
+	}
+	if valid == 0 || valid == len(corpus) {
+		t.Errorf("expected valid and invalid samples but actual %d valid", valid)
+	}
+}
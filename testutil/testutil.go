@@ -0,0 +1,90 @@
+// Package testutil provisions stable test wallets for integration tests: the
+// labeled phrases, seeds and bip32 master keys are derived from one
+// passphrase with a weak argon2id so every ci run gets the same wallets in
+// milliseconds. Never hold funds on these wallets, the weak kdf makes the
+// passphrase cheap to brute force. GenerateCorpus builds deterministic fuzz
+// inputs for the phrase import of a wallet
+package testutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/nomnemonic/nomnemonic"
+	"github.com/nomnemonic/nomnemonic/bip32"
+)
+
+const (
+	_salt = "nomnemonic testutil v1\x00"
+
+	// weak argon2id costs, fast on purpose
+	_kdfTime    = 1
+	_kdfMemory  = 64
+	_kdfThreads = 1
+)
+
+// Account is a provisioned test wallet
+type Account struct {
+	Label string
+	Words []string
+	Seed  []byte
+	Key   *bip32.Key
+}
+
+// Labels returns the n labels prefix-0 to prefix-(n-1)
+func Labels(prefix string, n int) []string {
+	labels := make([]string, n)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("%s-%d", prefix, i)
+	}
+	return labels
+}
+
+// Provision derives a wallet of size words for every label, the same
+// passphrase and label always give the same wallet and distinct labels give
+// unrelated ones
+func Provision(m nomnemonic.Mnemonicer, passphrase string, size int, labels ...string) ([]Account, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is required")
+	}
+	if size < 12 || size > 24 || size%3 != 0 {
+		return nil, fmt.Errorf("unsupported size %d", size)
+	}
+
+	accounts := make([]Account, 0, len(labels))
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		if seen[label] {
+			return nil, fmt.Errorf("duplicate label %s", label)
+		}
+		seen[label] = true
+
+		a, err := provision(m, passphrase, size, label)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, a)
+	}
+	return accounts, nil
+}
+
+func provision(m nomnemonic.Mnemonicer, passphrase string, size int, label string) (Account, error) {
+	salt := []byte(fmt.Sprintf("%s%d:%s", _salt, len(label), label))
+	entropy := argon2.IDKey([]byte(passphrase), salt, _kdfTime, _kdfMemory, _kdfThreads, uint32(size*4/3))
+	words, err := m.EntropyToWords(entropy)
+	if err != nil {
+		return Account{}, err
+	}
+	seed, err := m.GenerateSeed(strings.Join(words, " "), "")
+	if err != nil {
+		return Account{}, err
+	}
+	key, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return Account{}, err
+	}
+	return Account{Label: label, Words: words, Seed: seed, Key: key}, nil
+}
@@ -0,0 +1,84 @@
+package testutil
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestProvision(t *testing.T) {
+	m := buildMnemonicer(t)
+	labels := Labels("alice", 3)
+	if strings.Join(labels, ",") != "alice-0,alice-1,alice-2" {
+		t.Fatalf("expected alice-0 to alice-2 but actual %v", labels)
+	}
+
+	accounts, err := Provision(m, "ci passphrase", 12, labels...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := "chase chimney judge home talent way crash strike dance segment book cup"
+	if actual := strings.Join(accounts[0].Words, " "); actual != expected {
+		t.Errorf("expected %s but actual %s", expected, actual)
+	}
+
+	again, err := Provision(m, "ci passphrase", 12, labels[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if strings.Join(again[0].Words, " ") != strings.Join(accounts[1].Words, " ") || !bytes.Equal(again[0].Seed, accounts[1].Seed) {
+		t.Errorf("expected the wallet of %s not to depend on the other labels but actual %v and %v", labels[1], again[0].Words, accounts[1].Words)
+	}
+
+	phrases := make(map[string]bool)
+	for _, a := range accounts {
+		if ok, err := m.IsValid(a.Words); !ok || err != nil {
+			t.Errorf("%s: expected valid words but actual %v %v", a.Label, a.Words, err)
+		}
+		if len(a.Words) != 12 || len(a.Seed) != 64 || !a.Key.IsPrivate() {
+			t.Errorf("%s: expected 12 words, a 64 bytes seed and a private key but actual %+v", a.Label, a)
+		}
+		phrases[strings.Join(a.Words, " ")] = true
+	}
+	if len(phrases) != len(accounts) {
+		t.Errorf("expected unrelated wallets but actual %v", phrases)
+	}
+
+	other, err := Provision(m, "other passphrase", 12, labels[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if strings.Join(other[0].Words, " ") == strings.Join(accounts[0].Words, " ") {
+		t.Error("expected another passphrase to give another wallet")
+	}
+
+	for _, test := range []struct {
+		passphrase string
+		size       int
+		labels     []string
+		err        string
+	}{
+		{passphrase: "", size: 12, err: "passphrase is required"},
+		{passphrase: "ci passphrase", size: 13, err: "unsupported size 13"},
+		{passphrase: "ci passphrase", size: 12, labels: []string{"a", "a"}, err: "duplicate label a"},
+	} {
+		if _, err := Provision(m, test.passphrase, test.size, test.labels...); err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+}
+
+func buildMnemonicer(t *testing.T) nomnemonic.Mnemonicer {
+	bytes, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	m, err := nomnemonic.New(strings.Split(string(bytes), "\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return m
+}
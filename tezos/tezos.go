@@ -0,0 +1,96 @@
+// Package tezos derives Tezos ed25519 (tz1) accounts from the bip39 seed
+// using the slip-0010 path shared by Temple, Kukai and Ledger
+package tezos
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/nomnemonic/nomnemonic/internal/base58"
+	"github.com/nomnemonic/nomnemonic/slip10"
+)
+
+const (
+	_coinType = 1729
+
+	_addressHashSize = 20
+)
+
+var (
+	_prefixTz1  = []byte{6, 161, 159}
+	_prefixEdpk = []byte{13, 15, 37, 217}
+	_prefixEdsk = []byte{13, 15, 58, 7}
+)
+
+// Path returns the derivation path of the account
+func Path(account uint32) string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0'", _coinType, account)
+}
+
+// Key derives the ed25519 private key of the account from the seed
+func Key(seed []byte, account uint32) (ed25519.PrivateKey, error) {
+	master, err := slip10.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	k, err := master.DerivePath(Path(account))
+	if err != nil {
+		return nil, err
+	}
+	return k.PrivateKey(), nil
+}
+
+// Address derives the tz1 address of the account from the seed
+func Address(seed []byte, account uint32) (string, error) {
+	k, err := Key(seed, account)
+	if err != nil {
+		return "", err
+	}
+	return EncodeAddress(k.Public().(ed25519.PublicKey)), nil
+}
+
+// SecretKey derives the edsk encoded secret key of the account from the seed
+func SecretKey(seed []byte, account uint32) (string, error) {
+	k, err := Key(seed, account)
+	if err != nil {
+		return "", err
+	}
+	return EncodeSecretKey(k), nil
+}
+
+// EncodeAddress encodes the blake2b hash of the public key as tz1 address
+func EncodeAddress(publicKey ed25519.PublicKey) string {
+	h, _ := blake2b.New(_addressHashSize, nil)
+	h.Write(publicKey)
+	return encode(_prefixTz1, h.Sum(nil))
+}
+
+// EncodePublicKey encodes the public key as edpk
+func EncodePublicKey(publicKey ed25519.PublicKey) string {
+	return encode(_prefixEdpk, publicKey)
+}
+
+// EncodeSecretKey encodes the 32 bytes seed of the private key as edsk
+func EncodeSecretKey(privateKey ed25519.PrivateKey) string {
+	return encode(_prefixEdsk, privateKey.Seed())
+}
+
+// DecodeSecretKey decodes the unencrypted edsk secret key
+func DecodeSecretKey(secretKey string) (ed25519.PrivateKey, error) {
+	b, err := base58.CheckDecode(secretKey, base58.AlphabetBitcoin)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != len(_prefixEdsk)+ed25519.SeedSize || !bytes.HasPrefix(b, _prefixEdsk) {
+		return nil, errors.New("invalid edsk secret key")
+	}
+	return ed25519.NewKeyFromSeed(b[len(_prefixEdsk):]), nil
+}
+
+func encode(prefix, payload []byte) string {
+	return base58.CheckEncode(append(append([]byte{}, prefix...), payload...), base58.AlphabetBitcoin)
+}
@@ -0,0 +1,70 @@
+package tezos
+
+import (
+	"crypto/ed25519"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestAddress(t *testing.T) {
+	seed, err := buildSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+	if err != nil {
+		t.Errorf("couldn't generate seed: %s", err.Error())
+	}
+
+	address, err := Address(seed, 0)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if address != "tz1VQA4RP4fLjEEMW2FR4pE9kAg5abb5h5GL" {
+		t.Errorf("expected address '%s' but actual '%s'", "tz1VQA4RP4fLjEEMW2FR4pE9kAg5abb5h5GL", address)
+	}
+
+	secretKey, err := SecretKey(seed, 0)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if secretKey != "edsk4BBVKnpwdnJrx9PB4hLkXZHtceSdSZVTfKBXArhmZ3Jg87Lcxi" {
+		t.Errorf("expected secret key '%s' but actual '%s'", "edsk4BBVKnpwdnJrx9PB4hLkXZHtceSdSZVTfKBXArhmZ3Jg87Lcxi", secretKey)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	secretKey := "edsk4FTF78Qf1m2rykGpHqostAiq5gYW4YZEoGUSWBTJr2njsDHSnd"
+	k, err := DecodeSecretKey(secretKey)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	if actual := EncodeSecretKey(k); actual != secretKey {
+		t.Errorf("expected secret key '%s' but actual '%s'", secretKey, actual)
+	}
+
+	publicKey := k.Public().(ed25519.PublicKey)
+	if actual := EncodePublicKey(publicKey); actual != "edpkv45regue1bWtuHnCgLU8xWKLwa9qRqv4gimgJKro4LSc3C5VjV" {
+		t.Errorf("expected public key '%s' but actual '%s'", "edpkv45regue1bWtuHnCgLU8xWKLwa9qRqv4gimgJKro4LSc3C5VjV", actual)
+	}
+	if actual := EncodeAddress(publicKey); actual != "tz1LggX2HUdvJ1tF4Fvv8fjsrzLeW4Jr9t2Q" {
+		t.Errorf("expected address '%s' but actual '%s'", "tz1LggX2HUdvJ1tF4Fvv8fjsrzLeW4Jr9t2Q", actual)
+	}
+
+	_, err = DecodeSecretKey("tz1LggX2HUdvJ1tF4Fvv8fjsrzLeW4Jr9t2Q")
+	if err == nil || err.Error() != "invalid edsk secret key" {
+		t.Errorf("expected invalid edsk secret key err but actual %v", err)
+	}
+}
+
+func buildSeed(sentence string) ([]byte, error) {
+	bytes, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		return nil, err
+	}
+	m, err := nomnemonic.New(strings.Split(string(bytes), "\n"))
+	if err != nil {
+		return nil, err
+	}
+	return m.GenerateSeed(sentence, "")
+}
@@ -0,0 +1,197 @@
+// Package timelock locks export containers in a Rivest-Shamir-Wagner time
+// lock puzzle, so a backup only opens after a number of sequential modular
+// squarings, for inheritance and cooling-off periods. Locking is instant with
+// the factors of the modulus, which are thrown away, and unlocking takes the
+// squarings one after the other, they can not be parallelized. The time is
+// an estimate from the squaring rate of a machine, a faster one opens
+// earlier, so calibrate on the fastest hardware the heirs may use. drand
+// rounds would tie it to a date but need pairings this module does not have
+package timelock
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	_version = 1
+	_keyTag  = "nomnemonic timelock v1"
+
+	// _progressChunk is the number of squarings between two progress calls
+	_progressChunk = 1 << 14
+)
+
+// _modulusBits is the size of the rsa modulus, a var so the tests use a
+// smaller one
+var _modulusBits = 2048
+
+type (
+	// Progress is called between the chunks of squarings with the completed
+	// fraction, returning an error aborts Unlock with it
+	Progress func(done float64) error
+
+	// Info is the public part of a locked blob
+	Info struct {
+		Squarings uint64
+		// NotBefore is the date the locker expected, it is informational,
+		// the squarings are what holds the lock
+		NotBefore time.Time
+	}
+
+	envelope struct {
+		Version    int       `json:"version"`
+		Modulus    []byte    `json:"modulus"`
+		Squarings  uint64    `json:"squarings"`
+		NotBefore  time.Time `json:"not_before,omitempty"`
+		Nonce      []byte    `json:"nonce"`
+		Ciphertext []byte    `json:"ciphertext"`
+	}
+)
+
+// Lock encrypts the container with a key only recovered after the squarings,
+// notBefore is recorded for the heirs, use Squarings to get the count of a
+// date
+func Lock(container []byte, squarings uint64, notBefore time.Time) ([]byte, error) {
+	if squarings == 0 {
+		return nil, errors.New("timelock: at least one squaring is required")
+	}
+	p, err := rand.Prime(rand.Reader, _modulusBits/2)
+	if err != nil {
+		return nil, err
+	}
+	q, err := rand.Prime(rand.Reader, _modulusBits/2)
+	if err != nil {
+		return nil, err
+	}
+	n := new(big.Int).Mul(p, q)
+	one := big.NewInt(1)
+	phi := new(big.Int).Mul(new(big.Int).Sub(p, one), new(big.Int).Sub(q, one))
+
+	// 2^(2^t) mod n is 2^(2^t mod phi) mod n with the factors
+	e := new(big.Int).Exp(big.NewInt(2), new(big.Int).SetUint64(squarings), phi)
+	solution := new(big.Int).Exp(big.NewInt(2), e, n)
+
+	env := envelope{Version: _version, Modulus: n.Bytes(), Squarings: squarings, NotBefore: notBefore.UTC().Truncate(time.Second)}
+	aead, err := chacha20poly1305.NewX(env.key(solution))
+	if err != nil {
+		return nil, err
+	}
+	env.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return nil, err
+	}
+	env.Ciphertext = aead.Seal(nil, env.Nonce, container, env.additionalData())
+	return json.Marshal(env)
+}
+
+// Unlock computes the squarings of the blob and returns the container, the
+// progress is optional
+func Unlock(blob []byte, progress Progress) ([]byte, error) {
+	env, err := parse(blob)
+	if err != nil {
+		return nil, err
+	}
+	n := new(big.Int).SetBytes(env.Modulus)
+	x := big.NewInt(2)
+	for i := uint64(1); i <= env.Squarings; i++ {
+		x.Mul(x, x).Mod(x, n)
+		if progress != nil && i%_progressChunk == 0 {
+			if err := progress(float64(i) / float64(env.Squarings)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	aead, err := chacha20poly1305.NewX(env.key(x))
+	if err != nil {
+		return nil, err
+	}
+	container, err := aead.Open(nil, env.Nonce, env.Ciphertext, env.additionalData())
+	if err != nil {
+		return nil, errors.New("timelock: corrupted blob")
+	}
+	if progress != nil {
+		if err := progress(1); err != nil {
+			return nil, err
+		}
+	}
+	return container, nil
+}
+
+// Inspect returns the squarings and the expected date of the blob without
+// unlocking it
+func Inspect(blob []byte) (Info, error) {
+	env, err := parse(blob)
+	if err != nil {
+		return Info{}, err
+	}
+	return Info{Squarings: env.Squarings, NotBefore: env.NotBefore}, nil
+}
+
+// Rate measures the squarings per second of this machine on a modulus of
+// the lock size
+func Rate(sample time.Duration) (float64, error) {
+	n, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), uint(_modulusBits)))
+	if err != nil {
+		return 0, err
+	}
+	n.SetBit(n, _modulusBits-1, 1).SetBit(n, 0, 1)
+	x := big.NewInt(2)
+	count := 0
+	start := time.Now()
+	for time.Since(start) < sample || count == 0 {
+		for i := 0; i < 1024; i++ {
+			x.Mul(x, x).Mod(x, n)
+		}
+		count += 1024
+	}
+	return float64(count) / time.Since(start).Seconds(), nil
+}
+
+// Squarings returns the squarings taking until the date at the rate
+func Squarings(until time.Time, rate float64) (uint64, error) {
+	d := time.Until(until)
+	if d <= 0 {
+		return 0, errors.New("timelock: the date is in the past")
+	}
+	if rate <= 0 {
+		return 0, errors.New("timelock: the rate must be positive")
+	}
+	return uint64(d.Seconds() * rate), nil
+}
+
+func parse(blob []byte) (envelope, error) {
+	var env envelope
+	if err := json.Unmarshal(blob, &env); err != nil || env.Version != _version {
+		return envelope{}, errors.New("timelock: invalid blob")
+	}
+	if len(env.Modulus) < 64 || env.Squarings == 0 || len(env.Nonce) != chacha20poly1305.NonceSizeX {
+		return envelope{}, errors.New("timelock: invalid blob")
+	}
+	return env, nil
+}
+
+// key hashes the solution, padded to the size of the modulus, into the key
+func (e envelope) key(solution *big.Int) []byte {
+	h := sha256.New()
+	h.Write([]byte(_keyTag))
+	h.Write(solution.FillBytes(make([]byte, len(e.Modulus))))
+	return h.Sum(nil)
+}
+
+// additionalData authenticates the version, the squarings, the date and the
+// modulus
+func (e envelope) additionalData() []byte {
+	ad := []byte(fmt.Sprintf("%s %d ", _keyTag, e.Version))
+	ad = binary.BigEndian.AppendUint64(ad, e.Squarings)
+	ad = binary.BigEndian.AppendUint64(ad, uint64(e.NotBefore.Unix()))
+	return append(ad, e.Modulus...)
+}
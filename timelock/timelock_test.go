@@ -0,0 +1,121 @@
+package timelock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	_modulusBits = 512
+	m.Run()
+}
+
+func TestLockUnlock(t *testing.T) {
+	container := []byte("container")
+	notBefore := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []uint64{1, 2, 1000, 3 * _progressChunk}
+	for _, squarings := range tests {
+		blob, err := Lock(container, squarings, notBefore)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		info, err := Inspect(blob)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if info.Squarings != squarings || !info.NotBefore.Equal(notBefore) {
+			t.Errorf("expected %d squarings until %s but actual %+v", squarings, notBefore, info)
+		}
+
+		var calls []float64
+		actual, err := Unlock(blob, func(done float64) error {
+			calls = append(calls, done)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if !bytes.Equal(actual, container) {
+			t.Errorf("expected %q but actual %q", container, actual)
+		}
+		if expected := int(squarings/_progressChunk) + 1; len(calls) != expected || calls[len(calls)-1] != 1 {
+			t.Errorf("expected %d progress calls ending with 1 but actual %v", expected, calls)
+		}
+	}
+}
+
+func TestUnlockInvalid(t *testing.T) {
+	blob, err := Lock([]byte("container"), 100, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	tamper := func(f func(*envelope)) []byte {
+		var env envelope
+		if err := json.Unmarshal(blob, &env); err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		f(&env)
+		b, _ := json.Marshal(env)
+		return b
+	}
+
+	tests := []struct {
+		name string
+		blob []byte
+	}{
+		{name: "garbage", blob: []byte("garbage")},
+		{name: "version", blob: tamper(func(e *envelope) { e.Version = 2 })},
+		{name: "fewer squarings", blob: tamper(func(e *envelope) { e.Squarings = 99 })},
+		{name: "no squarings", blob: tamper(func(e *envelope) { e.Squarings = 0 })},
+		{name: "date", blob: tamper(func(e *envelope) { e.NotBefore = time.Unix(1, 0).UTC() })},
+		{name: "ciphertext", blob: tamper(func(e *envelope) { e.Ciphertext[0] ^= 1 })},
+	}
+	for _, tc := range tests {
+		if _, err := Unlock(tc.blob, nil); err == nil {
+			t.Errorf("%s: expected an error but actual none", tc.name)
+		}
+	}
+}
+
+func TestUnlockAbort(t *testing.T) {
+	blob, err := Lock([]byte("container"), 2*_progressChunk, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	abort := errors.New("abort")
+	if _, err := Unlock(blob, func(float64) error { return abort }); !errors.Is(err, abort) {
+		t.Errorf("expected %v but actual %v", abort, err)
+	}
+}
+
+func TestSquarings(t *testing.T) {
+	if _, err := Lock([]byte("container"), 0, time.Time{}); err == nil {
+		t.Error("expected an error for no squarings but actual none")
+	}
+	if _, err := Squarings(time.Now().Add(-time.Hour), 1000); err == nil {
+		t.Error("expected an error for a past date but actual none")
+	}
+	if _, err := Squarings(time.Now().Add(time.Hour), 0); err == nil {
+		t.Error("expected an error for no rate but actual none")
+	}
+	squarings, err := Squarings(time.Now().Add(time.Hour), 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if squarings < 3599000 || squarings > 3600000 {
+		t.Errorf("expected about 3600000 squarings but actual %d", squarings)
+	}
+
+	rate, err := Rate(10 * time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if rate <= 0 {
+		t.Errorf("expected a positive rate but actual %f", rate)
+	}
+}
@@ -0,0 +1,177 @@
+// Package tpm seals export containers to the pcr policy of the local TPM 2.0,
+// through tpm2-tools, so a stored backup blob is only opened on the same
+// machine with the same boot chain. The TPM seals a random key encrypting the
+// container, sealed objects hold 128 bytes at most
+package tpm
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/nomnemonic/nomnemonic/internal/command"
+)
+
+const (
+	_version = 1
+
+	// DefaultPCRs are the firmware, its configuration, the boot loader and
+	// the secure boot state
+	DefaultPCRs = "sha256:0,1,2,3,4,7"
+)
+
+var (
+	// ErrPCRMismatch is returned when the pcrs of the machine differ from the
+	// sealed ones, the machine or its boot chain changed
+	ErrPCRMismatch = errors.New("tpm: the pcrs of the machine differ from the sealed ones")
+
+	_pcrs = regexp.MustCompile(`^(sha1|sha256|sha384):\d{1,2}(,\d{1,2})*$`)
+)
+
+type (
+	// Sealer seals to the pcrs of the local TPM, like sha256:0,7
+	Sealer struct {
+		pcrs string
+		run  command.Runner
+	}
+
+	// sealed is the json of a sealed container
+	sealed struct {
+		Version    int    `json:"version"`
+		PCRs       string `json:"pcrs"`
+		Public     []byte `json:"public"`
+		Private    []byte `json:"private"`
+		Nonce      []byte `json:"nonce"`
+		Ciphertext []byte `json:"ciphertext"`
+	}
+)
+
+// New returns the sealer of the pcrs, DefaultPCRs when empty
+func New(pcrs string) (*Sealer, error) {
+	if pcrs == "" {
+		pcrs = DefaultPCRs
+	}
+	if !_pcrs.MatchString(pcrs) {
+		return nil, fmt.Errorf("tpm: invalid pcrs %s", pcrs)
+	}
+	return &Sealer{pcrs: pcrs, run: command.Run}, nil
+}
+
+// Seal encrypts the container with a random key sealed to the current pcrs
+// of the TPM and returns the sealed blob
+func (s *Sealer) Seal(container []byte) ([]byte, error) {
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	var public, private []byte
+	err := s.session(func(dir string) error {
+		policy := filepath.Join(dir, "policy.digest")
+		if _, err := s.run(nil, "tpm2_createpolicy", "--policy-pcr", "-l", s.pcrs, "-L", policy); err != nil {
+			return err
+		}
+		pub, priv := filepath.Join(dir, "seal.pub"), filepath.Join(dir, "seal.priv")
+		if _, err := s.run(key, "tpm2_create", "-C", filepath.Join(dir, "primary.ctx"), "-L", policy, "-i", "-", "-u", pub, "-r", priv); err != nil {
+			return err
+		}
+		var err error
+		if public, err = os.ReadFile(pub); err != nil {
+			return err
+		}
+		private, err = os.ReadFile(priv)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b := sealed{Version: _version, PCRs: s.pcrs, Public: public, Private: private}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	b.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(b.Nonce); err != nil {
+		return nil, err
+	}
+	b.Ciphertext = aead.Seal(nil, b.Nonce, container, b.additionalData())
+	return json.Marshal(b)
+}
+
+// Unseal unseals the key of the blob with the TPM and returns the container,
+// it fails with ErrPCRMismatch on another machine or boot chain
+func (s *Sealer) Unseal(blob []byte) ([]byte, error) {
+	var b sealed
+	if err := json.Unmarshal(blob, &b); err != nil || b.Version != _version || !_pcrs.MatchString(b.PCRs) {
+		return nil, errors.New("tpm: invalid sealed blob")
+	}
+
+	var key []byte
+	err := s.session(func(dir string) error {
+		pub, priv, ctx := filepath.Join(dir, "seal.pub"), filepath.Join(dir, "seal.priv"), filepath.Join(dir, "seal.ctx")
+		if err := os.WriteFile(pub, b.Public, 0o600); err != nil {
+			return err
+		}
+		if err := os.WriteFile(priv, b.Private, 0o600); err != nil {
+			return err
+		}
+		if _, err := s.run(nil, "tpm2_load", "-C", filepath.Join(dir, "primary.ctx"), "-u", pub, "-r", priv, "-c", ctx); err != nil {
+			return err
+		}
+		var err error
+		key, err = s.run(nil, "tpm2_unseal", "-c", ctx, "-p", "pcr:"+b.PCRs)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, errors.New("tpm: invalid sealed key")
+	}
+	container, err := aead.Open(nil, b.Nonce, b.Ciphertext, b.additionalData())
+	if err != nil {
+		return nil, errors.New("tpm: invalid sealed blob")
+	}
+	return container, nil
+}
+
+// session runs the steps in a private directory holding the primary key of
+// the owner hierarchy, the same primary is derived on every call
+func (s *Sealer) session(steps func(dir string) error) error {
+	dir, err := os.MkdirTemp("", "nomnemonic-tpm")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := s.run(nil, "tpm2_createprimary", "-C", "o", "-c", filepath.Join(dir, "primary.ctx")); err != nil {
+		return tpmError(err)
+	}
+	return tpmError(steps(dir))
+}
+
+// additionalData binds the ciphertext to the pcrs and the sealed object
+func (b sealed) additionalData() []byte {
+	return []byte(fmt.Sprintf("nomnemonic tpm v%d\x00%s\x00%x", b.Version, b.PCRs, b.Public))
+}
+
+// tpmError maps the tpm2-tools errors
+func tpmError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if strings.Contains(err.Error(), "TPM_RC_POLICY_FAIL") || strings.Contains(err.Error(), "TPM_RC_PCR_CHANGED") {
+		return ErrPCRMismatch
+	}
+	return fmt.Errorf("tpm: %w", err)
+}
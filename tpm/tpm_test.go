@@ -0,0 +1,95 @@
+package tpm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// fakeTPM emulates tpm2-tools with pcrs of the boot chain in state
+type fakeTPM struct {
+	state string
+}
+
+func (f *fakeTPM) run(stdin []byte, name string, args ...string) ([]byte, error) {
+	arg := func(flag string) string {
+		for i, a := range args {
+			if a == flag {
+				return args[i+1]
+			}
+		}
+		return ""
+	}
+	switch name {
+	case "tpm2_createprimary":
+		return nil, os.WriteFile(arg("-c"), []byte("primary"), 0o600)
+	case "tpm2_createpolicy":
+		return nil, os.WriteFile(arg("-L"), []byte(arg("-l")+f.state), 0o600)
+	case "tpm2_create":
+		policy, _ := os.ReadFile(arg("-L"))
+		if err := os.WriteFile(arg("-u"), policy, 0o600); err != nil {
+			return nil, err
+		}
+		return nil, os.WriteFile(arg("-r"), stdin, 0o600)
+	case "tpm2_load":
+		pub, _ := os.ReadFile(arg("-u"))
+		priv, _ := os.ReadFile(arg("-r"))
+		return nil, os.WriteFile(arg("-c"), append(append(pub, 0), priv...), 0o600)
+	case "tpm2_unseal":
+		ctx, _ := os.ReadFile(arg("-c"))
+		i := bytes.IndexByte(ctx, 0)
+		if string(ctx[:i]) != strings.TrimPrefix(arg("-p"), "pcr:")+f.state {
+			return nil, errors.New("exit status 1: ERROR: Esys_Unseal(0x99D) - tpm:session(1):a policy check failed TPM_RC_POLICY_FAIL")
+		}
+		return ctx[i+1:], nil
+	}
+	return nil, errors.New("unknown command")
+}
+
+func TestSeal(t *testing.T) {
+	if _, err := New("sha256:0,x"); err == nil || err.Error() != "tpm: invalid pcrs sha256:0,x" {
+		t.Errorf("expected an invalid pcrs error but actual %v", err)
+	}
+
+	tpm := &fakeTPM{state: "boot a"}
+	s, err := New("")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	s.run = tpm.run
+
+	container := []byte("export container")
+	blob, err := s.Seal(container)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if bytes.Contains(blob, container) {
+		t.Error("expected the container to be encrypted in the blob")
+	}
+	opened, err := s.Unseal(blob)
+	if err != nil || !bytes.Equal(opened, container) {
+		t.Errorf("expected '%s' but actual '%s' %v", container, opened, err)
+	}
+
+	var b sealed
+	_ = json.Unmarshal(blob, &b)
+	if b.PCRs != DefaultPCRs {
+		t.Errorf("expected pcrs %s but actual %s", DefaultPCRs, b.PCRs)
+	}
+	b.PCRs = "sha256:0"
+	tampered, _ := json.Marshal(b)
+	if _, err := s.Unseal(tampered); err == nil {
+		t.Error("expected an error for other pcrs")
+	}
+	if _, err := s.Unseal([]byte("{}")); err == nil || err.Error() != "tpm: invalid sealed blob" {
+		t.Errorf("expected an invalid blob error but actual %v", err)
+	}
+
+	tpm.state = "boot b"
+	if _, err := s.Unseal(blob); !errors.Is(err, ErrPCRMismatch) {
+		t.Errorf("expected %v but actual %v", ErrPCRMismatch, err)
+	}
+}
@@ -0,0 +1,465 @@
+package ur
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	// DefaultFragmentSize fits a part in a version 10 or so qr code, dense
+	// enough for phone cameras
+	DefaultFragmentSize = 100
+
+	_minFragmentSize = 10
+)
+
+type (
+	// Encoder emits the parts of a multi-part ur for an animated qr code,
+	// the first parts are the fragments of the message and the next ones
+	// are fountain coded mixes of them, so a receiver completes from any
+	// large enough subset of the parts
+	Encoder struct {
+		ur        UR
+		fragments [][]byte
+		checksum  uint32
+		seq       uint32
+	}
+
+	// Decoder assembles a ur from the parts of an Encoder received in any
+	// order, duplicates and missing parts included
+	Decoder struct {
+		typ      string
+		seqLen   int
+		size     int
+		checksum uint32
+		simple   map[int][]byte
+		mixed    []mixedPart
+		result   *UR
+	}
+
+	// part is the cbor body of a multi-part ur
+	part struct {
+		_        struct{} `cbor:",toarray"`
+		Seq      uint32
+		SeqLen   int
+		Size     int
+		Checksum uint32
+		Data     []byte
+	}
+
+	mixedPart struct {
+		indexes map[int]bool
+		data    []byte
+	}
+
+	// xoshiro256 is the xoshiro256** generator, seeded by a sha256 digest,
+	// the fountain code needs the bit exact sequence of the reference
+	// implementation
+	xoshiro256 [4]uint64
+
+	// sampler picks a degree with the walker alias method
+	sampler struct {
+		probs   []float64
+		aliases []int
+	}
+)
+
+// NewEncoder splits the resource into fragments of at most maxFragmentSize
+// bytes
+func NewEncoder(u UR, maxFragmentSize int) (*Encoder, error) {
+	if !validType(u.Type) {
+		return nil, fmt.Errorf("ur: invalid type %s", u.Type)
+	}
+	if len(u.CBOR) == 0 {
+		return nil, errors.New("ur: empty message")
+	}
+	if maxFragmentSize < _minFragmentSize {
+		return nil, fmt.Errorf("ur: fragments must be at least %d bytes", _minFragmentSize)
+	}
+	size := fragmentSize(len(u.CBOR), maxFragmentSize)
+	count := (len(u.CBOR) + size - 1) / size
+	padded := make([]byte, count*size)
+	copy(padded, u.CBOR)
+
+	e := &Encoder{ur: u, checksum: crc32.ChecksumIEEE(u.CBOR)}
+	for i := 0; i < count; i++ {
+		e.fragments = append(e.fragments, padded[i*size:(i+1)*size])
+	}
+	return e, nil
+}
+
+// fragmentSize returns the smallest fragment size splitting the message in
+// fragments of at most maxSize bytes
+func fragmentSize(messageSize, maxSize int) int {
+	maxCount := messageSize / _minFragmentSize
+	if maxCount < 1 {
+		maxCount = 1
+	}
+	size := messageSize
+	for count := 1; count <= maxCount; count++ {
+		if size = (messageSize + count - 1) / count; size <= maxSize {
+			break
+		}
+	}
+	return size
+}
+
+// SinglePart reports whether the resource fits a single part
+func (e *Encoder) SinglePart() bool {
+	return len(e.fragments) == 1
+}
+
+// SeqLen returns the number of fragments, the least number of parts to
+// decode
+func (e *Encoder) SeqLen() int {
+	return len(e.fragments)
+}
+
+// NextPart returns the next part, the single part ur when the resource fits
+// one part. It never ends, the qr code loops over the parts until the
+// receiver completes
+func (e *Encoder) NextPart() string {
+	if e.SinglePart() {
+		return e.ur.String()
+	}
+	e.seq++
+	data := make([]byte, len(e.fragments[0]))
+	for _, i := range chooseFragments(e.seq, len(e.fragments), e.checksum) {
+		xor(data, e.fragments[i])
+	}
+	body, _ := cbor.Marshal(part{
+		Seq:      e.seq,
+		SeqLen:   len(e.fragments),
+		Size:     len(e.ur.CBOR),
+		Checksum: e.checksum,
+		Data:     data,
+	})
+	return fmt.Sprintf("%s%s/%d-%d/%s", _scheme, e.ur.Type, e.seq, len(e.fragments), Encode(body))
+}
+
+// NewDecoder returns an empty decoder
+func NewDecoder() *Decoder {
+	return &Decoder{simple: map[int][]byte{}}
+}
+
+// Receive adds a single or multi-part ur, parts of another resource are
+// rejected
+func (d *Decoder) Receive(s string) error {
+	if d.result != nil {
+		return nil
+	}
+	typ, components, err := split(s)
+	if err != nil {
+		return err
+	}
+	if len(components) == 1 {
+		u, err := Parse(s)
+		if err != nil {
+			return err
+		}
+		d.result = &u
+		return nil
+	}
+
+	seq, seqLen, err := parseSequence(components[0])
+	if err != nil {
+		return err
+	}
+	body, err := Decode(components[1])
+	if err != nil {
+		return err
+	}
+	var p part
+	if err := cbor.Unmarshal(body, &p); err != nil {
+		return fmt.Errorf("ur: invalid part: %w", err)
+	}
+	if p.Seq != seq || p.SeqLen != seqLen || p.Seq == 0 || p.SeqLen < 1 || len(p.Data) == 0 ||
+		p.Size < 1 || p.Size > p.SeqLen*len(p.Data) {
+		return errors.New("ur: invalid part header")
+	}
+
+	if d.typ == "" {
+		d.typ, d.seqLen, d.size, d.checksum = typ, p.SeqLen, p.Size, p.Checksum
+	} else if typ != d.typ || p.SeqLen != d.seqLen || p.Size != d.size || p.Checksum != d.checksum ||
+		len(p.Data) != d.fragmentSize() {
+		return errors.New("ur: part of another resource")
+	}
+
+	indexes := map[int]bool{}
+	for _, i := range chooseFragments(p.Seq, p.SeqLen, p.Checksum) {
+		indexes[i] = true
+	}
+	d.add(mixedPart{indexes: indexes, data: p.Data})
+	if len(d.simple) == d.seqLen {
+		return d.join()
+	}
+	return nil
+}
+
+func (d *Decoder) fragmentSize() int {
+	for _, f := range d.simple {
+		return len(f)
+	}
+	for _, m := range d.mixed {
+		return len(m.data)
+	}
+	return 0
+}
+
+// add reduces the part by the known fragments and mixes until it is a known
+// fragment, a new fragment or a new mix, new fragments reduce the mixes in
+// turn
+func (d *Decoder) add(p mixedPart) {
+	queue := []mixedPart{p}
+	for len(queue) > 0 {
+		p, queue = queue[0], queue[1:]
+		for i := range p.indexes {
+			if f, ok := d.simple[i]; ok && len(p.indexes) > 1 {
+				p = reduce(p, map[int]bool{i: true}, f)
+			}
+		}
+		for _, m := range d.mixed {
+			if subset(m.indexes, p.indexes) && len(m.indexes) < len(p.indexes) {
+				p = reduce(p, m.indexes, m.data)
+			}
+		}
+		if len(p.indexes) == 0 {
+			continue
+		}
+
+		if len(p.indexes) == 1 {
+			i := first(p.indexes)
+			if _, ok := d.simple[i]; ok {
+				continue
+			}
+			d.simple[i] = p.data
+			mixed := d.mixed[:0]
+			for _, m := range d.mixed {
+				if m.indexes[i] {
+					queue = append(queue, m)
+				} else {
+					mixed = append(mixed, m)
+				}
+			}
+			d.mixed = mixed
+			continue
+		}
+
+		known := false
+		for _, m := range d.mixed {
+			known = known || sameIndexes(m.indexes, p.indexes)
+		}
+		if !known {
+			d.mixed = append(d.mixed, p)
+		}
+	}
+}
+
+func (d *Decoder) join() error {
+	message := make([]byte, 0, d.seqLen*d.fragmentSize())
+	for i := 0; i < d.seqLen; i++ {
+		message = append(message, d.simple[i]...)
+	}
+	message = message[:d.size]
+	if crc32.ChecksumIEEE(message) != d.checksum {
+		return ErrChecksum
+	}
+	d.result = &UR{Type: d.typ, CBOR: message}
+	return nil
+}
+
+// Complete reports whether the resource is decoded
+func (d *Decoder) Complete() bool {
+	return d.result != nil
+}
+
+// Progress returns the share of the fragments decoded, between 0 and 1
+func (d *Decoder) Progress() float64 {
+	if d.result != nil {
+		return 1
+	}
+	if d.seqLen == 0 {
+		return 0
+	}
+	return float64(len(d.simple)) / float64(d.seqLen)
+}
+
+// Result returns the decoded resource
+func (d *Decoder) Result() (UR, error) {
+	if d.result == nil {
+		return UR{}, errors.New("ur: incomplete resource")
+	}
+	return *d.result, nil
+}
+
+func parseSequence(s string) (uint32, int, error) {
+	seq, seqLen, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, errors.New("ur: invalid sequence")
+	}
+	n, err := strconv.ParseUint(seq, 10, 32)
+	if err != nil {
+		return 0, 0, errors.New("ur: invalid sequence")
+	}
+	l, err := strconv.Atoi(seqLen)
+	if err != nil {
+		return 0, 0, errors.New("ur: invalid sequence")
+	}
+	return uint32(n), l, nil
+}
+
+func reduce(p mixedPart, indexes map[int]bool, data []byte) mixedPart {
+	r := mixedPart{indexes: map[int]bool{}, data: append([]byte(nil), p.data...)}
+	for i := range p.indexes {
+		if !indexes[i] {
+			r.indexes[i] = true
+		}
+	}
+	xor(r.data, data)
+	return r
+}
+
+// first returns an index of the set, the only one of single fragments
+func first(indexes map[int]bool) int {
+	for i := range indexes {
+		return i
+	}
+	return -1
+}
+
+func subset(a, b map[int]bool) bool {
+	for i := range a {
+		if !b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sameIndexes(a, b map[int]bool) bool {
+	return len(a) == len(b) && subset(a, b)
+}
+
+func xor(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
+
+// chooseFragments returns the indexes of the fragments mixed in the part,
+// the first seqLen parts are the fragments in order
+func chooseFragments(seq uint32, seqLen int, checksum uint32) []int {
+	if int(seq) <= seqLen {
+		return []int{int(seq) - 1}
+	}
+	var seed [8]byte
+	binary.BigEndian.PutUint32(seed[:], seq)
+	binary.BigEndian.PutUint32(seed[4:], checksum)
+	rng := newXoshiro256(seed[:])
+
+	probs := make([]float64, seqLen)
+	for i := range probs {
+		probs[i] = 1 / float64(i+1)
+	}
+	degree := newSampler(probs).next(rng) + 1
+
+	remaining := make([]int, seqLen)
+	for i := range remaining {
+		remaining[i] = i
+	}
+	indexes := make([]int, 0, degree)
+	for len(indexes) < degree {
+		i := rng.nextInt(0, uint64(len(remaining)-1))
+		indexes = append(indexes, remaining[i])
+		remaining = append(remaining[:i], remaining[i+1:]...)
+	}
+	return indexes
+}
+
+func newXoshiro256(seed []byte) *xoshiro256 {
+	digest := sha256.Sum256(seed)
+	var x xoshiro256
+	for i := range x {
+		x[i] = binary.BigEndian.Uint64(digest[i*8:])
+	}
+	return &x
+}
+
+func (x *xoshiro256) next() uint64 {
+	result := bits.RotateLeft64(x[1]*5, 7) * 9
+	t := x[1] << 17
+	x[2] ^= x[0]
+	x[3] ^= x[1]
+	x[1] ^= x[2]
+	x[0] ^= x[3]
+	x[2] ^= t
+	x[3] = bits.RotateLeft64(x[3], 45)
+	return result
+}
+
+func (x *xoshiro256) nextDouble() float64 {
+	return float64(x.next()) / (float64(math.MaxUint64) + 1)
+}
+
+func (x *xoshiro256) nextInt(low, high uint64) int {
+	return int(uint64(x.nextDouble()*float64(high-low+1)) + low)
+}
+
+func newSampler(probs []float64) *sampler {
+	sum := 0.0
+	for _, p := range probs {
+		sum += p
+	}
+	n := len(probs)
+	scaled := make([]float64, n)
+	var small, large []int
+	for i := n - 1; i >= 0; i-- {
+		scaled[i] = probs[i] * float64(n) / sum
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	s := &sampler{probs: make([]float64, n), aliases: make([]int, n)}
+	for len(small) > 0 && len(large) > 0 {
+		a := small[len(small)-1]
+		small = small[:len(small)-1]
+		g := large[len(large)-1]
+		large = large[:len(large)-1]
+		s.probs[a] = scaled[a]
+		s.aliases[a] = g
+		scaled[g] += scaled[a] - 1
+		if scaled[g] < 1 {
+			small = append(small, g)
+		} else {
+			large = append(large, g)
+		}
+	}
+	for _, i := range large {
+		s.probs[i] = 1
+	}
+	for _, i := range small {
+		s.probs[i] = 1
+	}
+	return s
+}
+
+func (s *sampler) next(rng *xoshiro256) int {
+	r1, r2 := rng.nextDouble(), rng.nextDouble()
+	i := int(float64(len(s.probs)) * r1)
+	if r2 < s.probs[i] {
+		return i
+	}
+	return s.aliases[i]
+}
@@ -0,0 +1,504 @@
+package ur
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+	"github.com/nomnemonic/nomnemonic/internal/base58"
+)
+
+// registry types
+const (
+	TypeSeed    = "crypto-seed"
+	TypeHDKey   = "crypto-hdkey"
+	TypeAccount = "crypto-account"
+)
+
+// output scripts of an account
+const (
+	ScriptPKH    = "pkh"
+	ScriptSHWPKH = "sh-wpkh"
+	ScriptWPKH   = "wpkh"
+	ScriptTR     = "tr"
+)
+
+const (
+	_tagDate     = 100
+	_tagHDKey    = 303
+	_tagKeypath  = 304
+	_tagCoinInfo = 305
+
+	_tagSH   = 400
+	_tagPKH  = 403
+	_tagWPKH = 404
+	_tagTR   = 409
+
+	_secondsPerDay = 24 * 60 * 60
+)
+
+var (
+	_cborEnc cbor.EncMode
+	_cborDec cbor.DecMode
+
+	// _scripts are the tags of the script expressions wrapping the key
+	_scripts = map[string][]uint64{
+		ScriptPKH:    {_tagPKH},
+		ScriptSHWPKH: {_tagSH, _tagWPKH},
+		ScriptWPKH:   {_tagWPKH},
+		ScriptTR:     {_tagTR},
+	}
+)
+
+type (
+	// Seed is a crypto-seed, the Birthdate is the day the seed was created
+	Seed struct {
+		Payload   []byte
+		Birthdate time.Time
+		Name      string
+		Note      string
+	}
+
+	// HDKey is a crypto-hdkey, the Key is the 33 bytes compressed public
+	// key or the 32 bytes private key
+	HDKey struct {
+		Master            bool
+		Private           bool
+		Key               []byte
+		ChainCode         []byte
+		UseInfo           *CoinInfo
+		Origin            *KeyPath
+		Children          *KeyPath
+		ParentFingerprint uint32
+		Name              string
+		Note              string
+	}
+
+	// CoinInfo is the coin type of slip44 and the network, 0 for mainnet and
+	// 1 for testnet
+	CoinInfo struct {
+		Type    uint32
+		Network uint32
+	}
+
+	// KeyPath is a crypto-keypath, the path from the master key of the
+	// SourceFingerprint
+	KeyPath struct {
+		Components        []PathComponent
+		SourceFingerprint uint32
+		Depth             uint8
+	}
+
+	// PathComponent is an index of a path, or * when Wildcard
+	PathComponent struct {
+		Index    uint32
+		Hardened bool
+		Wildcard bool
+	}
+
+	// Account is a crypto-account, the account keys of a master key as
+	// output descriptors
+	Account struct {
+		MasterFingerprint uint32
+		Outputs           []Output
+	}
+
+	// Output is an output descriptor of an account key
+	Output struct {
+		Script string
+		Key    HDKey
+	}
+
+	date uint64
+
+	seedCBOR struct {
+		Payload   []byte `cbor:"1,keyasint"`
+		Birthdate *date  `cbor:"2,keyasint,omitempty"`
+		Name      string `cbor:"3,keyasint,omitempty"`
+		Note      string `cbor:"4,keyasint,omitempty"`
+	}
+
+	hdkeyCBOR struct {
+		Master            bool          `cbor:"1,keyasint,omitempty"`
+		Private           bool          `cbor:"2,keyasint,omitempty"`
+		Key               []byte        `cbor:"3,keyasint"`
+		ChainCode         []byte        `cbor:"4,keyasint,omitempty"`
+		UseInfo           *coinInfoCBOR `cbor:"5,keyasint,omitempty"`
+		Origin            *keypathCBOR  `cbor:"6,keyasint,omitempty"`
+		Children          *keypathCBOR  `cbor:"7,keyasint,omitempty"`
+		ParentFingerprint uint32        `cbor:"8,keyasint,omitempty"`
+		Name              string        `cbor:"9,keyasint,omitempty"`
+		Note              string        `cbor:"10,keyasint,omitempty"`
+	}
+
+	coinInfoCBOR struct {
+		Type    uint32 `cbor:"1,keyasint,omitempty"`
+		Network uint32 `cbor:"2,keyasint,omitempty"`
+	}
+
+	keypathCBOR struct {
+		Components        []interface{} `cbor:"1,keyasint"`
+		SourceFingerprint uint32        `cbor:"2,keyasint,omitempty"`
+		Depth             uint8         `cbor:"3,keyasint,omitempty"`
+	}
+
+	accountCBOR struct {
+		MasterFingerprint uint32        `cbor:"1,keyasint"`
+		Outputs           []cbor.RawTag `cbor:"2,keyasint"`
+	}
+)
+
+func init() {
+	tags := cbor.NewTagSet()
+	opts := cbor.TagOptions{EncTag: cbor.EncTagRequired, DecTag: cbor.DecTagRequired}
+	for typ, num := range map[reflect.Type]uint64{
+		reflect.TypeOf(date(0)):        _tagDate,
+		reflect.TypeOf(coinInfoCBOR{}): _tagCoinInfo,
+		reflect.TypeOf(keypathCBOR{}):  _tagKeypath,
+	} {
+		if err := tags.Add(opts, typ, num); err != nil {
+			panic(err)
+		}
+	}
+	var err error
+	if _cborEnc, err = cbor.CoreDetEncOptions().EncModeWithTags(tags); err != nil {
+		panic(err)
+	}
+	if _cborDec, err = (cbor.DecOptions{DupMapKey: cbor.DupMapKeyEnforcedAPF}).DecModeWithTags(tags); err != nil {
+		panic(err)
+	}
+}
+
+// UR encodes the seed as a crypto-seed
+func (s Seed) UR() (UR, error) {
+	if len(s.Payload) == 0 {
+		return UR{}, errors.New("ur: empty seed")
+	}
+	v := seedCBOR{Payload: s.Payload, Name: s.Name, Note: s.Note}
+	if !s.Birthdate.IsZero() {
+		d := date(s.Birthdate.Unix() / _secondsPerDay)
+		v.Birthdate = &d
+	}
+	return marshal(TypeSeed, v)
+}
+
+// ParseSeed decodes a crypto-seed
+func ParseSeed(u UR) (Seed, error) {
+	var v seedCBOR
+	if err := unmarshal(u, TypeSeed, &v); err != nil {
+		return Seed{}, err
+	}
+	if len(v.Payload) == 0 {
+		return Seed{}, errors.New("ur: empty seed")
+	}
+	s := Seed{Payload: v.Payload, Name: v.Name, Note: v.Note}
+	if v.Birthdate != nil {
+		s.Birthdate = time.Unix(int64(*v.Birthdate)*_secondsPerDay, 0).UTC()
+	}
+	return s, nil
+}
+
+// NewHDKey returns the crypto-hdkey of the bip32 key derived along the path
+// from the master key of the fingerprint, the master key itself when the
+// path is empty
+func NewHDKey(k *bip32.Key, masterFingerprint []byte, path []uint32) (HDKey, error) {
+	if len(path) != int(k.Depth()) {
+		return HDKey{}, fmt.Errorf("ur: path of %d levels for a key of depth %d", len(path), k.Depth())
+	}
+	h := HDKey{Private: k.IsPrivate(), Key: k.PublicKey(), ChainCode: k.ChainCode()}
+	if h.Private {
+		h.Key = k.PrivateKey()
+	}
+	if len(path) == 0 {
+		h.Master = true
+		return h, nil
+	}
+	if len(masterFingerprint) != 4 {
+		return HDKey{}, errors.New("ur: invalid master fingerprint")
+	}
+
+	h.Origin = &KeyPath{SourceFingerprint: binary.BigEndian.Uint32(masterFingerprint), Depth: k.Depth()}
+	for _, index := range path {
+		h.Origin.Components = append(h.Origin.Components, PathComponent{
+			Index:    index &^ bip32.HardenedOffset,
+			Hardened: index >= bip32.HardenedOffset,
+		})
+	}
+	h.ParentFingerprint = binary.BigEndian.Uint32(k.ParentFingerprint())
+	return h, nil
+}
+
+// ExtendedKey returns the bip32 key, the child number is the last
+// component of the origin
+func (h HDKey) ExtendedKey() (*bip32.Key, error) {
+	if len(h.ChainCode) != 32 {
+		return nil, errors.New("ur: hdkey without chain code")
+	}
+	b := make([]byte, 0, 78)
+	version := bip32.VersionPublic
+	if h.Private {
+		version = bip32.VersionPrivate
+	}
+	b = binary.BigEndian.AppendUint32(b, version)
+	var childNumber uint32
+	if h.Origin != nil && len(h.Origin.Components) > 0 {
+		last := h.Origin.Components[len(h.Origin.Components)-1]
+		if last.Wildcard {
+			return nil, errors.New("ur: hdkey origin with a wildcard")
+		}
+		if childNumber = last.Index; last.Hardened {
+			childNumber += bip32.HardenedOffset
+		}
+		b = append(b, byte(len(h.Origin.Components)))
+	} else {
+		b = append(b, 0)
+	}
+	b = binary.BigEndian.AppendUint32(b, h.ParentFingerprint)
+	b = binary.BigEndian.AppendUint32(b, childNumber)
+	b = append(b, h.ChainCode...)
+	if h.Private {
+		b = append(b, 0)
+	}
+	b = append(b, h.Key...)
+	k, _, err := bip32.ParseKey(base58.CheckEncode(b, base58.AlphabetBitcoin))
+	return k, err
+}
+
+// UR encodes the key as a crypto-hdkey
+func (h HDKey) UR() (UR, error) {
+	v, err := h.cbor()
+	if err != nil {
+		return UR{}, err
+	}
+	return marshal(TypeHDKey, v)
+}
+
+// ParseHDKey decodes a crypto-hdkey
+func ParseHDKey(u UR) (HDKey, error) {
+	var v hdkeyCBOR
+	if err := unmarshal(u, TypeHDKey, &v); err != nil {
+		return HDKey{}, err
+	}
+	return v.hdkey()
+}
+
+func (h HDKey) cbor() (hdkeyCBOR, error) {
+	v := hdkeyCBOR{
+		Master:            h.Master,
+		Private:           h.Private,
+		Key:               h.Key,
+		ChainCode:         h.ChainCode,
+		ParentFingerprint: h.ParentFingerprint,
+		Name:              h.Name,
+		Note:              h.Note,
+	}
+	if (h.Private && len(h.Key) != 32) || (!h.Private && len(h.Key) != 33) {
+		return v, errors.New("ur: invalid hdkey key data")
+	}
+	if h.Private {
+		// key data is 33 bytes, private keys are prefixed with 0
+		v.Key = append([]byte{0}, h.Key...)
+	}
+	if h.UseInfo != nil {
+		v.UseInfo = &coinInfoCBOR{Type: h.UseInfo.Type, Network: h.UseInfo.Network}
+	}
+	v.Origin, v.Children = h.Origin.cbor(), h.Children.cbor()
+	return v, nil
+}
+
+func (v hdkeyCBOR) hdkey() (HDKey, error) {
+	h := HDKey{
+		Master:            v.Master,
+		Private:           v.Private,
+		Key:               v.Key,
+		ChainCode:         v.ChainCode,
+		ParentFingerprint: v.ParentFingerprint,
+		Name:              v.Name,
+		Note:              v.Note,
+	}
+	if len(v.Key) != 33 || (h.Private != (v.Key[0] == 0)) || (v.ChainCode != nil && len(v.ChainCode) != 32) {
+		return HDKey{}, errors.New("ur: invalid hdkey key data")
+	}
+	if h.Private {
+		h.Key = v.Key[1:]
+	}
+	if v.UseInfo != nil {
+		h.UseInfo = &CoinInfo{Type: v.UseInfo.Type, Network: v.UseInfo.Network}
+	}
+	var err error
+	if h.Origin, err = v.Origin.keypath(); err != nil {
+		return HDKey{}, err
+	}
+	if h.Children, err = v.Children.keypath(); err != nil {
+		return HDKey{}, err
+	}
+	return h, nil
+}
+
+func (p *KeyPath) cbor() *keypathCBOR {
+	if p == nil {
+		return nil
+	}
+	v := &keypathCBOR{Components: []interface{}{}, SourceFingerprint: p.SourceFingerprint, Depth: p.Depth}
+	for _, c := range p.Components {
+		if c.Wildcard {
+			v.Components = append(v.Components, []interface{}{}, c.Hardened)
+		} else {
+			v.Components = append(v.Components, c.Index, c.Hardened)
+		}
+	}
+	return v
+}
+
+func (v *keypathCBOR) keypath() (*KeyPath, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if len(v.Components)%2 != 0 {
+		return nil, errors.New("ur: invalid keypath components")
+	}
+	p := &KeyPath{SourceFingerprint: v.SourceFingerprint, Depth: v.Depth}
+	for i := 0; i < len(v.Components); i += 2 {
+		var c PathComponent
+		hardened, ok := v.Components[i+1].(bool)
+		if !ok {
+			return nil, errors.New("ur: invalid keypath components")
+		}
+		switch index := v.Components[i].(type) {
+		case uint64:
+			if index >= uint64(bip32.HardenedOffset) {
+				return nil, errors.New("ur: keypath index out of range")
+			}
+			c.Index = uint32(index)
+		case []interface{}:
+			if len(index) != 0 {
+				return nil, errors.New("ur: keypath ranges are not supported")
+			}
+			c.Wildcard = true
+		default:
+			return nil, errors.New("ur: invalid keypath components")
+		}
+		c.Hardened = hardened
+		p.Components = append(p.Components, c)
+	}
+	return p, nil
+}
+
+// NewAccount returns the crypto-account of the bip44 legacy, bip49 nested
+// segwit and bip84 native segwit account keys of the coin type, the usual
+// export of a wallet to a watch only coordinator
+func NewAccount(master *bip32.Key, coinType, account uint32) (Account, error) {
+	if master.Depth() != 0 {
+		return Account{}, errors.New("ur: a master key is required")
+	}
+	a := Account{MasterFingerprint: binary.BigEndian.Uint32(master.Fingerprint())}
+	for _, o := range []struct {
+		script  string
+		purpose uint32
+	}{{ScriptPKH, 44}, {ScriptSHWPKH, 49}, {ScriptWPKH, 84}} {
+		path := []uint32{o.purpose + bip32.HardenedOffset, coinType + bip32.HardenedOffset, account + bip32.HardenedOffset}
+		k := master
+		for _, index := range path {
+			var err error
+			if k, err = k.Derive(index); err != nil {
+				return Account{}, err
+			}
+		}
+		h, err := NewHDKey(k.Neuter(), master.Fingerprint(), path)
+		if err != nil {
+			return Account{}, err
+		}
+		if coinType != 0 {
+			h.UseInfo = &CoinInfo{Type: coinType}
+		}
+		a.Outputs = append(a.Outputs, Output{Script: o.script, Key: h})
+	}
+	return a, nil
+}
+
+// UR encodes the account as a crypto-account
+func (a Account) UR() (UR, error) {
+	v := accountCBOR{MasterFingerprint: a.MasterFingerprint}
+	for _, o := range a.Outputs {
+		tags, ok := _scripts[o.Script]
+		if !ok {
+			return UR{}, fmt.Errorf("ur: unsupported script %s", o.Script)
+		}
+		key, err := o.Key.cbor()
+		if err != nil {
+			return UR{}, err
+		}
+		content, err := _cborEnc.Marshal(cbor.Tag{Number: _tagHDKey, Content: key})
+		if err != nil {
+			return UR{}, err
+		}
+		for i := len(tags) - 1; i > 0; i-- {
+			if content, err = _cborEnc.Marshal(cbor.RawTag{Number: tags[i], Content: content}); err != nil {
+				return UR{}, err
+			}
+		}
+		v.Outputs = append(v.Outputs, cbor.RawTag{Number: tags[0], Content: content})
+	}
+	return marshal(TypeAccount, v)
+}
+
+// ParseAccount decodes a crypto-account, outputs of other scripts are
+// rejected
+func ParseAccount(u UR) (Account, error) {
+	var v accountCBOR
+	if err := unmarshal(u, TypeAccount, &v); err != nil {
+		return Account{}, err
+	}
+	a := Account{MasterFingerprint: v.MasterFingerprint}
+	for _, t := range v.Outputs {
+		var tags []uint64
+		for t.Number != _tagHDKey {
+			tags = append(tags, t.Number)
+			if err := _cborDec.Unmarshal(t.Content, &t); err != nil {
+				return Account{}, err
+			}
+		}
+		script := ""
+		for name, s := range _scripts {
+			if reflect.DeepEqual(s, tags) {
+				script = name
+			}
+		}
+		if script == "" {
+			return Account{}, fmt.Errorf("ur: unsupported output tags %v", tags)
+		}
+		var key hdkeyCBOR
+		if err := _cborDec.Unmarshal(t.Content, &key); err != nil {
+			return Account{}, err
+		}
+		h, err := key.hdkey()
+		if err != nil {
+			return Account{}, err
+		}
+		a.Outputs = append(a.Outputs, Output{Script: script, Key: h})
+	}
+	return a, nil
+}
+
+func marshal(typ string, v interface{}) (UR, error) {
+	b, err := _cborEnc.Marshal(v)
+	if err != nil {
+		return UR{}, err
+	}
+	return UR{Type: typ, CBOR: b}, nil
+}
+
+func unmarshal(u UR, typ string, v interface{}) error {
+	if u.Type != typ {
+		return fmt.Errorf("ur: expected %s but given %s", typ, u.Type)
+	}
+	if err := _cborDec.Unmarshal(u.CBOR, v); err != nil {
+		return fmt.Errorf("ur: invalid %s: %w", typ, err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+// Package ur implements the Blockchain Commons uniform resources used by
+// Keystone, Passport and the other air-gapped wallets: bytewords, single
+// and fountain coded multi-part URs for animated qr codes, and the
+// crypto-seed, crypto-hdkey and crypto-account registry types
+package ur
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+)
+
+const (
+	_scheme = "ur:"
+
+	_checksumSize = 4
+)
+
+var (
+	// ErrChecksum is returned when the bytewords checksum does not match
+	ErrChecksum = errors.New("ur: invalid bytewords checksum")
+
+	_bytewords = strings.Fields(`
+able acid also apex aqua arch atom aunt away axis back bald barn belt beta bias
+blue body brag brew bulb buzz calm cash cats chef city claw code cola cook cost
+crux curl cusp cyan dark data days deli dice diet door down draw drop drum dull
+duty each easy echo edge epic even exam exit eyes fact fair fern figs film fish
+fizz flap flew flux foxy free frog fuel fund gala game gear gems gift girl glow
+good gray grim guru gush gyro half hang hard hawk heat help high hill holy hope
+horn huts iced idea idle inch inky into iris iron item jade jazz join jolt jowl
+judo jugs jump junk jury keep keno kept keys kick kiln king kite kiwi knob lamb
+lava lazy leaf legs liar limp lion list logo loud love luau luck lung main many
+math maze memo menu meow mild mint miss monk nail navy need news next noon note
+numb obey oboe omit onyx open oval owls paid part peck play plus poem pool pose
+puff puma purr quad quiz race ramp real redo rich road rock roof ruby ruin runs
+rust safe saga scar sets silk skew slot soap solo song stub surf swan taco task
+taxi tent tied time tiny toil tomb toys trip tuna twin ugly undo unit urge user
+vast very veto vial vibe view visa void vows wall wand warm wasp wave waxy webs
+what when whiz wolf work yank yawn yell yoga yurt zaps zero zest zinc zone zoom`)
+
+	_minimal = minimalIndex()
+)
+
+// UR is a uniform resource, CBOR is the untagged cbor body of the registry
+// Type
+type UR struct {
+	Type string
+	CBOR []byte
+}
+
+func minimalIndex() map[string]byte {
+	index := make(map[string]byte, len(_bytewords))
+	for i, w := range _bytewords {
+		index[w[:1]+w[3:]] = byte(i)
+	}
+	return index
+}
+
+// New returns the uniform resource of the type, types are lowercase letters,
+// digits and dashes
+func New(typ string, cbor []byte) (UR, error) {
+	if !validType(typ) {
+		return UR{}, fmt.Errorf("ur: invalid type %s", typ)
+	}
+	return UR{Type: typ, CBOR: cbor}, nil
+}
+
+// String encodes the resource as a single part ur, uppercase it for
+// alphanumeric qr codes
+func (u UR) String() string {
+	return _scheme + u.Type + "/" + Encode(u.CBOR)
+}
+
+// Parse decodes a single part ur, case insensitive
+func Parse(s string) (UR, error) {
+	typ, components, err := split(s)
+	if err != nil {
+		return UR{}, err
+	}
+	if len(components) != 1 {
+		return UR{}, errors.New("ur: multi-part ur, use a Decoder")
+	}
+	cbor, err := Decode(components[0])
+	if err != nil {
+		return UR{}, err
+	}
+	return UR{Type: typ, CBOR: cbor}, nil
+}
+
+// split returns the type and the path components of the ur
+func split(s string) (string, []string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if !strings.HasPrefix(s, _scheme) {
+		return "", nil, errors.New("ur: invalid scheme")
+	}
+	components := strings.Split(s[len(_scheme):], "/")
+	if len(components) < 2 || len(components) > 3 || !validType(components[0]) {
+		return "", nil, errors.New("ur: invalid path")
+	}
+	return components[0], components[1:], nil
+}
+
+func validType(typ string) bool {
+	if typ == "" {
+		return false
+	}
+	for _, c := range typ {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// Encode encodes the data as minimal bytewords, the first and last letters
+// of the words, followed by the crc32 checksum
+func Encode(data []byte) string {
+	var b strings.Builder
+	b.Grow(2 * (len(data) + _checksumSize))
+	for _, c := range withChecksum(data) {
+		w := _bytewords[c]
+		b.WriteByte(w[0])
+		b.WriteByte(w[3])
+	}
+	return b.String()
+}
+
+// EncodeWords encodes the data as bytewords separated by spaces, the form
+// read out loud or written on paper
+func EncodeWords(data []byte) string {
+	sum := withChecksum(data)
+	words := make([]string, len(sum))
+	for i, c := range sum {
+		words[i] = _bytewords[c]
+	}
+	return strings.Join(words, " ")
+}
+
+// Decode decodes minimal bytewords and checks their checksum
+func Decode(s string) ([]byte, error) {
+	s = strings.ToLower(s)
+	if len(s)%2 != 0 {
+		return nil, errors.New("ur: invalid bytewords length")
+	}
+	data := make([]byte, 0, len(s)/2)
+	for i := 0; i < len(s); i += 2 {
+		c, ok := _minimal[s[i:i+2]]
+		if !ok {
+			return nil, fmt.Errorf("ur: invalid byteword %s", s[i:i+2])
+		}
+		data = append(data, c)
+	}
+	return checkChecksum(data)
+}
+
+// DecodeWords decodes bytewords separated by spaces or dashes and checks
+// their checksum
+func DecodeWords(s string) ([]byte, error) {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return r == ' ' || r == '-' })
+	data := make([]byte, 0, len(words))
+	for _, w := range words {
+		c, ok := _minimal[w[:1]+w[len(w)-1:]]
+		if !ok || len(w) != 4 || _bytewords[c] != w {
+			return nil, fmt.Errorf("ur: invalid byteword %s", w)
+		}
+		data = append(data, c)
+	}
+	return checkChecksum(data)
+}
+
+func withChecksum(data []byte) []byte {
+	return binary.BigEndian.AppendUint32(append([]byte(nil), data...), crc32.ChecksumIEEE(data))
+}
+
+func checkChecksum(data []byte) ([]byte, error) {
+	if len(data) < _checksumSize {
+		return nil, errors.New("ur: bytewords too short")
+	}
+	body := data[:len(data)-_checksumSize]
+	if binary.BigEndian.Uint32(data[len(body):]) != crc32.ChecksumIEEE(body) {
+		return nil, ErrChecksum
+	}
+	return body, nil
+}
@@ -0,0 +1,335 @@
+package ur
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+)
+
+const _abandonAboutSeed = "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4"
+
+func TestBytewords(t *testing.T) {
+	data := []byte{0, 1, 2, 128, 255}
+	if actual := EncodeWords(data); actual != "able acid also lava zoom jade need echo taxi" {
+		t.Errorf("expected able acid also lava zoom jade need echo taxi but actual %s", actual)
+	}
+	if actual := Encode(data); actual != "aeadaolazmjendeoti" {
+		t.Errorf("expected aeadaolazmjendeoti but actual %s", actual)
+	}
+
+	tests := []struct {
+		name   string
+		decode func(string) ([]byte, error)
+		s      string
+		err    string
+	}{
+		{name: "minimal", decode: Decode, s: "AEADAOLAZMJENDEOTI"},
+		{name: "words", decode: DecodeWords, s: "able acid also lava zoom jade need echo taxi"},
+		{name: "dashes", decode: DecodeWords, s: "able-acid-also-lava-zoom-jade-need-echo-taxi"},
+		{name: "checksum", decode: Decode, s: "aeadaolazmjendeota", err: ErrChecksum.Error()},
+		{name: "odd length", decode: Decode, s: "aeadaolazmjendeot", err: "ur: invalid bytewords length"},
+		{name: "unknown word", decode: DecodeWords, s: "able acid also lava zoom jade need echo taxa", err: "ur: invalid byteword taxa"},
+		{name: "too short", decode: Decode, s: "aead", err: "ur: bytewords too short"},
+	}
+	for _, test := range tests {
+		actual, err := test.decode(test.s)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s: expected error %s but actual %v", test.name, test.err, err)
+			}
+			continue
+		}
+		if err != nil || !bytes.Equal(actual, data) {
+			t.Errorf("%s: expected %x but actual %x, %v", test.name, data, actual, err)
+		}
+	}
+}
+
+func TestSeed(t *testing.T) {
+	// bcr-2020-006 example
+	expected := "ur:crypto-seed/oeadgdstaslplabghydrpfmkbggufgludprfgmaotpiecffltnlpqdenos"
+	payload, _ := hex.DecodeString("c7098580125e2ab0981253468b2dbc52")
+	birthdate := time.Date(2020, 5, 12, 0, 0, 0, 0, time.UTC)
+
+	u, err := Seed{Payload: payload, Birthdate: birthdate}.UR()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if actual := u.String(); actual != expected {
+		t.Errorf("expected %s but actual %s", expected, actual)
+	}
+
+	u, err = Parse(strings.ToUpper(expected))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	s, err := ParseSeed(u)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !bytes.Equal(s.Payload, payload) || !s.Birthdate.Equal(birthdate) {
+		t.Errorf("expected %x %s but actual %x %s", payload, birthdate, s.Payload, s.Birthdate)
+	}
+
+	if _, err := ParseSeed(UR{Type: TypeHDKey, CBOR: u.CBOR}); err == nil {
+		t.Errorf("expected an error for another type")
+	}
+	if _, err := (Seed{}).UR(); err == nil {
+		t.Errorf("expected an error for an empty seed")
+	}
+}
+
+func TestHDKey(t *testing.T) {
+	seed, _ := hex.DecodeString(_abandonAboutSeed)
+	master, _ := bip32.NewMasterKey(seed)
+	path, _ := bip32.ParsePath("m/84'/0'/0'")
+	account, _ := master.DerivePath("m/84'/0'/0'")
+
+	tests := []struct {
+		name     string
+		key      *bip32.Key
+		path     []uint32
+		expected string
+	}{
+		{name: "master", key: master, expected: master.String()},
+		{name: "account xpub", key: account.Neuter(), path: path, expected: "xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V"},
+		{name: "account xprv", key: account, path: path, expected: account.String()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h, err := NewHDKey(test.key, master.Fingerprint(), test.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			h.Children = &KeyPath{Components: []PathComponent{{Index: 0}, {Wildcard: true}}}
+			u, err := h.UR()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if u, err = Parse(u.String()); err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			decoded, err := ParseHDKey(u)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if !reflect.DeepEqual(decoded, h) {
+				t.Errorf("expected %v but actual %v", h, decoded)
+			}
+			k, err := decoded.ExtendedKey()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if actual := k.String(); actual != test.expected {
+				t.Errorf("expected %s but actual %s", test.expected, actual)
+			}
+		})
+	}
+
+	if _, err := NewHDKey(account, master.Fingerprint(), path[:2]); err == nil {
+		t.Errorf("expected an error for a path of another depth")
+	}
+}
+
+func TestAccount(t *testing.T) {
+	seed, _ := hex.DecodeString(_abandonAboutSeed)
+	master, _ := bip32.NewMasterKey(seed)
+
+	a, err := NewAccount(master, 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	u, err := a.UR()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	// map of the fingerprint 73c5da0a and three outputs, the second one
+	// wrapped in sh(wpkh())
+	if !bytes.HasPrefix(u.CBOR, []byte{0xa2, 0x01, 0x1a, 0x73, 0xc5, 0xda, 0x0a, 0x02, 0x83, 0xd9, 0x01, 0x93, 0xd9, 0x01, 0x2f}) ||
+		!bytes.Contains(u.CBOR, []byte{0xd9, 0x01, 0x90, 0xd9, 0x01, 0x94, 0xd9, 0x01, 0x2f}) {
+		t.Errorf("unexpected crypto-account cbor %x", u.CBOR)
+	}
+
+	decoded, err := ParseAccount(u)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := map[string]string{
+		ScriptPKH:    "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj",
+		ScriptSHWPKH: "xpub6C6nQwHaWbSrzs5tZ1q7m5R9cPK9eYpNMFesiXsYrgc1P8bvLLAet9JfHjYXKjToD8cBRswJXXbbFpXgwsswVPAZzKMa1jUp2kVkGVUaJa7",
+		ScriptWPKH:   "xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V",
+	}
+	if decoded.MasterFingerprint != 0x73c5da0a || len(decoded.Outputs) != len(expected) {
+		t.Fatalf("expected 3 outputs of 73c5da0a but actual %v", decoded)
+	}
+	for _, o := range decoded.Outputs {
+		k, err := o.Key.ExtendedKey()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if actual := k.String(); actual != expected[o.Script] {
+			t.Errorf("%s: expected %s but actual %s", o.Script, expected[o.Script], actual)
+		}
+	}
+
+	a.Outputs[0].Script = "multi"
+	if _, err := a.UR(); err == nil {
+		t.Errorf("expected an error for an unsupported script")
+	}
+}
+
+func TestXoshiro256(t *testing.T) {
+	// bc-ur reference vectors
+	rng := newXoshiro256([]byte("Wolf"))
+	expected := []uint64{42, 81, 85, 8, 82, 84, 76, 73, 70, 88}
+	for i, e := range expected {
+		if actual := rng.next() % 100; actual != e {
+			t.Errorf("%d: expected %d but actual %d", i, e, actual)
+		}
+	}
+
+	probs := make([]float64, 11)
+	for i := range probs {
+		probs[i] = 1 / float64(i+1)
+	}
+	s := newSampler(probs)
+	degrees := []int{11, 3, 6, 5, 2, 1, 2, 11, 1, 3, 9, 10, 10, 4, 2, 1, 1, 2, 1, 1}
+	for i, e := range degrees {
+		rng := newXoshiro256([]byte(fmt.Sprintf("Wolf-%d", i+1)))
+		if actual := s.next(rng) + 1; actual != e {
+			t.Errorf("nonce %d: expected degree %d but actual %d", i+1, e, actual)
+		}
+	}
+}
+
+func TestFountain(t *testing.T) {
+	u := wolfMessage(t, 256)
+	e, err := NewEncoder(u, 30)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if e.SinglePart() || e.SeqLen() != 9 {
+		t.Fatalf("expected 9 parts but actual %d", e.SeqLen())
+	}
+	parts := make([]string, 60)
+	for i := range parts {
+		parts[i] = e.NextPart()
+	}
+	// bc-ur reference vector
+	expected := "ur:bytes/1-9/lpadascfadaxcywenbpljkhdcahkadaemejtswhhylkepmykhhtsytsnoyoyaxaedsuttydmmhhpktpmsrjtdkgslpgh"
+	if parts[0] != expected {
+		t.Errorf("expected %s but actual %s", expected, parts[0])
+	}
+
+	tests := []struct {
+		name  string
+		parts []string
+	}{
+		{name: "in order", parts: parts[:9]},
+		{name: "fragments lost", parts: append(append([]string{}, parts[3:9]...), parts[9:]...)},
+		{name: "mixes only", parts: parts[9:]},
+		{name: "reversed", parts: reverse(parts)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := NewDecoder()
+			for _, p := range test.parts {
+				if err := d.Receive(p); err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				if d.Complete() {
+					break
+				}
+			}
+			if !d.Complete() || d.Progress() != 1 {
+				t.Fatalf("expected a complete resource but actual %f", d.Progress())
+			}
+			actual, err := d.Result()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if actual.Type != u.Type || !bytes.Equal(actual.CBOR, u.CBOR) {
+				t.Errorf("expected %x but actual %x", u.CBOR, actual.CBOR)
+			}
+		})
+	}
+
+	d := NewDecoder()
+	if err := d.Receive(parts[0]); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if _, err := d.Result(); err == nil || d.Progress() != 1.0/9 {
+		t.Errorf("expected an incomplete resource but actual %f", d.Progress())
+	}
+	other, _ := NewEncoder(wolfMessage(t, 300), 30)
+	if err := d.Receive(other.NextPart()); err == nil {
+		t.Errorf("expected an error for a part of another resource")
+	}
+	if err := d.Receive(strings.Replace(parts[1], "/2-9/", "/3-9/", 1)); err == nil {
+		t.Errorf("expected an error for a mismatched sequence")
+	}
+}
+
+func TestSinglePart(t *testing.T) {
+	u := wolfMessage(t, 16)
+	e, err := NewEncoder(u, DefaultFragmentSize)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !e.SinglePart() || e.NextPart() != u.String() {
+		t.Fatalf("expected a single part")
+	}
+	d := NewDecoder()
+	if err := d.Receive(e.NextPart()); err != nil || !d.Complete() {
+		t.Fatalf("expected a complete resource but actual %v", err)
+	}
+
+	if _, err := Parse(e.NextPart()[:21]); !errors.Is(err, ErrChecksum) {
+		t.Errorf("expected %v but actual %v", ErrChecksum, err)
+	}
+	for _, s := range []string{"bytes/aeadaolazmjendeoti", "ur:/aeadaolazmjendeoti", "ur:by_tes/aeadaolazmjendeoti", "ur:bytes/1-2/aead"} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("%s: expected an error", s)
+		}
+	}
+	if _, err := NewEncoder(u, 5); err == nil {
+		t.Errorf("expected an error for a small fragment size")
+	}
+}
+
+// wolfMessage returns the bytes resource of the reference tests, a cbor byte
+// string of size bytes from the xoshiro256 generator seeded with Wolf
+func wolfMessage(t *testing.T, size int) UR {
+	rng := newXoshiro256([]byte("Wolf"))
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(rng.nextInt(0, 255))
+	}
+	b, err := cbor.Marshal(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	u, err := New("bytes", b)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return u
+}
+
+func reverse(parts []string) []string {
+	r := make([]string, len(parts))
+	for i, p := range parts {
+		r[len(parts)-1-i] = p
+	}
+	return r
+}
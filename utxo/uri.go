@@ -0,0 +1,59 @@
+package utxo
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const _satoshisPerCoin = 100000000
+
+// Payment is the optional part of a payment uri, Amount is in satoshis and
+// left out when zero
+type Payment struct {
+	Amount  uint64
+	Label   string
+	Message string
+}
+
+// PaymentURI renders the address as a bip21 payment uri like
+// bitcoin:bc1q...?amount=0.5&label=Savings, for qr codes shown to verify the
+// receive addresses
+func (n Network) PaymentURI(address string, p Payment) (string, error) {
+	if n.URIScheme == "" {
+		return "", fmt.Errorf("%s has no payment uri scheme", n.Name)
+	}
+	if address == "" {
+		return "", errors.New("address is empty")
+	}
+
+	var params []string
+	if p.Amount > 0 {
+		params = append(params, "amount="+formatAmount(p.Amount))
+	}
+	if p.Label != "" {
+		params = append(params, "label="+escape(p.Label))
+	}
+	if p.Message != "" {
+		params = append(params, "message="+escape(p.Message))
+	}
+
+	uri := n.URIScheme + ":" + address
+	if len(params) > 0 {
+		uri += "?" + strings.Join(params, "&")
+	}
+	return uri, nil
+}
+
+// formatAmount formats the satoshis as a decimal coin amount without
+// trailing zeros
+func formatAmount(satoshis uint64) string {
+	amount := fmt.Sprintf("%d.%08d", satoshis/_satoshisPerCoin, satoshis%_satoshisPerCoin)
+	return strings.TrimSuffix(strings.TrimRight(amount, "0"), ".")
+}
+
+// escape percent encodes the value, bip21 has no plus for spaces
+func escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
@@ -0,0 +1,51 @@
+package utxo
+
+import "testing"
+
+func TestPaymentURI(t *testing.T) {
+	tests := []struct {
+		network  Network
+		address  string
+		payment  Payment
+		expected string
+	}{
+		{
+			network:  Bitcoin,
+			address:  "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+			expected: "bitcoin:bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+		},
+		{
+			network:  Bitcoin,
+			address:  "175tWpb8K1S7NmH4Zx6rewF9WQrcZv245W",
+			payment:  Payment{Amount: 2030000000, Label: "Luke-Jr", Message: "Donation for project xyz"},
+			expected: "bitcoin:175tWpb8K1S7NmH4Zx6rewF9WQrcZv245W?amount=20.3&label=Luke-Jr&message=Donation%20for%20project%20xyz",
+		},
+		{
+			network:  Litecoin,
+			address:  "ltc1qjmxnz78nmc8nq77wuxh25n2es7rzm5c2rkk4wh",
+			payment:  Payment{Amount: 1, Label: "a&b=c"},
+			expected: "litecoin:ltc1qjmxnz78nmc8nq77wuxh25n2es7rzm5c2rkk4wh?amount=0.00000001&label=a%26b%3Dc",
+		},
+		{
+			network:  Dogecoin,
+			address:  "DBus3bamQjgJULBJtYXpEzDWQRwF5iwxgC",
+			payment:  Payment{Amount: 100000000},
+			expected: "dogecoin:DBus3bamQjgJULBJtYXpEzDWQRwF5iwxgC?amount=1",
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.network.PaymentURI(test.address, test.payment)
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", test.address, err.Error())
+			continue
+		}
+		if actual != test.expected {
+			t.Errorf("expected %s but actual %s", test.expected, actual)
+		}
+	}
+
+	if _, err := (Network{Name: "custom"}).PaymentURI("x", Payment{}); err == nil || err.Error() != "custom has no payment uri scheme" {
+		t.Errorf("expected err 'custom has no payment uri scheme' but actual %v", err)
+	}
+}
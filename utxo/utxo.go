@@ -0,0 +1,240 @@
+// Package utxo derives addresses, WIFs and extended keys for bitcoin like
+// chains from network parameters, so new chains only need a Network value
+package utxo
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+	"github.com/nomnemonic/nomnemonic/internal/base58"
+	"github.com/nomnemonic/nomnemonic/internal/bech32"
+)
+
+const (
+	_wifCompressedSuffix = 0x01
+)
+
+// AddressType is the script type of the address
+type AddressType int
+
+const (
+	// AddressP2PKH is the legacy pay to public key hash address (bip44)
+	AddressP2PKH AddressType = iota
+	// AddressP2SHP2WPKH is the segwit address nested in pay to script hash
+	// (bip49)
+	AddressP2SHP2WPKH
+	// AddressP2WPKH is the native segwit address (bip84)
+	AddressP2WPKH
+)
+
+var _purposes = map[AddressType]uint32{
+	AddressP2PKH:      44,
+	AddressP2SHP2WPKH: 49,
+	AddressP2WPKH:     84,
+}
+
+// Network is the parameter set of a UTXO chain
+type Network struct {
+	Name              string
+	CoinType          uint32
+	PubKeyHashVersion byte
+	ScriptHashVersion byte
+	WIFVersion        byte
+	Bech32HRP         string // empty when the chain has no segwit support
+	XPrvVersion       uint32
+	XPubVersion       uint32
+	URIScheme         string // bip21 payment uri scheme, empty when there is none
+}
+
+var (
+	Bitcoin = Network{
+		Name:              "bitcoin",
+		CoinType:          0,
+		PubKeyHashVersion: 0x00,
+		ScriptHashVersion: 0x05,
+		WIFVersion:        0x80,
+		Bech32HRP:         "bc",
+		XPrvVersion:       0x0488ade4,
+		XPubVersion:       0x0488b21e,
+		URIScheme:         "bitcoin",
+	}
+	BitcoinTestnet = Network{
+		Name:              "bitcoin-testnet",
+		CoinType:          1,
+		PubKeyHashVersion: 0x6f,
+		ScriptHashVersion: 0xc4,
+		WIFVersion:        0xef,
+		Bech32HRP:         "tb",
+		XPrvVersion:       0x04358394,
+		XPubVersion:       0x043587cf,
+		URIScheme:         "bitcoin",
+	}
+	Litecoin = Network{
+		Name:              "litecoin",
+		CoinType:          2,
+		PubKeyHashVersion: 0x30,
+		ScriptHashVersion: 0x32,
+		WIFVersion:        0xb0,
+		Bech32HRP:         "ltc",
+		XPrvVersion:       0x019d9cfe,
+		XPubVersion:       0x019da462,
+		URIScheme:         "litecoin",
+	}
+	Dogecoin = Network{
+		Name:              "dogecoin",
+		CoinType:          3,
+		PubKeyHashVersion: 0x1e,
+		ScriptHashVersion: 0x16,
+		WIFVersion:        0x9e,
+		XPrvVersion:       0x02fac398,
+		XPubVersion:       0x02facafd,
+		URIScheme:         "dogecoin",
+	}
+
+	_registryMu sync.RWMutex
+	_registry   = map[string]Network{
+		Bitcoin.Name:        Bitcoin,
+		BitcoinTestnet.Name: BitcoinTestnet,
+		Litecoin.Name:       Litecoin,
+		Dogecoin.Name:       Dogecoin,
+	}
+)
+
+// Register adds the network parameters to the registry, the name must be
+// unique
+func Register(n Network) error {
+	if n.Name == "" {
+		return fmt.Errorf("network name is required")
+	}
+
+	_registryMu.Lock()
+	defer _registryMu.Unlock()
+	if _, exists := _registry[n.Name]; exists {
+		return fmt.Errorf("network %s is already registered", n.Name)
+	}
+	_registry[n.Name] = n
+	return nil
+}
+
+// Lookup returns the registered network by name
+func Lookup(name string) (Network, error) {
+	_registryMu.RLock()
+	defer _registryMu.RUnlock()
+	n, exists := _registry[name]
+	if !exists {
+		return Network{}, fmt.Errorf("unknown network %s", name)
+	}
+	return n, nil
+}
+
+// Networks returns the names of the registered networks in order
+func Networks() []string {
+	_registryMu.RLock()
+	defer _registryMu.RUnlock()
+	names := make([]string, 0, len(_registry))
+	for name := range _registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AccountPath returns the account level derivation path of the address type
+func (n Network) AccountPath(t AddressType, account uint32) (string, error) {
+	purpose, ok := _purposes[t]
+	if !ok {
+		return "", fmt.Errorf("unsupported address type %d", t)
+	}
+	return fmt.Sprintf("m/%d'/%d'/%d'", purpose, n.CoinType, account), nil
+}
+
+// Path returns the derivation path of the first account receive address
+func (n Network) Path(t AddressType, index uint32) (string, error) {
+	path, err := n.AccountPath(t, 0)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/0/%d", path, index), nil
+}
+
+// Key derives the bip32 key of the first account receive address
+func (n Network) Key(seed []byte, t AddressType, index uint32) (*bip32.Key, error) {
+	path, err := n.Path(t, index)
+	if err != nil {
+		return nil, err
+	}
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	return master.DerivePath(path)
+}
+
+// Address derives the receive address of the index from the seed
+func (n Network) Address(seed []byte, t AddressType, index uint32) (string, error) {
+	k, err := n.Key(seed, t, index)
+	if err != nil {
+		return "", err
+	}
+	return n.EncodeAddress(k.PublicKey(), t)
+}
+
+// WIF derives the wallet import format private key of the receive address
+func (n Network) WIF(seed []byte, t AddressType, index uint32) (string, error) {
+	k, err := n.Key(seed, t, index)
+	if err != nil {
+		return "", err
+	}
+	return n.EncodeWIF(k.PrivateKey()), nil
+}
+
+// ExtendedPublicKey derives the account extended public key serialized with
+// the network xpub version
+func (n Network) ExtendedPublicKey(seed []byte, t AddressType, account uint32) (string, error) {
+	path, err := n.AccountPath(t, account)
+	if err != nil {
+		return "", err
+	}
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return "", err
+	}
+	k, err := master.DerivePath(path)
+	if err != nil {
+		return "", err
+	}
+	return k.Neuter().Serialize(n.XPubVersion), nil
+}
+
+// EncodeAddress encodes the 33 bytes compressed public key as address
+func (n Network) EncodeAddress(publicKey []byte, t AddressType) (string, error) {
+	hash := bip32.Hash160(publicKey)
+	switch t {
+	case AddressP2PKH:
+		return base58.CheckEncode(append([]byte{n.PubKeyHashVersion}, hash...), base58.AlphabetBitcoin), nil
+	case AddressP2SHP2WPKH:
+		if n.Bech32HRP == "" {
+			return "", fmt.Errorf("%s does not support segwit", n.Name)
+		}
+		redeemScript := append([]byte{0x00, 0x14}, hash...)
+		return base58.CheckEncode(append([]byte{n.ScriptHashVersion}, bip32.Hash160(redeemScript)...), base58.AlphabetBitcoin), nil
+	case AddressP2WPKH:
+		if n.Bech32HRP == "" {
+			return "", fmt.Errorf("%s does not support segwit", n.Name)
+		}
+		return bech32.EncodeSegwit(n.Bech32HRP, 0, hash)
+	}
+	return "", fmt.Errorf("unsupported address type %d", t)
+}
+
+// EncodeWIF encodes the 32 bytes private key in wallet import format for
+// compressed public keys
+func (n Network) EncodeWIF(privateKey []byte) string {
+	payload := make([]byte, 0, len(privateKey)+2)
+	payload = append(payload, n.WIFVersion)
+	payload = append(payload, privateKey...)
+	payload = append(payload, _wifCompressedSuffix)
+	return base58.CheckEncode(payload, base58.AlphabetBitcoin)
+}
@@ -0,0 +1,141 @@
+package utxo
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestAddress(t *testing.T) {
+	seed, err := buildSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+	if err != nil {
+		t.Errorf("couldn't generate seed: %s", err.Error())
+	}
+
+	tests := []struct {
+		network     Network
+		addressType AddressType
+		address     string
+		wif         string
+	}{
+		{
+			network:     Bitcoin,
+			addressType: AddressP2PKH,
+			address:     "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA",
+		},
+		{
+			network:     Bitcoin,
+			addressType: AddressP2SHP2WPKH,
+			address:     "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf",
+		},
+		{
+			network:     Bitcoin,
+			addressType: AddressP2WPKH,
+			address:     "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+			wif:         "KyZpNDKnfs94vbrwhJneDi77V6jF64PWPF8x5cdJb8ifgg2DUc9d",
+		},
+		{
+			network:     Litecoin,
+			addressType: AddressP2PKH,
+			address:     "LUWPbpM43E2p7ZSh8cyTBEkvpHmr3cB8Ez",
+			wif:         "T5b4RiWRs7XG8xZ2bCHBoJcn4JrpMTbGRFYXgoZHd7nD8izwqhMK",
+		},
+		{
+			network:     Dogecoin,
+			addressType: AddressP2PKH,
+			address:     "DBus3bamQjgJULBJtYXpEzDWQRwF5iwxgC",
+			wif:         "QPkeC1ZfHx3c9g7WTj9cQ8gnvk2iSAfAcbq1aVAWjNTwDAKfZUzx",
+		},
+	}
+
+	for _, test := range tests {
+		address, err := test.network.Address(seed, test.addressType, 0)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.network.Name, err.Error())
+		}
+		if address != test.address {
+			t.Errorf("expected %s address '%s' but actual '%s'", test.network.Name, test.address, address)
+		}
+
+		if test.wif == "" {
+			continue
+		}
+		wif, err := test.network.WIF(seed, test.addressType, 0)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.network.Name, err.Error())
+		}
+		if wif != test.wif {
+			t.Errorf("expected %s wif '%s' but actual '%s'", test.network.Name, test.wif, wif)
+		}
+	}
+
+	_, err = Dogecoin.Address(seed, AddressP2WPKH, 0)
+	if err == nil || err.Error() != "dogecoin does not support segwit" {
+		t.Errorf("expected segwit support err but actual %v", err)
+	}
+}
+
+func TestExtendedPublicKey(t *testing.T) {
+	seed, err := buildSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+	if err != nil {
+		t.Errorf("couldn't generate seed: %s", err.Error())
+	}
+
+	xpub, err := Bitcoin.ExtendedPublicKey(seed, AddressP2PKH, 0)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	expected := "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj"
+	if xpub != expected {
+		t.Errorf("expected xpub '%s' but actual '%s'", expected, xpub)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	custom := Network{
+		Name:              "custom",
+		CoinType:          1234,
+		PubKeyHashVersion: 0x3c,
+		ScriptHashVersion: 0x7a,
+		WIFVersion:        0xbc,
+	}
+
+	err := Register(custom)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+
+	err = Register(custom)
+	if err == nil || err.Error() != "network custom is already registered" {
+		t.Errorf("expected already registered err but actual %v", err)
+	}
+
+	n, err := Lookup("custom")
+	if err != nil || n.CoinType != 1234 {
+		t.Errorf("expected custom network but actual %v, %v", n, err)
+	}
+
+	_, err = Lookup("unknown")
+	if err == nil || err.Error() != "unknown network unknown" {
+		t.Errorf("expected unknown network err but actual %v", err)
+	}
+
+	expected := "bitcoin,bitcoin-testnet,custom,dogecoin,litecoin"
+	if actual := strings.Join(Networks(), ","); actual != expected {
+		t.Errorf("expected networks '%s' but actual '%s'", expected, actual)
+	}
+}
+
+func buildSeed(sentence string) ([]byte, error) {
+	bytes, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		return nil, err
+	}
+	m, err := nomnemonic.New(strings.Split(string(bytes), "\n"))
+	if err != nil {
+		return nil, err
+	}
+	return m.GenerateSeed(sentence, "")
+}
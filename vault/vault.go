@@ -0,0 +1,152 @@
+// Package vault wraps export containers with the transit engine of HashiCorp
+// Vault, the container is encrypted again by a named transit key so platform
+// teams control and audit who opens the stored backups
+package vault
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMount is the mount path of the transit engine by default
+	DefaultMount = "transit"
+
+	_ciphertextPrefix = "vault:v"
+	_timeout          = 30 * time.Second
+)
+
+// Transit is a named key of the transit engine
+type Transit struct {
+	// Address is the address of vault, like https://vault.example.com:8200
+	Address string
+	// Token authenticates the requests
+	Token string
+	// Namespace is the enterprise namespace, none when empty
+	Namespace string
+	// Mount is the mount path of the transit engine
+	Mount string
+	// Key is the name of the transit key
+	Key string
+
+	Client *http.Client
+}
+
+// New returns the transit key of the name with the address, the token and the
+// namespace of the VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE environment
+// variables like the vault cli
+func New(key string) (*Transit, error) {
+	t := &Transit{
+		Address:   os.Getenv("VAULT_ADDR"),
+		Token:     os.Getenv("VAULT_TOKEN"),
+		Namespace: os.Getenv("VAULT_NAMESPACE"),
+		Mount:     DefaultMount,
+		Key:       key,
+		Client:    &http.Client{Timeout: _timeout},
+	}
+	return t, t.validate()
+}
+
+func (t *Transit) validate() error {
+	switch {
+	case t.Address == "":
+		return errors.New("vault: no address, set VAULT_ADDR")
+	case t.Token == "":
+		return errors.New("vault: no token, set VAULT_TOKEN")
+	case t.Key == "" || strings.Contains(t.Key, "/"):
+		return fmt.Errorf("vault: invalid transit key %q", t.Key)
+	}
+	return nil
+}
+
+// Encrypt wraps the container with the transit key and returns the vault
+// ciphertext, like vault:v1:...
+func (t *Transit) Encrypt(container []byte) ([]byte, error) {
+	var out struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	if err := t.call("encrypt", map[string]string{"plaintext": base64.StdEncoding.EncodeToString(container)}, &out); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(out.Ciphertext, _ciphertextPrefix) {
+		return nil, errors.New("vault: invalid ciphertext returned")
+	}
+	return []byte(out.Ciphertext), nil
+}
+
+// Decrypt unwraps the vault ciphertext and returns the container
+func (t *Transit) Decrypt(ciphertext []byte) ([]byte, error) {
+	c := strings.TrimSpace(string(ciphertext))
+	if !strings.HasPrefix(c, _ciphertextPrefix) {
+		return nil, errors.New("vault: not a vault ciphertext")
+	}
+	var out struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err := t.call("decrypt", map[string]string{"ciphertext": c}, &out); err != nil {
+		return nil, err
+	}
+	container, err := base64.StdEncoding.DecodeString(out.Plaintext)
+	if err != nil {
+		return nil, errors.New("vault: invalid plaintext returned")
+	}
+	return container, nil
+}
+
+// call posts the body to the operation of the key and decodes the data of
+// the response
+func (t *Transit) call(operation string, body interface{}, data interface{}) error {
+	if err := t.validate(); err != nil {
+		return err
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("%s/v1/%s/%s/%s", strings.TrimRight(t.Address, "/"), strings.Trim(t.Mount, "/"), operation, url.PathEscape(t.Key))
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", t.Token)
+	if t.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", t.Namespace)
+	}
+
+	client := t.Client
+	if client == nil {
+		client = &http.Client{Timeout: _timeout}
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault: %w", err)
+	}
+	defer res.Body.Close()
+
+	var out struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []string        `json:"errors"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil && res.StatusCode == http.StatusOK {
+		return errors.New("vault: invalid response")
+	}
+	if res.StatusCode != http.StatusOK {
+		if len(out.Errors) > 0 {
+			return fmt.Errorf("vault: %s %s: %s", operation, res.Status, strings.Join(out.Errors, ", "))
+		}
+		return fmt.Errorf("vault: %s %s", operation, res.Status)
+	}
+	if err := json.Unmarshal(out.Data, data); err != nil {
+		return errors.New("vault: invalid response")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// transitServer emulates the transit engine with a reversible "encryption"
+func transitServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		if r.Header.Get("X-Vault-Namespace") != "team" {
+			t.Errorf("expected the team namespace but actual %s", r.Header.Get("X-Vault-Namespace"))
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/backups":
+			_, _ = w.Write([]byte(`{"data":{"ciphertext":"vault:v1:` + body["plaintext"] + `"}}`))
+		case "/v1/transit/decrypt/backups":
+			_, _ = w.Write([]byte(`{"data":{"plaintext":"` + strings.TrimPrefix(body["ciphertext"], "vault:v1:") + `"}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["encryption key not found"]}`))
+		}
+	}))
+}
+
+func TestTransit(t *testing.T) {
+	server := transitServer(t)
+	defer server.Close()
+
+	t.Setenv("VAULT_ADDR", server.URL+"/")
+	t.Setenv("VAULT_TOKEN", "s.token")
+	t.Setenv("VAULT_NAMESPACE", "team")
+	transit, err := New("backups")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	container := []byte("export container")
+	ciphertext, err := transit.Encrypt(container)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := "vault:v1:" + base64.StdEncoding.EncodeToString(container); string(ciphertext) != expected {
+		t.Errorf("expected %s but actual %s", expected, ciphertext)
+	}
+	opened, err := transit.Decrypt(append(ciphertext, '\n'))
+	if err != nil || !bytes.Equal(opened, container) {
+		t.Errorf("expected '%s' but actual '%s' %v", container, opened, err)
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		token   string
+		decrypt string
+		err     string
+	}{
+		{name: "unknown key", key: "other", token: "s.token", err: "vault: encrypt 400 Bad Request: encryption key not found"},
+		{name: "denied", key: "backups", token: "s.other", err: "vault: encrypt 403 Forbidden: permission denied"},
+		{name: "no token", key: "backups", err: "vault: no token, set VAULT_TOKEN"},
+		{name: "invalid key", key: "a/b", token: "s.token", err: `vault: invalid transit key "a/b"`},
+		{name: "not a ciphertext", key: "backups", token: "s.token", decrypt: "container", err: "vault: not a vault ciphertext"},
+	}
+	for _, test := range tests {
+		transit.Key, transit.Token = test.key, test.token
+		if test.decrypt != "" {
+			err = func() error { _, err := transit.Decrypt([]byte(test.decrypt)); return err }()
+		} else {
+			err = func() error { _, err := transit.Encrypt(container); return err }()
+		}
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%s: expected err '%s' but actual %v", test.name, test.err, err)
+		}
+	}
+
+	t.Setenv("VAULT_ADDR", "")
+	if _, err := New("backups"); err == nil || err.Error() != "vault: no address, set VAULT_ADDR" {
+		t.Errorf("expected a missing address error but actual %v", err)
+	}
+}
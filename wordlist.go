@@ -0,0 +1,91 @@
+package nomnemonic
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const _wordlistSize = 2048
+
+// WordlistIssue is a problem of a word list at the index, Index is -1 for the
+// problems of the whole list
+type WordlistIssue struct {
+	Index   int
+	Word    string
+	Problem string
+}
+
+func (i WordlistIssue) String() string {
+	if i.Index < 0 {
+		return i.Problem
+	}
+	return fmt.Sprintf("%d %s: %s", i.Index, i.Word, i.Problem)
+}
+
+// CheckWordlist checks the word list has 2048 unique words without spaces,
+// sorted when sorted is set and with unique prefixes of prefixSize chars
+// when it is not 0, like bip39 recommends for the english list
+func CheckWordlist(words []string, prefixSize int, sorted bool) []WordlistIssue {
+	var issues []WordlistIssue
+	if len(words) != _wordlistSize {
+		issues = append(issues, WordlistIssue{Index: -1, Problem: fmt.Sprintf("expected %d words but actual %d", _wordlistSize, len(words))})
+	}
+
+	seen := make(map[string]int, len(words))
+	prefixes := make(map[string]int, len(words))
+	for i, w := range words {
+		if w == "" || strings.ContainsAny(w, " \t　") {
+			issues = append(issues, WordlistIssue{Index: i, Word: w, Problem: "empty or contains spaces"})
+			continue
+		}
+		if j, ok := seen[w]; ok {
+			issues = append(issues, WordlistIssue{Index: i, Word: w, Problem: fmt.Sprintf("duplicate of %d", j)})
+			continue
+		}
+		seen[w] = i
+
+		if sorted && i > 0 && words[i-1] > w {
+			issues = append(issues, WordlistIssue{Index: i, Word: w, Problem: fmt.Sprintf("not sorted after %s", words[i-1])})
+		}
+		if prefixSize > 0 {
+			p := runePrefix(w, prefixSize)
+			if j, ok := prefixes[p]; ok {
+				issues = append(issues, WordlistIssue{Index: i, Word: w, Problem: fmt.Sprintf("prefix %s is shared with %s", p, words[j])})
+				continue
+			}
+			prefixes[p] = i
+		}
+	}
+	return issues
+}
+
+// DiffWordlist lists the indexes where the custom list differs from the
+// official one, a missing word on either side is empty
+func DiffWordlist(official, custom []string) []WordlistIssue {
+	var diff []WordlistIssue
+	size := len(official)
+	if len(custom) > size {
+		size = len(custom)
+	}
+	for i := 0; i < size; i++ {
+		var o, c string
+		if i < len(official) {
+			o = official[i]
+		}
+		if i < len(custom) {
+			c = custom[i]
+		}
+		if o != c {
+			diff = append(diff, WordlistIssue{Index: i, Word: c, Problem: fmt.Sprintf("official word is %s", o)})
+		}
+	}
+	return diff
+}
+
+func runePrefix(w string, size int) string {
+	if utf8.RuneCountInString(w) <= size {
+		return w
+	}
+	return string([]rune(w)[:size])
+}
@@ -0,0 +1,72 @@
+package nomnemonic
+
+import (
+	"testing"
+)
+
+func TestCheckWordlist(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issues := CheckWordlist(words, 4, true); len(issues) != 0 {
+		t.Errorf("expected no issues on the english list but actual %v", issues)
+	}
+
+	tests := []struct {
+		name     string
+		words    []string
+		prefix   int
+		sorted   bool
+		expected []string
+	}{
+		{
+			name:     "short",
+			words:    []string{"apple", "banana"},
+			expected: []string{"expected 2048 words but actual 2"},
+		},
+		{
+			name:     "duplicate and space",
+			words:    []string{"apple", "apple", "big apple"},
+			expected: []string{"expected 2048 words but actual 3", "1 apple: duplicate of 0", "2 big apple: empty or contains spaces"},
+		},
+		{
+			name:     "unsorted",
+			words:    []string{"banana", "apple"},
+			sorted:   true,
+			expected: []string{"expected 2048 words but actual 2", "1 apple: not sorted after banana"},
+		},
+		{
+			name:     "prefix",
+			words:    []string{"abandon", "abandoned", "ability"},
+			prefix:   4,
+			expected: []string{"expected 2048 words but actual 3", "1 abandoned: prefix aban is shared with abandon"},
+		},
+	}
+
+	for _, test := range tests {
+		issues := CheckWordlist(test.words, test.prefix, test.sorted)
+		if len(issues) != len(test.expected) {
+			t.Errorf("%s: expected %d issues but actual %v", test.name, len(test.expected), issues)
+			continue
+		}
+		for i, issue := range issues {
+			if issue.String() != test.expected[i] {
+				t.Errorf("%s: expected '%s' but actual '%s'", test.name, test.expected[i], issue.String())
+			}
+		}
+	}
+}
+
+func TestDiffWordlist(t *testing.T) {
+	diff := DiffWordlist([]string{"apple", "banana", "cherry"}, []string{"apple", "blueberry"})
+	expected := []string{"1 blueberry: official word is banana", "2 : official word is cherry"}
+	if len(diff) != len(expected) {
+		t.Fatalf("expected %d differences but actual %v", len(expected), diff)
+	}
+	for i, d := range diff {
+		if d.String() != expected[i] {
+			t.Errorf("expected '%s' but actual '%s'", expected[i], d.String())
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package nomnemonic
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// _xofFunction is the cshake256 function name of GenerateSeedXOF, it is
+	// part of the algorithm and never changes
+	_xofFunction = "nomnemonic seed xof v1"
+
+	_xofMinSize = 16
+	_xofMaxSize = 1 << 16
+)
+
+// GenerateSeedXOF expands the bip39 seed of the sentence and passphrase into
+// size bytes of key material with cshake256, the info label is its
+// customization string so distinct labels, like "signing" and "encryption",
+// give unrelated outputs. A shorter size of the same label is a prefix of a
+// longer one, put the size in the label when layered keys must not overlap
+func (m *mnemonicer) GenerateSeedXOF(sentence, passphrase, info string, size int) ([]byte, error) {
+	if info == "" {
+		return nil, errors.New("info label is required")
+	}
+	if size < _xofMinSize || size > _xofMaxSize {
+		return nil, fmt.Errorf("size must be between %d and %d bytes but given %d", _xofMinSize, _xofMaxSize, size)
+	}
+	seed, err := m.GenerateSeed(sentence, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(seed)
+
+	h := sha3.NewCShake256([]byte(_xofFunction), []byte(info))
+	h.Write(seed)
+	out := make([]byte, size)
+	_, _ = h.Read(out)
+	return out, nil
+}
@@ -0,0 +1,59 @@
+package nomnemonic
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestGenerateSeedXOF(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sentence := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	signing, err := m.GenerateSeedXOF(sentence, "", "signing", 96)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signing) != 96 {
+		t.Fatalf("expected 96 bytes but actual %d", len(signing))
+	}
+	expected := "ec3f075b790981d5879ee043cae18b99da88b3f094b3906c9fcac9f33c5d1ec3"
+	if actual := hex.EncodeToString(signing[:32]); actual != expected {
+		t.Errorf("expected %s but actual %s", expected, actual)
+	}
+
+	short, _ := m.GenerateSeedXOF(sentence, "", "signing", 32)
+	if !bytes.Equal(short, signing[:32]) {
+		t.Error("expected a shorter output to be a prefix of a longer one")
+	}
+	encryption, _ := m.GenerateSeedXOF(sentence, "", "encryption", 96)
+	if bytes.Equal(encryption[:32], signing[:32]) {
+		t.Error("expected distinct labels to give distinct outputs")
+	}
+	passphrase, _ := m.GenerateSeedXOF(sentence, "TREZOR", "signing", 96)
+	if bytes.Equal(passphrase[:32], signing[:32]) {
+		t.Error("expected the passphrase to change the output")
+	}
+
+	tests := []struct {
+		info string
+		size int
+		err  string
+	}{
+		{info: "", size: 32, err: "info label is required"},
+		{info: "signing", size: 8, err: "size must be between 16 and 65536 bytes but given 8"},
+		{info: "signing", size: 1<<16 + 1, err: "size must be between 16 and 65536 bytes but given 65537"},
+	}
+	for _, test := range tests {
+		if _, err := m.GenerateSeedXOF(sentence, "", test.info, test.size); err == nil || err.Error() != test.err {
+			t.Errorf("expected err '%s' but actual %v", test.err, err)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package nomnemonic
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	_xorMinParts = 2
+	_xorMaxParts = 3
+)
+
+var _random io.Reader = rand.Reader
+
+// SplitXOR splits the mnemonic words into Coldcard compatible Seed XOR
+// parts, each part is a valid bip39 mnemonic with the same size and the xor
+// of their entropies is the entropy of the words
+func (m *mnemonicer) SplitXOR(words []string, parts int) ([][]string, error) {
+	if parts < _xorMinParts || parts > _xorMaxParts {
+		return nil, fmt.Errorf("seed xor parts must be between %d and %d", _xorMinParts, _xorMaxParts)
+	}
+
+	entropy, err := m.CalculateEntropy(words)
+	if err != nil {
+		return nil, err
+	}
+
+	last := append([]byte{}, entropy...)
+	shares := make([][]string, 0, parts)
+	for i := 0; i < parts-1; i++ {
+		part := make([]byte, len(entropy))
+		if _, err := io.ReadFull(_random, part); err != nil {
+			return nil, err
+		}
+		xorBytes(last, part)
+		shares = append(shares, m.entropyToWords(part))
+	}
+	return append(shares, m.entropyToWords(last)), nil
+}
+
+// CombineXOR combines the Seed XOR parts into the original mnemonic words,
+// every part must be a valid mnemonic of the same size
+func (m *mnemonicer) CombineXOR(parts [][]string) ([]string, error) {
+	if len(parts) < _xorMinParts {
+		return nil, fmt.Errorf("seed xor needs at least %d parts", _xorMinParts)
+	}
+
+	var entropy []byte
+	for i, p := range parts {
+		part, err := m.CalculateEntropy(p)
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %w", i+1, err)
+		}
+		if entropy == nil {
+			entropy = part
+			continue
+		}
+		if len(part) != len(entropy) {
+			return nil, errors.New("seed xor parts must have the same number of words")
+		}
+		xorBytes(entropy, part)
+	}
+	return m.entropyToWords(entropy), nil
+}
+
+func xorBytes(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
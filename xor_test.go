@@ -0,0 +1,114 @@
+package nomnemonic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitXOR(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	tests := []struct {
+		sentence string
+		parts    int
+		err      string
+	}{
+		{
+			sentence: "edge defense waste choose enrich upon flee junk siren film clown finish luggage leader kid quick brick print evidence swap drill paddle truly occur",
+			parts:    2,
+		},
+		{
+			sentence: "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby",
+			parts:    3,
+		},
+		{
+			sentence: "cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby",
+			parts:    4,
+			err:      "seed xor parts must be between 2 and 3",
+		},
+		{
+			sentence: "cinnamon venue broken old brass vague paddle unaware critic alarm consider consider",
+			parts:    2,
+			err:      "invalid checksum",
+		},
+	}
+
+	for _, test := range tests {
+		sentence := strings.Split(test.sentence, " ")
+		parts, err := m.SplitXOR(sentence, test.parts)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected err '%s' but actual %v", test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			continue
+		}
+
+		if len(parts) != test.parts {
+			t.Errorf("expected %d parts but actual %d", test.parts, len(parts))
+		}
+		for _, p := range parts {
+			valid, _ := m.IsValid(p)
+			if !valid || len(p) != len(sentence) {
+				t.Errorf("expected valid %d words part but actual '%s'", len(sentence), strings.Join(p, " "))
+			}
+		}
+
+		combined, err := m.CombineXOR(parts)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+		if actual := strings.Join(combined, " "); actual != test.sentence {
+			t.Errorf("expected combined sentence '%s' but actual '%s'", test.sentence, actual)
+		}
+	}
+}
+
+func TestCombineXOR(t *testing.T) {
+	words, err := buildWords()
+	if err != nil {
+		t.Error("couldn't load words")
+	}
+
+	m, err := New(words)
+	if err != nil {
+		t.Errorf("unexpected error")
+	}
+
+	// coldcard seed xor documentation parts
+	parts := [][]string{
+		strings.Split("romance wink lottery autumn shop bring dawn tongue range crater truth ability miss spice fitness easy legal release recall obey exchange recycle dragon room", " "),
+		strings.Split("lion misery divide hurry latin fluid camp advance illegal lab pyramid unaware eager fringe sick camera series noodle toy crowd jeans select depth lounge", " "),
+		strings.Split("vault nominee cradle silk own frown throw leg cactus recall talent worry gadget surface shy planet purpose coffee drip few seven term squeeze educate", " "),
+	}
+	expected := "silent toe meat possible chair blossom wait occur this worth option bag nurse find fish scene bench asthma bike wage world quit primary indoor"
+
+	combined, err := m.CombineXOR(parts)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if actual := strings.Join(combined, " "); actual != expected {
+		t.Errorf("expected combined sentence '%s' but actual '%s'", expected, actual)
+	}
+
+	_, err = m.CombineXOR(parts[:1])
+	if err == nil || err.Error() != "seed xor needs at least 2 parts" {
+		t.Errorf("expected parts count err but actual %v", err)
+	}
+
+	_, err = m.CombineXOR([][]string{parts[0], strings.Split("cinnamon venue broken old brass vague paddle unaware critic alarm consider hobby", " ")})
+	if err == nil || err.Error() != "seed xor parts must have the same number of words" {
+		t.Errorf("expected same size err but actual %v", err)
+	}
+}
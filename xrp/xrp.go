@@ -0,0 +1,120 @@
+// Package xrp derives XRP Ledger classic addresses from the bip39 seed and
+// encodes ed25519 family seeds (sEd...)
+package xrp
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/nomnemonic/nomnemonic/bip32"
+	"github.com/nomnemonic/nomnemonic/internal/base58"
+)
+
+const (
+	_coinType = 144
+
+	_prefixAccountID        = 0x00
+	_prefixEd25519PublicKey = 0xed
+
+	// AlphabetXRP is the base58 alphabet of the XRP Ledger
+	AlphabetXRP = "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz"
+
+	AccountIDSize  = 20
+	FamilySeedSize = 16
+)
+
+var (
+	_prefixFamilySeedEd25519 = []byte{0x01, 0xe1, 0x4b}
+)
+
+// Path returns the bip44 derivation path of the address index, it is the
+// path used by Ledger and Xumm for bip39 phrases
+func Path(index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/0'/0/%d", _coinType, index)
+}
+
+// Key derives the secp256k1 bip32 key of the address index from the seed
+func Key(seed []byte, index uint32) (*bip32.Key, error) {
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	return master.DerivePath(Path(index))
+}
+
+// Address derives the classic address of the index from the seed
+func Address(seed []byte, index uint32) (string, error) {
+	k, err := Key(seed, index)
+	if err != nil {
+		return "", err
+	}
+	return EncodeAddress(bip32.Hash160(k.PublicKey())), nil
+}
+
+// PrivateKey derives the hex encoded private key of the address index
+func PrivateKey(seed []byte, index uint32) (string, error) {
+	k, err := Key(seed, index)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(k.PrivateKey()), nil
+}
+
+// EncodeAddress encodes the 20 bytes account id as classic address
+func EncodeAddress(accountID []byte) string {
+	return base58.CheckEncode(append([]byte{_prefixAccountID}, accountID...), AlphabetXRP)
+}
+
+// DecodeAddress decodes the classic address into 20 bytes account id
+func DecodeAddress(address string) ([]byte, error) {
+	b, err := base58.CheckDecode(address, AlphabetXRP)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != AccountIDSize+1 || b[0] != _prefixAccountID {
+		return nil, errors.New("invalid xrp address")
+	}
+	return b[1:], nil
+}
+
+// EncodeFamilySeed exports 16 bytes entropy as ed25519 family seed which
+// starts with sEd, 12 words phrases carry exactly 16 bytes entropy
+func EncodeFamilySeed(entropy []byte) (string, error) {
+	if len(entropy) != FamilySeedSize {
+		return "", fmt.Errorf("family seed must be %d bytes but given %d", FamilySeedSize, len(entropy))
+	}
+	return base58.CheckEncode(append(append([]byte{}, _prefixFamilySeedEd25519...), entropy...), AlphabetXRP), nil
+}
+
+// DecodeFamilySeed decodes the ed25519 family seed into 16 bytes entropy
+func DecodeFamilySeed(familySeed string) ([]byte, error) {
+	b, err := base58.CheckDecode(familySeed, AlphabetXRP)
+	if err != nil {
+		return nil, err
+	}
+	prefixSize := len(_prefixFamilySeedEd25519)
+	if len(b) != prefixSize+FamilySeedSize || string(b[:prefixSize]) != string(_prefixFamilySeedEd25519) {
+		return nil, errors.New("invalid ed25519 family seed")
+	}
+	return b[prefixSize:], nil
+}
+
+// FamilySeedAddress returns the classic address of the ed25519 family seed
+func FamilySeedAddress(familySeed string) (string, error) {
+	entropy, err := DecodeFamilySeed(familySeed)
+	if err != nil {
+		return "", err
+	}
+	return EncodeAddress(bip32.Hash160(ed25519PublicKey(entropy))), nil
+}
+
+// ed25519PublicKey returns the 33 bytes ed prefixed public key, the private
+// key is the first half of sha512 of the family seed entropy
+func ed25519PublicKey(entropy []byte) []byte {
+	h := sha512.Sum512(entropy)
+	pub := ed25519.NewKeyFromSeed(h[:32]).Public().(ed25519.PublicKey)
+	return append([]byte{_prefixEd25519PublicKey}, pub...)
+}
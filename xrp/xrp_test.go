@@ -0,0 +1,94 @@
+package xrp
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nomnemonic/nomnemonic"
+)
+
+func TestAddress(t *testing.T) {
+	seed, err := buildSeed("test test test test test test test test test test test junk")
+	if err != nil {
+		t.Errorf("couldn't generate seed: %s", err.Error())
+	}
+
+	address, err := Address(seed, 0)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if address != "rnrbiYDUYTJS4JVdSV5FtyCj4HFuRjfLKM" {
+		t.Errorf("expected address '%s' but actual '%s'", "rnrbiYDUYTJS4JVdSV5FtyCj4HFuRjfLKM", address)
+	}
+}
+
+func TestEncodeAddress(t *testing.T) {
+	tests := []struct {
+		accountID []byte
+		address   string
+	}{
+		{accountID: make([]byte, 20), address: "rrrrrrrrrrrrrrrrrrrrrhoLvTp"},
+		{accountID: append(make([]byte, 19), 1), address: "rrrrrrrrrrrrrrrrrrrrBZbvji"},
+	}
+
+	for _, test := range tests {
+		actual := EncodeAddress(test.accountID)
+		if actual != test.address {
+			t.Errorf("expected address '%s' but actual '%s'", test.address, actual)
+		}
+
+		decoded, err := DecodeAddress(test.address)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", test.address, err.Error())
+		}
+		if !bytes.Equal(decoded, test.accountID) {
+			t.Errorf("expected account id %x but actual %x", test.accountID, decoded)
+		}
+	}
+}
+
+func TestFamilySeed(t *testing.T) {
+	address, err := FamilySeedAddress("sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if address != "rLUEXYuLiQptky37CqLcm9USQpPiz5rkpD" {
+		t.Errorf("expected address '%s' but actual '%s'", "rLUEXYuLiQptky37CqLcm9USQpPiz5rkpD", address)
+	}
+
+	entropy, err := DecodeFamilySeed("sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	familySeed, err := EncodeFamilySeed(entropy)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if familySeed != "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r" {
+		t.Errorf("expected family seed '%s' but actual '%s'", "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r", familySeed)
+	}
+
+	_, err = EncodeFamilySeed(make([]byte, 32))
+	if err == nil || err.Error() != "family seed must be 16 bytes but given 32" {
+		t.Errorf("expected family seed size err but actual %v", err)
+	}
+
+	_, err = DecodeFamilySeed("rrrrrrrrrrrrrrrrrrrrrhoLvTp")
+	if err == nil || err.Error() != "invalid ed25519 family seed" {
+		t.Errorf("expected invalid ed25519 family seed err but actual %v", err)
+	}
+}
+
+func buildSeed(sentence string) ([]byte, error) {
+	bytes, err := os.ReadFile("../test/english.txt")
+	if err != nil {
+		return nil, err
+	}
+	m, err := nomnemonic.New(strings.Split(string(bytes), "\n"))
+	if err != nil {
+		return nil, err
+	}
+	return m.GenerateSeed(sentence, "")
+}
@@ -0,0 +1,74 @@
+// Package yubikey answers the challenges of nomnemonic.WithFactor with the
+// hmac-sha1 challenge-response slot of a YubiKey, through ykchalresp of
+// yubikey-personalization, so the phrases are only regenerated with the key
+// plugged in
+package yubikey
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nomnemonic/nomnemonic/internal/command"
+)
+
+const (
+	_command = "ykchalresp"
+)
+
+type (
+	// ChallengeResponse is the hmac-sha1 challenge-response slot, 1 or 2, of
+	// a YubiKey programmed with variable length challenges, like
+	// ykpersonalize -2 -ochal-resp -ochal-hmac -ohmac-lt64
+	ChallengeResponse struct {
+		slot int
+		// Command is the path of ykchalresp, looked up in PATH by default
+		Command string
+
+		run command.Runner
+	}
+
+	// Software computes the responses of a slot programmed with the secret,
+	// keep the secret offline to regenerate the phrases without the key or to
+	// program a spare one
+	Software []byte
+)
+
+// New returns the challenge-response of the slot
+func New(slot int) (*ChallengeResponse, error) {
+	if slot != 1 && slot != 2 {
+		return nil, fmt.Errorf("unsupported yubikey slot %d", slot)
+	}
+	return &ChallengeResponse{slot: slot, Command: _command, run: command.Run}, nil
+}
+
+// Respond sends the challenge to the slot and returns the hmac-sha1 response,
+// it waits for a touch when the slot requires one
+func (c *ChallengeResponse) Respond(challenge []byte) ([]byte, error) {
+	if len(challenge) == 0 || len(challenge) > 64 {
+		return nil, errors.New("challenge must be 1 to 64 bytes")
+	}
+	out, err := c.run(nil, c.Command, "-"+strconv.Itoa(c.slot), "-H", "-x", hex.EncodeToString(challenge))
+	if err != nil {
+		return nil, fmt.Errorf("yubikey slot %d: %w", c.slot, err)
+	}
+	response, err := hex.DecodeString(strings.TrimSpace(string(out)))
+	if err != nil || len(response) != sha1.Size {
+		return nil, fmt.Errorf("yubikey slot %d: invalid response", c.slot)
+	}
+	return response, nil
+}
+
+// Respond returns the hmac-sha1 of the challenge keyed by the secret
+func (s Software) Respond(challenge []byte) ([]byte, error) {
+	if len(s) == 0 {
+		return nil, errors.New("empty yubikey secret")
+	}
+	mac := hmac.New(sha1.New, s)
+	mac.Write(challenge)
+	return mac.Sum(nil), nil
+}
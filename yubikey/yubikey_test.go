@@ -0,0 +1,76 @@
+package yubikey
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSoftware(t *testing.T) {
+	// rfc 2202 test case 2
+	response, err := Software("Jefe").Respond([]byte("what do ya want for nothing?"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := "effcdf6ae5eb2fa2d27416d5f184df9c259a7c79"
+	if actual := hex.EncodeToString(response); actual != expected {
+		t.Errorf("expected %s but actual %s", expected, actual)
+	}
+	if _, err := Software(nil).Respond([]byte("x")); err == nil {
+		t.Error("expected an error for an empty secret")
+	}
+}
+
+func TestChallengeResponse(t *testing.T) {
+	if _, err := New(3); err == nil || err.Error() != "unsupported yubikey slot 3" {
+		t.Errorf("expected an unsupported slot error but actual %v", err)
+	}
+
+	secret := Software("slot secret")
+	c, err := New(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	var args []string
+	c.run = func(_ []byte, name string, a ...string) ([]byte, error) {
+		args = append([]string{name}, a...)
+		challenge, _ := hex.DecodeString(a[len(a)-1])
+		response, _ := secret.Respond(challenge)
+		return []byte(hex.EncodeToString(response) + "\n"), nil
+	}
+
+	challenge := []byte("challenge")
+	response, err := c.Respond(challenge)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected, _ := secret.Respond(challenge)
+	if !bytes.Equal(response, expected) {
+		t.Errorf("expected %x but actual %x", expected, response)
+	}
+	if actual := strings.Join(args, " "); actual != "ykchalresp -2 -H -x "+hex.EncodeToString(challenge) {
+		t.Errorf("expected the ykchalresp command but actual %s", actual)
+	}
+
+	tests := []struct {
+		name      string
+		challenge []byte
+		out       string
+		err       error
+		expected  string
+	}{
+		{name: "empty challenge", challenge: nil, expected: "challenge must be 1 to 64 bytes"},
+		{name: "long challenge", challenge: make([]byte, 65), expected: "challenge must be 1 to 64 bytes"},
+		{name: "no key", challenge: challenge, err: errors.New("no yubikey present"), expected: "yubikey slot 2: no yubikey present"},
+		{name: "invalid response", challenge: challenge, out: "zz", expected: "yubikey slot 2: invalid response"},
+		{name: "short response", challenge: challenge, out: "abcd", expected: "yubikey slot 2: invalid response"},
+	}
+	for _, test := range tests {
+		c.run = func([]byte, string, ...string) ([]byte, error) { return []byte(test.out), test.err }
+		if _, err := c.Respond(test.challenge); err == nil || err.Error() != test.expected {
+			t.Errorf("%s: expected err '%s' but actual %v", test.name, test.expected, err)
+		}
+	}
+}